@@ -124,6 +124,8 @@ func RenderDiffEvent(action apitype.UpdateKind, event engine.Event,
 		return renderDiffDiagEvent(event.Payload().(engine.DiagEventPayload), opts)
 	case engine.PolicyViolationEvent:
 		return renderDiffPolicyViolationEvent(event.Payload().(engine.PolicyViolationEventPayload), opts)
+	case engine.PolicyRemediationEvent:
+		return renderDiffPolicyRemediationEvent(event.Payload().(engine.PolicyRemediationEventPayload), opts)
 
 	default:
 		contract.Failf("unknown event type '%s'", event.Type)
@@ -142,6 +144,24 @@ func renderDiffPolicyViolationEvent(payload engine.PolicyViolationEventPayload,
 	return opts.Color.Colorize(payload.Prefix + payload.Message)
 }
 
+func renderDiffPolicyRemediationEvent(payload engine.PolicyRemediationEventPayload, opts Options) string {
+	return opts.Color.Colorize(renderPolicyRemediation(payload, opts.Debug))
+}
+
+// renderPolicyRemediation renders a header naming the policy that remediated a resource, followed by the changes the
+// remediation made to the resource's inputs.
+func renderPolicyRemediation(payload engine.PolicyRemediationEventPayload, debug bool) string {
+	b := &bytes.Buffer{}
+	fprintfIgnoreError(b, "%s[remediate]  %s v%s %s %s (%s: %s)\n",
+		colors.SpecInfo, payload.PolicyPackName, payload.PolicyPackVersion, colors.Reset,
+		payload.PolicyName, payload.ResourceURN.Type(), payload.ResourceURN.Name())
+
+	if diff := payload.Before.Diff(payload.After, resource.IsInternalPropertyKey); diff != nil {
+		engine.PrintObjectDiff(b, *diff, nil, false /*planning*/, 2, true /*summary*/, debug)
+	}
+	return b.String()
+}
+
 func renderStdoutColorEvent(payload engine.StdoutEventPayload, opts Options) string {
 	return opts.Color.Colorize(payload.Message)
 }
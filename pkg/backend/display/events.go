@@ -67,6 +67,29 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			EnforcementLevel:     string(p.EnforcementLevel),
 		}
 
+	case engine.PolicyRemediationEvent:
+		p, ok := e.Payload().(engine.PolicyRemediationEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+
+		encrypter := config.BlindingCrypter
+		before, err := stack.SerializeProperties(p.Before, encrypter, false /* showSecrets */)
+		contract.IgnoreError(err)
+		after, err := stack.SerializeProperties(p.After, encrypter, false /* showSecrets */)
+		contract.IgnoreError(err)
+
+		apiEvent.PolicyRemediationEvent = &apitype.PolicyRemediationEvent{
+			ResourceURN:          string(p.ResourceURN),
+			Color:                string(p.Color),
+			PolicyName:           p.PolicyName,
+			PolicyPackName:       p.PolicyPackName,
+			PolicyPackVersion:    p.PolicyPackVersion,
+			PolicyPackVersionTag: p.PolicyPackVersion,
+			Before:               before,
+			After:                after,
+		}
+
 	case engine.PreludeEvent:
 		p, ok := e.Payload().(engine.PreludeEventPayload)
 		if !ok {
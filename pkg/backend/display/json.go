@@ -181,7 +181,7 @@ func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.E
 			// need to come up with a scheme for matching the failure to the associated step.
 
 		// Events ocurring late:
-		case engine.PolicyViolationEvent:
+		case engine.PolicyViolationEvent, engine.PolicyRemediationEvent:
 			// At this point in time, we don't handle policy events in JSON serialization
 			continue
 		case engine.SummaryEvent:
@@ -225,6 +225,8 @@ func getEventUrnAndMetadata(event engine.Event) (resource.URN, *engine.StepEvent
 		return event.Payload().(engine.DiagEventPayload).URN, nil
 	case engine.PolicyViolationEvent:
 		return event.Payload().(engine.PolicyViolationEventPayload).ResourceURN, nil
+	case engine.PolicyRemediationEvent:
+		return event.Payload().(engine.PolicyRemediationEventPayload).ResourceURN, nil
 	default:
 		return "", nil
 	}
@@ -736,6 +738,7 @@ func (display *ProgressDisplay) processEndSteps() {
 	display.writeBlankLine()
 	wroteDiagnosticHeader := display.printDiagnostics()
 	wrotePolicyViolations := display.printPolicyViolations()
+	display.printPolicyRemediations()
 	display.printOutputs()
 	// If no policies violated, print policy packs applied.
 	if !wrotePolicyViolations {
@@ -885,6 +888,44 @@ func (display *ProgressDisplay) printPolicyViolations() bool {
 	return true
 }
 
+// printPolicyRemediations prints a new "Policy Remediations:" section with all of the remediations applied to
+// resources, grouped by policy pack. If no remediations were applied, prints nothing.
+func (display *ProgressDisplay) printPolicyRemediations() {
+	// Loop through every resource and gather up all policy remediations that were applied.
+	var remediationEvents []engine.PolicyRemediationEventPayload
+	for _, row := range display.eventUrnToResourceRow {
+		remediationEvents = append(remediationEvents, row.PolicyRemediationPayloads()...)
+	}
+	if len(remediationEvents) == 0 {
+		return
+	}
+	// Sort remediation events by: policy pack name, policy pack version, policy name, and finally the URN of the
+	// resource. The sort is stable so that remediations applied to the same resource by the same policy remain in
+	// the order in which they were applied.
+	sort.SliceStable(remediationEvents, func(i, j int) bool {
+		eventI, eventJ := remediationEvents[i], remediationEvents[j]
+		if packNameCmp := strings.Compare(eventI.PolicyPackName, eventJ.PolicyPackName); packNameCmp != 0 {
+			return packNameCmp < 0
+		}
+		if packVerCmp := strings.Compare(eventI.PolicyPackVersion, eventJ.PolicyPackVersion); packVerCmp != 0 {
+			return packVerCmp < 0
+		}
+		if policyNameCmp := strings.Compare(eventI.PolicyName, eventJ.PolicyName); policyNameCmp != 0 {
+			return policyNameCmp < 0
+		}
+		return strings.Compare(string(eventI.ResourceURN), string(eventJ.ResourceURN)) < 0
+	})
+
+	display.writeSimpleMessage(display.opts.Color.Colorize(colors.SpecHeadline + "Policy Remediations:" + colors.Reset))
+	for _, remediationEvent := range remediationEvents {
+		// The rendered remediation spans multiple lines, so we massage it so it will be indented properly.
+		message := renderPolicyRemediation(remediationEvent, display.opts.Debug)
+		message = strings.TrimRightFunc(message, unicode.IsSpace)
+		display.writeSimpleMessage("    " + strings.ReplaceAll(message, "\n", "\n    "))
+	}
+	display.writeBlankLine()
+}
+
 // printOutputs prints the Stack's outputs for the display in a new section, if appropriate.
 func (display *ProgressDisplay) printOutputs() {
 	// Printing the stack's outputs wasn't desired.
@@ -1126,6 +1167,9 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	} else if event.Type == engine.PolicyViolationEvent {
 		// also record this policy violation so we print it at the end.
 		row.RecordPolicyViolationEvent(event)
+	} else if event.Type == engine.PolicyRemediationEvent {
+		// also record this policy remediation so we print it at the end.
+		row.RecordPolicyRemediationEvent(event)
 	} else {
 		contract.Failf("Unhandled event type '%s'", event.Type)
 	}
@@ -57,9 +57,11 @@ type ResourceRow interface {
 
 	DiagInfo() *DiagInfo
 	PolicyPayloads() []engine.PolicyViolationEventPayload
+	PolicyRemediationPayloads() []engine.PolicyRemediationEventPayload
 
 	RecordDiagEvent(diagEvent engine.Event)
 	RecordPolicyViolationEvent(diagEvent engine.Event)
+	RecordPolicyRemediationEvent(diagEvent engine.Event)
 }
 
 // Implementation of a Row, used for the header of the grid.
@@ -123,8 +125,9 @@ type resourceRowData struct {
 	// If we failed this operation for any reason.
 	failed bool
 
-	diagInfo       *DiagInfo
-	policyPayloads []engine.PolicyViolationEventPayload
+	diagInfo                  *DiagInfo
+	policyPayloads            []engine.PolicyViolationEventPayload
+	policyRemediationPayloads []engine.PolicyRemediationEventPayload
 
 	// If this row should be hidden by default.  We will hide unless we have any child nodes
 	// we need to show.
@@ -227,6 +230,17 @@ func (data *resourceRowData) RecordPolicyViolationEvent(event engine.Event) {
 	data.policyPayloads = append(data.policyPayloads, pePayload)
 }
 
+// PolicyRemediationPayloads returns the policy remediations recorded with the resourceRowData.
+func (data *resourceRowData) PolicyRemediationPayloads() []engine.PolicyRemediationEventPayload {
+	return data.policyRemediationPayloads
+}
+
+// RecordPolicyRemediationEvent records a policy remediation event with the resourceRowData.
+func (data *resourceRowData) RecordPolicyRemediationEvent(event engine.Event) {
+	prPayload := event.Payload().(engine.PolicyRemediationEventPayload)
+	data.policyRemediationPayloads = append(data.policyRemediationPayloads, prPayload)
+}
+
 type column int
 
 const (
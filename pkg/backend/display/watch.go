@@ -50,7 +50,7 @@ func ShowWatchEvents(op string, action apitype.UpdateKind, events <-chan engine.
 		case engine.PreludeEvent, engine.SummaryEvent, engine.StdoutColorEvent:
 			// Ignore it
 			continue
-		case engine.PolicyViolationEvent:
+		case engine.PolicyViolationEvent, engine.PolicyRemediationEvent:
 			// At this point in time, we don't handle policy events as part of pulumi watch
 			continue
 		case engine.DiagEvent:
@@ -0,0 +1,221 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// pulumi-language-pcl is the language host for programs written in PCL, the Pulumi Configuration Language. Rather
+// than launching a separate process, the host interprets the program's .pp files itself.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/interpreter"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/version"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// Launches the language host, which in turn fires up an RPC server implementing the LanguageRuntimeServer endpoint.
+func main() {
+	var tracing string
+	flag.StringVar(&tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
+
+	flag.Parse()
+	args := flag.Args()
+	logging.InitLogging(false, 0, false)
+	cmdutil.InitTracing("pulumi-language-pcl", "pulumi-language-pcl", tracing)
+
+	// Pluck out the engine so we can do logging, etc.
+	if len(args) == 0 {
+		cmdutil.Exit(errors.New("missing required engine RPC address argument"))
+	}
+	engineAddress := args[0]
+
+	// Fire up a gRPC server, letting the kernel choose a free port.
+	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			host := newLanguageHost(engineAddress)
+			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		cmdutil.Exit(errors.Wrapf(err, "could not start language host RPC server"))
+	}
+
+	// Otherwise, print out the port so that the spawner knows how to reach us.
+	fmt.Printf("%d\n", port)
+
+	// And finally wait for the server to stop serving.
+	if err := <-done; err != nil {
+		cmdutil.Exit(errors.Wrapf(err, "language host RPC stopped serving"))
+	}
+}
+
+// pclLanguageHost implements the LanguageRuntimeServer interface for use as an API endpoint.
+type pclLanguageHost struct {
+	engineAddress string
+}
+
+func newLanguageHost(engineAddress string) pulumirpc.LanguageRuntimeServer {
+	return &pclLanguageHost{
+		engineAddress: engineAddress,
+	}
+}
+
+// programDir returns the directory that contains the program's .pp files.
+func programDir(pwd, program string) string {
+	if filepath.IsAbs(program) {
+		return program
+	}
+	return filepath.Join(pwd, program)
+}
+
+// parseProgram parses the .pp files in the given directory.
+func parseProgram(dir string) (*syntax.Parser, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading program directory")
+	}
+
+	parser := syntax.NewParser()
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".pp" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err = parseFile(parser, path); err != nil {
+			return nil, err
+		}
+	}
+	if len(parser.Files) == 0 {
+		return nil, errors.Errorf("no .pp files found in %s", dir)
+	}
+	return parser, nil
+}
+
+func parseFile(parser *syntax.Parser, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+
+	return errors.Wrapf(parser.ParseFile(f, path), "reading %s", path)
+}
+
+// GetRequiredPlugins returns the resource plugins for the packages referenced by the program. PCL programs do not
+// record package versions, so the latest installed version of each plugin is used.
+func (host *pclLanguageHost) GetRequiredPlugins(ctx context.Context,
+	req *pulumirpc.GetRequiredPluginsRequest) (*pulumirpc.GetRequiredPluginsResponse, error) {
+
+	parser, err := parseProgram(programDir(req.GetPwd(), req.GetProgram()))
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []*pulumirpc.PluginDependency
+	for _, pkg := range hcl2.ReferencedPackages(parser.Files) {
+		logging.V(5).Infof("GetRequiredPlugins: Found plugin name: %s", pkg)
+		plugins = append(plugins, &pulumirpc.PluginDependency{
+			Name: pkg,
+			Kind: string(workspace.ResourcePlugin),
+		})
+	}
+	return &pulumirpc.GetRequiredPluginsResponse{Plugins: plugins}, nil
+}
+
+// RPC endpoint for LanguageRuntimeServer::Run
+func (host *pclLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest) (*pulumirpc.RunResponse, error) {
+	if err := host.run(ctx, req); err != nil {
+		return &pulumirpc.RunResponse{Error: err.Error()}, nil
+	}
+	return &pulumirpc.RunResponse{}, nil
+}
+
+func (host *pclLanguageHost) run(ctx context.Context, req *pulumirpc.RunRequest) error {
+	cfg := map[config.Key]string{}
+	for k, v := range req.GetConfig() {
+		key, err := config.ParseKey(k)
+		if err != nil {
+			return err
+		}
+		cfg[key] = v
+	}
+
+	dir := programDir(req.GetPwd(), req.GetProgram())
+	parser, err := parseProgram(dir)
+	if err != nil {
+		return err
+	}
+
+	diagWriter := parser.NewDiagnosticWriter(os.Stderr, 0, true)
+	if len(parser.Diagnostics) != 0 {
+		contract.IgnoreError(diagWriter.WriteDiagnostics(parser.Diagnostics))
+		if parser.Diagnostics.HasErrors() {
+			return errors.New("the program contains errors")
+		}
+	}
+
+	// Load the schemas of the program's packages using plugins that are resolved relative to the program's directory.
+	pctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, dir, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(pctx)
+
+	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.PluginHost(pctx.Host))
+	if err != nil {
+		return errors.Wrap(err, "binding program")
+	}
+	if len(diags) != 0 {
+		contract.IgnoreError(diagWriter.WriteDiagnostics(diags))
+		if diags.HasErrors() {
+			return errors.New("the program contains errors")
+		}
+	}
+
+	return interpreter.Run(ctx, program, plugin.RunInfo{
+		MonitorAddress: req.GetMonitorAddress(),
+		Project:        req.GetProject(),
+		Stack:          req.GetStack(),
+		Pwd:            req.GetPwd(),
+		Program:        req.GetProgram(),
+		Config:         cfg,
+		DryRun:         req.GetDryRun(),
+		Parallel:       int(req.GetParallel()),
+	})
+}
+
+func (host *pclLanguageHost) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{
+		Version: version.Version,
+	}, nil
+}
@@ -0,0 +1,442 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/v2/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/nodejs"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/python"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/version"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// programGenerators maps each supported language name to the program generator for that language.
+var programGenerators = map[string]programGeneratorFunc{
+	"dotnet": dotnet.GenerateProgram,
+	"go":     gogen.GenerateProgram,
+	"nodejs": nodejs.GenerateProgram,
+	"python": python.GenerateProgram,
+}
+
+func newConvertCmd() *cobra.Command {
+	var from string
+	var language string
+	var out string
+	var projectName string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "convert [source-directory]",
+		Args:  cmdutil.MaximumNArgs(1),
+		Short: "Convert a Pulumi program to another language",
+		Long: "Convert a Pulumi program to another language.\n" +
+			"\n" +
+			"The program in the given directory, or the current directory if none is given, is\n" +
+			"translated into the language given by --language and written to the output directory\n" +
+			"as a complete project, including a Pulumi.yaml file and the manifest that the\n" +
+			"language uses to track its dependencies.\n" +
+			"\n" +
+			"The only supported source language is currently PCL, the Pulumi Configuration\n" +
+			"Language. All of the .pp files in the source directory are read as a single program.\n" +
+			"The schemas of the packages that the program uses are loaded from the installed\n" +
+			"resource plugins.\n" +
+			"\n" +
+			"The output directory must be empty unless --force is given, in which case existing\n" +
+			"files that the project includes are overwritten.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if from != "pcl" {
+				return errors.Errorf("unsupported source language %q; the only supported source language is pcl", from)
+			}
+			generator, ok := programGenerators[language]
+			if !ok {
+				return errors.Errorf("unsupported language %q; supported languages are %s",
+					language, strings.Join(supportedProgramLanguages(), ", "))
+			}
+
+			source := "."
+			if len(args) == 1 {
+				source = args[0]
+			}
+
+			if projectName == "" {
+				abs, err := filepath.Abs(out)
+				if err != nil {
+					return err
+				}
+				projectName = filepath.Base(abs)
+			}
+			if err := workspace.ValidateProjectName(projectName); err != nil {
+				return errors.Wrapf(err, "invalid project name %q; use --name to choose another", projectName)
+			}
+			if !force {
+				if err := errorIfNotEmptyOutputDirectory(out); err != nil {
+					return err
+				}
+			}
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, cwd, nil, false, nil)
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(ctx)
+
+			color := cmdutil.GetGlobalColorization() != colors.Never
+			files, err := convertPCLProgram(source, language, projectName, generator,
+				schema.NewPluginLoader(ctx.Host), os.Stderr, color)
+			if err != nil {
+				return err
+			}
+
+			if err := writeSourceFiles(out, files); err != nil {
+				return err
+			}
+			fmt.Printf("Converted %s to a %s project in %s\n", source, language, out)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(&from,
+		"from", "pcl", "The language of the source program")
+	cmd.PersistentFlags().StringVar(&language,
+		"language", "", "The language to convert the program to: one of "+
+			strings.Join(supportedProgramLanguages(), ", "))
+	cmd.PersistentFlags().StringVarP(&out,
+		"out", "o", ".", "The directory to write the converted project to")
+	cmd.PersistentFlags().StringVarP(&projectName,
+		"name", "n", "", "The project name; defaults to the name of the output directory")
+	cmd.PersistentFlags().BoolVarP(&force,
+		"force", "f", false, "Write the project even if the output directory is not empty")
+
+	return cmd
+}
+
+// supportedProgramLanguages returns the sorted names of the languages that programs can be generated in.
+func supportedProgramLanguages() []string {
+	var langs []string
+	for name := range programGenerators {
+		langs = append(langs, name)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// convertPCLProgram reads and binds the PCL program in the given directory and generates a project for it in the
+// given language. Any diagnostics produced along the way are written to w. The result maps the path of each file in
+// the project, relative to the project's root, to the file's contents.
+func convertPCLProgram(dir, language, projectName string, generator programGeneratorFunc, loader schema.Loader,
+	w io.Writer, color bool) (map[string][]byte, error) {
+
+	program, diagWriter, err := bindPCLProgram([]string{dir}, loader, w, color)
+	if err != nil {
+		return nil, err
+	}
+
+	files, diags, err := generator(program)
+	if err != nil {
+		return nil, errors.Wrapf(err, "generating %s program", language)
+	}
+	if err = writePCLDiagnostics(diagWriter, diags); err != nil {
+		return nil, err
+	}
+
+	projectFiles, err := genProjectFiles(language, projectName, program.Packages())
+	if err != nil {
+		return nil, err
+	}
+	for path, contents := range projectFiles {
+		files[path] = contents
+	}
+	return files, nil
+}
+
+// bindPCLProgram reads and binds the PCL program made up of the files named by the given paths, which are resolved
+// using findPCLFiles. Any diagnostics produced along the way are written to w. The returned diagnostic writer may be
+// used to write further diagnostics that refer to the program's source.
+func bindPCLProgram(paths []string, loader schema.Loader, w io.Writer,
+	color bool) (*hcl2.Program, hcl.DiagnosticWriter, error) {
+
+	files, err := findPCLFiles(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parser := syntax.NewParser()
+	for _, path := range files {
+		if err = parsePCLFile(parser, path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	diagWriter := parser.NewDiagnosticWriter(w, 0, color)
+	if err = writePCLDiagnostics(diagWriter, parser.Diagnostics); err != nil {
+		return nil, nil, err
+	}
+
+	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.Loader(loader))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "binding program")
+	}
+	if err = writePCLDiagnostics(diagWriter, diags); err != nil {
+		return nil, nil, err
+	}
+	return program, diagWriter, nil
+}
+
+// writePCLDiagnostics writes the given diagnostics, if any, and returns an error if any of them is an error.
+func writePCLDiagnostics(w hcl.DiagnosticWriter, diags hcl.Diagnostics) error {
+	if len(diags) == 0 {
+		return nil
+	}
+	if err := w.WriteDiagnostics(diags); err != nil {
+		return err
+	}
+	if diags.HasErrors() {
+		return errors.New("the program contains errors")
+	}
+	return nil
+}
+
+func parsePCLFile(parser *syntax.Parser, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+
+	return errors.Wrapf(parser.ParseFile(f, path), "reading %s", path)
+}
+
+// errorIfNotEmptyOutputDirectory returns an error if dir exists and is not empty.
+func errorIfNotEmptyOutputDirectory(dir string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if len(infos) > 0 {
+		return errors.Errorf("%s is not empty; "+
+			"pass the path to an empty or new directory to --out, or use --force to overwrite its files", dir)
+	}
+	return nil
+}
+
+// genProjectFiles generates the Pulumi.yaml file for a project in the given language along with the files that the
+// language requires in order to build and run the project's program, such as its package manifest. The given packages
+// are added to the manifest as dependencies.
+func genProjectFiles(language, projectName string, packages []*schema.Package) (map[string][]byte, error) {
+	project := workspace.Project{
+		Name:    tokens.PackageName(projectName),
+		Runtime: workspace.NewProjectRuntimeInfo(language, nil),
+	}
+	projectYAML, err := encoding.YAML.Marshal(&project)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating Pulumi.yaml")
+	}
+	files := map[string][]byte{"Pulumi.yaml": projectYAML}
+
+	sdkVersion := pulumiSDKVersion()
+	switch language {
+	case "dotnet":
+		files[projectName+".csproj"] = genCSharpProjectFile(sdkVersion, packages)
+		files["Program.cs"] = []byte(csharpProgram)
+	case "go":
+		files["go.mod"] = genGoModFile(projectName, sdkVersion, packages)
+	case "nodejs":
+		packageJSON, err := genPackageJSON(projectName, sdkVersion, packages)
+		if err != nil {
+			return nil, err
+		}
+		files["package.json"] = packageJSON
+		files["tsconfig.json"] = []byte(typeScriptConfig)
+	case "python":
+		files["requirements.txt"] = genRequirementsFile(sdkVersion, packages)
+	default:
+		return nil, errors.Errorf("unsupported language %q", language)
+	}
+	return files, nil
+}
+
+// pulumiSDKVersion returns the version of the Pulumi SDK that generated projects depend on. This is the version of
+// the CLI if it is a release of the current major version, and the first release of the current major version
+// otherwise.
+func pulumiSDKVersion() semver.Version {
+	if v, err := semver.ParseTolerant(version.Version); err == nil && v.Major == 2 && len(v.Pre) == 0 {
+		v.Build = nil
+		return v
+	}
+	return semver.Version{Major: 2}
+}
+
+const csharpProgram = `using System.Threading.Tasks;
+using Pulumi;
+
+class Program
+{
+    static Task<int> Main() => Deployment.RunAsync<MyStack>();
+}
+`
+
+func genCSharpProjectFile(sdkVersion semver.Version, packages []*schema.Package) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<Project Sdk="Microsoft.NET.Sdk">
+
+  <PropertyGroup>
+    <OutputType>Exe</OutputType>
+    <TargetFramework>netcoreapp3.1</TargetFramework>
+    <Nullable>enable</Nullable>
+  </PropertyGroup>
+
+  <ItemGroup>
+    <PackageReference Include="Pulumi" Version="%s" />
+`, sdkVersion)
+	for _, pkg := range packages {
+		packageVersion := "*"
+		if pkg.Version != nil {
+			packageVersion = pkg.Version.String()
+		}
+		fmt.Fprintf(&b, "    <PackageReference Include=\"Pulumi.%s\" Version=\"%s\" />\n",
+			dotnet.Title(pkg.Name), packageVersion)
+	}
+	fmt.Fprintf(&b, "  </ItemGroup>\n\n</Project>\n")
+	return b.Bytes()
+}
+
+func genGoModFile(projectName string, sdkVersion semver.Version, packages []*schema.Package) []byte {
+	requires := []string{fmt.Sprintf("github.com/pulumi/pulumi/sdk/v2 v%s", sdkVersion)}
+	for _, pkg := range packages {
+		// Packages without a version are added to go.mod by the Go toolchain when the program is built.
+		if pkg.Version == nil {
+			continue
+		}
+		requires = append(requires, fmt.Sprintf("%s v%s", goModulePath(pkg), pkg.Version))
+	}
+	sort.Strings(requires)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "module %s\n\ngo 1.14\n\nrequire (\n", projectName)
+	for _, r := range requires {
+		fmt.Fprintf(&b, "\t%s\n", r)
+	}
+	fmt.Fprintf(&b, ")\n")
+	return b.Bytes()
+}
+
+// goModulePath returns the path of the Go module that contains the SDK for the given package.
+func goModulePath(pkg *schema.Package) string {
+	if info, ok := pkg.Language["go"].(gogen.GoPackageInfo); ok && info.ImportBasePath != "" {
+		if i := strings.Index(info.ImportBasePath, "/go/"); i != -1 {
+			return info.ImportBasePath[:i]
+		}
+		return info.ImportBasePath
+	}
+
+	var vPath string
+	if pkg.Version != nil && pkg.Version.Major > 1 {
+		vPath = fmt.Sprintf("/v%d", pkg.Version.Major)
+	}
+	return fmt.Sprintf("github.com/pulumi/pulumi-%s/sdk%s", pkg.Name, vPath)
+}
+
+const typeScriptConfig = `{
+    "compilerOptions": {
+        "strict": true,
+        "outDir": "bin",
+        "target": "es2016",
+        "module": "commonjs",
+        "moduleResolution": "node",
+        "sourceMap": true,
+        "experimentalDecorators": true,
+        "pretty": true,
+        "noFallthroughCasesInSwitch": true,
+        "noImplicitReturns": true,
+        "forceConsistentCasingInFileNames": true
+    },
+    "files": [
+        "index.ts"
+    ]
+}
+`
+
+func genPackageJSON(projectName string, sdkVersion semver.Version, packages []*schema.Package) ([]byte, error) {
+	dependencies := map[string]string{"@pulumi/pulumi": "^" + sdkVersion.String()}
+	for _, pkg := range packages {
+		name := "@pulumi/" + pkg.Name
+		if info, ok := pkg.Language["nodejs"].(nodejs.NodePackageInfo); ok && info.PackageName != "" {
+			name = info.PackageName
+		}
+		packageVersion := "latest"
+		if pkg.Version != nil {
+			packageVersion = "^" + pkg.Version.String()
+		}
+		dependencies[name] = packageVersion
+	}
+
+	packageJSON := map[string]interface{}{
+		"name":            projectName,
+		"devDependencies": map[string]string{"@types/node": "^10.0.0"},
+		"dependencies":    dependencies,
+	}
+	b, err := json.MarshalIndent(packageJSON, "", "    ")
+	if err != nil {
+		return nil, errors.Wrap(err, "generating package.json")
+	}
+	return append(b, '\n'), nil
+}
+
+func genRequirementsFile(sdkVersion semver.Version, packages []*schema.Package) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "pulumi>=%s,<%d.0.0\n", sdkVersion, sdkVersion.Major+1)
+	for _, pkg := range packages {
+		name := "pulumi-" + pkg.Name
+		if pkg.Version != nil {
+			fmt.Fprintf(&b, "%s>=%s,<%d.0.0\n", name, pkg.Version, pkg.Version.Major+1)
+		} else {
+			fmt.Fprintf(&b, "%s\n", name)
+		}
+	}
+	return b.Bytes()
+}
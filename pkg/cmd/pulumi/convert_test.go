@@ -0,0 +1,160 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// testSchemaLoader loads the schemas of packages from the codegen test data.
+type testSchemaLoader struct{}
+
+func (testSchemaLoader) LoadPackage(pkg string, version *semver.Version) (*schema.Package, error) {
+	schemaPath := filepath.Join("..", "..", "codegen", "internal", "test", "testdata", pkg+".json")
+	document, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading schema for %s", pkg)
+	}
+	var spec schema.PackageSpec
+	if err = json.Unmarshal(document, &spec); err != nil {
+		return nil, errors.Wrapf(err, "parsing schema for %s", pkg)
+	}
+	return schema.ImportSpec(spec, nil)
+}
+
+func writePCLProgram(t *testing.T, source string) string {
+	dir, err := ioutil.TempDir("", "convert")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "main.pp"), []byte(source), 0600)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return dir
+}
+
+func TestConvertPCLProgram(t *testing.T) {
+	dir := writePCLProgram(t, `
+config prefix string {
+}
+
+resource pet "random:index/randomPet:RandomPet" {
+	prefix = prefix
+}
+
+output name {
+	value = pet.id
+}
+`)
+	defer os.RemoveAll(dir)
+
+	expectedFiles := map[string][]string{
+		"dotnet": {"MyStack.cs", "Program.cs", "test-project.csproj"},
+		"go":     {"main.go", "go.mod"},
+		"nodejs": {"index.ts", "package.json", "tsconfig.json"},
+		"python": {"__main__.py", "requirements.txt"},
+	}
+	for language, expected := range expectedFiles {
+		var diags bytes.Buffer
+		files, err := convertPCLProgram(dir, language, "test-project", programGenerators[language],
+			testSchemaLoader{}, &diags, false)
+		if !assert.NoError(t, err, language) {
+			continue
+		}
+		assert.Empty(t, diags.String())
+
+		var names []string
+		for name := range files {
+			names = append(names, name)
+		}
+		assert.ElementsMatch(t, append(expected, "Pulumi.yaml"), names, language)
+		assert.Equal(t, "name: test-project\nruntime: "+language+"\n", string(files["Pulumi.yaml"]))
+	}
+}
+
+func TestConvertPCLProgramReportsDiagnostics(t *testing.T) {
+	dir := writePCLProgram(t, `
+resource pet "random:index/randomPet:RandomPet" {
+	prefix = unknownVariable
+}
+`)
+	defer os.RemoveAll(dir)
+
+	var diags bytes.Buffer
+	_, err := convertPCLProgram(dir, "go", "test-project", programGenerators["go"], testSchemaLoader{}, &diags, false)
+	assert.EqualError(t, err, "the program contains errors")
+	assert.Contains(t, diags.String(), "unknownVariable")
+	assert.Contains(t, diags.String(), "main.pp")
+}
+
+func TestGenProjectFiles(t *testing.T) {
+	version := semver.MustParse("2.2.0")
+	packages := []*schema.Package{{Name: "random", Version: &version}}
+	sdkVersion := pulumiSDKVersion()
+
+	files, err := genProjectFiles("go", "test-project", packages)
+	assert.NoError(t, err)
+	assert.Equal(t, "module test-project\n\ngo 1.14\n\nrequire (\n"+
+		"\tgithub.com/pulumi/pulumi-random/sdk/v2 v2.2.0\n"+
+		"\tgithub.com/pulumi/pulumi/sdk/v2 v"+sdkVersion.String()+"\n"+
+		")\n", string(files["go.mod"]))
+
+	files, err = genProjectFiles("python", "test-project", packages)
+	assert.NoError(t, err)
+	assert.Equal(t, "pulumi>="+sdkVersion.String()+",<3.0.0\npulumi-random>=2.2.0,<3.0.0\n",
+		string(files["requirements.txt"]))
+
+	files, err = genProjectFiles("nodejs", "test-project", packages)
+	assert.NoError(t, err)
+	assert.Contains(t, string(files["package.json"]), `"@pulumi/random": "^2.2.0"`)
+
+	files, err = genProjectFiles("dotnet", "test-project", packages)
+	assert.NoError(t, err)
+	assert.Contains(t, string(files["test-project.csproj"]),
+		`<PackageReference Include="Pulumi.Random" Version="2.2.0" />`)
+
+	_, err = genProjectFiles("cobol", "test-project", packages)
+	assert.Error(t, err)
+}
+
+func TestErrorIfNotEmptyOutputDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "convert")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	// Missing and empty directories may be written to.
+	assert.NoError(t, errorIfNotEmptyOutputDirectory(filepath.Join(dir, "missing")))
+	assert.NoError(t, errorIfNotEmptyOutputDirectory(dir))
+
+	err = ioutil.WriteFile(filepath.Join(dir, "Pulumi.yaml"), []byte("name: existing\n"), 0600)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Error(t, errorIfNotEmptyOutputDirectory(dir))
+}
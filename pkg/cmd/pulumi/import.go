@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"strings"
 
@@ -30,16 +31,13 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
-	"github.com/pulumi/pulumi/pkg/v2/codegen/dotnet"
-	gogen "github.com/pulumi/pulumi/pkg/v2/codegen/go"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/importer"
-	"github.com/pulumi/pulumi/pkg/v2/codegen/nodejs"
-	"github.com/pulumi/pulumi/pkg/v2/codegen/python"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/pkg/v2/engine"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
@@ -123,6 +121,91 @@ func readImportFile(p string) (importFile, error) {
 	return result, nil
 }
 
+// readTerraformImportFile builds an import file for the managed resources in the given Terraform state file. The
+// resources that cannot be imported are returned separately.
+func readTerraformImportFile(p string, loader schema.Loader) (importFile, []importer.UnmappedTerraformResource,
+	error) {
+
+	f, err := os.Open(p)
+	if err != nil {
+		return importFile{}, nil, err
+	}
+	defer contract.IgnoreClose(f)
+
+	state, err := importer.ReadTerraformState(f)
+	if err != nil {
+		return importFile{}, nil, err
+	}
+
+	imports, unmapped := importer.MapTerraformState(loader, state)
+	resources := make([]importSpec, len(imports))
+	for i, imp := range imports {
+		resources[i] = importSpec{
+			Type: imp.Type,
+			Name: imp.Name,
+			ID:   imp.ID,
+		}
+	}
+	return importFile{Resources: resources}, unmapped, nil
+}
+
+// parseImportFilter parses the JSON object passed to --filter into the inputs for a list function.
+func parseImportFilter(filter string) (resource.PropertyMap, error) {
+	if filter == "" {
+		return resource.PropertyMap{}, nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(filter), &m); err != nil {
+		return nil, errors.Wrap(err, "the filter must be a JSON object")
+	}
+	return resource.NewPropertyMapFromMap(m), nil
+}
+
+// discoverImportFile lists the existing resources of the given type that match the given filter using the type's list
+// function and returns an import file that describes the resources that are not already managed by the stack.
+func discoverImportFile(s backend.Stack, projectName tokens.PackageName, cfg backend.StackConfiguration,
+	typ tokens.Type, filter resource.PropertyMap) (importFile, error) {
+
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		return importFile{}, err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return importFile{}, err
+	}
+	ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, cwd, nil, false, nil)
+	if err != nil {
+		return importFile{}, err
+	}
+	defer contract.IgnoreClose(ctx)
+
+	target := &deploy.Target{
+		Name:      s.Ref().Name(),
+		Config:    cfg.Config,
+		Decrypter: cfg.Decrypter,
+		Snapshot:  snap,
+	}
+	imports, err := deploy.DiscoverImports(ctx.Host, target, projectName, []deploy.ImportQuery{{
+		Type:   typ,
+		Filter: filter,
+	}})
+	if err != nil {
+		return importFile{}, err
+	}
+
+	resources := make([]importSpec, len(imports))
+	for i, imp := range imports {
+		resources[i] = importSpec{
+			Type: imp.Type,
+			Name: imp.Name,
+			ID:   imp.ID,
+		}
+	}
+	return importFile{Resources: resources}, nil
+}
+
 func parseImportFile(f importFile, protectResources bool) ([]deploy.Import, importer.NameTable, error) {
 	// Build the name table.
 	names := importer.NameTable{}
@@ -194,6 +277,8 @@ func getCurrentDeploymentForStack(s backend.Stack) (*deploy.Snapshot, error) {
 
 type programGeneratorFunc func(p *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error)
 
+// generateImportedDefinitions generates definitions for the imported resources in the given snapshot. Literal values
+// that match the ID or an output of another imported resource are replaced with references to that resource.
 func generateImportedDefinitions(out io.Writer, stackName tokens.QName, projectName tokens.PackageName,
 	snap *deploy.Snapshot, programGenerator programGeneratorFunc, names importer.NameTable,
 	imports []deploy.Import, protectResources bool) (bool, error) {
@@ -224,17 +309,38 @@ func generateImportedDefinitions(out io.Writer, stackName tokens.QName, projectN
 		return false, nil
 	}
 
+	_, err := generateDefinitions(out, programGenerator, names, resources)
+	return true, err
+}
+
+// importDependencies returns a function that computes the dependencies between imported resources. These are the
+// references between the resources in the definitions that generateImportedDefinitions generates for them, which are
+// recorded by the engine as it imports the resources so that the stack's state matches the generated program.
+func importDependencies(programGenerator programGeneratorFunc,
+	names importer.NameTable) deploy.ImportDependenciesFunc {
+
+	return func(states []*resource.State) (map[resource.URN][]resource.URN, error) {
+		return generateDefinitions(ioutil.Discard, programGenerator, names, states)
+	}
+}
+
+// generateDefinitions generates definitions for the given resources and returns the dependencies that references
+// between them introduce.
+func generateDefinitions(out io.Writer, programGenerator programGeneratorFunc, names importer.NameTable,
+	resources []*resource.State) (importer.DependencyTable, error) {
+
 	cwd, err := os.Getwd()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	sink := cmdutil.Diag()
 	ctx, err := plugin.NewContext(sink, sink, nil, nil, cwd, nil, true, nil)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	defer contract.IgnoreClose(ctx)
 	loader := schema.NewPluginLoader(ctx.Host)
-	return true, importer.GenerateLanguageDefinitions(out, loader, func(w io.Writer, p *hcl2.Program) error {
+	return importer.GenerateProgram(out, loader, func(w io.Writer, p *hcl2.Program) error {
 		files, _, err := programGenerator(p)
 		if err != nil {
 			return err
@@ -256,6 +362,9 @@ func newImportCmd() *cobra.Command {
 	var parentSpec string
 	var providerSpec string
 	var importFilePath string
+	var terraformStatePath string
+	var discoverType string
+	var discoverFilter string
 	var outputFilePath string
 
 	var debug bool
@@ -327,10 +436,49 @@ func newImportCmd() *cobra.Command {
 			"these names must correspond to entries in the name table. If a resource does not\n" +
 			"specify a provider, it will be imported using the default provider for its type. A\n" +
 			"resource that does specify a provider may specify the version of the provider\n" +
-			"that will be used for its import.\n",
+			"that will be used for its import.\n" +
+			"\n" +
+			"When several resources are imported together, a property whose value exactly matches\n" +
+			"the ID or an output of exactly one other imported resource is generated as a reference\n" +
+			"to that resource rather than as a literal value. Each reference is also recorded as a\n" +
+			"dependency of the imported resource.\n" +
+			"\n" +
+			"Resources may also be imported from a Terraform state file using --from-terraform.\n" +
+			"Each managed resource in the state is imported as the Pulumi resource type that\n" +
+			"bridges its Terraform resource type, as described by the schema of the Pulumi package\n" +
+			"for its provider. Resources whose types cannot be mapped are reported and skipped.\n" +
+			"\n" +
+			"Finally, resources may be discovered rather than listed explicitly using --discover.\n" +
+			"The provider for the given type is asked to list the existing resources of that type\n" +
+			"using the list function named by the type's schema, and each listed resource that is\n" +
+			"not already managed by the stack is imported. The resources that are listed may be\n" +
+			"narrowed by passing the list function's inputs as a JSON object using --filter, e.g.\n" +
+			"\n" +
+			"    pulumi import --discover aws:s3/bucket:Bucket --filter '{\"tags\":{\"team\":\"web\"}}'\n" +
+			"\n" +
+			"Each discovered resource is named after the name reported by the provider, or after\n" +
+			"its ID if the provider does not report a name.\n",
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			var importFile importFile
-			if importFilePath != "" {
+			var filter resource.PropertyMap
+			switch {
+			case importFilePath != "" && terraformStatePath != "":
+				return result.Errorf("an import file may not be specified in conjunction with a Terraform state file")
+			case discoverType != "" && (importFilePath != "" || terraformStatePath != ""):
+				return result.Errorf("--discover may not be specified in conjunction with an import file or a " +
+					"Terraform state file")
+			case discoverType != "":
+				if len(args) != 0 || parentSpec != "" || providerSpec != "" {
+					return result.Errorf("an inline resource may not be specified in conjunction with --discover")
+				}
+				f, err := parseImportFilter(discoverFilter)
+				if err != nil {
+					return result.FromError(err)
+				}
+				filter = f
+			case discoverFilter != "":
+				return result.Errorf("--filter may only be specified in conjunction with --discover")
+			case importFilePath != "":
 				if len(args) != 0 || parentSpec != "" || providerSpec != "" {
 					return result.Errorf("an inline resource may not be specified in conjunction with an import file")
 				}
@@ -339,7 +487,34 @@ func newImportCmd() *cobra.Command {
 					return result.FromError(errors.Wrap(err, "could not read import file"))
 				}
 				importFile = f
-			} else {
+			case terraformStatePath != "":
+				if len(args) != 0 || parentSpec != "" || providerSpec != "" {
+					return result.Errorf("an inline resource may not be specified in conjunction with a Terraform " +
+						"state file")
+				}
+
+				cwd, err := os.Getwd()
+				if err != nil {
+					return result.FromError(err)
+				}
+				ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, cwd, nil, false, nil)
+				if err != nil {
+					return result.FromError(err)
+				}
+				defer contract.IgnoreClose(ctx)
+
+				f, unmapped, err := readTerraformImportFile(terraformStatePath, schema.NewPluginLoader(ctx.Host))
+				if err != nil {
+					return result.FromError(errors.Wrap(err, "could not read Terraform state file"))
+				}
+				for _, u := range unmapped {
+					cmdutil.Diag().Warningf(diag.Message("", "skipping Terraform resource %s: %s"), u.Address, u.Reason)
+				}
+				if len(f.Resources) == 0 {
+					return result.Errorf("none of the resources in %s can be imported", terraformStatePath)
+				}
+				importFile = f
+			default:
 				if len(args) != 3 {
 					return result.Errorf("an inline resource must be specified if no import file is used")
 				}
@@ -399,17 +574,8 @@ func newImportCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			var programGenerator programGeneratorFunc
-			switch proj.Runtime.Name() {
-			case "dotnet":
-				programGenerator = dotnet.GenerateProgram
-			case "go":
-				programGenerator = gogen.GenerateProgram
-			case "nodejs":
-				programGenerator = nodejs.GenerateProgram
-			case "python":
-				programGenerator = python.GenerateProgram
-			default:
+			programGenerator, ok := programGenerators[proj.Runtime.Name()]
+			if !ok {
 				return result.Errorf("cannot generate resource definitions for %v", proj.Runtime.Name())
 			}
 
@@ -434,10 +600,26 @@ func newImportCmd() *cobra.Command {
 				return result.FromError(errors.Wrap(err, "getting stack configuration"))
 			}
 
+			if discoverType != "" {
+				f, err := discoverImportFile(s, proj.Name, cfg, tokens.Type(discoverType), filter)
+				if err != nil {
+					return result.FromError(errors.Wrapf(err, "discovering resources of type %v", discoverType))
+				}
+				if len(f.Resources) == 0 {
+					return result.Errorf("no unmanaged resources of type %v were found", discoverType)
+				}
+				if imports, nameTable, err = parseImportFile(f, protectResources); err != nil {
+					return result.FromError(err)
+				}
+			}
+
+			// If the generated code refers to other imported resources, the engine records those references as
+			// dependencies as it imports the resources, so that the state matches the program.
 			opts.Engine = engine.UpdateOptions{
-				Parallel:      parallel,
-				Debug:         debug,
-				UseLegacyDiff: useLegacyDiff(),
+				Parallel:           parallel,
+				Debug:              debug,
+				UseLegacyDiff:      useLegacyDiff(),
+				ImportDependencies: importDependencies(programGenerator, nameTable),
 			}
 
 			_, res := s.Import(commandContext(), backend.UpdateOperation{
@@ -512,6 +694,15 @@ func newImportCmd() *cobra.Command {
 		&providerSpec, "provider", "", "The name and URN of the provider to use for the import in the format name=urn, where name is the variable name for the provider resource")
 	cmd.PersistentFlags().StringVarP(
 		&importFilePath, "file", "f", "", "The path to a JSON-encoded file containing a list of resources to import")
+	cmd.PersistentFlags().StringVar(
+		&terraformStatePath, "from-terraform", "",
+		"The path to a Terraform state file (version 4) containing the resources to import")
+	cmd.PersistentFlags().StringVar(
+		&discoverType, "discover", "",
+		"The type of the existing resources to discover and import using the type's list function")
+	cmd.PersistentFlags().StringVar(
+		&discoverFilter, "filter", "",
+		"A JSON object containing the inputs to the list function used by --discover")
 	cmd.PersistentFlags().StringVarP(
 		&outputFilePath, "out", "o", "", "The path to the file that will contain the generated resource declarations")
 
@@ -0,0 +1,115 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// tfTestLoader loads a package schema that maps a single Terraform resource type.
+type tfTestLoader struct{}
+
+func (tfTestLoader) LoadPackage(pkg string, version *semver.Version) (*schema.Package, error) {
+	var spec schema.PackageSpec
+	err := json.Unmarshal([]byte(`{
+		"name": "aws",
+		"resources": {
+			"aws:s3/bucket:Bucket": {"language": {"terraform": {"name": "aws_s3_bucket"}}}
+		}
+	}`), &spec)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ImportSpec(spec, nil)
+}
+
+func TestReadTerraformImportFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-import-tfstate")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "terraform.tfstate")
+	err = ioutil.WriteFile(path, []byte(`{
+	"version": 4,
+	"resources": [
+		{
+			"mode": "managed",
+			"type": "aws_s3_bucket",
+			"name": "logs",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [{"attributes": {"id": "my-logs"}}]
+		},
+		{
+			"mode": "managed",
+			"type": "aws_s3_bucket_policy",
+			"name": "logs",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [{"attributes": {"id": "my-logs"}}]
+		}
+	]
+}`), 0600)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	f, unmapped, err := readTerraformImportFile(path, tfTestLoader{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, importFile{
+		Resources: []importSpec{{Type: "aws:s3/bucket:Bucket", Name: "logs", ID: "my-logs"}},
+	}, f)
+	if assert.Len(t, unmapped, 1) {
+		assert.Equal(t, "aws_s3_bucket_policy.logs", unmapped[0].Address)
+	}
+
+	imports, _, err := parseImportFile(f, true)
+	if assert.NoError(t, err) && assert.Len(t, imports, 1) {
+		assert.Equal(t, tokens.Type("aws:s3/bucket:Bucket"), imports[0].Type)
+		assert.True(t, imports[0].Protect)
+	}
+}
+
+func TestParseImportFilter(t *testing.T) {
+	filter, err := parseImportFilter("")
+	if assert.NoError(t, err) {
+		assert.Equal(t, resource.PropertyMap{}, filter)
+	}
+
+	filter, err = parseImportFilter(`{"tags": {"team": "web"}}`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, resource.PropertyMap{
+			"tags": resource.NewObjectProperty(resource.PropertyMap{
+				"team": resource.NewStringProperty("web"),
+			}),
+		}, filter)
+	}
+
+	_, err = parseImportFilter(`["web"]`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newPackageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "package",
+		Short: "Work with Pulumi packages",
+		Long: "Work with Pulumi packages.\n" +
+			"\n" +
+			"A Pulumi package is described by a schema that lists the resources, functions and\n" +
+			"types it provides. The package family of commands operates on these schemas, for\n" +
+			"example to generate language SDKs for a provider or component package.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newPackageGenSDKCmd())
+	cmd.AddCommand(newPackageValidateCmd())
+
+	return cmd
+}
+
+// newPackagePluginContext creates a plugin context rooted at the current working directory. The context's host is
+// used to load resource plugins, both to fetch the schema of a package and to resolve references to other packages.
+func newPackagePluginContext() (*plugin.Context, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, cwd, nil, false, nil)
+}
+
+// loadPackageSchema reads the package schema named by source. If source names an existing file, the file is read as
+// a JSON schema. Otherwise, source is treated as the name of a resource plugin, optionally followed by `@version`,
+// and the schema is fetched from the plugin using GetSchema.
+func loadPackageSchema(host plugin.Host, source string) ([]byte, error) {
+	if _, err := os.Stat(source); err == nil {
+		document, err := ioutil.ReadFile(source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading schema %s", source)
+		}
+		return document, nil
+	}
+	return getPluginSchema(host, source)
+}
+
+// getPluginSchema loads the resource plugin named by the given `name[@version]` string and returns its schema.
+func getPluginSchema(host plugin.Host, source string) ([]byte, error) {
+	name, versionString := source, ""
+	if at := strings.LastIndex(source, "@"); at != -1 {
+		name, versionString = source[:at], source[at+1:]
+	}
+	if name == "" {
+		return nil, errors.Errorf("missing plugin name in %q", source)
+	}
+
+	var version *semver.Version
+	if versionString != "" {
+		v, err := semver.ParseTolerant(versionString)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid plugin semver %q", versionString)
+		}
+		version = &v
+	}
+
+	provider, err := host.Provider(tokens.Package(name), version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading resource plugin %s", source)
+	}
+
+	schemaBytes, err := provider.GetSchema(0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching schema from resource plugin %s", source)
+	}
+	return schemaBytes, nil
+}
+
+// bindPackageSchema validates and binds the package schema in document. All of the schema's validation diagnostics
+// are returned; if any of them are errors, the schema is not bound. References to other packages are resolved by
+// loading their schemas from the resource plugins available to the given host.
+func bindPackageSchema(host plugin.Host, document []byte) (*schema.Package, schema.Diagnostics, error) {
+	diags := schema.ValidateSpec(document)
+	if diags.HasErrors() {
+		return nil, diags, validationResult(diags, false)
+	}
+
+	var spec schema.PackageSpec
+	if err := json.Unmarshal(document, &spec); err != nil {
+		return nil, diags, errors.Wrap(err, "parsing schema")
+	}
+
+	pkg, err := schema.ImportSpecWithLoader(spec, nil, schema.NewPluginLoader(host))
+	if err != nil {
+		return nil, diags, errors.Wrapf(err, "invalid schema for package %s", spec.Name)
+	}
+	return pkg, diags, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/v2/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/nodejs"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/python"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// sdkGenerators maps each supported language name to the code generator for that language.
+var sdkGenerators = map[string]func(tool string, pkg *schema.Package) (map[string][]byte, error){
+	"dotnet": func(tool string, pkg *schema.Package) (map[string][]byte, error) {
+		return dotnet.GeneratePackage(tool, pkg, nil)
+	},
+	"go": gogen.GeneratePackage,
+	"nodejs": func(tool string, pkg *schema.Package) (map[string][]byte, error) {
+		return nodejs.GeneratePackage(tool, pkg, nil)
+	},
+	"python": func(tool string, pkg *schema.Package) (map[string][]byte, error) {
+		return python.GeneratePackage(tool, pkg, nil)
+	},
+}
+
+func newPackageGenSDKCmd() *cobra.Command {
+	var languages string
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "gen-sdk <schema.json|plugin[@version]>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Generate SDKs for a Pulumi package",
+		Long: "Generate SDKs for a Pulumi package.\n" +
+			"\n" +
+			"The package schema is read from the given JSON file or, if no such file exists, from\n" +
+			"the installed resource plugin with the given name and optional version. The schema\n" +
+			"is validated as by `pulumi package validate`, and every problem found is reported.\n" +
+			"If there are no errors, an SDK is written for each requested language to a\n" +
+			"subdirectory of the output directory named after the language.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			langs, err := parseSDKLanguages(languages)
+			if err != nil {
+				return err
+			}
+
+			ctx, err := newPackagePluginContext()
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(ctx)
+
+			document, err := loadPackageSchema(ctx.Host, args[0])
+			if err != nil {
+				return err
+			}
+			pkg, diags, err := bindPackageSchema(ctx.Host, document)
+			for _, d := range diags {
+				fmt.Fprintln(os.Stderr, d)
+			}
+			if err != nil {
+				return err
+			}
+
+			for _, lang := range langs {
+				dir := filepath.Join(out, lang)
+				if err := genSDK(lang, pkg, dir); err != nil {
+					return err
+				}
+				fmt.Printf("Generated %s SDK for package %s in %s\n", lang, pkg.Name, dir)
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(&languages,
+		"language", "dotnet,go,nodejs,python", "A comma-separated list of the languages to generate SDKs for")
+	cmd.PersistentFlags().StringVarP(&out,
+		"out", "o", "sdk", "The directory to write the generated SDKs to")
+
+	return cmd
+}
+
+// parseSDKLanguages parses a comma-separated list of language names, rejecting unsupported languages.
+func parseSDKLanguages(languages string) ([]string, error) {
+	var langs []string
+	seen := map[string]bool{}
+	for _, lang := range strings.Split(languages, ",") {
+		lang = strings.TrimSpace(lang)
+		if lang == "" || seen[lang] {
+			continue
+		}
+		if _, ok := sdkGenerators[lang]; !ok {
+			var supported []string
+			for name := range sdkGenerators {
+				supported = append(supported, name)
+			}
+			sort.Strings(supported)
+			return nil, errors.Errorf("unsupported language %q; supported languages are %s",
+				lang, strings.Join(supported, ", "))
+		}
+		seen[lang] = true
+		langs = append(langs, lang)
+	}
+	if len(langs) == 0 {
+		return nil, errors.New("at least one language must be specified")
+	}
+	return langs, nil
+}
+
+// genSDK generates the SDK for the given package in the given language and writes its files to dir.
+func genSDK(lang string, pkg *schema.Package, dir string) error {
+	files, err := sdkGenerators[lang]("the Pulumi CLI", pkg)
+	if err != nil {
+		return errors.Wrapf(err, "generating %s SDK", lang)
+	}
+
+	return writeSourceFiles(dir, files)
+}
+
+// writeSourceFiles writes the given generated source files to dir. The files are source code that is meant to be
+// checked in and published, so they and their directories are created world-readable.
+func writeSourceFiles(dir string, files map[string][]byte) error {
+	for path, contents := range files {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { // nolint:gosec
+			return errors.Wrapf(err, "creating directory for %s", path)
+		}
+		if err := ioutil.WriteFile(path, contents, 0644); err != nil { // nolint:gosec
+			return errors.Wrapf(err, "writing %s", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+func TestParseSDKLanguages(t *testing.T) {
+	langs, err := parseSDKLanguages("go, python,go")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "python"}, langs)
+
+	_, err = parseSDKLanguages("go,cobol")
+	assert.EqualError(t, err, `unsupported language "cobol"; supported languages are dotnet, go, nodejs, python`)
+
+	_, err = parseSDKLanguages(",")
+	assert.Error(t, err)
+}
+
+func TestGenSDK(t *testing.T) {
+	ctx, err := newPackagePluginContext()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer contract.IgnoreClose(ctx)
+
+	schemaPath := filepath.Join("..", "..", "codegen", "internal", "test", "testdata",
+		"simple-methods-schema", "schema.json")
+	document, err := loadPackageSchema(ctx.Host, schemaPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pkg, diags, err := bindPackageSchema(ctx.Host, document)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, diags.HasErrors())
+
+	dir, err := ioutil.TempDir("", "gen-sdk")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	for lang, file := range map[string]string{
+		"go":     filepath.Join("go", "example", "foo.go"),
+		"python": filepath.Join("python", "pulumi_example", "foo.py"),
+	} {
+		err = genSDK(lang, pkg, filepath.Join(dir, lang))
+		assert.NoError(t, err)
+
+		info, err := os.Stat(filepath.Join(dir, file))
+		if assert.NoError(t, err) {
+			assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+		}
+	}
+}
+
+func TestBindPackageSchemaDiagnostics(t *testing.T) {
+	ctx, err := newPackagePluginContext()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer contract.IgnoreClose(ctx)
+
+	_, diags, err := bindPackageSchema(ctx.Host, []byte(`{"version": "1.0.0", "description": "d",
+		"functions": {"other:index:f": {"description": "A function."}}}`))
+	assert.EqualError(t, err, "schema validation failed with 2 error(s) and 0 warning(s)")
+	assert.Len(t, diags, 2)
+}
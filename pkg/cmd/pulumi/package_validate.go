@@ -0,0 +1,106 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newPackageValidateCmd() *cobra.Command {
+	var jsonOut bool
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "validate <schema.json>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Validate a Pulumi package schema",
+		Long: "Validate a Pulumi package schema.\n" +
+			"\n" +
+			"The schema is checked against the package metaschema and a set of semantic rules, e.g.\n" +
+			"that all type references resolve, that all tokens belong to the package and match its\n" +
+			"module format, and that the outputs of each resource include its inputs. Every problem\n" +
+			"is reported along with a JSON pointer to the offending value. Problems that degrade\n" +
+			"the generated SDKs, such as missing descriptions and unused types, are reported as\n" +
+			"warnings.\n" +
+			"\n" +
+			"References to other packages are not resolved, so validation does not require any\n" +
+			"plugins or network access. The command fails if any errors are found, or, if --strict\n" +
+			"is passed, if any warnings are found.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			diags, err := validatePackageSchema(args[0])
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				if diags == nil {
+					diags = schema.Diagnostics{}
+				}
+				if err = printJSON(diags); err != nil {
+					return err
+				}
+			} else {
+				for _, d := range diags {
+					fmt.Println(d)
+				}
+			}
+
+			return validationResult(diags, strict)
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&strict, "strict", false,
+		"Treat warnings as errors")
+
+	return cmd
+}
+
+// validatePackageSchema reads the package schema at the given path and validates it.
+func validatePackageSchema(path string) (schema.Diagnostics, error) {
+	document, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading schema %s", path)
+	}
+	return schema.ValidateSpec(document), nil
+}
+
+// validationResult returns an error that summarizes the given diagnostics if the schema failed validation.
+func validationResult(diags schema.Diagnostics, strict bool) error {
+	errs, warnings := 0, 0
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+
+	if errs > 0 || strict && warnings > 0 {
+		return errors.Errorf("schema validation failed with %d error(s) and %d warning(s)", errs, warnings)
+	}
+	return nil
+}
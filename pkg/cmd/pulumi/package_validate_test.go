@@ -0,0 +1,62 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePackageSchema(t *testing.T) {
+	schemaPath := filepath.Join("..", "..", "codegen", "internal", "test", "testdata",
+		"simple-methods-schema", "schema.json")
+	diags, err := validatePackageSchema(schemaPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, diags.HasErrors())
+	assert.NoError(t, validationResult(diags, false))
+	assert.EqualError(t, validationResult(diags, true),
+		"schema validation failed with 0 error(s) and 5 warning(s)")
+
+	dir, err := ioutil.TempDir("", "validate")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	invalidPath := filepath.Join(dir, "schema.json")
+	err = ioutil.WriteFile(invalidPath, []byte(`{"name": "example", "description": "An example package.",
+		"functions": {"other:index:f": {"description": "A function."}}}`), 0600)
+	if !assert.NoError(t, err) {
+		return
+	}
+	diags, err = validatePackageSchema(invalidPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "/functions/other:index:f", diags[0].Pointer)
+	}
+	assert.EqualError(t, validationResult(diags, false),
+		"schema validation failed with 1 error(s) and 0 warning(s)")
+
+	_, err = validatePackageSchema(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
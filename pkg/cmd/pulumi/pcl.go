@@ -0,0 +1,81 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newPclCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pcl",
+		Short: "Work with PCL programs",
+		Long: "Work with PCL programs.\n" +
+			"\n" +
+			"PCL, the Pulumi Configuration Language, is the language-neutral representation of\n" +
+			"Pulumi programs that is read by `pulumi convert`. The pcl family of commands helps to\n" +
+			"author PCL programs by hand.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newPclFmtCmd())
+	cmd.AddCommand(newPclCheckCmd())
+
+	return cmd
+}
+
+// findPCLFiles returns the paths of the PCL files named by the given paths. Each path may name either a file, which
+// is returned as-is, or a directory, in which case the .pp files that the directory contains are returned. If no
+// paths are given, the current directory is searched.
+func findPCLFiles(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading directory %s", path)
+		}
+		found := false
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".pp" {
+				continue
+			}
+			files, found = append(files, filepath.Join(path, entry.Name())), true
+		}
+		if !found {
+			return nil, errors.Errorf("no .pp files found in %s", path)
+		}
+	}
+	return files, nil
+}
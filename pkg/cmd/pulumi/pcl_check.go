@@ -0,0 +1,65 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+func newPclCheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check [file-or-directory...]",
+		Short: "Check PCL programs for errors",
+		Long: "Check PCL programs for errors.\n" +
+			"\n" +
+			"The given files, and the .pp files in the given directories, are read as a single\n" +
+			"program, which is bound against the schemas of the packages it uses. If no files or\n" +
+			"directories are given, the .pp files in the current directory are read. The schemas\n" +
+			"are loaded from the installed resource plugins.\n" +
+			"\n" +
+			"Any problems with the program are printed along with the source ranges they refer to,\n" +
+			"and the command fails if the program contains errors.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, cwd, nil, false, nil)
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(ctx)
+
+			color := cmdutil.GetGlobalColorization() != colors.Never
+			if _, _, err = bindPCLProgram(args, schema.NewPluginLoader(ctx.Host), os.Stderr, color); err != nil {
+				return err
+			}
+
+			fmt.Println("No errors found")
+			return nil
+		}),
+	}
+
+	return cmd
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newPclFmtCmd() *cobra.Command {
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "fmt [file-or-directory...]",
+		Short: "Format PCL programs",
+		Long: "Format PCL programs.\n" +
+			"\n" +
+			"Each of the given files, and each .pp file in the given directories, is rewritten in\n" +
+			"the canonical PCL format. If no files or directories are given, the .pp files in the\n" +
+			"current directory are formatted. Comments are preserved.\n" +
+			"\n" +
+			"With --check, files are not rewritten. Instead, the names of the files that are not\n" +
+			"formatted are printed, and the command fails if there are any such files. This is\n" +
+			"useful for checking the formatting of programs in CI.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			paths, err := findPCLFiles(args)
+			if err != nil {
+				return err
+			}
+
+			color := cmdutil.GetGlobalColorization() != colors.Never
+
+			var unformatted []string
+			for _, path := range paths {
+				changed, err := formatPCLFile(path, !check, os.Stderr, color)
+				if err != nil {
+					return err
+				}
+				if changed {
+					unformatted = append(unformatted, path)
+				}
+			}
+
+			if check {
+				for _, path := range unformatted {
+					fmt.Println(path)
+				}
+				if len(unformatted) != 0 {
+					return errors.Errorf("%d of %d files are not formatted", len(unformatted), len(paths))
+				}
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVar(&check,
+		"check", false, "Report the files that are not formatted instead of rewriting them")
+
+	return cmd
+}
+
+// formatPCLFile formats the PCL file at the given path and returns true if its formatting changed. If write is true,
+// the file is rewritten with its new contents. If the file cannot be parsed, its diagnostics are written to w.
+func formatPCLFile(path string, write bool, w io.Writer, color bool) (bool, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "reading %s", path)
+	}
+
+	formatted, diags := syntax.Format(contents, path)
+	if diags.HasErrors() {
+		files := map[string]*hcl.File{path: {Bytes: contents}}
+		if err = hcl.NewDiagnosticTextWriter(w, files, 0, color).WriteDiagnostics(diags); err != nil {
+			return false, err
+		}
+		return false, errors.Errorf("%s contains errors", path)
+	}
+
+	if bytes.Equal(contents, formatted) {
+		return false, nil
+	}
+	if write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+		if err = ioutil.WriteFile(path, formatted, info.Mode()); err != nil {
+			return false, errors.Wrapf(err, "writing %s", path)
+		}
+	}
+	return true, nil
+}
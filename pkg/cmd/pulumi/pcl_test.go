@@ -0,0 +1,111 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPCLFile(t *testing.T) {
+	dir := writePCLProgram(t, "resource pet \"random:index/randomPet:RandomPet\" {\n  prefix=\"a\" // comment\n}\n")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "main.pp")
+
+	var diags bytes.Buffer
+	changed, err := formatPCLFile(path, false, &diags, false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = formatPCLFile(path, true, &diags, false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	contents, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "resource pet \"random:index/randomPet:RandomPet\" {\n    prefix = \"a\" // comment\n}\n",
+		string(contents))
+
+	changed, err = formatPCLFile(path, false, &diags, false)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Empty(t, diags.String())
+}
+
+func TestFormatPCLFileReportsDiagnostics(t *testing.T) {
+	dir := writePCLProgram(t, "resource pet {\n")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "main.pp")
+
+	var diags bytes.Buffer
+	_, err := formatPCLFile(path, true, &diags, false)
+	assert.EqualError(t, err, path+" contains errors")
+	assert.Contains(t, diags.String(), "main.pp line 1")
+}
+
+func TestFindPCLFiles(t *testing.T) {
+	dir := writePCLProgram(t, "")
+	defer os.RemoveAll(dir)
+	err := ioutil.WriteFile(filepath.Join(dir, "README.md"), nil, 0600)
+	assert.NoError(t, err)
+
+	files, err := findPCLFiles([]string{dir})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "main.pp")}, files)
+
+	empty, err := ioutil.TempDir("", "pcl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(empty)
+
+	_, err = findPCLFiles([]string{empty})
+	assert.EqualError(t, err, "no .pp files found in "+empty)
+}
+
+func TestCheckPCLProgram(t *testing.T) {
+	dir := writePCLProgram(t, `
+config prefix string {
+}
+
+resource pet "random:index/randomPet:RandomPet" {
+	prefx = prefix
+}
+
+output name {
+	value = pet.ids
+}
+
+output other {
+	value = prefixx
+}
+`)
+	defer os.RemoveAll(dir)
+
+	var diags bytes.Buffer
+	_, _, err := bindPCLProgram([]string{dir}, testSchemaLoader{}, &diags, false)
+	assert.EqualError(t, err, "the program contains errors")
+
+	output := diags.String()
+	assert.Contains(t, output, "main.pp line 6")
+	assert.Contains(t, output, "unsupported attribute 'prefx'; did you mean 'prefix'?")
+	assert.Contains(t, output, "unknown property 'ids'")
+	assert.Contains(t, output, "Did you mean 'id'?")
+	assert.Contains(t, output, "undefined variable prefixx")
+	assert.Contains(t, output, "Did you mean 'prefix'?")
+}
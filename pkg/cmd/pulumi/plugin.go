@@ -43,8 +43,11 @@ func newPluginCmd() *cobra.Command {
 		Args: cmdutil.NoArgs,
 	}
 
+	cmd.AddCommand(newPluginDaemonCmd())
 	cmd.AddCommand(newPluginInstallCmd())
 	cmd.AddCommand(newPluginLsCmd())
+	cmd.AddCommand(newPluginMirrorCmd())
+	cmd.AddCommand(newPluginPruneCmd())
 	cmd.AddCommand(newPluginRmCmd())
 
 	return cmd
@@ -0,0 +1,95 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+func newPluginDaemonCmd() *cobra.Command {
+	var address string
+	var idleTimeout time.Duration
+
+	var cmd = &cobra.Command{
+		Use:   "daemon",
+		Args:  cmdutil.NoArgs,
+		Short: "Run a daemon that shares resource provider plugins between deployments",
+		Long: "Run a daemon that shares resource provider plugins between deployments.\n" +
+			"\n" +
+			"The daemon keeps the resource provider plugins that deployments use running after\n" +
+			"the deployments finish, so that later deployments can reuse them rather than\n" +
+			"relaunching them.  To use the daemon, set the " + plugin.ProviderDaemonEnvVar + "\n" +
+			"environment variable to the address that it prints before running other commands.\n" +
+			"A plugin is only reused by deployments that run in the same directory with the same\n" +
+			"environment, and only if it was configured identically.  Plugins that go unused for\n" +
+			"longer than --idle-timeout are shut down.\n" +
+			"\n" +
+			"The daemon only listens on loopback addresses.  It writes a random token to a file\n" +
+			"under ~/.pulumi/provider-daemons that only the current user can read, and only leases\n" +
+			"plugins to processes that present that token.  Variables that change how a process\n" +
+			"is loaded, such as LD_PRELOAD, are removed from the environment of the plugins that\n" +
+			"it launches.\n" +
+			"\n" +
+			"The daemon runs until it is interrupted.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if idleTimeout <= 0 {
+				return errors.New("--idle-timeout must be positive")
+			}
+
+			daemon, err := plugin.NewProviderDaemon(address, idleTimeout)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Serving provider plugins at %s\n", daemon.Addr())
+			fmt.Printf("To use them, set %s=%s\n", plugin.ProviderDaemonEnvVar, daemon.Addr())
+
+			sigint := make(chan os.Signal, 1)
+			signal.Notify(sigint, os.Interrupt)
+			defer signal.Stop(sigint)
+			closed := make(chan error, 1)
+			go func() {
+				<-sigint
+				closed <- daemon.Close()
+			}()
+
+			// Serve only returns without an error once the daemon has been closed.
+			if err = daemon.Serve(); err != nil {
+				contract.IgnoreError(daemon.Close())
+				return err
+			}
+			return <-closed
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&address, "address", "127.0.0.1:0",
+		"The loopback address to listen on; by default, an unused port on 127.0.0.1")
+	cmd.PersistentFlags().DurationVar(
+		&idleTimeout, "idle-timeout", plugin.DefaultProviderPoolIdleTimeout,
+		"How long to keep an unused provider plugin running")
+
+	return cmd
+}
@@ -16,7 +16,6 @@ package main
 
 import (
 	"fmt"
-	"io"
 	"os"
 
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
@@ -25,7 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
-	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
@@ -38,43 +37,51 @@ func newPluginInstallCmd() *cobra.Command {
 	var reinstall bool
 
 	var cmd = &cobra.Command{
-		Use:   "install [KIND NAME VERSION]",
-		Args:  cmdutil.MaximumNArgs(3),
+		Use:   "install [KIND NAME VERSION]...",
+		Args:  cmdutil.ArgsFunc(cobra.ArbitraryArgs),
 		Short: "Install one or more plugins",
 		Long: "Install one or more plugins.\n" +
 			"\n" +
 			"This command is used manually install plugins required by your program.  It may\n" +
-			"be run either with a specific KIND, NAME, and VERSION, or by omitting these and\n" +
-			"letting Pulumi compute the set of plugins that may be required by the current\n" +
-			"project.  VERSION cannot be a range: it must be a specific number.\n" +
+			"be run either with one or more specific KIND, NAME, and VERSION triples, or by omitting\n" +
+			"these and letting Pulumi compute the set of plugins that may be required by the current\n" +
+			"project.  VERSION cannot be a range: it must be a specific number.  Plugins are\n" +
+			"installed concurrently.\n" +
 			"\n" +
 			"If you let Pulumi compute the set to download, it is conservative and may end up\n" +
-			"downloading more plugins than is strictly necessary.",
+			"downloading more plugins than is strictly necessary.\n" +
+			"\n" +
+			"Plugins are downloaded from the first configured plugin source that has them, falling\n" +
+			"back to the --server URL or the plugin's default server.  Plugin sources are listed,\n" +
+			"separated by commas, in the PULUMI_PLUGIN_SOURCES environment variable or, when run\n" +
+			"within a project, in the `pluginSources` workspace setting.  See `pulumi plugin mirror`\n" +
+			"for more on plugin sources.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
-			displayOpts := display.Options{
-				Color: cmdutil.GetGlobalColorization(),
-			}
-
-			// Parse the kind, name, and version, if specified.
+			// Parse the kind, name, and version triples, if specified.
 			var installs []workspace.PluginInfo
 			if len(args) > 0 {
-				if !workspace.IsPluginKind(args[0]) {
-					return errors.Errorf("unrecognized plugin kind: %s", args[0])
-				} else if len(args) < 2 {
-					return errors.New("missing plugin name argument")
-				} else if len(args) < 3 {
-					return errors.New("missing plugin version argument")
+				if file != "" && len(args) > 3 {
+					return errors.New("--file (-f) is only valid if a single plugin is being installed")
 				}
-				version, err := semver.ParseTolerant(args[2])
-				if err != nil {
-					return errors.Wrap(err, "invalid plugin semver")
+				for i := 0; i < len(args); i += 3 {
+					if !workspace.IsPluginKind(args[i]) {
+						return errors.Errorf("unrecognized plugin kind: %s", args[i])
+					} else if len(args) < i+2 {
+						return errors.New("missing plugin name argument")
+					} else if len(args) < i+3 {
+						return errors.New("missing plugin version argument")
+					}
+					version, err := semver.ParseTolerant(args[i+2])
+					if err != nil {
+						return errors.Wrap(err, "invalid plugin semver")
+					}
+					installs = append(installs, workspace.PluginInfo{
+						Kind:      workspace.PluginKind(args[i]),
+						Name:      args[i+1],
+						Version:   &version,
+						ServerURL: serverURL, // If empty, will use default plugin source.
+					})
 				}
-				installs = append(installs, workspace.PluginInfo{
-					Kind:      workspace.PluginKind(args[0]),
-					Name:      args[1],
-					Version:   &version,
-					ServerURL: serverURL, // If empty, will use default plugin source.
-				})
 			} else {
 				if file != "" {
 					return errors.New("--file (-f) is only valid if a specific package is being installed")
@@ -94,12 +101,11 @@ func newPluginInstallCmd() *cobra.Command {
 				}
 			}
 
-			// Now for each kind, name, version pair, download it from the release website, and install it.
+			// If the plugins already exist, don't download them unless --reinstall was passed.  Note that
+			// by default we accept plugins with >= constraints, unless --exact was passed which requires ==.
+			var downloads []workspace.PluginInfo
 			for _, install := range installs {
 				label := fmt.Sprintf("[%s plugin %s]", install.Kind, install)
-
-				// If the plugin already exists, don't download it unless --reinstall was passed.  Note that
-				// by default we accept plugins with >= constraints, unless --exact was passed which requires ==.
 				if !reinstall {
 					if exact {
 						if workspace.HasPlugin(install) {
@@ -113,30 +119,27 @@ func newPluginInstallCmd() *cobra.Command {
 						}
 					}
 				}
+				downloads = append(downloads, install)
+			}
+
+			if file == "" {
+				// Download and install each plugin from the release website.
+				return engine.InstallPlugins(downloads, engine.PluginInstallConcurrency())
+			}
 
+			for _, install := range downloads {
+				label := fmt.Sprintf("[%s plugin %s]", install.Kind, install)
 				cmdutil.Diag().Infoerrf(
 					diag.Message("", "%s installing"), label)
 
-				// If we got here, actually try to do the download.
-				var source string
-				var tarball io.ReadCloser
-				var err error
-				if file == "" {
-					var size int64
-					if tarball, size, err = install.Download(); err != nil {
-						return errors.Wrapf(err, "%s downloading from %s", label, install.ServerURL)
-					}
-					tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", displayOpts.Color)
-				} else {
-					source = file
-					logging.V(1).Infof("%s opening tarball from %s", label, file)
-					if tarball, err = os.Open(file); err != nil {
-						return errors.Wrapf(err, "opening file %s", source)
-					}
+				logging.V(1).Infof("%s opening tarball from %s", label, file)
+				tarball, err := os.Open(file)
+				if err != nil {
+					return errors.Wrapf(err, "opening file %s", file)
 				}
 				logging.V(1).Infof("%s installing tarball ...", label)
 				if err = install.Install(tarball); err != nil {
-					return errors.Wrapf(err, "installing %s from %s", label, source)
+					return errors.Wrapf(err, "installing %s from %s", label, file)
 				}
 			}
 
@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
@@ -29,10 +30,17 @@ import (
 func newPluginLsCmd() *cobra.Command {
 	var projectOnly bool
 	var jsonOut bool
+	var verify bool
 	cmd := &cobra.Command{
 		Use:   "ls",
 		Short: "List plugins",
-		Args:  cmdutil.NoArgs,
+		Long: "List plugins.\n" +
+			"\n" +
+			"With --verify, the files of each installed plugin are re-checked against the checksums\n" +
+			"recorded when the plugin was installed, and the command fails if any plugin has been modified.\n" +
+			"Plugins installed by older versions of Pulumi have no recorded checksums and are reported as\n" +
+			"unverified.",
+		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			// Produce a list of plugins, sorted by name and version.
 			var plugins []workspace.PluginInfo
@@ -60,10 +68,35 @@ func newPluginLsCmd() *cobra.Command {
 				return false
 			})
 
+			var verifications []pluginVerification
+			if verify {
+				verifications = make([]pluginVerification, len(plugins))
+				for i, plugin := range plugins {
+					if verifications[i], err = verifyPlugin(plugin); err != nil {
+						return err
+					}
+				}
+			}
+
 			if jsonOut {
-				return formatPluginsJSON(plugins)
+				err = formatPluginsJSON(plugins, verifications)
+			} else {
+				err = formatPluginConsole(plugins, verifications)
+			}
+			if err != nil {
+				return err
+			}
+
+			modified := 0
+			for _, v := range verifications {
+				if v.status == pluginModified {
+					modified++
+				}
+			}
+			if modified > 0 {
+				return errors.Errorf("%d plugin(s) failed verification", modified)
 			}
-			return formatPluginConsole(plugins)
+			return nil
 		}),
 	}
 
@@ -73,6 +106,9 @@ func newPluginLsCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&jsonOut, "json", "j", false,
 		"Emit output as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&verify, "verify", false,
+		"Verify that the files of each installed plugin have not been modified since it was installed")
 
 	return cmd
 }
@@ -80,15 +116,44 @@ func newPluginLsCmd() *cobra.Command {
 // pluginInfoJSON is the shape of the --json output for a configuration value.  While we can add fields to this
 // structure in the future, we should not change existing fields.
 type pluginInfoJSON struct {
-	Name         string  `json:"name"`
-	Kind         string  `json:"kind"`
-	Version      string  `json:"version"`
-	Size         int     `json:"size"`
-	InstallTime  *string `json:"installTime,omitempty"`
-	LastUsedTime *string `json:"lastUsedTime,omitempty"`
+	Name         string   `json:"name"`
+	Kind         string   `json:"kind"`
+	Version      string   `json:"version"`
+	Size         int      `json:"size"`
+	InstallTime  *string  `json:"installTime,omitempty"`
+	LastUsedTime *string  `json:"lastUsedTime,omitempty"`
+	Verification *string  `json:"verification,omitempty"`
+	Modified     []string `json:"modified,omitempty"`
 }
 
-func formatPluginsJSON(plugins []workspace.PluginInfo) error {
+// The possible results of verifying an installed plugin.
+const (
+	pluginVerified   = "verified"
+	pluginUnverified = "unverified"
+	pluginModified   = "modified"
+)
+
+// pluginVerification is the result of verifying an installed plugin.
+type pluginVerification struct {
+	status   string   // the result of the verification.
+	modified []string // the files that were modified, if the status is pluginModified.
+}
+
+// verifyPlugin checks that the given plugin's files have not been modified since it was installed.
+func verifyPlugin(plugin workspace.PluginInfo) (pluginVerification, error) {
+	recorded, err := plugin.VerifyInstall()
+	if mismatch, ok := err.(*workspace.ChecksumMismatchError); ok {
+		return pluginVerification{status: pluginModified, modified: mismatch.Files}, nil
+	} else if err != nil {
+		return pluginVerification{}, errors.Wrapf(err, "verifying %s plugin %s", plugin.Kind, plugin)
+	}
+	if !recorded {
+		return pluginVerification{status: pluginUnverified}, nil
+	}
+	return pluginVerification{status: pluginVerified}, nil
+}
+
+func formatPluginsJSON(plugins []workspace.PluginInfo, verifications []pluginVerification) error {
 	makeStringRef := func(s string) *string {
 		return &s
 	}
@@ -109,17 +174,22 @@ func formatPluginsJSON(plugins []workspace.PluginInfo) error {
 		if !plugin.LastUsedTime.IsZero() {
 			jsonPluginInfo[idx].LastUsedTime = makeStringRef(plugin.LastUsedTime.UTC().Format(timeFormat))
 		}
+
+		if verifications != nil {
+			jsonPluginInfo[idx].Verification = makeStringRef(verifications[idx].status)
+			jsonPluginInfo[idx].Modified = verifications[idx].modified
+		}
 	}
 
 	return printJSON(jsonPluginInfo)
 }
 
-func formatPluginConsole(plugins []workspace.PluginInfo) error {
+func formatPluginConsole(plugins []workspace.PluginInfo, verifications []pluginVerification) error {
 	var totalSize uint64
 
 	rows := []cmdutil.TableRow{}
 
-	for _, plugin := range plugins {
+	for idx, plugin := range plugins {
 		var version string
 		if plugin.Version != nil {
 			version = plugin.Version.String()
@@ -143,15 +213,25 @@ func formatPluginConsole(plugins []workspace.PluginInfo) error {
 			lastUsedTime = humanize.Time(plugin.LastUsedTime)
 		}
 
-		rows = append(rows, cmdutil.TableRow{
-			Columns: []string{plugin.Name, string(plugin.Kind), version, bytes, installTime, lastUsedTime},
-		})
+		columns := []string{plugin.Name, string(plugin.Kind), version, bytes, installTime, lastUsedTime}
+		if verifications != nil {
+			status := verifications[idx].status
+			if len(verifications[idx].modified) > 0 {
+				status = fmt.Sprintf("%s: %s", status, strings.Join(verifications[idx].modified, ", "))
+			}
+			columns = append(columns, status)
+		}
+		rows = append(rows, cmdutil.TableRow{Columns: columns})
 
 		totalSize += uint64(plugin.Size)
 	}
 
+	headers := []string{"NAME", "KIND", "VERSION", "SIZE", "INSTALLED", "LAST USED"}
+	if verifications != nil {
+		headers = append(headers, "VERIFICATION")
+	}
 	cmdutil.PrintTable(cmdutil.Table{
-		Headers: []string{"NAME", "KIND", "VERSION", "SIZE", "INSTALLED", "LAST USED"},
+		Headers: headers,
 		Rows:    rows,
 	})
 
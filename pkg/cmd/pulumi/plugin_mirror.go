@@ -0,0 +1,153 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newPluginMirrorCmd() *cobra.Command {
+	var platforms []string
+
+	var cmd = &cobra.Command{
+		Use:   "mirror DIR",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Copy the plugins required by the current project into a local directory",
+		Long: "Copy the plugins required by the current project into a local directory.\n" +
+			"\n" +
+			"The plugin tarballs, along with their checksums if they are published, are downloaded\n" +
+			"into DIR so that it can be used as a plugin source on machines without access to the\n" +
+			"plugins' servers.  Plugin sources are listed, in the order in which they should be\n" +
+			"consulted, in the PULUMI_PLUGIN_SOURCES environment variable (separated by commas) or\n" +
+			"in the `pluginSources` list in the project's workspace settings file (under\n" +
+			"~/.pulumi/workspaces).  Workspace settings only apply within the project, so use the\n" +
+			"environment variable when installing plugins elsewhere.  A source may be a local\n" +
+			"directory, a file:// URL, or the URL of an HTTP server that serves the contents of\n" +
+			"such a directory.\n" +
+			"\n" +
+			"The plugins that are mirrored are those reported by the project's program and those\n" +
+			"pinned by the project's Pulumi.lock file, if any.  Use --platform to mirror plugins for\n" +
+			"other platforms than the current one.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			dir, err := filepath.Abs(args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(platforms) == 0 {
+				platforms = []string{runtime.GOOS + "-" + runtime.GOARCH}
+			}
+			for _, platform := range platforms {
+				if len(strings.Split(platform, "-")) != 2 {
+					return errors.Errorf("invalid platform %q: expected OS-ARCH, e.g. linux-amd64", platform)
+				}
+			}
+
+			plugins, err := getMirrorPlugins()
+			if err != nil {
+				return err
+			}
+
+			for _, plugin := range plugins {
+				for _, platform := range platforms {
+					parts := strings.Split(platform, "-")
+					label := fmt.Sprintf("[%s plugin %s %s]", plugin.Kind, plugin, platform)
+					cmdutil.Diag().Infoerrf(diag.Message("", "%s mirroring"), label)
+					warnings, err := plugin.Mirror(dir, parts[0], parts[1])
+					if err != nil {
+						return errors.Wrapf(err, "%s mirroring", label)
+					}
+					for _, warning := range warnings {
+						cmdutil.Diag().Warningf(diag.Message("", "%s %s"), label, warning)
+					}
+				}
+			}
+
+			fmt.Printf("Mirrored %d plugin(s) to %s. To use the mirror, set %s=%s\n",
+				len(plugins), dir, workspace.PluginSourcesEnvVar, dir)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&platforms,
+		"platform", nil, "The OS-ARCH pairs (e.g. linux-amd64) to mirror plugins for; defaults to the current platform")
+
+	return cmd
+}
+
+// getMirrorPlugins returns the plugins required by the current project that can be mirrored: the resource and
+// analyzer plugins reported by the project's program, and those pinned by the project's lock file.
+func getMirrorPlugins() ([]workspace.PluginInfo, error) {
+	_, root, err := readProject()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := workspace.LoadPluginLock(filepath.Join(root, workspace.PluginLockFile))
+	if err != nil {
+		return nil, err
+	}
+
+	projectPlugins, err := getProjectPlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []workspace.PluginInfo
+	seen := make(map[string]bool)
+	add := func(plugin workspace.PluginInfo) {
+		if key := fmt.Sprintf("%s-%s", plugin.Kind, plugin); !seen[key] {
+			seen[key] = true
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	for _, plugin := range projectPlugins {
+		// Language plugins are distributed with the CLI rather than downloaded.
+		if plugin.Kind == workspace.LanguagePlugin {
+			continue
+		}
+		if plugin.Version == nil {
+			// Unversioned plugins may still be pinned by the lock file, which is consulted below.
+			if _, ok := lock.Get(plugin.Kind, plugin.Name); !ok {
+				cmdutil.Diag().Warningf(diag.Message("",
+					"skipping %s plugin %s, which the program does not request a specific version of"),
+					plugin.Kind, plugin.Name)
+			}
+			continue
+		}
+		add(plugin)
+	}
+
+	if lock != nil {
+		for _, locked := range lock.Plugins {
+			plugin, err := locked.PluginInfo()
+			if err != nil {
+				return nil, err
+			}
+			add(plugin)
+		}
+	}
+	return plugins, nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newPluginPruneCmd() *cobra.Command {
+	var stackNames []string
+	var keepLatest int
+	var dryRun bool
+	var yes bool
+
+	var cmd = &cobra.Command{
+		Use:   "prune",
+		Args:  cmdutil.NoArgs,
+		Short: "Remove resource plugins that are no longer referenced from the download cache",
+		Long: "Remove resource plugins that are no longer referenced from the download cache.\n" +
+			"\n" +
+			"A plugin version is kept if it is required by the current project's program or pinned by\n" +
+			"its Pulumi.lock file, or if it is used by a provider in the most recent deployment of one\n" +
+			"of the stacks given with --stack.  If a plugin is referenced without a version, its newest\n" +
+			"installed version is kept.  Use --keep-latest to also keep the newest N versions of every\n" +
+			"plugin.  All other versions of resource plugins are removed; language and analyzer\n" +
+			"plugins are never removed.\n" +
+			"\n" +
+			"Plugins that are being installed by another process are skipped.  Plugins are not\n" +
+			"checked for use by deployments that are running: a deployment that has already loaded\n" +
+			"a pruned plugin is unaffected, but one that loads it later must download it again.\n" +
+			"Avoid pruning while deployments are running.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if keepLatest < 0 {
+				return errors.New("--keep-latest must not be negative")
+			}
+
+			referenced, err := getReferencedPlugins(stackNames, opts)
+			if err != nil {
+				return err
+			}
+			installed, err := workspace.GetPlugins()
+			if err != nil {
+				return errors.Wrap(err, "loading plugins")
+			}
+
+			prunes := selectPluginsToPrune(installed, referenced, keepLatest)
+			if len(prunes) == 0 {
+				cmdutil.Diag().Infof(diag.Message("", "no plugins found to prune"))
+				return nil
+			}
+
+			var size uint64
+			for _, plugin := range prunes {
+				size += uint64(plugin.Size)
+			}
+			verb := "This will remove"
+			if dryRun {
+				verb = "Would remove"
+			}
+			fmt.Print(opts.Color.Colorize(fmt.Sprintf("%s%s %d plugin(s) (%s) from the cache:%s\n",
+				colors.SpecAttention, verb, len(prunes), humanize.Bytes(size), colors.Reset)))
+			for _, plugin := range prunes {
+				fmt.Printf("    %s %s\n", plugin.Kind, plugin.String())
+			}
+			if dryRun || !(yes || confirmPrompt("", "yes", opts)) {
+				return nil
+			}
+
+			var result error
+			for _, plugin := range prunes {
+				deleted, err := plugin.TryDelete()
+				if err != nil {
+					result = multierror.Append(
+						result, errors.Wrapf(err, "failed to delete %s plugin %s", plugin.Kind, plugin))
+				} else if !deleted {
+					cmdutil.Diag().Warningf(diag.Message("",
+						"skipping %s plugin %s, which is locked by another process"), plugin.Kind, plugin)
+				}
+			}
+			return result
+		}),
+	}
+
+	cmd.PersistentFlags().StringArrayVarP(
+		&stackNames, "stack", "s", nil,
+		"Keep the plugins used by the given stack; may be specified multiple times")
+	cmd.PersistentFlags().IntVar(
+		&keepLatest, "keep-latest", 0,
+		"Keep the newest N installed versions of each plugin, whether or not they are referenced")
+	cmd.PersistentFlags().BoolVar(
+		&dryRun, "dry-run", false,
+		"Show the plugins that would be removed without removing them")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with removal anyway")
+
+	return cmd
+}
+
+// getReferencedPlugins returns the plugins referenced by the current project, if there is one, and by the most recent
+// deployments of the given stacks.
+func getReferencedPlugins(stackNames []string, opts display.Options) ([]workspace.PluginInfo, error) {
+	var referenced []workspace.PluginInfo
+
+	_, root, err := readProject()
+	switch {
+	case err == nil:
+		plugins, err := getProjectPlugins()
+		if err != nil {
+			return nil, err
+		}
+		referenced = append(referenced, plugins...)
+
+		lock, err := workspace.LoadPluginLock(filepath.Join(root, workspace.PluginLockFile))
+		if err != nil {
+			return nil, err
+		}
+		if lock != nil {
+			for _, locked := range lock.Plugins {
+				plugin, err := locked.PluginInfo()
+				if err != nil {
+					return nil, err
+				}
+				referenced = append(referenced, plugin)
+			}
+		}
+	case len(stackNames) == 0:
+		// Without a project or any stacks, every plugin would be removed, which is surely not what was intended.
+		return nil, errors.Wrap(err, "run this command in a project directory or pass --stack")
+	}
+
+	for _, stackName := range stackNames {
+		s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+		if err != nil {
+			return nil, err
+		}
+		snap, err := s.Snapshot(commandContext())
+		if err != nil {
+			return nil, err
+		}
+		plugins, err := getSnapshotPlugins(snap)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading plugins used by stack %s", stackName)
+		}
+		referenced = append(referenced, plugins...)
+	}
+	return referenced, nil
+}
+
+// getSnapshotPlugins returns the plugins loaded by the deployment that produced the given snapshot, along with those
+// used by the providers it contains.
+func getSnapshotPlugins(snap *deploy.Snapshot) ([]workspace.PluginInfo, error) {
+	if snap == nil {
+		return nil, nil
+	}
+
+	plugins := append([]workspace.PluginInfo{}, snap.Manifest.Plugins...)
+	for _, res := range snap.Resources {
+		if !providers.IsProviderType(res.Type) {
+			continue
+		}
+		version, err := providers.GetProviderVersion(res.Inputs)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, workspace.PluginInfo{
+			Name:    providers.GetProviderPackage(res.Type).String(),
+			Kind:    workspace.ResourcePlugin,
+			Version: version,
+		})
+	}
+	return plugins, nil
+}
+
+// selectPluginsToPrune returns the installed resource plugins that are neither referenced nor among the newest
+// keepLatest versions of their plugin. A referenced plugin without a version refers to the newest installed version
+// of the plugin.
+func selectPluginsToPrune(installed, referenced []workspace.PluginInfo, keepLatest int) []workspace.PluginInfo {
+	// Group the installed resource plugins by name, newest first.
+	byName := make(map[string][]workspace.PluginInfo)
+	for _, plugin := range installed {
+		if plugin.Kind == workspace.ResourcePlugin && plugin.Version != nil {
+			byName[plugin.Name] = append(byName[plugin.Name], plugin)
+		}
+	}
+	for _, versions := range byName {
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].Version.GT(*versions[j].Version)
+		})
+	}
+
+	keep := make(map[string]bool)
+	for _, versions := range byName {
+		for i := 0; i < keepLatest && i < len(versions); i++ {
+			keep[versions[i].String()] = true
+		}
+	}
+	for _, plugin := range referenced {
+		if plugin.Kind != workspace.ResourcePlugin {
+			continue
+		}
+		if plugin.Version != nil {
+			keep[plugin.String()] = true
+		} else if versions := byName[plugin.Name]; len(versions) > 0 {
+			keep[versions[0].String()] = true
+		}
+	}
+
+	var prunes []workspace.PluginInfo
+	for _, plugin := range installed {
+		if plugin.Kind == workspace.ResourcePlugin && plugin.Version != nil && !keep[plugin.String()] {
+			prunes = append(prunes, plugin)
+		}
+	}
+	sort.Slice(prunes, func(i, j int) bool {
+		pi, pj := prunes[i], prunes[j]
+		if pi.Name != pj.Name {
+			return pi.Name < pj.Name
+		}
+		return pi.Version.LT(*pj.Version)
+	})
+	return prunes
+}
@@ -0,0 +1,105 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func testPlugin(kind workspace.PluginKind, name, version string) workspace.PluginInfo {
+	plugin := workspace.PluginInfo{Name: name, Kind: kind}
+	if version != "" {
+		v := semver.MustParse(version)
+		plugin.Version = &v
+	}
+	return plugin
+}
+
+func TestSelectPluginsToPrune(t *testing.T) {
+	installed := []workspace.PluginInfo{
+		testPlugin(workspace.ResourcePlugin, "aws", "1.0.0"),
+		testPlugin(workspace.ResourcePlugin, "aws", "2.0.0"),
+		testPlugin(workspace.ResourcePlugin, "aws", "3.0.0"),
+		testPlugin(workspace.ResourcePlugin, "gcp", "1.0.0"),
+		testPlugin(workspace.ResourcePlugin, "gcp", "2.0.0"),
+		testPlugin(workspace.ResourcePlugin, "random", "1.0.0"),
+		testPlugin(workspace.LanguagePlugin, "nodejs", "1.0.0"),
+		testPlugin(workspace.AnalyzerPlugin, "policy", "1.0.0"),
+	}
+	referenced := []workspace.PluginInfo{
+		testPlugin(workspace.ResourcePlugin, "aws", "1.0.0"),
+		testPlugin(workspace.ResourcePlugin, "gcp", ""), // the newest installed version.
+		testPlugin(workspace.LanguagePlugin, "nodejs", ""),
+	}
+
+	prunes := selectPluginsToPrune(installed, referenced, 0)
+	assert.Equal(t, []workspace.PluginInfo{
+		testPlugin(workspace.ResourcePlugin, "aws", "2.0.0"),
+		testPlugin(workspace.ResourcePlugin, "aws", "3.0.0"),
+		testPlugin(workspace.ResourcePlugin, "gcp", "1.0.0"),
+		testPlugin(workspace.ResourcePlugin, "random", "1.0.0"),
+	}, prunes)
+
+	prunes = selectPluginsToPrune(installed, referenced, 1)
+	assert.Equal(t, []workspace.PluginInfo{
+		testPlugin(workspace.ResourcePlugin, "aws", "2.0.0"),
+		testPlugin(workspace.ResourcePlugin, "gcp", "1.0.0"),
+	}, prunes)
+
+	assert.Empty(t, selectPluginsToPrune(installed, nil, 3))
+}
+
+func TestGetSnapshotPlugins(t *testing.T) {
+	snap := &deploy.Snapshot{
+		Manifest: deploy.Manifest{
+			Plugins: []workspace.PluginInfo{testPlugin(workspace.ResourcePlugin, "aws", "1.0.0")},
+		},
+		Resources: []*resource.State{
+			{
+				Type:   "pulumi:providers:gcp",
+				URN:    "urn:pulumi:stack::project::pulumi:providers:gcp::default",
+				Inputs: resource.PropertyMap{"version": resource.NewStringProperty("2.0.0")},
+			},
+			{
+				Type:   "pulumi:providers:random",
+				URN:    "urn:pulumi:stack::project::pulumi:providers:random::default",
+				Inputs: resource.PropertyMap{},
+			},
+			{
+				Type: "aws:s3/bucket:Bucket",
+				URN:  "urn:pulumi:stack::project::aws:s3/bucket:Bucket::bucket",
+			},
+		},
+	}
+
+	plugins, err := getSnapshotPlugins(snap)
+	assert.NoError(t, err)
+	assert.Equal(t, []workspace.PluginInfo{
+		testPlugin(workspace.ResourcePlugin, "aws", "1.0.0"),
+		testPlugin(workspace.ResourcePlugin, "gcp", "2.0.0"),
+		testPlugin(workspace.ResourcePlugin, "random", ""),
+	}, plugins)
+
+	plugins, err = getSnapshotPlugins(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, plugins)
+}
@@ -45,7 +45,8 @@ func newPluginRmCmd() *cobra.Command {
 			"\n" +
 			"This removal cannot be undone.  If a deleted plugin is subsequently required\n" +
 			"in order to execute a Pulumi program, it must be re-downloaded and installed\n" +
-			"using the plugin install command.",
+			"using the plugin install command.  Plugins are not checked for use by deployments\n" +
+			"that are running, which must download a removed plugin again if they load it later.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			yes = yes || skipConfirmations()
 			opts := display.Options{
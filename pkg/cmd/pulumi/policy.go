@@ -34,6 +34,7 @@ func newPolicyCmd() *cobra.Command {
 	cmd.AddCommand(newPolicyPublishCmd())
 	cmd.AddCommand(newPolicyRmCmd())
 	cmd.AddCommand(newPolicyValidateCmd())
+	cmd.AddCommand(newPolicyValidateStackCmd())
 
 	return cmd
 }
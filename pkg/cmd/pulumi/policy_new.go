@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"sort"
 	"strings"
 
@@ -25,6 +26,8 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/executable"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/goversion"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
 	"github.com/pulumi/pulumi/sdk/v2/python"
 	"github.com/spf13/cobra"
@@ -53,8 +56,8 @@ func newPolicyNewCmd() *cobra.Command {
 		Short:      "Create a new Pulumi Policy Pack",
 		Long: "Create a new Pulumi Policy Pack from a template.\n" +
 			"\n" +
-			"To create a Policy Pack from a specific template, pass the template name (such as `aws-typescript`\n" +
-			"or `azure-python`).  If no template name is provided, a list of suggested templates will be presented\n" +
+			"To create a Policy Pack from a specific template, pass the template name (such as `aws-typescript`,\n" +
+			"`azure-python`, or `go`).  If no template name is provided, a list of suggested templates will be presented\n" +
 			"which can be selected interactively.\n" +
 			"\n" +
 			"Once you're done authoring the Policy Pack, you will need to publish the pack to your organization.\n" +
@@ -117,19 +120,33 @@ func runNewPolicyPack(args newPolicyArgs) error {
 		}
 	}
 
-	// Retrieve the templates-policy repo.
-	repo, err := workspace.RetrieveTemplates(args.templateNameOrURL, args.offline, workspace.TemplateKindPolicyPack)
+	// Built-in templates are offered alongside the templates-policy repo's when no template is specified, and are
+	// used directly when named.
+	builtins, cleanup, err := writeBuiltinPolicyTemplates(func(name string) bool {
+		return args.templateNameOrURL == "" || args.templateNameOrURL == name
+	})
 	if err != nil {
 		return err
 	}
-	defer func() {
-		contract.IgnoreError(repo.Delete())
-	}()
+	defer cleanup()
 
-	// List the templates from the repo.
-	templates, err := repo.PolicyTemplates()
-	if err != nil {
-		return err
+	templates := builtins
+	if args.templateNameOrURL == "" || len(builtins) == 0 {
+		// Retrieve the templates-policy repo.
+		repo, err := workspace.RetrieveTemplates(args.templateNameOrURL, args.offline, workspace.TemplateKindPolicyPack)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			contract.IgnoreError(repo.Delete())
+		}()
+
+		// List the templates from the repo.
+		repoTemplates, err := repo.PolicyTemplates()
+		if err != nil {
+			return err
+		}
+		templates = append(templates, repoTemplates...)
 	}
 
 	var template workspace.PolicyPackTemplate
@@ -203,7 +220,40 @@ func installPolicyPackDependencies(proj *workspace.PolicyPackProject, projPath,
 		if err := proj.Save(projPath); err != nil {
 			return errors.Wrapf(err, "saving project at %s", projPath)
 		}
+	} else if strings.EqualFold(proj.Runtime.Name(), "go") {
+		if err := goInstallPolicySDK(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// goInstallPolicySDK adds the Go SDK to the requirements of the Go Policy Pack in the current directory, downloading
+// the SDK and its dependencies.
+func goInstallPolicySDK() error {
+	fmt.Println("Installing dependencies...")
+	fmt.Println()
+
+	gobin, err := executable.FindExecutable("go")
+	if err != nil {
+		return err
+	}
+
+	if err = goversion.CheckMinimumGoVersion(gobin); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(gobin, "get", goPolicySDKRequirement())
+	cmd.Env = os.Environ()
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "`go get` failed to install dependencies; rerun manually to try again")
 	}
+
+	fmt.Println("Finished installing dependencies")
+	fmt.Println()
+
 	return nil
 }
 
@@ -215,6 +265,8 @@ func printPolicyPackNextSteps(proj *workspace.PolicyPackProject, root string, ge
 			commands = append(commands, "npm install")
 		} else if strings.EqualFold(proj.Runtime.Name(), "python") {
 			commands = append(commands, pythonCommands()...)
+		} else if strings.EqualFold(proj.Runtime.Name(), "go") {
+			commands = append(commands, "go get "+goPolicySDKRequirement())
 		}
 	}
 
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/version"
 )
 
 func TestCreatingPolicyPackWithArgsSpecifiedName(t *testing.T) {
@@ -106,3 +108,15 @@ func TestInvalidPolicyPackTemplateName(t *testing.T) {
 		assert.Contains(t, err.Error(), "not found")
 	})
 }
+
+func TestGoPolicySDKRequirement(t *testing.T) {
+	defer func(v string) { version.Version = v }(version.Version)
+
+	version.Version = "v2.22.0"
+	assert.Equal(t, "github.com/pulumi/pulumi/sdk/v2@v2.22.0", goPolicySDKRequirement())
+
+	for _, v := range []string{"", "v2.22.0-alpha.1613589219+g4d2a4f2", "v2.22.0-dev"} {
+		version.Version = v
+		assert.Equal(t, "github.com/pulumi/pulumi/sdk/v2@latest", goPolicySDKRequirement())
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/version"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// builtinPolicyTemplates are Policy Pack templates that ship with the CLI rather than the templates-policy repo,
+// keyed by template name. Each template maps file names to file contents.
+var builtinPolicyTemplates = map[string]map[string]string{
+	"go": {
+		"PulumiPolicy.yaml": `description: A minimal Policy Pack written in Go
+runtime: go
+`,
+		// The Go SDK requirement is added when the Policy Pack's dependencies are installed; see
+		// goPolicySDKRequirement.
+		"go.mod": `module pulumi-policy-pack
+
+go 1.14
+`,
+		"main.go": `package main
+
+import (
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/policy"
+)
+
+func main() {
+	policy.Run(policy.PolicyPack{
+		Name:             "go-policies",
+		EnforcementLevel: policy.Mandatory,
+		ResourcePolicies: []policy.ResourceValidationPolicy{{
+			Name:        "s3-no-public-read",
+			Description: "Prohibits setting the publicRead or publicReadWrite permission on AWS S3 buckets.",
+			Validate: func(args policy.ResourceValidationArgs, reportViolation policy.ReportViolation) {
+				if args.Resource.Type != "aws:s3/bucket:Bucket" {
+					return
+				}
+				acl := args.Resource.Properties["acl"]
+				if acl.DeepEquals(resource.NewStringProperty("public-read")) ||
+					acl.DeepEquals(resource.NewStringProperty("public-read-write")) {
+					reportViolation("You cannot set public-read or public-read-write on an S3 bucket. " +
+						"Read more about ACLs here: https://docs.aws.amazon.com/AmazonS3/latest/dev/acl-overview.html")
+				}
+			},
+		}},
+	})
+}
+`,
+	},
+}
+
+// goPolicySDKRequirement returns the `module@version` query that Go Policy Packs created by this CLI use to require the
+// Go SDK. Release builds of the CLI require the SDK release of the same version, which is published alongside the CLI.
+// Development builds require the latest published release, as their own version of the SDK has not been published.
+func goPolicySDKRequirement() string {
+	const module = "github.com/pulumi/pulumi/sdk/v2"
+	if v, err := semver.ParseTolerant(version.Version); err == nil && len(v.Pre) == 0 && len(v.Build) == 0 {
+		return fmt.Sprintf("%s@v%s", module, v)
+	}
+	return module + "@latest"
+}
+
+// writeBuiltinPolicyTemplates writes the built-in Policy Pack templates that satisfy the given filter to a temporary
+// directory. The returned function deletes the directory.
+func writeBuiltinPolicyTemplates(filter func(name string) bool) ([]workspace.PolicyPackTemplate, func(), error) {
+	var names []string
+	for name := range builtinPolicyTemplates {
+		if filter(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, func() {}, nil
+	}
+	sort.Strings(names)
+
+	root, err := ioutil.TempDir("", "pulumi-policy-templates")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating temporary directory")
+	}
+	cleanup := func() { contract.IgnoreError(os.RemoveAll(root)) }
+
+	var templates []workspace.PolicyPackTemplate
+	for _, name := range names {
+		dir := filepath.Join(root, name)
+		if err := os.Mkdir(dir, 0700); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		for file, contents := range builtinPolicyTemplates[name] {
+			if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(contents), 0600); err != nil {
+				cleanup()
+				return nil, nil, err
+			}
+		}
+
+		template, err := workspace.LoadPolicyPackTemplate(dir)
+		if err != nil {
+			cleanup()
+			return nil, nil, errors.Wrapf(err, "loading built-in template '%s'", name)
+		}
+		templates = append(templates, template)
+	}
+	return templates, cleanup, nil
+}
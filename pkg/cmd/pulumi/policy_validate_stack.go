@@ -0,0 +1,218 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// policyViolationJSON is the shape of a policy violation in the JSON output of `pulumi policy validate`.
+type policyViolationJSON struct {
+	PolicyPackName    string                   `json:"policyPackName"`
+	PolicyPackVersion string                   `json:"policyPackVersion,omitempty"`
+	PolicyName        string                   `json:"policyName"`
+	EnforcementLevel  apitype.EnforcementLevel `json:"enforcementLevel"`
+	URN               resource.URN             `json:"urn,omitempty"`
+	Message           string                   `json:"message"`
+}
+
+func newPolicyValidateStackCmd() *cobra.Command {
+	var stackName string
+	var policyPackPaths []string
+	var policyPackConfigPaths []string
+	var jsonOut bool
+
+	var cmd = &cobra.Command{
+		Use:   "validate",
+		Args:  cmdutil.NoArgs,
+		Short: "Validate an existing stack against local Policy Packs",
+		Long: "Validate an existing stack against local Policy Packs.\n" +
+			"\n" +
+			"The resources in the stack's most recent deployment are checked against the given Policy Packs\n" +
+			"without running the stack's program or any resource providers. Resource policies are evaluated\n" +
+			"against each resource's last known inputs and stack policies against the resources' last known\n" +
+			"outputs. Secret values are masked before they are sent to the Policy Packs.\n" +
+			"\n" +
+			"The command exits with a non-zero exit code if any mandatory policy violations are found.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if len(policyPackPaths) == 0 {
+				return errors.New(`at least one "--policy-pack" must be specified`)
+			}
+			if err := validatePolicyPackConfig(policyPackPaths, policyPackConfigPaths); err != nil {
+				return err
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+
+			violations, err := validateSnapshotPolicies(s.Ref().Name().String(), snap,
+				engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths))
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				if err = printPolicyViolationsJSON(violations); err != nil {
+					return err
+				}
+			} else {
+				printPolicyViolations(violations, opts)
+			}
+
+			mandatory := 0
+			for _, v := range violations {
+				if v.EnforcementLevel == apitype.Mandatory {
+					mandatory++
+				}
+			}
+			if mandatory > 0 {
+				return errors.Errorf("%d mandatory policy violation(s) found", mandatory)
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to validate. Defaults to the current stack")
+	cmd.PersistentFlags().StringSliceVar(
+		&policyPackPaths, "policy-pack", []string{},
+		"Run one or more policy packs against the stack")
+	cmd.PersistentFlags().StringSliceVar(
+		&policyPackConfigPaths, "policy-pack-config", []string{},
+		`Path to JSON file containing the config for the policy pack of the corresponding "--policy-pack" flag`)
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit output as JSON")
+
+	return cmd
+}
+
+// validateSnapshotPolicies runs the given local Policy Packs against the resources in the given snapshot and returns
+// the resulting violations, sorted by Policy Pack, enforcement level, policy, and resource.
+func validateSnapshotPolicies(stackName string, snap *deploy.Snapshot,
+	policyPacks []engine.LocalPolicyPack) ([]plugin.AnalyzeDiagnostic, error) {
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, cwd, nil, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(ctx)
+
+	// The project is not loaded, so recover its name from the stack's root resource if there is one.
+	var projectName string
+	if snap != nil {
+		for _, r := range snap.Resources {
+			if r.Type == resource.RootStackType && r.Parent == "" {
+				projectName = string(r.URN.Project())
+				break
+			}
+		}
+	}
+
+	violations, err := engine.AnalyzeSnapshot(ctx, snap, policyPacks, &plugin.PolicyAnalyzerOptions{
+		Project: projectName,
+		Stack:   stackName,
+		DryRun:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		vi, vj := violations[i], violations[j]
+		if vi.PolicyPackName != vj.PolicyPackName {
+			return vi.PolicyPackName < vj.PolicyPackName
+		}
+		if vi.EnforcementLevel != vj.EnforcementLevel {
+			return vi.EnforcementLevel > vj.EnforcementLevel // mandatory before advisory
+		}
+		if vi.PolicyName != vj.PolicyName {
+			return vi.PolicyName < vj.PolicyName
+		}
+		return vi.URN < vj.URN
+	})
+	return violations, nil
+}
+
+func printPolicyViolationsJSON(violations []plugin.AnalyzeDiagnostic) error {
+	result := make([]policyViolationJSON, len(violations))
+	for i, v := range violations {
+		result[i] = policyViolationJSON{
+			PolicyPackName:    v.PolicyPackName,
+			PolicyPackVersion: v.PolicyPackVersion,
+			PolicyName:        v.PolicyName,
+			EnforcementLevel:  v.EnforcementLevel,
+			URN:               v.URN,
+			Message:           v.Message,
+		}
+	}
+	return printJSON(result)
+}
+
+func printPolicyViolations(violations []plugin.AnalyzeDiagnostic, opts display.Options) {
+	if len(violations) == 0 {
+		fmt.Println("No policy violations found.")
+		return
+	}
+
+	fmt.Println(opts.Color.Colorize(colors.SpecHeadline + "Policy Violations:" + colors.Reset))
+	for _, v := range violations {
+		c := colors.SpecImportant
+		if v.EnforcementLevel == apitype.Mandatory {
+			c = colors.SpecError
+		}
+
+		resourceLabel := "stack"
+		if v.URN != "" {
+			resourceLabel = fmt.Sprintf("%s: %s", v.URN.Type(), v.URN.Name())
+		}
+		fmt.Println(opts.Color.Colorize(fmt.Sprintf("    %s[%s]  %s v%s %s %s (%s)",
+			c, v.EnforcementLevel, v.PolicyPackName, v.PolicyPackVersion, colors.Reset, v.PolicyName,
+			resourceLabel)))
+
+		// The message may span multiple lines, so we massage it so it will be indented properly.
+		fmt.Printf("    %s\n", strings.ReplaceAll(v.Message, "\n", "\n    "))
+	}
+}
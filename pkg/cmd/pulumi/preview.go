@@ -54,6 +54,7 @@ func newPreviewCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var updateLock bool
 
 	var cmd = &cobra.Command{
 		Use:        "preview",
@@ -154,6 +155,7 @@ func newPreviewCmd() *cobra.Command {
 					DisableResourceReferences: disableResourceReferences(),
 					UpdateTargets:             targetURNs,
 					TargetDependents:          targetDependents,
+					UpdateLock:                updateLock,
 				},
 				Display: displayOpts,
 			}
@@ -220,6 +222,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().BoolVar(
+		&updateLock, "update-lock", false,
+		"Preview with the plugins the program requests instead of those pinned by the project's Pulumi.lock file")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
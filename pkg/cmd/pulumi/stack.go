@@ -170,6 +170,7 @@ func newStackCmd() *cobra.Command {
 		&showStackName, "show-name", false, "Display only the stack name")
 
 	cmd.AddCommand(newStackExportCmd())
+	cmd.AddCommand(newStackGenCodeCmd())
 	cmd.AddCommand(newStackGraphCmd())
 	cmd.AddCommand(newStackImportCmd())
 	cmd.AddCommand(newStackInitCmd())
@@ -0,0 +1,238 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/importer"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+func newStackGenCodeCmd() *cobra.Command {
+	var stackName string
+	var language string
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "gen-code",
+		Args:  cmdutil.NoArgs,
+		Short: "Generate a program from a stack's state",
+		Long: "Generate a program from a stack's state.\n" +
+			"\n" +
+			"This command generates a program in the language given by --language that defines\n" +
+			"each custom resource and provider in the stack's most recent deployment. The program\n" +
+			"preserves each resource's parent, provider, protection and aliases. Literal values that\n" +
+			"exactly match the ID or an output of another resource in the stack are replaced with\n" +
+			"references to that resource.\n" +
+			"\n" +
+			"Component resources are not generated: the children of a component are parented to\n" +
+			"its nearest generated ancestor instead, and are aliased to their original URNs so that\n" +
+			"running the generated program does not replace them. External resources that were read\n" +
+			"rather than managed by the stack are skipped.\n" +
+			"\n" +
+			"This is useful for recovering the source of a stack whose program has been lost.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			generator, ok := programGenerators[language]
+			if !ok {
+				return errors.Errorf("unsupported language %q; supported languages are %s",
+					language, strings.Join(supportedProgramLanguages(), ", "))
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			if snap == nil {
+				return errors.Errorf("unable to find snapshot for stack %q", stackName)
+			}
+
+			states, names, external := stackProgramStates(snap)
+			for _, urn := range external {
+				cmdutil.Diag().Warningf(diag.Message(urn, "skipping external resource %s"), urn)
+			}
+			if len(states) == 0 {
+				return errors.Errorf("stack %q does not contain any resources", s.Ref().Name())
+			}
+
+			output := io.Writer(os.Stdout)
+			if out != "" {
+				f, err := os.Create(out)
+				if err != nil {
+					return errors.Wrap(err, "could not open output file")
+				}
+				defer contract.IgnoreClose(f)
+				output = f
+			}
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, cwd, nil, false, nil)
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(ctx)
+
+			loader := schema.NewPluginLoader(ctx.Host)
+			_, err = importer.GenerateProgram(output, loader, func(w io.Writer, p *hcl2.Program) error {
+				files, _, err := generator(p)
+				if err != nil {
+					return err
+				}
+				for _, contents := range files {
+					if _, err := w.Write(contents); err != nil {
+						return err
+					}
+				}
+				return nil
+			}, states, names)
+			if err != nil {
+				if diags, ok := err.(*importer.DiagnosticsError); ok {
+					diagWriter := diags.NewDiagnosticWriter(os.Stderr, 0, true)
+					contract.IgnoreError(diagWriter.WriteDiagnostics(diags.Diagnostics()))
+					return errors.New("could not generate a program for the stack")
+				}
+				return err
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(&language,
+		"language", "", "The language to generate: one of "+strings.Join(supportedProgramLanguages(), ", "))
+	cmd.PersistentFlags().StringVarP(&out,
+		"out", "o", "", "The file to write the generated program to. Defaults to stdout")
+
+	return cmd
+}
+
+// stackProgramStates returns the states of the resources in the given snapshot that should be defined by a program
+// generated from the snapshot, the names of those resources, and the URNs of any external resources, which are not
+// generated.
+//
+// Only custom resources and non-default providers are generated. The children of a resource that is not generated are
+// parented to its nearest generated ancestor and aliased to their original URNs.
+func stackProgramStates(snap *deploy.Snapshot) ([]*resource.State, importer.NameTable, []resource.URN) {
+	var states []*resource.State
+	var external []resource.URN
+	names := importer.NameTable{}
+	taken := map[string]bool{}
+
+	// parents maps the URN of each resource to the URN of its nearest generated ancestor, or to the empty string if it
+	// has none. urns maps the URN of each generated resource to the URN it has in the generated program.
+	parents := map[resource.URN]resource.URN{}
+	urns := map[resource.URN]resource.URN{}
+
+	for _, state := range snap.Resources {
+		if state.Delete {
+			continue
+		}
+
+		parent := state.Parent
+		if _, ok := urns[parent]; !ok {
+			parent = parents[parent]
+		}
+
+		switch {
+		case !state.Custom || providers.IsDefaultProvider(state.URN):
+			parents[state.URN] = parent
+			continue
+		case state.External:
+			external = append(external, state.URN)
+			parents[state.URN] = parent
+			continue
+		}
+
+		s := *state
+		s.Parent = parent
+
+		var parentType tokens.Type
+		if parent != "" {
+			parentType = urns[parent].QualifiedType()
+		}
+		urn := resource.NewURN(state.URN.Stack(), state.URN.Project(), parentType, state.Type, state.URN.Name())
+		urns[state.URN] = urn
+		if urn != state.URN {
+			s.Aliases = append(append([]resource.URN{}, state.Aliases...), state.URN)
+		}
+
+		s.Dependencies = nil
+		for _, dep := range state.Dependencies {
+			if _, ok := urns[dep]; ok {
+				s.Dependencies = append(s.Dependencies, dep)
+			}
+		}
+
+		names[state.URN] = uniqueResourceName(string(state.URN.Name()), taken)
+		states = append(states, &s)
+	}
+
+	return states, names, external
+}
+
+// uniqueResourceName returns a valid PCL identifier derived from the given resource name that is not already taken.
+func uniqueResourceName(name string, taken map[string]bool) string {
+	var b strings.Builder
+	for i, c := range name {
+		if c == '_' || c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)) {
+			if i == 0 && unicode.IsDigit(c) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(c)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	base := b.String()
+	if base == "" {
+		base = "resource"
+	}
+
+	unique := base
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", base, i)
+	}
+	taken[unique] = true
+	return unique
+}
@@ -0,0 +1,104 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/importer"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func TestStackProgramStates(t *testing.T) {
+	newURN := func(parent resource.URN, typ tokens.Type, name string) resource.URN {
+		var parentType tokens.Type
+		if parent != "" && parent.Type() != resource.RootStackType {
+			parentType = parent.QualifiedType()
+		}
+		return resource.NewURN("dev", "proj", parentType, typ, tokens.QName(name))
+	}
+
+	stackURN := newURN("", resource.RootStackType, "proj-dev")
+	defaultProviderURN := newURN("", providers.MakeProviderType("pkgA"), "default")
+	providerURN := newURN("", providers.MakeProviderType("pkgA"), "my-provider")
+	componentURN := newURN(stackURN, "my:component:Component", "comp")
+	childURN := newURN(componentURN, "pkgA:m:typA", "child")
+	grandchildURN := newURN(childURN, "pkgA:m:typA", "child")
+	externalURN := newURN(stackURN, "pkgA:m:typA", "external")
+	deletedURN := newURN(stackURN, "pkgA:m:typA", "deleted")
+	topURN := newURN(stackURN, "pkgA:m:typA", "1st")
+
+	snap := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{URN: stackURN, Type: resource.RootStackType},
+			{URN: defaultProviderURN, Type: providers.MakeProviderType("pkgA"), Custom: true, ID: "p0"},
+			{URN: providerURN, Type: providers.MakeProviderType("pkgA"), Custom: true, ID: "p1", Parent: stackURN},
+			{URN: componentURN, Type: "my:component:Component", Parent: stackURN},
+			{URN: childURN, Type: "pkgA:m:typA", Custom: true, ID: "c", Parent: componentURN,
+				Provider: string(providerURN) + "::p1"},
+			{URN: grandchildURN, Type: "pkgA:m:typA", Custom: true, ID: "g", Parent: childURN,
+				Dependencies: []resource.URN{componentURN, childURN}},
+			{URN: externalURN, Type: "pkgA:m:typA", Custom: true, ID: "e", Parent: stackURN, External: true},
+			{URN: deletedURN, Type: "pkgA:m:typA", Custom: true, ID: "d", Parent: stackURN, Delete: true},
+			{URN: topURN, Type: "pkgA:m:typA", Custom: true, ID: "t", Parent: stackURN, Protect: true,
+				Dependencies: []resource.URN{externalURN, providerURN}},
+		},
+	}
+
+	states, names, external := stackProgramStates(snap)
+
+	assert.Equal(t, []resource.URN{externalURN}, external)
+	assert.Equal(t, importer.NameTable{
+		providerURN:   "my_provider",
+		childURN:      "child",
+		grandchildURN: "child2",
+		topURN:        "_1st",
+	}, names)
+
+	if !assert.Len(t, states, 4) {
+		t.FailNow()
+	}
+
+	provider, child, grandchild, top := states[0], states[1], states[2], states[3]
+
+	// Resources whose URNs do not change are not aliased.
+	assert.Equal(t, providerURN, provider.URN)
+	assert.Equal(t, resource.URN(""), provider.Parent)
+	assert.Len(t, provider.Aliases, 0)
+
+	// The child of the component is parented to the stack and aliased to its original URN.
+	assert.Equal(t, childURN, child.URN)
+	assert.Equal(t, resource.URN(""), child.Parent)
+	assert.Equal(t, []resource.URN{childURN}, child.Aliases)
+	assert.Equal(t, string(providerURN)+"::p1", child.Provider)
+
+	// The grandchild keeps its parent, but its URN changes because its parent's URN changes.
+	assert.Equal(t, childURN, grandchild.Parent)
+	assert.Equal(t, []resource.URN{grandchildURN}, grandchild.Aliases)
+	assert.Equal(t, []resource.URN{childURN}, grandchild.Dependencies)
+
+	assert.True(t, top.Protect)
+	assert.Len(t, top.Aliases, 0)
+	assert.Equal(t, []resource.URN{providerURN}, top.Dependencies)
+
+	// The snapshot itself is not modified.
+	assert.Equal(t, componentURN, snap.Resources[4].Parent)
+	assert.Len(t, snap.Resources[4].Aliases, 0)
+}
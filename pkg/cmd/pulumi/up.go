@@ -73,6 +73,7 @@ func newUpCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var updateLock bool
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
 	upWorkingDirectory := func(opts backend.UpdateOptions) result.Result {
@@ -133,6 +134,7 @@ func newUpCmd() *cobra.Command {
 			DisableResourceReferences: disableResourceReferences(),
 			UpdateTargets:             targetURNs,
 			TargetDependents:          targetDependents,
+			UpdateLock:                updateLock,
 		}
 
 		changes, res := s.Update(commandContext(), backend.UpdateOperation{
@@ -290,6 +292,7 @@ func newUpCmd() *cobra.Command {
 			Parallel:         parallel,
 			Debug:            debug,
 			Refresh:          refresh,
+			UpdateLock:       updateLock,
 		}
 
 		// TODO for the URL case:
@@ -424,6 +427,10 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().BoolVar(
+		&updateLock, "update-lock", false,
+		"Update the project's Pulumi.lock file to match the plugins used by this update, instead of failing if they "+
+			"differ")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
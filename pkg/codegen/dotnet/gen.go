@@ -912,6 +912,11 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 		fmt.Fprintf(w, "        }\n")
 	}
 
+	// Write the resource's methods.
+	for _, method := range r.Methods {
+		mod.genMethod(w, className, method)
+	}
+
 	// Close the class.
 	fmt.Fprintf(w, "    }\n")
 
@@ -956,12 +961,95 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 		}
 	}
 
+	// Generate the args and result types of the resource's methods, if any.
+	for _, method := range r.Methods {
+		methodName := Title(method.Name)
+		if args := methodArgs(method.Function); len(args) > 0 {
+			methodArgs := &plainType{
+				mod:                   mod,
+				res:                   r,
+				name:                  name + methodName + "Args",
+				baseClass:             "InvokeArgs",
+				propertyTypeQualifier: "Inputs",
+				properties:            args,
+				wrapInput:             true,
+			}
+			if err := methodArgs.genInputType(w, 1); err != nil {
+				return err
+			}
+		}
+		if method.Function.Outputs != nil {
+			methodResult := &plainType{
+				mod:                   mod,
+				name:                  name + methodName + "Result",
+				propertyTypeQualifier: "Outputs",
+				properties:            method.Function.Outputs.Properties,
+			}
+			methodResult.genOutputType(w, 1)
+		}
+	}
+
 	// Close the namespace.
 	fmt.Fprintf(w, "}\n")
 
 	return nil
 }
 
+// methodArgs returns the arguments of a method, excluding its `__self__` argument.
+func methodArgs(f *schema.Function) []*schema.Property {
+	if f.Inputs == nil {
+		return nil
+	}
+	var args []*schema.Property
+	for _, p := range f.Inputs.Properties {
+		if p.Name != "__self__" {
+			args = append(args, p)
+		}
+	}
+	return args
+}
+
+func (mod *modContext) genMethod(w io.Writer, className string, method *schema.Method) {
+	f := method.Function
+	methodName := Title(method.Name)
+	args := methodArgs(f)
+
+	var typeParameter, retty string
+	if f.Outputs != nil {
+		typeParameter = fmt.Sprintf("<%s%sResult>", className, methodName)
+		retty = "Output" + typeParameter
+	} else {
+		retty = "void"
+	}
+
+	var argsParamDef string
+	argsParamRef := "InvokeArgs.Empty"
+	if len(args) > 0 {
+		allOptionalInputs := true
+		for _, prop := range args {
+			allOptionalInputs = allOptionalInputs && !prop.IsRequired
+		}
+
+		var argsDefault, sigil string
+		if allOptionalInputs {
+			// If the number of required input properties was zero, we can make the args object optional.
+			argsDefault, sigil = " = null", "?"
+		}
+
+		argsParamDef = fmt.Sprintf("%s%sArgs%s args%s", className, methodName, sigil, argsDefault)
+		argsParamRef = fmt.Sprintf("args ?? new %s%sArgs()", className, methodName)
+	}
+
+	fmt.Fprintf(w, "\n")
+	printComment(w, f.Comment, "        ")
+	if f.DeprecationMessage != "" {
+		fmt.Fprintf(w, "        [Obsolete(@\"%s\")]\n", strings.Replace(f.DeprecationMessage, `"`, `""`, -1))
+	}
+	fmt.Fprintf(w, "        public %s %s(%s)\n", retty, methodName, argsParamDef)
+	fmt.Fprintf(w, "            => Pulumi.Deployment.Instance.Call%s(\"%s\", %s, this);\n",
+		typeParameter, f.Token, argsParamRef)
+}
+
 func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) error {
 	className := tokenToFunctionName(fun.Token)
 
@@ -1007,6 +1095,19 @@ func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) error {
 	fmt.Fprintf(w, "            => Pulumi.Deployment.Instance.InvokeAsync%s(\"%s\", %s, options.WithVersion());\n",
 		typeParameter, fun.Token, argsParamRef)
 
+	// Emit a variant of the datasource method that accepts inputs and returns an output.
+	if fun.NeedsOutputVersion() {
+		outputArgsParamDef := strings.Replace(argsParamDef, className+"Args", className+"InvokeArgs", 1)
+		outputArgsParamRef := fmt.Sprintf("args ?? new %sInvokeArgs()", className)
+
+		fmt.Fprintf(w, "\n")
+		printComment(w, fun.Comment, "        ")
+		fmt.Fprintf(w, "        public static Output%s Invoke(%sInvokeOptions? options = null)\n",
+			typeParameter, outputArgsParamDef)
+		fmt.Fprintf(w, "            => Pulumi.Deployment.Instance.Invoke%s(\"%s\", %s, options.WithVersion());\n",
+			typeParameter, fun.Token, outputArgsParamRef)
+	}
+
 	// Close the class.
 	fmt.Fprintf(w, "    }\n")
 
@@ -1024,6 +1125,22 @@ func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) error {
 		if err := args.genInputType(w, 1); err != nil {
 			return err
 		}
+
+		if fun.NeedsOutputVersion() {
+			fmt.Fprintf(w, "\n")
+
+			args := &plainType{
+				mod:                   mod,
+				name:                  className + "InvokeArgs",
+				baseClass:             "InvokeArgs",
+				propertyTypeQualifier: "Inputs",
+				properties:            fun.Inputs.Properties,
+				wrapInput:             true,
+			}
+			if err := args.genInputType(w, 1); err != nil {
+				return err
+			}
+		}
 	}
 	if fun.Outputs != nil {
 		fmt.Fprintf(w, "\n")
@@ -1331,6 +1448,16 @@ func (mod *modContext) getImports(member interface{}, imports map[string]codegen
 		for _, p := range member.InputProperties {
 			mod.getTypeImports(p.Type, false, imports, seen)
 		}
+		for _, method := range member.Methods {
+			for _, p := range methodArgs(method.Function) {
+				mod.getTypeImports(p.Type, false, imports, seen)
+			}
+			if method.Function.Outputs != nil {
+				for _, p := range method.Function.Outputs.Properties {
+					mod.getTypeImports(p.Type, false, imports, seen)
+				}
+			}
+		}
 		return
 	case *schema.Function:
 		if member.Inputs != nil {
@@ -1850,7 +1977,10 @@ func generateModuleContextMap(tool string, pkg *schema.Package) (map[string]*mod
 	// Find input and output types referenced by functions.
 	for _, f := range pkg.Functions {
 		mod := getModFromToken(f.Token, pkg)
-		mod.functions = append(mod.functions, f)
+		// Methods are generated as part of the resources to which they belong.
+		if !f.IsMethod {
+			mod.functions = append(mod.functions, f)
+		}
 		if f.Inputs != nil {
 			visitObjectTypes(f.Inputs, func(t *schema.ObjectType) {
 				details := getModFromToken(t.Token, t.Package).details(t)
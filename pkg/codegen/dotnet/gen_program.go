@@ -42,10 +42,14 @@ type generator struct {
 	tokenToModules map[string]func(x string) string
 	// Type names per invoke function token.
 	functionArgs map[string]string
+	// True if the arguments being generated are for an invoke that returns an output.
+	outputVersionInvoke bool
 	// Whether awaits are needed, and therefore an async Initialize method should be declared.
 	asyncInit     bool
 	configCreated bool
 	diagnostics   hcl.Diagnostics
+	// The names of the builtin functions whose helper methods must be emitted into the stack class.
+	helpers codegen.StringSet
 }
 
 const pulumiPackage = "pulumi"
@@ -83,6 +87,7 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 		compatibilities: compatibilities,
 		tokenToModules:  tokenToModules,
 		functionArgs:    functionArgs,
+		helpers:         codegen.NewStringSet(),
 	}
 	g.Formatter = format.NewFormatter(g)
 
@@ -163,6 +168,9 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 						pulumiUsings.Add(i)
 					}
 				}
+				if _, ok := functionHelpers[call.Name]; ok {
+					g.helpers.Add(call.Name)
+				}
 			}
 			if _, ok := n.(*model.SplatExpression); ok {
 				systemUsings.Add("System.Linq")
@@ -170,6 +178,13 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 			return n, nil
 		})
 		contract.Assert(len(diags) == 0)
+
+		if o, ok := n.(*hcl2.OutputVariable); ok && !g.asyncInit {
+			if strings.HasPrefix(outputElementTypeName(o.Value.Type()), "ImmutableArray<") {
+				systemUsings.Add("System.Collections.Immutable")
+				systemUsings.Add("System.Linq")
+			}
+		}
 	}
 
 	if g.asyncInit {
@@ -241,6 +256,11 @@ func (g *generator) genPostamble(w io.Writer, nodes []hcl2.Node) {
 				g.genOutputProperty(w, n)
 			}
 		}
+
+		// Emit helper methods
+		for _, name := range g.helpers.SortedValues() {
+			g.Fprintf(w, "\n%s", functionHelpers[name])
+		}
 	})
 	g.Fprint(w, "}\n")
 }
@@ -345,10 +365,15 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type)
 
 	token := objType.Token
 	tokenRange := expr.SyntaxNode().Range()
-	qualifier := "Inputs"
+	qualifier, suffix := "Inputs", "Args"
 	if f, ok := g.functionArgs[token]; ok {
 		token = f
 		qualifier = ""
+
+		// The variant of the function that returns an output accepts arguments that may be outputs.
+		if g.outputVersionInvoke {
+			suffix = "InvokeArgs"
+		}
 	}
 
 	pkg, _, member, diags := hcl2.DecomposeToken(token, tokenRange)
@@ -368,7 +393,7 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type)
 	} else if qualifier != "" {
 		namespace = namespace + "." + qualifier
 	}
-	member = member + "Args"
+	member = member + suffix
 
 	return fmt.Sprintf("%s%s.%s", rootNamespace, namespace, Title(member))
 }
@@ -388,13 +413,15 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	}
 
 	var result bytes.Buffer
-	appendOption := func(name string, value model.Expression) {
+	startOptions := func() {
 		if result.Len() == 0 {
 			_, err := fmt.Fprintf(&result, ", new CustomResourceOptions\n%s{", g.Indent)
 			g.Indent += "    "
 			contract.IgnoreError(err)
 		}
-
+	}
+	appendOption := func(name string, value model.Expression) {
+		startOptions()
 		g.Fgenf(&result, "\n%s%s = %v,", g.Indent, name, g.lowerExpression(value, value.Type()))
 	}
 
@@ -413,6 +440,19 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	if opts.IgnoreChanges != nil {
 		appendOption("IgnoreChanges", opts.IgnoreChanges)
 	}
+	if aliases, ok := opts.Aliases.(*model.TupleConsExpression); ok {
+		// Aliases are given as URNs, each of which must be wrapped in an Alias.
+		startOptions()
+		g.Fgenf(&result, "\n%sAliases =\n%s{", g.Indent, g.Indent)
+		g.Indented(func() {
+			for _, urn := range aliases.Expressions {
+				g.Fgenf(&result, "\n%snew Alias { Urn = %.v },", g.Indent, g.lowerExpression(urn, urn.Type()))
+			}
+		})
+		g.Fgenf(&result, "\n%s},", g.Indent)
+	} else if opts.Aliases != nil {
+		appendOption("Aliases", opts.Aliases)
+	}
 
 	if result.Len() != 0 {
 		g.Indent = g.Indent[:len(g.Indent)-4]
@@ -536,19 +576,76 @@ func (g *generator) genLocalVariable(w io.Writer, v *hcl2.LocalVariable) {
 }
 
 func (g *generator) genOutputAssignment(w io.Writer, v *hcl2.OutputVariable) {
+	expr := g.lowerExpression(v.Value, v.Type())
 	if g.asyncInit {
-		g.Fgenf(w, "%svar %s", g.Indent, makeValidIdentifier(v.Name()))
-	} else {
-		g.Fgenf(w, "%sthis.%s", g.Indent, propertyName(v.Name()))
-	}
-	g.Fgenf(w, " = %.3v;\n", g.lowerExpression(v.Value, v.Type()))
+		g.Fgenf(w, "%svar %s = %.3v;\n", g.Indent, makeValidIdentifier(v.Name()), expr)
+		return
+	}
+
+	g.Fgenf(w, "%sthis.%s = ", g.Indent, propertyName(v.Name()))
+	elementType := outputElementTypeName(v.Value.Type())
+	isEventual := false
+	switch expr.Type().(type) {
+	case *model.OutputType, *model.PromiseType:
+		isEventual = true
+	}
+	switch {
+	case isEventual && elementType == "object":
+		g.Fgenf(w, "%.v.Apply(value => (object)value)", expr)
+	case isEventual:
+		g.Fgenf(w, "%.3v", expr)
+	case strings.HasPrefix(elementType, "ImmutableArray<"):
+		elementType = strings.TrimSuffix(strings.TrimPrefix(elementType, "ImmutableArray<"), ">")
+		g.Fgenf(w, "Output.Create(%.20v.Cast<%s>().ToImmutableArray())", expr, elementType)
+	case elementType == "object":
+		g.Fgenf(w, "Output.Create<object>(%.v)", expr)
+	default:
+		g.Fgenf(w, "Output.Create(%.v)", expr)
+	}
+	g.Fgen(w, ";\n")
 }
 
 func (g *generator) genOutputProperty(w io.Writer, v *hcl2.OutputVariable) {
 	// TODO(pdg): trivia
 	g.Fgenf(w, "%s[Output(\"%s\")]\n", g.Indent, v.Name())
-	// TODO(msh): derive the element type of the Output from the type of its value.
-	g.Fgenf(w, "%spublic Output<string> %s { get; set; }\n", g.Indent, propertyName(v.Name()))
+
+	// The outputs of programs that are initialized asynchronously are all returned as strings.
+	elementType := "string"
+	if !g.asyncInit {
+		elementType = outputElementTypeName(v.Value.Type())
+	}
+	g.Fgenf(w, "%spublic Output<%s> %s { get; set; }\n", g.Indent, elementType, propertyName(v.Name()))
+}
+
+// outputElementTypeName returns the name of the C# type of the value of a stack output of the given type. Lists of
+// primitive values are immutable arrays; any other value that is not primitive is an object.
+func outputElementTypeName(t model.Type) string {
+	if list, ok := model.ResolveOutputs(t).(*model.ListType); ok {
+		if elementType := primitiveTypeName(list.ElementType); elementType != "" {
+			return "ImmutableArray<" + elementType + ">"
+		}
+		return "object"
+	}
+	if name := primitiveTypeName(t); name != "" {
+		return name
+	}
+	return "object"
+}
+
+// primitiveTypeName returns the name of the C# type that corresponds to the given primitive type, if any.
+func primitiveTypeName(t model.Type) string {
+	switch model.ResolveOutputs(t) {
+	case model.StringType:
+		return "string"
+	case model.NumberType:
+		return "double"
+	case model.IntType:
+		return "int"
+	case model.BoolType:
+		return "bool"
+	default:
+		return ""
+	}
 }
 
 func (g *generator) genNYI(w io.Writer, reason string, vs ...interface{}) {
@@ -88,9 +88,9 @@ func (g *generator) awaitInvokes(x model.Expression) model.Expression {
 	contract.Assert(g.asyncInit)
 
 	rewriter := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
-		// Ignore the node if it is not a call to invoke.
+		// Ignore the node if it is not a call to invoke or if it is a call to an invoke that returns an output.
 		call, ok := x.(*model.FunctionCallExpression)
-		if !ok || call.Name != hcl2.Invoke {
+		if !ok || call.Name != hcl2.Invoke || hcl2.IsOutputVersionInvokeCall(call) {
 			return x, nil
 		}
 
@@ -239,9 +239,66 @@ func (g *generator) genRange(w io.Writer, call *model.FunctionCallExpression, en
 }
 
 var functionNamespaces = map[string][]string{
-	"readDir":  {"System.IO", "System.Linq"},
-	"readFile": {"System.IO"},
-	"toJSON":   {"System.Text.Json", "System.Collections.Generic"},
+	"cidrsubnet": {"System", "System.Net"},
+	"flatten":    {"System.Linq"},
+	"fromBase64": {"System", "System.Text"},
+	"fromJSON":   {"System.Text.Json"},
+	"merge":      {"System.Collections.Generic"},
+	"readDir":    {"System.IO", "System.Linq"},
+	"readFile":   {"System.IO"},
+	"sha1":       {"System", "System.Security.Cryptography", "System.Text"},
+	"sha256":     {"System", "System.Security.Cryptography", "System.Text"},
+	"toBase64":   {"System", "System.Text"},
+	"toJSON":     {"System.Text.Json", "System.Collections.Generic"},
+}
+
+// functionHelpers contains the definitions of the helper methods that are used to implement builtin functions that
+// have no equivalent in the standard library. Each helper is emitted into the stack class of any program that uses it.
+var functionHelpers = map[string]string{
+	"cidrsubnet": `    private static string CidrSubnet(string prefix, int newbits, int netnum)
+    {
+        var parts = prefix.Split('/');
+        var bytes = IPAddress.Parse(parts[0]).GetAddressBytes();
+        var length = int.Parse(parts[1]);
+        var newLength = length + newbits;
+        if (newLength > bytes.Length * 8 || netnum >= 1L << newbits)
+        {
+            throw new ArgumentException($"cannot create subnet {netnum} of {prefix} with {newbits} additional bits");
+        }
+        for (var i = length; i < bytes.Length * 8; i++)
+        {
+            var set = i < newLength && ((long)netnum >> (newLength - i - 1) & 1) == 1;
+            var mask = (byte)(0x80 >> (i % 8));
+            bytes[i / 8] = (byte)(set ? bytes[i / 8] | mask : bytes[i / 8] & ~mask);
+        }
+        return $"{new IPAddress(bytes)}/{newLength}";
+    }
+`,
+	"merge": `    private static Dictionary<string, object?> Merge(params IDictionary<string, object?>[] maps)
+    {
+        var result = new Dictionary<string, object?>();
+        foreach (var map in maps)
+        {
+            foreach (var entry in map)
+            {
+                result[entry.Key] = entry.Value;
+            }
+        }
+        return result;
+    }
+`,
+	"sha1": `    private static string ComputeSHA1(string input)
+    {
+        var hash = SHA1.Create().ComputeHash(Encoding.UTF8.GetBytes(input));
+        return BitConverter.ToString(hash).Replace("-", "").ToLowerInvariant();
+    }
+`,
+	"sha256": `    private static string ComputeSHA256(string input)
+    {
+        var hash = SHA256.Create().ComputeHash(Encoding.UTF8.GetBytes(input));
+        return BitConverter.ToString(hash).Replace("-", "").ToLowerInvariant();
+    }
+`,
 }
 
 func (g *generator) genFunctionUsings(x *model.FunctionCallExpression) []string {
@@ -270,6 +327,8 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgenf(w, "Output.Create(%.v)", expr.Args[0])
 	case "element":
 		g.Fgenf(w, "%.20v[%.v]", expr.Args[0], expr.Args[1])
+	case "cidrsubnet":
+		g.Fgenf(w, "CidrSubnet(%.v, %.v, %.v)", expr.Args[0], expr.Args[1], expr.Args[2])
 	case "entries":
 		switch model.ResolveOutputs(expr.Args[0].Type()).(type) {
 		case *model.ListType, *model.TupleType:
@@ -286,6 +345,25 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgenf(w, "new FileArchive(%.v)", expr.Args[0])
 	case "fileAsset":
 		g.Fgenf(w, "new FileAsset(%.v)", expr.Args[0])
+	case "flatten":
+		g.genCollection(w, expr.Args[0])
+		g.Fgen(w, ".SelectMany(l => l)")
+	case "format":
+		format, ok := formatString(expr.Args[0])
+		if !ok {
+			g.genNYI(w, "call %v with a non-literal format string", expr.Name)
+			return
+		}
+		g.Fgen(w, "string.Format(")
+		g.genStringLiteral(w, format)
+		for _, arg := range expr.Args[1:] {
+			g.Fgenf(w, ", %.v", arg)
+		}
+		g.Fgen(w, ")")
+	case "fromBase64":
+		g.Fgenf(w, "Encoding.UTF8.GetString(Convert.FromBase64String(%.v))", expr.Args[0])
+	case "fromJSON":
+		g.Fgenf(w, "JsonDocument.Parse(%.v).RootElement", expr.Args[0])
 	case hcl2.Invoke:
 		_, name := g.functionName(expr.Args[0])
 
@@ -296,7 +374,21 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 			optionsBag = buf.String()
 		}
 
-		g.Fgenf(w, "%s.InvokeAsync(%.v%v)", name, expr.Args[1], optionsBag)
+		method, outputVersionInvoke := "InvokeAsync", hcl2.IsOutputVersionInvokeCall(expr)
+		if outputVersionInvoke {
+			method = "Invoke"
+		}
+
+		prev := g.outputVersionInvoke
+		g.outputVersionInvoke = outputVersionInvoke
+		g.Fgenf(w, "%s.%s(%.v%v)", name, method, expr.Args[1], optionsBag)
+		g.outputVersionInvoke = prev
+	case "join":
+		g.Fgenf(w, "string.Join(%.v, ", expr.Args[0])
+		g.genCollection(w, expr.Args[1])
+		g.Fgen(w, ")")
+	case "keys":
+		g.Fgenf(w, "%.20v.Keys", expr.Args[0])
 	case "length":
 		g.Fgenf(w, "%.20v.Length", expr.Args[0])
 	case "lookup":
@@ -304,25 +396,92 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		if len(expr.Args) == 3 {
 			g.Fgenf(w, " ?? %v", expr.Args[2])
 		}
+	case "merge":
+		g.Fgen(w, "Merge(")
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.genCollection(w, arg)
+		}
+		g.Fgen(w, ")")
 	case "range":
 		g.genRange(w, expr, false)
 	case "readFile":
 		g.Fgenf(w, "File.ReadAllText(%v)", expr.Args[0])
 	case "readDir":
 		g.Fgenf(w, "Directory.GetFiles(%.v).Select(Path.GetFileName)", expr.Args[0])
+	case "replace":
+		g.Fgenf(w, "%.20v.Replace(%.v, %.v)", expr.Args[0], expr.Args[1], expr.Args[2])
 	case "secret":
 		g.Fgenf(w, "Output.CreateSecret(%v)", expr.Args[0])
+	case "sha1":
+		g.Fgenf(w, "ComputeSHA1(%.v)", expr.Args[0])
+	case "sha256":
+		g.Fgenf(w, "ComputeSHA256(%.v)", expr.Args[0])
 	case "split":
 		g.Fgenf(w, "%.20v.Split(%v)", expr.Args[1], expr.Args[0])
+	case "toBase64":
+		g.Fgenf(w, "Convert.ToBase64String(Encoding.UTF8.GetBytes(%.v))", expr.Args[0])
 	case "toJSON":
 		g.Fgen(w, "JsonSerializer.Serialize(")
 		g.genDictionary(w, expr.Args[0])
 		g.Fgen(w, ")")
+	case "values":
+		g.Fgenf(w, "%.20v.Values", expr.Args[0])
 	default:
 		g.genNYI(w, "call %v", expr.Name)
 	}
 }
 
+// formatString converts the given literal PCL format string into a .NET composite format string. Each %s, %d, or %v
+// verb is replaced with the corresponding indexed placeholder.
+func formatString(expr model.Expression) (string, bool) {
+	template, ok := expr.(*model.TemplateExpression)
+	if !ok {
+		return "", false
+	}
+	var spec strings.Builder
+	for _, part := range template.Parts {
+		lit, ok := part.(*model.LiteralValueExpression)
+		if !ok || lit.Type() != model.StringType {
+			return "", false
+		}
+		spec.WriteString(lit.Value.AsString())
+	}
+
+	var result strings.Builder
+	runes, arg := []rune(spec.String()), 0
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; {
+		case c == '%' && i+1 < len(runes) && runes[i+1] == '%':
+			result.WriteRune('%')
+			i++
+		case c == '%' && i+1 < len(runes) && strings.ContainsRune("sdv", runes[i+1]):
+			fmt.Fprintf(&result, "{%d}", arg)
+			arg++
+			i++
+		case c == '{' || c == '}':
+			result.WriteRune(c)
+			result.WriteRune(c)
+		default:
+			result.WriteRune(c)
+		}
+	}
+	return result.String(), true
+}
+
+// genCollection generates a list or map argument to a builtin function. Literal lists and maps are generated as arrays
+// and dictionaries, respectively.
+func (g *generator) genCollection(w io.Writer, expr model.Expression) {
+	switch expr.(type) {
+	case *model.ObjectConsExpression, *model.TupleConsExpression:
+		g.genDictionary(w, expr)
+	default:
+		g.Fgenf(w, "%.20v", expr)
+	}
+}
+
 func (g *generator) genDictionary(w io.Writer, expr model.Expression) {
 	switch expr := expr.(type) {
 	case *model.ObjectConsExpression:
@@ -23,6 +23,13 @@ func TestGeneratePackage(t *testing.T) {
 				"ArgFunction.cs",
 			},
 		},
+		{
+			"Simple schema with methods",
+			"simple-methods-schema",
+			[]string{
+				"Foo.cs",
+			},
+		},
 		{
 			"Simple schema with enum types",
 			"simple-enum-schema",
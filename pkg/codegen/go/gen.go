@@ -1251,9 +1251,19 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 			fmt.Fprintf(w, "}\n\n")
 		}
 	}
+	// Emit the resource's methods.
+	for _, method := range r.Methods {
+		pkg.genMethod(w, name, method)
+	}
+
 	// Register all output types
 	fmt.Fprintf(w, "func init() {\n")
 	fmt.Fprintf(w, "\tpulumi.RegisterOutputType(%sOutput{})\n", name)
+	for _, method := range r.Methods {
+		if method.Function.Outputs != nil {
+			fmt.Fprintf(w, "\tpulumi.RegisterOutputType(%s%sResultOutput{})\n", name, Title(method.Name))
+		}
+	}
 
 	if generateResourceContainerTypes {
 		fmt.Fprintf(w, "\tpulumi.RegisterOutputType(%sPtrOutput{})\n", name)
@@ -1267,6 +1277,109 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 	return nil
 }
 
+// methodArgs returns the arguments of a method, excluding its `__self__` argument.
+func methodArgs(f *schema.Function) []*schema.Property {
+	if f.Inputs == nil {
+		return nil
+	}
+	var args []*schema.Property
+	for _, p := range f.Inputs.Properties {
+		if p.Name != "__self__" {
+			args = append(args, p)
+		}
+	}
+	return args
+}
+
+func (pkg *pkgContext) genMethod(w io.Writer, resourceName string, method *schema.Method) {
+	f := method.Function
+	methodName := Title(method.Name)
+	typeName := resourceName + methodName
+	args := methodArgs(f)
+
+	printCommentWithDeprecationMessage(w, f.Comment, f.DeprecationMessage, false)
+
+	// Emit the method signature.
+	argsig := "ctx *pulumi.Context"
+	if len(args) > 0 {
+		argsig = fmt.Sprintf("%s, args *%sArgs", argsig, typeName)
+	}
+	var retty string
+	if f.Outputs == nil {
+		retty = "error"
+	} else {
+		retty = fmt.Sprintf("(%sResultOutput, error)", typeName)
+	}
+	fmt.Fprintf(w, "func (r *%s) %s(%s) %s {\n", resourceName, methodName, argsig, retty)
+
+	var inputsVar string
+	if len(args) == 0 {
+		inputsVar = "nil"
+	} else {
+		inputsVar = "args"
+	}
+
+	// Call the method via the runtime, passing the resource as its `__self__` argument.
+	if f.Outputs == nil {
+		fmt.Fprintf(w, "\t_, err := ctx.Call(%q, %s, pulumi.AnyOutput{}, r)\n", f.Token, inputsVar)
+		fmt.Fprintf(w, "\treturn err\n")
+	} else {
+		fmt.Fprintf(w, "\tout, err := ctx.Call(%q, %s, %sResultOutput{}, r)\n", f.Token, inputsVar, typeName)
+		fmt.Fprintf(w, "\tif err != nil {\n")
+		fmt.Fprintf(w, "\t\treturn %sResultOutput{}, err\n", typeName)
+		fmt.Fprintf(w, "\t}\n")
+		fmt.Fprintf(w, "\treturn out.(%sResultOutput), nil\n", typeName)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	// If there are argument and/or return types, emit them.
+	if len(args) > 0 {
+		fmt.Fprintf(w, "type %sArgs struct {\n", camel(typeName))
+		for _, p := range args {
+			printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+			fmt.Fprintf(w, "\t%s %s `pulumi:\"%s\"`\n", Title(p.Name), pkg.plainType(p.Type, !p.IsRequired), p.Name)
+		}
+		fmt.Fprintf(w, "}\n\n")
+
+		fmt.Fprintf(w, "// The set of arguments for the %s.%s method.\n", resourceName, methodName)
+		fmt.Fprintf(w, "type %sArgs struct {\n", typeName)
+		for _, p := range args {
+			printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+			fmt.Fprintf(w, "\t%s %s\n", Title(p.Name), pkg.inputType(p.Type, !p.IsRequired))
+		}
+		fmt.Fprintf(w, "}\n\n")
+
+		fmt.Fprintf(w, "func (%sArgs) ElementType() reflect.Type {\n", typeName)
+		fmt.Fprintf(w, "\treturn reflect.TypeOf((*%sArgs)(nil)).Elem()\n", camel(typeName))
+		fmt.Fprintf(w, "}\n\n")
+	}
+	if f.Outputs != nil {
+		pkg.genPlainType(w, typeName+"Result", f.Outputs.Comment, "", f.Outputs.Properties)
+		pkg.genResultOutput(w, typeName+"Result", f.Outputs)
+	}
+}
+
+// genResultOutput emits the Output type for the result of a method or function.
+func (pkg *pkgContext) genResultOutput(w io.Writer, name string, t *schema.ObjectType) {
+	fmt.Fprintf(w, "type %sOutput struct { *pulumi.OutputState }\n\n", name)
+
+	genOutputMethods(w, name, name, false)
+
+	for _, p := range t.Properties {
+		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, false)
+		outputType, applyType := pkg.outputType(p.Type, !p.IsRequired), pkg.plainType(p.Type, !p.IsRequired)
+
+		propName := Title(p.Name)
+		switch strings.ToLower(p.Name) {
+		case "elementtype", "issecret":
+			propName = "Get" + propName
+		}
+		fmt.Fprintf(w, "func (o %sOutput) %s() %s {\n", name, propName, outputType)
+		fmt.Fprintf(w, "\treturn o.ApplyT(func (v %s) %s { return v.%s }).(%s)\n", name, applyType, Title(p.Name), outputType)
+		fmt.Fprintf(w, "}\n\n")
+	}
+}
+
 func (pkg *pkgContext) genFunction(w io.Writer, f *schema.Function) {
 	// If the function starts with New or Get, it will conflict; so rename them.
 	name := pkg.functionNames[f]
@@ -1326,6 +1439,48 @@ func (pkg *pkgContext) genFunction(w io.Writer, f *schema.Function) {
 		fmt.Fprintf(w, "\n")
 		pkg.genPlainType(w, fmt.Sprintf("%sResult", name), f.Outputs.Comment, "", f.Outputs.Properties)
 	}
+
+	if f.NeedsOutputVersion() {
+		pkg.genFunctionOutputVersion(w, f)
+	}
+}
+
+// genFunctionOutputVersion emits the variant of a function that accepts inputs and returns an output. The variant
+// waits for its arguments to resolve before invoking the function, and its result carries the dependencies and
+// secretness of its arguments.
+func (pkg *pkgContext) genFunctionOutputVersion(w io.Writer, f *schema.Function) {
+	name := pkg.functionNames[f]
+
+	printCommentWithDeprecationMessage(w, f.Comment, f.DeprecationMessage, false)
+	fmt.Fprintf(w, "func %[1]sOutput(ctx *pulumi.Context, args %[1]sOutputArgs, opts ...pulumi.InvokeOption) %[1]sResultOutput {\n", name)
+	fmt.Fprintf(w, "\treturn pulumi.ToOutputWithContext(ctx.Context(), args).\n")
+	fmt.Fprintf(w, "\t\tApplyT(func(v interface{}) (%sResult, error) {\n", name)
+	fmt.Fprintf(w, "\t\t\targs := v.(%sArgs)\n", name)
+	fmt.Fprintf(w, "\t\t\tr, err := %s(ctx, &args, opts...)\n", name)
+	fmt.Fprintf(w, "\t\t\tif err != nil {\n")
+	fmt.Fprintf(w, "\t\t\t\treturn %sResult{}, err\n", name)
+	fmt.Fprintf(w, "\t\t\t}\n")
+	fmt.Fprintf(w, "\t\t\treturn *r, nil\n")
+	fmt.Fprintf(w, "\t\t}).(%sResultOutput)\n", name)
+	fmt.Fprintf(w, "}\n\n")
+
+	printComment(w, f.Inputs.Comment, false)
+	fmt.Fprintf(w, "type %sOutputArgs struct {\n", name)
+	for _, p := range f.Inputs.Properties {
+		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+		fmt.Fprintf(w, "\t%s %s `pulumi:\"%s\"`\n", Title(p.Name), pkg.inputType(p.Type, !p.IsRequired), p.Name)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "func (%sOutputArgs) ElementType() reflect.Type {\n", name)
+	fmt.Fprintf(w, "\treturn reflect.TypeOf((*%sArgs)(nil)).Elem()\n", name)
+	fmt.Fprintf(w, "}\n\n")
+
+	pkg.genResultOutput(w, name+"Result", f.Outputs)
+
+	fmt.Fprintf(w, "func init() {\n")
+	fmt.Fprintf(w, "\tpulumi.RegisterOutputType(%sResultOutput{})\n", name)
+	fmt.Fprintf(w, "}\n")
 }
 
 func (pkg *pkgContext) genType(w io.Writer, obj *schema.ObjectType) {
@@ -1500,6 +1655,16 @@ func (pkg *pkgContext) getImports(member interface{}, importsAndAliases map[stri
 				importsAndAliases["github.com/pkg/errors"] = ""
 			}
 		}
+		for _, method := range member.Methods {
+			for _, p := range methodArgs(method.Function) {
+				pkg.getTypeImports(p.Type, false, importsAndAliases, seen)
+			}
+			if method.Function.Outputs != nil {
+				for _, p := range method.Function.Outputs.Properties {
+					pkg.getTypeImports(p.Type, false, importsAndAliases, seen)
+				}
+			}
+		}
 	case *schema.Function:
 		if member.Inputs != nil {
 			pkg.getTypeImports(member.Inputs, true, importsAndAliases, seen)
@@ -1819,6 +1984,14 @@ func generatePackageContextMap(tool string, pkg *schema.Package, goInfo GoPackag
 			pkg.names.Add("Get" + resourceName(r))
 		}
 
+		for _, method := range r.Methods {
+			methodName := resourceName(r) + Title(method.Name)
+			pkg.names.Add(methodName + "Args")
+			pkg.names.Add(camel(methodName) + "Args")
+			pkg.names.Add(methodName + "Result")
+			pkg.names.Add(methodName + "ResultOutput")
+		}
+
 		markOptionalPropertyTypesAsRequiringPtr(seenMap, r.InputProperties, !r.IsProvider)
 		markOptionalPropertyTypesAsRequiringPtr(seenMap, r.Properties, !r.IsProvider)
 	}
@@ -1829,6 +2002,11 @@ func generatePackageContextMap(tool string, pkg *schema.Package, goInfo GoPackag
 	}
 
 	for _, f := range pkg.Functions {
+		// Methods are generated as part of the resources to which they belong.
+		if f.IsMethod {
+			continue
+		}
+
 		pkg := getPkgFromToken(f.Token)
 		pkg.functions = append(pkg.functions, f)
 
@@ -1850,6 +2028,14 @@ func generatePackageContextMap(tool string, pkg *schema.Package, goInfo GoPackag
 		if f.Outputs != nil {
 			pkg.names.Add(name + "Result")
 		}
+		if f.NeedsOutputVersion() {
+			pkg.names.Add(name + "Output")
+			pkg.names.Add(name + "OutputArgs")
+			pkg.names.Add(name + "ResultOutput")
+
+			markOptionalPropertyTypesAsRequiringPtr(seenMap, f.Inputs.Properties, false)
+			markOptionalPropertyTypesAsRequiringPtr(seenMap, f.Outputs.Properties, false)
+		}
 	}
 
 	return packages
@@ -1991,8 +2177,13 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 			importsAndAliases := map[string]string{}
 			pkg.getImports(f, importsAndAliases)
 
+			var goImports []string
+			if f.NeedsOutputVersion() {
+				goImports = []string{"context", "reflect"}
+			}
+
 			buffer := &bytes.Buffer{}
-			pkg.genHeader(buffer, nil, importsAndAliases)
+			pkg.genHeader(buffer, goImports, importsAndAliases)
 
 			pkg.genFunction(buffer, f)
 
@@ -30,10 +30,14 @@ type generator struct {
 	readDirTempSpiller  *readDirSpiller
 	splatSpiller        *splatSpiller
 	optionalSpiller     *optionalSpiller
+	builtinSpiller      *builtinSpiller
 	scopeTraversalRoots codegen.StringSet
 	arrayHelpers        map[string]*promptToInputArrayHelper
+	builtinHelpers      codegen.StringSet
 	isErrAssigned       bool
 	configCreated       bool
+	// True if the arguments being generated are for an invoke that returns an output.
+	outputVersionInvoke bool
 }
 
 func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
@@ -54,8 +58,10 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 		readDirTempSpiller:  &readDirSpiller{},
 		splatSpiller:        &splatSpiller{},
 		optionalSpiller:     &optionalSpiller{},
+		builtinSpiller:      &builtinSpiller{},
 		scopeTraversalRoots: codegen.NewStringSet(),
 		arrayHelpers:        make(map[string]*promptToInputArrayHelper),
+		builtinHelpers:      codegen.NewStringSet(),
 	}
 
 	g.Formatter = format.NewFormatter(g)
@@ -317,6 +323,9 @@ func (g *generator) genHelpers(w io.Writer) {
 	for _, v := range g.arrayHelpers {
 		v.generateHelperMethod(w)
 	}
+	for _, name := range g.builtinHelpers.SortedValues() {
+		g.Fprintf(w, "\n%s", builtinHelpers[name])
+	}
 }
 
 func (g *generator) genNode(w io.Writer, n hcl2.Node) {
@@ -374,6 +383,9 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 	if opts.IgnoreChanges != nil {
 		appendOption("IgnoreChanges", opts.IgnoreChanges, model.NewListType(model.StringType))
 	}
+	if opts.Aliases != nil {
+		appendOption("Aliases", opts.Aliases, model.NewListType(model.StringType))
+	}
 
 	return block, temps
 }
@@ -385,6 +397,15 @@ func (g *generator) genResourceOptions(w io.Writer, block *model.Block) {
 
 	for _, item := range block.Body.Items {
 		attr := item.(*model.Attribute)
+		if aliases, ok := attr.Value.(*model.TupleConsExpression); ok && attr.Name == "Aliases" {
+			// Aliases are given as URNs, each of which must be wrapped in a pulumi.Alias.
+			g.Fgen(w, ", pulumi.Aliases([]pulumi.Alias{\n")
+			for _, urn := range aliases.Expressions {
+				g.Fgenf(w, "{\nURN: pulumi.URN(%v),\n},\n", urn)
+			}
+			g.Fgen(w, "})")
+			continue
+		}
 		g.Fgenf(w, ", pulumi.%s(%v)", attr.Name, attr.Value)
 	}
 }
@@ -476,7 +497,25 @@ func (g *generator) genOutputAssignment(w io.Writer, v *hcl2.OutputVariable) {
 	isInput := false
 	expr, temps := g.lowerExpression(v.Value, v.Type(), isInput)
 	g.genTemps(w, temps)
-	g.Fgenf(w, "ctx.Export(\"%s\", %.3v)\n", v.Name(), expr)
+
+	// Exported values must be inputs, so wrap any prompt values in the appropriate input type.
+	switch expr.Type().(type) {
+	case *model.OutputType, *model.PromiseType:
+		g.Fgenf(w, "ctx.Export(\"%s\", %.3v)\n", v.Name(), expr)
+	default:
+		inputType := "pulumi.Any"
+		switch expr.Type() {
+		case model.StringType:
+			inputType = "pulumi.String"
+		case model.NumberType:
+			inputType = "pulumi.Float64"
+		case model.IntType:
+			inputType = "pulumi.Int"
+		case model.BoolType:
+			inputType = "pulumi.Bool"
+		}
+		g.Fgenf(w, "ctx.Export(\"%s\", %s(%.v))\n", v.Name(), inputType, expr)
+	}
 }
 func (g *generator) genTemps(w io.Writer, temps []interface{}) {
 	singleReturn := ""
@@ -489,7 +528,7 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 	if zeroValueType != "" {
 		for _, t := range temps {
 			switch t.(type) {
-			case *jsonTemp, *readDirTemp:
+			case *jsonTemp, *readDirTemp, *builtinTemp:
 				genZeroValueDecl = true
 			default:
 			}
@@ -555,6 +594,23 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 			g.Fgenf(w, "}\n")
 		case *optionalTemp:
 			g.Fgenf(w, "%s := %.v\n", t.Name, t.Value)
+		case *builtinTemp:
+			g.builtinHelpers.Add(t.Value.Name)
+			g.Fgenf(w, "%s, err := %s(", t.Name, t.Value.Name)
+			for i, arg := range t.Value.Args {
+				if i > 0 {
+					g.Fgenf(w, ", ")
+				}
+				g.Fgenf(w, "%.v", arg)
+			}
+			g.Fgenf(w, ")\n")
+			g.Fgenf(w, "if err != nil {\n")
+			if genZeroValueDecl {
+				g.Fgenf(w, "return _zero, err\n")
+			} else {
+				g.Fgenf(w, "return err\n")
+			}
+			g.Fgenf(w, "}\n")
 		default:
 			contract.Failf("unexpected temp type: %v", t)
 		}
@@ -577,11 +633,17 @@ func (g *generator) genLocalVariable(w io.Writer, v *hcl2.LocalVariable) {
 	case *model.FunctionCallExpression:
 		switch expr.Name {
 		case hcl2.Invoke:
+			if hcl2.IsOutputVersionInvokeCall(expr) {
+				g.Fgenf(w, "%s := %.3v;\n", name, expr)
+				break
+			}
 			g.Fgenf(w, "%s, err %s %.3v;\n", name, assignment, expr)
 			g.isErrAssigned = true
 			g.Fgenf(w, "if err != nil {\n")
 			g.Fgenf(w, "return err\n")
 			g.Fgenf(w, "}\n")
+		default:
+			g.Fgenf(w, "%s := %.3v;\n", name, expr)
 		}
 	default:
 		g.Fgenf(w, "%s := %.3v;\n", name, expr)
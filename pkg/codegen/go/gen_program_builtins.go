@@ -0,0 +1,163 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+)
+
+// builtinTemp is a temporary that holds the result of a call to a builtin function that may fail, e.g. fromJSON.
+type builtinTemp struct {
+	Name  string
+	Value *model.FunctionCallExpression
+}
+
+func (bt *builtinTemp) Type() model.Type {
+	return bt.Value.Type()
+}
+
+func (bt *builtinTemp) Traverse(traverser hcl.Traverser) (model.Traversable, hcl.Diagnostics) {
+	return bt.Type().Traverse(traverser)
+}
+
+func (bt *builtinTemp) SyntaxNode() hclsyntax.Node {
+	return syntax.None
+}
+
+// builtinTempNames maps the names of the builtin functions that may fail to the prefixes of their temporaries.
+var builtinTempNames = map[string]string{
+	"cidrsubnet": "subnet",
+	"fromBase64": "decoded",
+	"fromJSON":   "parsed",
+}
+
+type builtinSpiller struct {
+	temps []*builtinTemp
+	count int
+}
+
+func (bs *builtinSpiller) spillExpression(x model.Expression) (model.Expression, hcl.Diagnostics) {
+	call, ok := x.(*model.FunctionCallExpression)
+	if !ok {
+		return x, nil
+	}
+	prefix, ok := builtinTempNames[call.Name]
+	if !ok {
+		return x, nil
+	}
+
+	temp := &builtinTemp{
+		Name:  fmt.Sprintf("%s%d", prefix, bs.count),
+		Value: call,
+	}
+	bs.temps = append(bs.temps, temp)
+	bs.count++
+	return &model.ScopeTraversalExpression{
+		RootName:  temp.Name,
+		Traversal: hcl.Traversal{hcl.TraverseRoot{Name: ""}},
+		Parts:     []model.Traversable{temp},
+	}, nil
+}
+
+func (g *generator) rewriteBuiltins(
+	x model.Expression,
+	spiller *builtinSpiller,
+) (model.Expression, []*builtinTemp, hcl.Diagnostics) {
+	spiller.temps = nil
+	x, diags := model.VisitExpression(x, spiller.spillExpression, nil)
+
+	return x, spiller.temps, diags
+}
+
+// builtinHelpers holds the source of the helper functions that implement builtins with no direct equivalent in the
+// Go standard library. Helpers are emitted after main for each builtin that the program uses.
+var builtinHelpers = map[string]string{
+	"cidrsubnet": `func cidrsubnet(prefix string, newbits, netnum float64) (string, error) {
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", err
+	}
+	length, bits := network.Mask.Size()
+	newLength := length + int(newbits)
+	if newLength > bits {
+		return "", fmt.Errorf("cannot extend prefix %v by %v bits", prefix, newbits)
+	}
+	if int(netnum) >= 1<<uint(newbits) {
+		return "", fmt.Errorf("prefix extension of %v bits cannot accommodate network number %v", newbits, netnum)
+	}
+	ip := make(net.IP, len(network.IP))
+	copy(ip, network.IP)
+	for i := 0; i < int(newbits); i++ {
+		if int(netnum)&(1<<uint(int(newbits)-1-i)) != 0 {
+			bit := length + i
+			ip[bit/8] |= 1 << uint(7-bit%8)
+		}
+	}
+	subnet := net.IPNet{IP: ip, Mask: net.CIDRMask(newLength, bits)}
+	return subnet.String(), nil
+}
+`,
+	"flatten": `func flatten(list interface{}) []interface{} {
+	var result []interface{}
+	outer := reflect.ValueOf(list)
+	for i := 0; i < outer.Len(); i++ {
+		inner := reflect.ValueOf(outer.Index(i).Interface())
+		for j := 0; j < inner.Len(); j++ {
+			result = append(result, inner.Index(j).Interface())
+		}
+	}
+	return result
+}
+`,
+	"fromBase64": `func fromBase64(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+`,
+	"fromJSON": `func fromJSON(s string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+`,
+	"keys": `func keys(m map[string]interface{}) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+`,
+	"merge": `func merge(maps ...map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, m := range maps {
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result
+}
+`,
+	"values": `func values(m map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]interface{}, len(keys))
+	for i, k := range keys {
+		result[i] = m[k]
+	}
+	return result
+}
+`,
+}
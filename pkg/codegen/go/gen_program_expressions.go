@@ -207,6 +207,11 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		}
 		name := fmt.Sprintf("%s.%s", module, fn)
 
+		outputVersionInvoke := hcl2.IsOutputVersionInvokeCall(expr)
+		if outputVersionInvoke {
+			name += "Output"
+		}
+
 		optionsBag := ""
 		var buf bytes.Buffer
 		if len(expr.Args) == 3 {
@@ -216,9 +221,12 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		}
 		optionsBag = buf.String()
 
+		prev := g.outputVersionInvoke
+		g.outputVersionInvoke = outputVersionInvoke
 		g.Fgenf(w, "%s(ctx, ", name)
 		g.Fgenf(w, "%.v", expr.Args[1])
 		g.Fgenf(w, "%v)", optionsBag)
+		g.outputVersionInvoke = prev
 	case "length":
 		g.genNYI(w, "call %v", expr.Name)
 		// g.Fgenf(w, "%.20v.Length", expr.Args[0])
@@ -244,6 +252,37 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		contract.Failf("unlowered toJSON function expression @ %v", expr.SyntaxNode().Range())
 	case "mimeType":
 		g.Fgenf(w, "mime.TypeByExtension(path.Ext(%.v))", expr.Args[0])
+	case "cidrsubnet", "fromBase64", "fromJSON":
+		contract.Failf("unlowered %s function expression @ %v", expr.Name, expr.SyntaxNode().Range())
+	case "flatten", "keys", "values":
+		g.builtinHelpers.Add(expr.Name)
+		g.Fgenf(w, "%s(%.v)", expr.Name, expr.Args[0])
+	case "format":
+		g.Fgenf(w, "fmt.Sprintf(%.v", expr.Args[0])
+		for _, arg := range expr.Args[1:] {
+			g.Fgenf(w, ", %.v", arg)
+		}
+		g.Fgenf(w, ")")
+	case "join":
+		g.Fgenf(w, "strings.Join(%.v, %.v)", expr.Args[1], expr.Args[0])
+	case "merge":
+		g.builtinHelpers.Add(expr.Name)
+		g.Fgenf(w, "merge(")
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgenf(w, ", ")
+			}
+			g.Fgenf(w, "%.v", arg)
+		}
+		g.Fgenf(w, ")")
+	case "replace":
+		g.Fgenf(w, "strings.ReplaceAll(%.v, %.v, %.v)", expr.Args[0], expr.Args[1], expr.Args[2])
+	case "sha1":
+		g.Fgenf(w, "fmt.Sprintf(\"%%x\", sha1.Sum([]byte(%.v)))", expr.Args[0])
+	case "sha256":
+		g.Fgenf(w, "fmt.Sprintf(\"%%x\", sha256.Sum256([]byte(%.v)))", expr.Args[0])
+	case "toBase64":
+		g.Fgenf(w, "base64.StdEncoding.EncodeToString([]byte(%.v))", expr.Args[0])
 	default:
 		g.genNYI(w, "call %v", expr.Name)
 	}
@@ -343,8 +382,9 @@ func (g *generator) genObjectConsExpression(
 		if strings.HasSuffix(typeName, "Args") {
 			isInput = true
 		}
-		// invokes are not inputty
-		if strings.Contains(typeName, ".Lookup") || strings.Contains(typeName, ".Get") {
+		// invokes are not inputty, unless they return outputs
+		isOutputArgs := strings.HasSuffix(typeName, "OutputArgs")
+		if (strings.Contains(typeName, ".Lookup") || strings.Contains(typeName, ".Get")) && !isOutputArgs {
 			isInput = false
 		}
 		isMap := strings.HasPrefix(typeName, "map[")
@@ -364,7 +404,7 @@ func (g *generator) genObjectConsExpression(
 		}
 		g.genTemps(w, temps)
 
-		if isMap || !strings.HasSuffix(typeName, "Args") {
+		if isMap || !strings.HasSuffix(typeName, "Args") || isOutputArgs {
 			g.Fgenf(w, "%s", typeName)
 		} else {
 			g.Fgenf(w, "&%s", typeName)
@@ -509,6 +549,9 @@ func (g *generator) GenTemplateExpression(w io.Writer, expr *model.TemplateExpre
 			g.GenLiteralValueExpression(w, lit)
 			return
 		}
+	} else if str, ok := literalTemplateString(expr); ok {
+		// The parser splits literal strings that contain template sequences, e.g. "%", into several parts.
+		g.genStringLiteral(w, str)
 	} else {
 		fmtMaker := make([]string, len(expr.Parts)+1)
 		fmtStr := strings.Join(fmtMaker, "%v")
@@ -520,6 +563,19 @@ func (g *generator) GenTemplateExpression(w io.Writer, expr *model.TemplateExpre
 	}
 }
 
+// literalTemplateString returns the string value of a template that consists only of string literals, if any.
+func literalTemplateString(expr *model.TemplateExpression) (string, bool) {
+	var b strings.Builder
+	for _, part := range expr.Parts {
+		lit, ok := part.(*model.LiteralValueExpression)
+		if !ok || lit.Type() != model.StringType {
+			return "", false
+		}
+		b.WriteString(lit.Value.AsString())
+	}
+	return b.String(), true
+}
+
 // GenTemplateJoinExpression generates code for a TemplateJoinExpression.
 func (g *generator) GenTemplateJoinExpression(w io.Writer, expr *model.TemplateJoinExpression) { /*TODO*/
 }
@@ -612,6 +668,10 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 				if g.useLookupInvokeForm(token) {
 					member = strings.Replace(member, "Get", "Lookup", 1)
 				}
+				// The variant of the function that returns an output accepts arguments that may be outputs.
+				if g.outputVersionInvoke && strings.HasSuffix(member, "Args") {
+					member = strings.TrimSuffix(member, "Args") + "OutputArgs"
+				}
 				return fmt.Sprintf("%s.%s", importPrefix, member)
 			}
 			fmtString := "%s.%s"
@@ -789,6 +849,7 @@ func (g *generator) lowerExpression(expr model.Expression, typ model.Type, isInp
 	expr, rTemps, readDirDiags := g.rewriteReadDir(expr, g.readDirTempSpiller)
 	expr, sTemps, splatDiags := g.rewriteSplat(expr, g.splatSpiller)
 	expr, oTemps, optDiags := g.rewriteOptionals(expr, g.optionalSpiller)
+	expr, bTemps, builtinDiags := g.rewriteBuiltins(expr, g.builtinSpiller)
 
 	if isInput {
 		expr = rewriteInputs(expr)
@@ -809,11 +870,15 @@ func (g *generator) lowerExpression(expr model.Expression, typ model.Type, isInp
 	for _, t := range oTemps {
 		temps = append(temps, t)
 	}
+	for _, t := range bTemps {
+		temps = append(temps, t)
+	}
 	diags = append(diags, ternDiags...)
 	diags = append(diags, jsonDiags...)
 	diags = append(diags, readDirDiags...)
 	diags = append(diags, splatDiags...)
 	diags = append(diags, optDiags...)
+	diags = append(diags, builtinDiags...)
 	contract.Assert(len(diags) == 0)
 	return expr, temps
 }
@@ -975,9 +1040,21 @@ func (g *generator) functionName(tokenArg model.Expression) (string, string, str
 }
 
 var functionPackages = map[string][]string{
-	"toJSON":   {"encoding/json"},
-	"readDir":  {"io/ioutil"},
-	"mimeType": {"mime", "path"},
+	"toJSON":     {"encoding/json"},
+	"readDir":    {"io/ioutil"},
+	"mimeType":   {"mime", "path"},
+	"cidrsubnet": {"fmt", "net"},
+	"flatten":    {"reflect"},
+	"format":     {"fmt"},
+	"fromBase64": {"encoding/base64"},
+	"fromJSON":   {"encoding/json"},
+	"join":       {"strings"},
+	"keys":       {"sort"},
+	"replace":    {"strings"},
+	"sha1":       {"crypto/sha1", "fmt"},
+	"sha256":     {"crypto/sha256", "fmt"},
+	"toBase64":   {"encoding/base64"},
+	"values":     {"sort"},
 }
 
 func (g *generator) genFunctionPackages(x *model.FunctionCallExpression) []string {
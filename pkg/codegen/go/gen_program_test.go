@@ -119,8 +119,10 @@ func newTestGenerator(t *testing.T, testFile string) *generator {
 			readDirTempSpiller:  &readDirSpiller{},
 			splatSpiller:        &splatSpiller{},
 			optionalSpiller:     &optionalSpiller{},
+			builtinSpiller:      &builtinSpiller{},
 			scopeTraversalRoots: codegen.NewStringSet(),
 			arrayHelpers:        make(map[string]*promptToInputArrayHelper),
+			builtinHelpers:      codegen.NewStringSet(),
 		}
 		g.Formatter = format.NewFormatter(g)
 		return g
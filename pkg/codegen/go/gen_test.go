@@ -8,6 +8,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test/testdata/simple-enum-schema/go/plant"
 	tree "github.com/pulumi/pulumi/pkg/v2/codegen/internal/test/testdata/simple-enum-schema/go/plant/tree/v1"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test/testdata/simple-resource-schema/go/example"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
@@ -107,6 +108,18 @@ func TestGeneratePackage(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"Simple schema with methods",
+			"simple-methods-schema",
+			[]string{
+				filepath.Join("example", "doc.go"),
+				filepath.Join("example", "foo.go"),
+				filepath.Join("example", "init.go"),
+				filepath.Join("example", "provider.go"),
+				filepath.Join("example", "pulumiUtilities.go"),
+			},
+			false,
+		},
 	}
 	testDir := filepath.Join("..", "internal", "test", "testdata")
 	for _, tt := range tests {
@@ -255,3 +268,48 @@ func TestEnumUsage(t *testing.T) {
 		}, pulumi.WithMocks("project", "stack", mocks(1))))
 	})
 }
+
+type functionMocks struct {
+	args resource.PropertyMap
+}
+
+func (m *functionMocks) NewResource(
+	typeToken string,
+	name string,
+	inputs resource.PropertyMap,
+	provider string,
+	id string,
+) (string, resource.PropertyMap, error) {
+	return name + "_id", inputs, nil
+}
+
+func (m *functionMocks) Call(token string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+	m.args = args
+	return resource.PropertyMap{}, nil
+}
+
+func TestFunctionOutputVersion(t *testing.T) {
+	m := &functionMocks{}
+	require.NoError(t, pulumi.RunErr(func(ctx *pulumi.Context) error {
+		res, err := example.NewResource(ctx, "res", &example.ResourceArgs{})
+		require.NoError(t, err)
+
+		result := example.ArgFunctionOutput(ctx, example.ArgFunctionOutputArgs{
+			Arg1: res.ToResourceOutput(),
+		})
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		pulumi.All(res.URN(), result).ApplyT(func(all []interface{}) error {
+			arg1 := m.args["arg1"]
+			if assert.True(t, arg1.IsResourceReference()) {
+				assert.Equal(t, string(all[0].(pulumi.URN)), string(arg1.ResourceReferenceValue().URN))
+			}
+			assert.Nil(t, all[1].(example.ArgFunctionResult).Result)
+			wg.Done()
+			return nil
+		})
+		wg.Wait()
+		return nil
+	}, pulumi.WithMocks("project", "stack", m)))
+}
@@ -166,6 +166,9 @@ func (s *optionsScopes) GetScopeForAttribute(attr *hclsyntax.Attribute) (*model.
 	return s.root, nil
 }
 
+// resourceOptionNames is the list of attributes that may appear in a resource's options block.
+var resourceOptionNames = []string{"aliases", "dependsOn", "ignoreChanges", "parent", "protect", "provider", "range"}
+
 // bindResourceBody binds the body of a resource.
 func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 	var diagnostics hcl.Diagnostics
@@ -265,16 +268,25 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 
 	// Typecheck the attributes.
 	if objectType, ok := node.InputType.(*model.ObjectType); ok {
-		attrNames := codegen.StringSet{}
 		for _, attr := range node.Inputs {
-			attrNames.Add(attr.Name)
-
 			if typ, ok := objectType.Properties[attr.Name]; ok {
 				if !typ.ConversionFrom(attr.Value.Type()).Exists() {
 					diagnostics = append(diagnostics, model.ExprNotConvertible(typ, attr.Value))
 				}
-			} else {
-				diagnostics = append(diagnostics, unsupportedAttribute(attr.Name, attr.Syntax.NameRange))
+			}
+		}
+	}
+
+	// Check the names of the attributes. The input type is usually the union of an object type and its output, so
+	// resolve outputs first to get at the object's properties.
+	if objectType, ok := model.ResolveOutputs(node.InputType).(*model.ObjectType); ok {
+		attrNames := codegen.StringSet{}
+		for _, attr := range node.Inputs {
+			attrNames.Add(attr.Name)
+
+			if _, ok := objectType.Properties[attr.Name]; !ok {
+				diagnostics = append(diagnostics, unsupportedAttribute(attr.Name, attr.Syntax.NameRange,
+					codegen.SortedKeys(objectType.Properties)))
 			}
 		}
 
@@ -313,8 +325,11 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 				case "ignoreChanges":
 					t = model.NewListType(ResourcePropertyType)
 					resourceOptions.IgnoreChanges = item.Value
+				case "aliases":
+					t = model.NewListType(model.StringType)
+					resourceOptions.Aliases = item.Value
 				default:
-					diagnostics = append(diagnostics, unsupportedAttribute(item.Name, item.Syntax.NameRange))
+					diagnostics = append(diagnostics, unsupportedAttribute(item.Name, item.Syntax.NameRange, resourceOptionNames))
 					continue
 				}
 				if model.InputType(t).ConversionFrom(item.Value.Type()) == model.NoConversion {
@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 )
@@ -107,8 +108,11 @@ func (b *binder) loadReferencedPackageSchemas(n Node) error {
 
 	if r, ok := n.(*Resource); ok {
 		token, tokenRange := getResourceToken(r)
-		packageName, _, _, _ := DecomposeToken(token, tokenRange)
-		if packageName != "pulumi" {
+		packageName, module, name, _ := DecomposeToken(token, tokenRange)
+		switch {
+		case packageName == "pulumi" && module == "providers":
+			packageNames.Add(name)
+		case packageName != "pulumi":
 			packageNames.Add(packageName)
 		}
 	}
@@ -143,6 +147,43 @@ func (b *binder) loadReferencedPackageSchemas(n Node) error {
 	return nil
 }
 
+// ReferencedPackages returns the sorted names of the packages whose resources or functions are referenced by the given
+// files. Unlike binding, this does not require the packages' schemas, so it can be used to decide which plugins a
+// program needs before it is bound.
+func ReferencedPackages(files []*syntax.File) []string {
+	packageNames := codegen.StringSet{}
+	addToken := func(token string) {
+		packageName, module, name, diags := DecomposeToken(token, hcl.Range{})
+		switch {
+		case diags.HasErrors():
+			// Malformed tokens are reported during binding.
+		case packageName == "pulumi" && module == "providers":
+			packageNames.Add(name)
+		case packageName != "pulumi":
+			packageNames.Add(packageName)
+		}
+	}
+
+	for _, file := range files {
+		for _, block := range file.Body.Blocks {
+			if block.Type == "resource" && len(block.Labels) == 2 {
+				addToken(block.Labels[1])
+			}
+		}
+
+		diags := hclsyntax.VisitAll(file.Body, func(node hclsyntax.Node) hcl.Diagnostics {
+			if call, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+				if token, _, ok := getInvokeToken(call); ok {
+					addToken(token)
+				}
+			}
+			return nil
+		})
+		contract.Assert(len(diags) == 0)
+	}
+	return packageNames.SortedValues()
+}
+
 // schemaTypeToType converts a schema.Type to a model Type.
 func (b *binder) schemaTypeToType(src schema.Type) (result model.Type) {
 	return b.schemaTypeToTypeImpl(src, map[schema.Type]model.Type{})
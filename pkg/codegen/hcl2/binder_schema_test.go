@@ -1,8 +1,12 @@
 package hcl2
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
@@ -16,3 +20,25 @@ func BenchmarkLoadPackage(b *testing.B) {
 		contract.AssertNoError(err)
 	}
 }
+
+func TestReferencedPackages(t *testing.T) {
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(`
+resource provider "pulumi:providers:kubernetes" {
+}
+
+resource bucket "aws:s3/bucket:Bucket" {
+	tags = {
+		zone = invoke("azure:core/getResourceGroup:getResourceGroup", { name = "rg" }).location
+	}
+}
+
+resource other "aws:s3/bucketObject:BucketObject" {
+	bucket = bucket.id
+}
+`), "main.pp")
+	assert.NoError(t, err)
+	assert.Len(t, parser.Diagnostics, 0)
+
+	assert.Equal(t, []string{"aws", "azure", "kubernetes"}, ReferencedPackages(parser.Files))
+}
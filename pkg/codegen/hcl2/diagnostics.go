@@ -2,9 +2,11 @@ package hcl2
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
 )
 
@@ -53,8 +55,12 @@ func unsupportedBlock(blockType string, typeRange hcl.Range) *hcl.Diagnostic {
 	return errorf(typeRange, "unsupported block of type '%v'", blockType)
 }
 
-func unsupportedAttribute(attrName string, nameRange hcl.Range) *hcl.Diagnostic {
-	return errorf(nameRange, "unsupported attribute '%v'", attrName)
+func unsupportedAttribute(attrName string, nameRange hcl.Range, supported []string) *hcl.Diagnostic {
+	diag := errorf(nameRange, "unsupported attribute '%v'", attrName)
+	if similar := codegen.SimilarNames(attrName, supported); len(similar) != 0 {
+		diag.Detail = fmt.Sprintf("%s; did you mean '%s'?", diag.Summary, strings.Join(similar, "', '"))
+	}
+	return diag
 }
 
 func missingRequiredAttribute(attrName string, missingRange hcl.Range) *hcl.Diagnostic {
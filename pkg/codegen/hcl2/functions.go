@@ -15,7 +15,11 @@
 package hcl2
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/hcl/v2"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
 )
 
@@ -40,6 +44,154 @@ func getEntriesSignature(args []model.Expression) (model.StaticFunctionSignature
 	return signature, diagnostics
 }
 
+// getCollectionElementType returns the element type of the given list, tuple, or set type, if any.
+func getCollectionElementType(t model.Type) (model.Type, bool) {
+	switch t := model.ResolveOutputs(t).(type) {
+	case *model.ListType:
+		return t.ElementType, true
+	case *model.SetType:
+		return t.ElementType, true
+	case *model.TupleType:
+		_, elementType := model.UnifyTypes(t.ElementTypes...)
+		return elementType, true
+	default:
+		return nil, false
+	}
+}
+
+// getMapElementType returns the element type of the given map or object type, if any.
+func getMapElementType(t model.Type) (model.Type, bool) {
+	switch t := model.ResolveOutputs(t).(type) {
+	case *model.MapType:
+		return t.ElementType, true
+	case *model.ObjectType:
+		if len(t.Properties) == 0 {
+			return model.DynamicType, true
+		}
+		types := make([]model.Type, 0, len(t.Properties))
+		for _, k := range codegen.SortedKeys(t.Properties) {
+			types = append(types, t.Properties[k])
+		}
+		_, elementType := model.UnifyTypes(types...)
+		return elementType, true
+	default:
+		return nil, false
+	}
+}
+
+func getFlattenSignature(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+	var diagnostics hcl.Diagnostics
+
+	listType, elementType := model.Type(model.DynamicType), model.Type(model.DynamicType)
+	if len(args) > 0 {
+		listType = args[0].Type()
+		if t, ok := getCollectionElementType(args[0].Type()); ok {
+			// Flatten one level of nesting. If the elements of the list are themselves lists, the result contains
+			// the elements of those lists.
+			elementType = t
+			if t, ok := getCollectionElementType(t); ok {
+				elementType = t
+			}
+		} else if model.ResolveOutputs(listType) != model.DynamicType {
+			rng := args[0].SyntaxNode().Range()
+			diagnostics = hcl.Diagnostics{&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "the first argument to 'flatten' must be a list or tuple",
+				Subject:  &rng,
+			}}
+		}
+	}
+	return model.StaticFunctionSignature{
+		Parameters: []model.Parameter{{
+			Name: "list",
+			Type: listType,
+		}},
+		ReturnType: model.NewListType(elementType),
+	}, diagnostics
+}
+
+// getMapFunctionSignature returns a generic signature for a function with a single map parameter whose return type is
+// computed from the map's element type.
+func getMapFunctionSignature(name string,
+	returnType func(elementType model.Type) model.Type) model.GenericFunctionSignature {
+
+	return model.GenericFunctionSignature(
+		func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+			var diagnostics hcl.Diagnostics
+
+			mapType, elementType := model.Type(model.DynamicType), model.Type(model.DynamicType)
+			if len(args) > 0 {
+				mapType = args[0].Type()
+				if t, ok := getMapElementType(args[0].Type()); ok {
+					elementType = t
+				} else if model.ResolveOutputs(mapType) != model.DynamicType {
+					rng := args[0].SyntaxNode().Range()
+					diagnostics = hcl.Diagnostics{&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  fmt.Sprintf("the first argument to '%s' must be a map or object", name),
+						Subject:  &rng,
+					}}
+				}
+			}
+			return model.StaticFunctionSignature{
+				Parameters: []model.Parameter{{
+					Name: "map",
+					Type: mapType,
+				}},
+				ReturnType: returnType(elementType),
+			}, diagnostics
+		})
+}
+
+func getMergeSignature(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+	var diagnostics hcl.Diagnostics
+
+	// If all of the arguments are objects, the result is an object whose properties are the union of the properties of
+	// the arguments. Otherwise, the result is a map whose element type unifies the element types of the arguments.
+	properties, allObjects := map[string]model.Type{}, true
+	var elementTypes []model.Type
+	for _, arg := range args {
+		switch t := model.ResolveOutputs(arg.Type()).(type) {
+		case *model.ObjectType:
+			for k, v := range t.Properties {
+				properties[k] = v
+			}
+		case *model.MapType:
+			allObjects = false
+		default:
+			if t != model.DynamicType {
+				rng := arg.SyntaxNode().Range()
+				diagnostics = append(diagnostics, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "the arguments to 'merge' must be maps or objects",
+					Subject:  &rng,
+				})
+			}
+			allObjects = false
+		}
+		if t, ok := getMapElementType(arg.Type()); ok {
+			elementTypes = append(elementTypes, t)
+		}
+	}
+
+	returnType := model.Type(model.NewMapType(model.DynamicType))
+	switch {
+	case len(args) > 0 && allObjects:
+		returnType = model.NewObjectType(properties)
+	case len(elementTypes) > 0:
+		_, elementType := model.UnifyTypes(elementTypes...)
+		returnType = model.NewMapType(elementType)
+	}
+
+	return model.StaticFunctionSignature{
+		VarargsParameter: &model.Parameter{
+			Name: "maps",
+			Type: model.DynamicType,
+		},
+		ReturnType: returnType,
+	}, diagnostics
+}
+
 var pulumiBuiltins = map[string]*model.Function{
 	"element": model.NewFunction(model.GenericFunctionSignature(
 		func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
@@ -76,6 +228,23 @@ var pulumiBuiltins = map[string]*model.Function{
 				ReturnType: returnType,
 			}, diagnostics
 		})),
+	"cidrsubnet": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{
+			{
+				Name: "prefix",
+				Type: model.StringType,
+			},
+			{
+				Name: "newbits",
+				Type: model.NumberType,
+			},
+			{
+				Name: "netnum",
+				Type: model.NumberType,
+			},
+		},
+		ReturnType: model.StringType,
+	}),
 	"entries": model.NewFunction(model.GenericFunctionSignature(getEntriesSignature)),
 	"fileArchive": model.NewFunction(model.StaticFunctionSignature{
 		Parameters: []model.Parameter{{
@@ -91,6 +260,48 @@ var pulumiBuiltins = map[string]*model.Function{
 		}},
 		ReturnType: AssetType,
 	}),
+	"flatten": model.NewFunction(model.GenericFunctionSignature(getFlattenSignature)),
+	"format": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{{
+			Name: "format",
+			Type: model.StringType,
+		}},
+		VarargsParameter: &model.Parameter{
+			Name: "args",
+			Type: model.DynamicType,
+		},
+		ReturnType: model.StringType,
+	}),
+	"fromBase64": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{{
+			Name: "string",
+			Type: model.StringType,
+		}},
+		ReturnType: model.StringType,
+	}),
+	"fromJSON": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{{
+			Name: "string",
+			Type: model.StringType,
+		}},
+		ReturnType: model.DynamicType,
+	}),
+	"join": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{
+			{
+				Name: "separator",
+				Type: model.StringType,
+			},
+			{
+				Name: "list",
+				Type: model.NewListType(model.StringType),
+			},
+		},
+		ReturnType: model.StringType,
+	}),
+	"keys": model.NewFunction(getMapFunctionSignature("keys", func(model.Type) model.Type {
+		return model.NewListType(model.StringType)
+	})),
 	"length": model.NewFunction(model.GenericFunctionSignature(
 		func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
 			var diagnostics hcl.Diagnostics
@@ -162,6 +373,7 @@ var pulumiBuiltins = map[string]*model.Function{
 				ReturnType: elementType,
 			}, diagnostics
 		})),
+	"merge": model.NewFunction(model.GenericFunctionSignature(getMergeSignature)),
 	"mimeType": model.NewFunction(model.StaticFunctionSignature{
 		Parameters: []model.Parameter{{
 			Name: "path",
@@ -196,6 +408,23 @@ var pulumiBuiltins = map[string]*model.Function{
 		}},
 		ReturnType: model.StringType,
 	}),
+	"replace": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{
+			{
+				Name: "string",
+				Type: model.StringType,
+			},
+			{
+				Name: "substring",
+				Type: model.StringType,
+			},
+			{
+				Name: "replacement",
+				Type: model.StringType,
+			},
+		},
+		ReturnType: model.StringType,
+	}),
 	"secret": model.NewFunction(model.GenericFunctionSignature(
 		func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
 			valueType := model.Type(model.DynamicType)
@@ -211,6 +440,20 @@ var pulumiBuiltins = map[string]*model.Function{
 				ReturnType: model.NewOutputType(valueType),
 			}, nil
 		})),
+	"sha1": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{{
+			Name: "string",
+			Type: model.StringType,
+		}},
+		ReturnType: model.StringType,
+	}),
+	"sha256": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{{
+			Name: "string",
+			Type: model.StringType,
+		}},
+		ReturnType: model.StringType,
+	}),
 	"split": model.NewFunction(model.StaticFunctionSignature{
 		Parameters: []model.Parameter{
 			{
@@ -224,6 +467,13 @@ var pulumiBuiltins = map[string]*model.Function{
 		},
 		ReturnType: model.NewListType(model.StringType),
 	}),
+	"toBase64": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{{
+			Name: "string",
+			Type: model.StringType,
+		}},
+		ReturnType: model.StringType,
+	}),
 	"toJSON": model.NewFunction(model.StaticFunctionSignature{
 		Parameters: []model.Parameter{{
 			Name: "value",
@@ -231,4 +481,7 @@ var pulumiBuiltins = map[string]*model.Function{
 		}},
 		ReturnType: model.StringType,
 	}),
+	"values": model.NewFunction(getMapFunctionSignature("values", func(elementType model.Type) model.Type {
+		return model.NewListType(elementType)
+	})),
 }
@@ -0,0 +1,497 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// errorf returns an error that describes a failure to evaluate the given expression.
+func errorf(x model.Expression, format string, args ...interface{}) error {
+	return errors.Errorf("%v: %s", x.SyntaxNode().Range(), fmt.Sprintf(format, args...))
+}
+
+// evaluate evaluates the given expression. Values that depend on values that are not yet known are themselves
+// unknown, and values that depend on secrets are themselves secret.
+func (e *evaluator) evaluate(x model.Expression) (resource.PropertyValue, error) {
+	switch x := x.(type) {
+	case *model.LiteralValueExpression:
+		return ctyToProperty(x.Value)
+	case *model.TemplateExpression:
+		return e.evaluateTemplate(x)
+	case *model.TemplateJoinExpression:
+		return e.evaluateTemplateJoin(x)
+	case *model.ScopeTraversalExpression:
+		root, err := e.lookup(x)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return e.traverse(x, root, x.Traversal[1:])
+	case *model.RelativeTraversalExpression:
+		source, err := e.evaluate(x.Source)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return e.traverse(x, source, x.Traversal)
+	case *model.IndexExpression:
+		collection, err := e.evaluate(x.Collection)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		key, err := e.evaluate(x.Key)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return index(x, collection, key)
+	case *model.ConditionalExpression:
+		return e.evaluateConditional(x)
+	case *model.BinaryOpExpression:
+		return e.evaluateOperation(x, x.Operation, x.LeftOperand, x.RightOperand)
+	case *model.UnaryOpExpression:
+		return e.evaluateOperation(x, x.Operation, x.Operand)
+	case *model.ObjectConsExpression:
+		return e.evaluateObjectCons(x)
+	case *model.TupleConsExpression:
+		elements := make([]resource.PropertyValue, len(x.Expressions))
+		for i, expr := range x.Expressions {
+			element, err := e.evaluate(expr)
+			if err != nil {
+				return resource.PropertyValue{}, err
+			}
+			elements[i] = element
+		}
+		return resource.NewArrayProperty(elements), nil
+	case *model.ForExpression:
+		return e.evaluateFor(x)
+	case *model.SplatExpression:
+		return e.evaluateSplat(x)
+	case *model.FunctionCallExpression:
+		return e.evaluateCall(x)
+	default:
+		return resource.PropertyValue{}, errorf(x, "cannot evaluate expressions of type %T", x)
+	}
+}
+
+// lookup returns the value of the root of the given traversal.
+func (e *evaluator) lookup(x *model.ScopeTraversalExpression) (resource.PropertyValue, error) {
+	root := x.Parts[0]
+	if c, ok := root.(*model.Constant); ok {
+		return ctyToProperty(c.ConstantValue)
+	}
+	if v, ok := e.values[root]; ok {
+		return v, nil
+	}
+	// The range variable of a resource is defined in a scope that is private to the resource.
+	if v, ok := root.(*model.Variable); ok && v.Name == "range" && e.rangeValue != nil {
+		return *e.rangeValue, nil
+	}
+	return resource.PropertyValue{}, errorf(x, "%s is not defined", x.RootName)
+}
+
+// traverse applies the given traversal to the given value.
+func (e *evaluator) traverse(x model.Expression, value resource.PropertyValue,
+	traversal hcl.Traversal) (resource.PropertyValue, error) {
+
+	for _, traverser := range traversal {
+		var key resource.PropertyValue
+		switch traverser := traverser.(type) {
+		case hcl.TraverseAttr:
+			key = resource.NewStringProperty(traverser.Name)
+		case hcl.TraverseIndex:
+			k, err := ctyToProperty(traverser.Key)
+			if err != nil {
+				return resource.PropertyValue{}, errorf(x, "%v", err)
+			}
+			key = k
+		default:
+			return resource.PropertyValue{}, errorf(x, "unsupported traversal")
+		}
+
+		v, err := index(x, value, key)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		value = v
+	}
+	return value, nil
+}
+
+// index returns the element of the given collection with the given key. A missing object property is null.
+func index(x model.Expression, collection, key resource.PropertyValue) (resource.PropertyValue, error) {
+	collection, secret := unwrap(collection)
+	key, keySecret := unwrap(key)
+	secret = secret || keySecret
+	if isUnknown(collection) || isUnknown(key) {
+		return wrap(unknown, secret), nil
+	}
+
+	switch {
+	case collection.IsObject():
+		k, err := toString(key)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "invalid key: %v", err)
+		}
+		v, ok := collection.ObjectValue()[resource.PropertyKey(k)]
+		if !ok {
+			return wrap(resource.NewNullProperty(), secret), nil
+		}
+		return wrap(v, secret), nil
+	case collection.IsArray():
+		if !key.IsNumber() {
+			return resource.PropertyValue{}, errorf(x, "lists must be indexed by numbers")
+		}
+		elements, i := collection.ArrayValue(), key.NumberValue()
+		if i != math.Trunc(i) || i < 0 || int(i) >= len(elements) {
+			return resource.PropertyValue{}, errorf(x, "index %v is out of range for a list of length %v",
+				i, len(elements))
+		}
+		return wrap(elements[int(i)], secret), nil
+	case collection.IsNull():
+		return resource.PropertyValue{}, errorf(x, "cannot access an element of a null value")
+	default:
+		return resource.PropertyValue{}, errorf(x, "cannot access an element of a %v", collection.TypeString())
+	}
+}
+
+func (e *evaluator) evaluateTemplate(x *model.TemplateExpression) (resource.PropertyValue, error) {
+	// As in HCL, a template that consists of a single interpolation evaluates to the interpolated value.
+	if len(x.Parts) == 1 {
+		if _, isLiteral := x.Parts[0].(*model.LiteralValueExpression); !isLiteral {
+			return e.evaluate(x.Parts[0])
+		}
+	}
+
+	parts := make([]resource.PropertyValue, len(x.Parts))
+	for i, part := range x.Parts {
+		v, err := e.evaluate(part)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		parts[i] = v
+	}
+	return concat(x, parts, "")
+}
+
+func (e *evaluator) evaluateTemplateJoin(x *model.TemplateJoinExpression) (resource.PropertyValue, error) {
+	tuple, err := e.evaluate(x.Tuple)
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+	tuple, secret := unwrap(tuple)
+	if isUnknown(tuple) {
+		return wrap(unknown, secret), nil
+	}
+	if !tuple.IsArray() {
+		return resource.PropertyValue{}, errorf(x, "expected a list, not a %v", tuple.TypeString())
+	}
+	result, err := concat(x, tuple.ArrayValue(), "")
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+	return wrap(result, secret), nil
+}
+
+// concat joins the string representations of the given values with the given separator.
+func concat(x model.Expression, values []resource.PropertyValue, sep string) (resource.PropertyValue, error) {
+	var parts []string
+	secret, known := false, true
+	for _, v := range values {
+		v, s := unwrap(v)
+		secret = secret || s
+		if isUnknown(v) {
+			known = false
+			continue
+		}
+		str, err := toString(v)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		parts = append(parts, str)
+	}
+	if !known {
+		return wrap(unknown, secret), nil
+	}
+	return wrap(resource.NewStringProperty(strings.Join(parts, sep)), secret), nil
+}
+
+func (e *evaluator) evaluateConditional(x *model.ConditionalExpression) (resource.PropertyValue, error) {
+	condition, err := e.evaluate(x.Condition)
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+	condition, secret := unwrap(condition)
+	if isUnknown(condition) {
+		return wrap(unknown, secret), nil
+	}
+	if !condition.IsBool() {
+		return resource.PropertyValue{}, errorf(x, "the condition must be a bool, not a %v", condition.TypeString())
+	}
+
+	result := x.FalseResult
+	if condition.BoolValue() {
+		result = x.TrueResult
+	}
+	v, err := e.evaluate(result)
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+	return wrap(v, secret), nil
+}
+
+// evaluateOperation applies the given HCL operation to the given operands.
+func (e *evaluator) evaluateOperation(x model.Expression, op *hclsyntax.Operation,
+	operands ...model.Expression) (resource.PropertyValue, error) {
+
+	args := make([]cty.Value, len(operands))
+	secret, known := false, true
+	for i, operand := range operands {
+		v, err := e.evaluate(operand)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		v, s := unwrap(v)
+		secret, known = secret || s, known && !isUnknown(v)
+
+		arg, err := propertyToCty(v)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(operand, "%v", err)
+		}
+		args[i] = arg
+	}
+	if !known {
+		return wrap(unknown, secret), nil
+	}
+
+	result, err := op.Impl.Call(args)
+	if err != nil {
+		return resource.PropertyValue{}, errorf(x, "%v", err)
+	}
+	v, err := ctyToProperty(result)
+	if err != nil {
+		return resource.PropertyValue{}, errorf(x, "%v", err)
+	}
+	return wrap(v, secret), nil
+}
+
+func (e *evaluator) evaluateObjectCons(x *model.ObjectConsExpression) (resource.PropertyValue, error) {
+	obj := resource.PropertyMap{}
+	for _, item := range x.Items {
+		key, err := e.evaluate(item.Key)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		key, _ = unwrap(key)
+		if isUnknown(key) {
+			return resource.PropertyValue{}, errorf(item.Key, "object keys must be known")
+		}
+		k, err := toString(key)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(item.Key, "invalid key: %v", err)
+		}
+
+		value, err := e.evaluate(item.Value)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		obj[resource.PropertyKey(k)] = value
+	}
+	return resource.NewObjectProperty(obj), nil
+}
+
+// entries returns the keys and values of the given list or object. The keys of a list are the indices of its
+// elements; the entries of an object are sorted by key.
+func entries(x model.Expression, collection resource.PropertyValue) ([]resource.PropertyValue,
+	[]resource.PropertyValue, error) {
+
+	switch {
+	case collection.IsArray():
+		elements := collection.ArrayValue()
+		keys := make([]resource.PropertyValue, len(elements))
+		for i := range elements {
+			keys[i] = resource.NewNumberProperty(float64(i))
+		}
+		return keys, elements, nil
+	case collection.IsObject():
+		obj := collection.ObjectValue()
+		var keys, values []resource.PropertyValue
+		for _, k := range obj.StableKeys() {
+			keys, values = append(keys, resource.NewStringProperty(string(k))), append(values, obj[k])
+		}
+		return keys, values, nil
+	case collection.IsNull():
+		return nil, nil, nil
+	default:
+		return nil, nil, errorf(x, "expected a list or map, not a %v", collection.TypeString())
+	}
+}
+
+// bind sets the value of the given variable for the duration of the given function.
+func (e *evaluator) bind(v model.Traversable, value resource.PropertyValue, f func() error) error {
+	old, hadOld := e.values[v]
+	e.values[v] = value
+	defer func() {
+		if hadOld {
+			e.values[v] = old
+		} else {
+			delete(e.values, v)
+		}
+	}()
+	return f()
+}
+
+func (e *evaluator) evaluateFor(x *model.ForExpression) (resource.PropertyValue, error) {
+	collection, err := e.evaluate(x.Collection)
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+	collection, secret := unwrap(collection)
+	if isUnknown(collection) {
+		return wrap(unknown, secret), nil
+	}
+	keys, values, err := entries(x.Collection, collection)
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+
+	var elements []resource.PropertyValue
+	obj := resource.PropertyMap{}
+	known := true
+	for i := range keys {
+		evaluateElement := func() error {
+			if x.Condition != nil {
+				condition, err := e.evaluate(x.Condition)
+				if err != nil {
+					return err
+				}
+				condition, s := unwrap(condition)
+				secret = secret || s
+				if isUnknown(condition) {
+					known = false
+					return nil
+				}
+				if !condition.IsBool() {
+					return errorf(x.Condition, "the condition must be a bool, not a %v", condition.TypeString())
+				}
+				if !condition.BoolValue() {
+					return nil
+				}
+			}
+
+			value, err := e.evaluate(x.Value)
+			if err != nil {
+				return err
+			}
+			if x.Key == nil {
+				elements = append(elements, value)
+				return nil
+			}
+
+			key, err := e.evaluate(x.Key)
+			if err != nil {
+				return err
+			}
+			key, s := unwrap(key)
+			secret = secret || s
+			if isUnknown(key) {
+				known = false
+				return nil
+			}
+			k, err := toString(key)
+			if err != nil {
+				return errorf(x.Key, "invalid key: %v", err)
+			}
+			pk := resource.PropertyKey(k)
+			switch existing, has := obj[pk]; {
+			case x.Group && has:
+				obj[pk] = resource.NewArrayProperty(append(existing.ArrayValue(), value))
+			case x.Group:
+				obj[pk] = resource.NewArrayProperty([]resource.PropertyValue{value})
+			case has:
+				return errorf(x.Key, "duplicate key %q", k)
+			default:
+				obj[pk] = value
+			}
+			return nil
+		}
+
+		bindValue := func() error { return e.bind(x.ValueVariable, values[i], evaluateElement) }
+		if x.KeyVariable != nil {
+			err = e.bind(x.KeyVariable, keys[i], bindValue)
+		} else {
+			err = bindValue()
+		}
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+	}
+
+	switch {
+	case !known:
+		return wrap(unknown, secret), nil
+	case x.Key != nil:
+		return wrap(resource.NewObjectProperty(obj), secret), nil
+	default:
+		if elements == nil {
+			elements = []resource.PropertyValue{}
+		}
+		return wrap(resource.NewArrayProperty(elements), secret), nil
+	}
+}
+
+func (e *evaluator) evaluateSplat(x *model.SplatExpression) (resource.PropertyValue, error) {
+	source, err := e.evaluate(x.Source)
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+	source, secret := unwrap(source)
+	if isUnknown(source) {
+		return wrap(unknown, secret), nil
+	}
+
+	// As in HCL, a splat of a value that is not a list treats the value as a list of one element.
+	var items []resource.PropertyValue
+	switch {
+	case source.IsArray():
+		items = source.ArrayValue()
+	case !source.IsNull():
+		items = []resource.PropertyValue{source}
+	}
+
+	elements := []resource.PropertyValue{}
+	for _, item := range items {
+		err = e.bind(x.Item, item, func() error {
+			element, err := e.evaluate(x.Each)
+			if err != nil {
+				return err
+			}
+			elements = append(elements, element)
+			return nil
+		})
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+	}
+	return wrap(resource.NewArrayProperty(elements), secret), nil
+}
@@ -0,0 +1,492 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpreter
+
+import (
+	// nolint: gosec
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"mime"
+	"net"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// evaluateCall evaluates a call to one of the PCL builtin functions.
+func (e *evaluator) evaluateCall(x *model.FunctionCallExpression) (resource.PropertyValue, error) {
+	args := make([]resource.PropertyValue, len(x.Args))
+	for i, arg := range x.Args {
+		v, err := e.evaluate(arg)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		args[i] = v
+	}
+
+	switch x.Name {
+	case hcl2.Invoke:
+		return e.invoke(x, args)
+	case "secret":
+		return resource.MakeSecret(args[0]), nil
+	case "toJSON":
+		if args[0].ContainsUnknowns() {
+			return wrap(unknown, args[0].ContainsSecrets()), nil
+		}
+		plain, err := toPlain(args[0])
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		b, err := json.Marshal(plain)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		return wrap(resource.NewStringProperty(string(b)), args[0].ContainsSecrets()), nil
+	}
+
+	// The remaining functions are strict in their arguments: if any argument is unknown, so is the result, and if any
+	// argument is secret, so is the result.
+	secret := false
+	for i, arg := range args {
+		v, s := unwrap(arg)
+		if isUnknown(v) {
+			return wrap(unknown, secret || s), nil
+		}
+		args[i], secret = v, secret || s
+	}
+	result, err := callBuiltin(x, args)
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+	return wrap(result, secret), nil
+}
+
+// stringArg returns the i'th argument to a function, which must be a string.
+func stringArg(x *model.FunctionCallExpression, args []resource.PropertyValue, i int) (string, error) {
+	if !args[i].IsString() {
+		return "", errorf(x.Args[i], "expected a string, not a %v", args[i].TypeString())
+	}
+	return args[i].StringValue(), nil
+}
+
+// numberArg returns the i'th argument to a function, which must be a whole number.
+func numberArg(x *model.FunctionCallExpression, args []resource.PropertyValue, i int) (int, error) {
+	if !args[i].IsNumber() || args[i].NumberValue() != math.Trunc(args[i].NumberValue()) {
+		return 0, errorf(x.Args[i], "expected a whole number, not a %v", args[i].TypeString())
+	}
+	return int(args[i].NumberValue()), nil
+}
+
+// listArg returns the i'th argument to a function, which must be a list.
+func listArg(x *model.FunctionCallExpression, args []resource.PropertyValue, i int) ([]resource.PropertyValue, error) {
+	if !args[i].IsArray() {
+		return nil, errorf(x.Args[i], "expected a list, not a %v", args[i].TypeString())
+	}
+	return args[i].ArrayValue(), nil
+}
+
+// mapArg returns the i'th argument to a function, which must be a map.
+func mapArg(x *model.FunctionCallExpression, args []resource.PropertyValue, i int) (resource.PropertyMap, error) {
+	if !args[i].IsObject() {
+		return nil, errorf(x.Args[i], "expected a map, not a %v", args[i].TypeString())
+	}
+	return args[i].ObjectValue(), nil
+}
+
+// callBuiltin calls the given builtin function with the given known, non-secret arguments.
+func callBuiltin(x *model.FunctionCallExpression, args []resource.PropertyValue) (resource.PropertyValue, error) {
+	switch x.Name {
+	case "cidrsubnet":
+		prefix, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		newbits, err := numberArg(x, args, 1)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		netnum, err := numberArg(x, args, 2)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		subnet, err := cidrSubnet(prefix, newbits, netnum)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		return resource.NewStringProperty(subnet), nil
+	case "element":
+		if !args[0].IsArray() {
+			return resource.PropertyValue{}, errorf(x.Args[0], "expected a list, not a %v", args[0].TypeString())
+		}
+		i, err := numberArg(x, args, 1)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		elements := args[0].ArrayValue()
+		if len(elements) == 0 {
+			return resource.PropertyValue{}, errorf(x, "cannot use element on an empty list")
+		}
+		// As in HCL, the index wraps around the end of the list.
+		return elements[(i%len(elements)+len(elements))%len(elements)], nil
+	case "entries":
+		keys, values, err := entries(x.Args[0], args[0])
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		result := make([]resource.PropertyValue, len(keys))
+		for i := range keys {
+			result[i] = resource.NewArrayProperty([]resource.PropertyValue{keys[i], values[i]})
+		}
+		return resource.NewArrayProperty(result), nil
+	case "flatten":
+		lists, err := listArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		// As with the other builtins, the result is unknown if any of the nested lists is unknown, and secret if any of
+		// the nested lists is secret.
+		elements, secret := []resource.PropertyValue{}, false
+		for _, l := range lists {
+			l, s := unwrap(l)
+			if isUnknown(l) {
+				return wrap(unknown, secret || s), nil
+			}
+			if !l.IsArray() {
+				return resource.PropertyValue{}, errorf(x.Args[0], "expected a list of lists, not a list of %v",
+					l.TypeString())
+			}
+			elements, secret = append(elements, l.ArrayValue()...), secret || s
+		}
+		return wrap(resource.NewArrayProperty(elements), secret), nil
+	case "format":
+		format, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		values := make([]interface{}, len(args)-1)
+		for i, arg := range args[1:] {
+			v, err := toPlain(arg)
+			if err != nil {
+				return resource.PropertyValue{}, errorf(x.Args[i+1], "%v", err)
+			}
+			// Whole numbers are formatted as integers so that they may be used with verbs like %d.
+			if f, ok := v.(float64); ok && f == math.Trunc(f) {
+				v = int64(f)
+			}
+			values[i] = v
+		}
+		return resource.NewStringProperty(fmt.Sprintf(format, values...)), nil
+	case "fromBase64":
+		str, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		b, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		return resource.NewStringProperty(string(b)), nil
+	case "fromJSON":
+		str, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		var v interface{}
+		if err = json.Unmarshal([]byte(str), &v); err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		return resource.NewPropertyValue(v), nil
+	case "fileArchive":
+		path, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		archive, err := resource.NewPathArchive(path)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		return resource.NewArchiveProperty(archive), nil
+	case "fileAsset":
+		path, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		asset, err := resource.NewPathAsset(path)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		return resource.NewAssetProperty(asset), nil
+	case "join":
+		sep, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		elements, err := listArg(x, args, 1)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		parts, secret := make([]string, len(elements)), false
+		for i, e := range elements {
+			e, s := unwrap(e)
+			if isUnknown(e) {
+				return wrap(unknown, secret || s), nil
+			}
+			if parts[i], err = toString(e); err != nil {
+				return resource.PropertyValue{}, errorf(x.Args[1], "%v", err)
+			}
+			secret = secret || s
+		}
+		return wrap(resource.NewStringProperty(strings.Join(parts, sep)), secret), nil
+	case "keys":
+		m, err := mapArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		keys := make([]resource.PropertyValue, 0, len(m))
+		for _, k := range m.StableKeys() {
+			keys = append(keys, resource.NewStringProperty(string(k)))
+		}
+		return resource.NewArrayProperty(keys), nil
+	case "length":
+		switch v := args[0]; {
+		case v.IsArray():
+			return resource.NewNumberProperty(float64(len(v.ArrayValue()))), nil
+		case v.IsObject():
+			return resource.NewNumberProperty(float64(len(v.ObjectValue()))), nil
+		case v.IsString():
+			return resource.NewNumberProperty(float64(utf8.RuneCountInString(v.StringValue()))), nil
+		default:
+			return resource.PropertyValue{}, errorf(x.Args[0], "cannot take the length of a %v", v.TypeString())
+		}
+	case "lookup":
+		if !args[0].IsObject() {
+			return resource.PropertyValue{}, errorf(x.Args[0], "expected a map, not a %v", args[0].TypeString())
+		}
+		key, err := stringArg(x, args, 1)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		if v, ok := args[0].ObjectValue()[resource.PropertyKey(key)]; ok {
+			return v, nil
+		}
+		if len(args) > 2 {
+			return args[2], nil
+		}
+		return resource.PropertyValue{}, errorf(x, "the map does not contain the key %q", key)
+	case "merge":
+		result := resource.PropertyMap{}
+		for i := range args {
+			m, err := mapArg(x, args, i)
+			if err != nil {
+				return resource.PropertyValue{}, err
+			}
+			for k, v := range m {
+				result[k] = v
+			}
+		}
+		return resource.NewObjectProperty(result), nil
+	case "mimeType":
+		path, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return resource.NewStringProperty(mime.TypeByExtension(filepath.Ext(path))), nil
+	case "range":
+		// range(n) produces [0, n); range(from, to) produces [from, to).
+		from, to := 0, 0
+		n, err := numberArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		if len(args) > 1 {
+			if to, err = numberArg(x, args, 1); err != nil {
+				return resource.PropertyValue{}, err
+			}
+			from = n
+		} else {
+			to = n
+		}
+		elements := []resource.PropertyValue{}
+		for i := from; i < to; i++ {
+			elements = append(elements, resource.NewNumberProperty(float64(i)))
+		}
+		return resource.NewArrayProperty(elements), nil
+	case "readDir":
+		path, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		names := make([]resource.PropertyValue, len(infos))
+		for i, info := range infos {
+			names[i] = resource.NewStringProperty(info.Name())
+		}
+		return resource.NewArrayProperty(names), nil
+	case "readFile":
+		path, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return resource.PropertyValue{}, errorf(x, "%v", err)
+		}
+		return resource.NewStringProperty(string(b)), nil
+	case "replace":
+		str, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		substring, err := stringArg(x, args, 1)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		replacement, err := stringArg(x, args, 2)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return resource.NewStringProperty(strings.ReplaceAll(str, substring, replacement)), nil
+	case "sha1":
+		str, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		sum := sha1.Sum([]byte(str))
+		return resource.NewStringProperty(hex.EncodeToString(sum[:])), nil
+	case "sha256":
+		str, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		sum := sha256.Sum256([]byte(str))
+		return resource.NewStringProperty(hex.EncodeToString(sum[:])), nil
+	case "split":
+		sep, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		str, err := stringArg(x, args, 1)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		parts := strings.Split(str, sep)
+		elements := make([]resource.PropertyValue, len(parts))
+		for i, p := range parts {
+			elements[i] = resource.NewStringProperty(p)
+		}
+		return resource.NewArrayProperty(elements), nil
+	case "toBase64":
+		str, err := stringArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return resource.NewStringProperty(base64.StdEncoding.EncodeToString([]byte(str))), nil
+	case "values":
+		m, err := mapArg(x, args, 0)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		values := make([]resource.PropertyValue, 0, len(m))
+		for _, k := range m.StableKeys() {
+			values = append(values, m[k])
+		}
+		return resource.NewArrayProperty(values), nil
+	default:
+		return resource.PropertyValue{}, errorf(x, "unknown function %s", x.Name)
+	}
+}
+
+// cidrSubnet computes the address of the netnum'th subnet of the given CIDR prefix that is newbits longer than the
+// prefix.
+func cidrSubnet(prefix string, newbits, netnum int) (string, error) {
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", err
+	}
+	length, bits := network.Mask.Size()
+	newLength := length + newbits
+	if newbits < 0 || newLength > bits {
+		return "", fmt.Errorf("cannot extend prefix %v by %v bits", prefix, newbits)
+	}
+	if netnum < 0 || newbits < 63 && netnum >= 1<<uint(newbits) {
+		return "", fmt.Errorf("prefix extension of %v bits cannot accommodate network number %v", newbits, netnum)
+	}
+
+	ip := make(net.IP, len(network.IP))
+	copy(ip, network.IP)
+	for i := 0; i < newbits; i++ {
+		if netnum&(1<<uint(newbits-1-i)) != 0 {
+			bit := length + i
+			ip[bit/8] |= 1 << uint(7-bit%8)
+		}
+	}
+	subnet := net.IPNet{IP: ip, Mask: net.CIDRMask(newLength, bits)}
+	return subnet.String(), nil
+}
+
+// invoke calls the provider function named by the first argument with the arguments given by the second. The
+// optional third argument names the provider to use, either as a provider reference or as a provider resource. If the
+// arguments are not yet known, neither is the result.
+func (e *evaluator) invoke(x *model.FunctionCallExpression, args []resource.PropertyValue) (resource.PropertyValue,
+	error) {
+
+	tok, err := stringArg(x, args, 0)
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+
+	inputs := resource.PropertyMap{}
+	if len(args) > 1 {
+		v, _ := unwrap(args[1])
+		switch {
+		case v.ContainsUnknowns():
+			return unknown, nil
+		case v.IsObject():
+			inputs = v.ObjectValue()
+		case !v.IsNull():
+			return resource.PropertyValue{}, errorf(x.Args[1], "expected an object, not a %v", v.TypeString())
+		}
+	}
+
+	var provider string
+	if len(args) > 2 {
+		v, _ := unwrap(args[2])
+		if isUnknown(v) {
+			return unknown, nil
+		}
+		if provider, err = providerReference(x.Args[2], v); err != nil {
+			return resource.PropertyValue{}, err
+		}
+	}
+
+	result, err := e.monitor.invoke(tok, provider, inputs)
+	if err != nil {
+		return resource.PropertyValue{}, errorf(x, "%v", err)
+	}
+	return resource.NewObjectProperty(result), nil
+}
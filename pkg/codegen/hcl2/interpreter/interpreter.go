@@ -0,0 +1,525 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interpreter evaluates bound PCL programs directly against the engine's resource monitor, without first
+// generating code in another language.
+package interpreter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// evaluator holds the state used to evaluate a node of a running program.
+type evaluator struct {
+	info    plugin.RunInfo
+	monitor *resourceMonitor
+
+	// The URN of the program's root stack resource.
+	stack resource.URN
+
+	// The values of the program's config variables, locals, and resources.
+	values map[model.Traversable]resource.PropertyValue
+	// The URNs of the resources each config variable, local, or resource depends on. For a resource, these are the
+	// URNs of the resource's own instances.
+	deps map[model.Traversable][]resource.URN
+
+	// The value of the range variable for the resource instance that is currently being evaluated, if any.
+	rangeValue *resource.PropertyValue
+}
+
+// Run evaluates the given program, registering its resources with the resource monitor named by the run info. The
+// program's outputs are registered as the outputs of its root stack resource.
+//
+// Each config variable, local, resource, and output is evaluated as soon as the nodes that it references have been
+// evaluated, so independent resources are registered concurrently. At most info.Parallel nodes are evaluated at once;
+// a degree of parallelism of one or less evaluates the program serially. Once any node fails, no further nodes are
+// evaluated, and the first failure is returned.
+func Run(ctx context.Context, program *hcl2.Program, info plugin.RunInfo) error {
+	monitor, err := dialMonitor(ctx, info.MonitorAddress)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(monitor)
+
+	stack, _, _, err := monitor.registerResource(&pulumirpc.RegisterResourceRequest{
+		Type: string(resource.RootStackType),
+		Name: fmt.Sprintf("%s-%s", info.Project, info.Stack),
+	}, resource.PropertyMap{})
+	if err != nil {
+		return errors.Wrap(err, "registering stack")
+	}
+
+	nodes := hcl2.Linearize(program)
+	done := make(map[hcl2.Node]chan struct{}, len(nodes))
+	for _, n := range nodes {
+		done[n] = make(chan struct{})
+	}
+
+	parallel := info.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	slots := make(chan struct{}, parallel)
+
+	var m sync.Mutex
+	values := map[model.Traversable]resource.PropertyValue{}
+	deps := map[model.Traversable][]resource.URN{}
+	outputs := resource.PropertyMap{}
+	var result error
+
+	var wg sync.WaitGroup
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n hcl2.Node) {
+			defer wg.Done()
+			defer close(done[n])
+
+			for _, d := range nodeDependencies(n) {
+				if ch, ok := done[d]; ok {
+					<-ch
+				}
+			}
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			// Evaluate the node with its own copy of the values computed so far, which include the values of all of
+			// the nodes that it references.
+			m.Lock()
+			if result != nil {
+				m.Unlock()
+				return
+			}
+			e := &evaluator{
+				info:    info,
+				monitor: monitor,
+				stack:   stack,
+				values:  make(map[model.Traversable]resource.PropertyValue, len(values)),
+				deps:    make(map[model.Traversable][]resource.URN, len(deps)),
+			}
+			for k, v := range values {
+				e.values[k] = v
+			}
+			for k, v := range deps {
+				e.deps[k] = v
+			}
+			m.Unlock()
+
+			output, err := e.evaluateNode(n)
+
+			m.Lock()
+			defer m.Unlock()
+			if err != nil {
+				if result == nil {
+					result = err
+				}
+				return
+			}
+			if v, ok := n.(*hcl2.OutputVariable); ok {
+				outputs[resource.PropertyKey(v.Name())] = output
+				return
+			}
+			if v, ok := e.values[n]; ok {
+				values[n] = v
+			}
+			if d, ok := e.deps[n]; ok {
+				deps[n] = d
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if result != nil {
+		return result
+	}
+	return monitor.registerResourceOutputs(stack, outputs)
+}
+
+// nodeDependencies returns the nodes that are referenced by the given node.
+func nodeDependencies(n hcl2.Node) []hcl2.Node {
+	var nodes []hcl2.Node
+	diags := n.VisitExpressions(nil, func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		if traversal, ok := x.(*model.ScopeTraversalExpression); ok {
+			if d, ok := traversal.Parts[0].(hcl2.Node); ok && d != n {
+				nodes = append(nodes, d)
+			}
+		}
+		return x, nil
+	})
+	contract.Assert(len(diags) == 0)
+	return nodes
+}
+
+// evaluateNode evaluates the given node. The value of an output variable is returned rather than recorded.
+func (e *evaluator) evaluateNode(n hcl2.Node) (resource.PropertyValue, error) {
+	switch n := n.(type) {
+	case *hcl2.ConfigVariable:
+		return resource.PropertyValue{}, e.evaluateConfigVariable(n)
+	case *hcl2.LocalVariable:
+		value, err := e.evaluate(n.Definition.Value)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		e.values[n], e.deps[n] = value, e.dependencies(n.Definition.Value)
+		return resource.PropertyValue{}, nil
+	case *hcl2.Resource:
+		return resource.PropertyValue{}, e.evaluateResource(n)
+	case *hcl2.OutputVariable:
+		return e.evaluate(n.Value)
+	default:
+		return resource.PropertyValue{}, nil
+	}
+}
+
+// evaluateConfigVariable reads the value of the given config variable from the stack's configuration. If the variable
+// is not set, its default value is used.
+func (e *evaluator) evaluateConfigVariable(v *hcl2.ConfigVariable) error {
+	key := config.MustMakeKey(e.info.Project, v.Name())
+	if strings.Contains(v.Name(), ":") {
+		k, err := config.ParseKey(v.Name())
+		if err != nil {
+			return errors.Wrapf(err, "config variable %s", v.Name())
+		}
+		key = k
+	}
+
+	str, ok := e.info.Config[key]
+	if !ok {
+		if v.DefaultValue == nil {
+			return errors.Errorf("missing required configuration variable '%s'; run `pulumi config set %s <value>`",
+				key, v.Name())
+		}
+		value, err := e.evaluate(v.DefaultValue)
+		if err != nil {
+			return err
+		}
+		e.values[v] = value
+		return nil
+	}
+
+	var value resource.PropertyValue
+	switch model.ResolveOutputs(v.Type()) {
+	case model.StringType:
+		value = resource.NewStringProperty(str)
+	case model.NumberType, model.IntType:
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return errors.Errorf("configuration variable '%s' must be a number", key)
+		}
+		value = resource.NewNumberProperty(f)
+	case model.BoolType:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return errors.Errorf("configuration variable '%s' must be a boolean", key)
+		}
+		value = resource.NewBoolProperty(b)
+	default:
+		var plain interface{}
+		if err := json.Unmarshal([]byte(str), &plain); err != nil {
+			return errors.Errorf("configuration variable '%s' must be a JSON value", key)
+		}
+		value = resource.NewPropertyValue(plain)
+	}
+	e.values[v] = value
+	return nil
+}
+
+// evaluateResource registers the instances of the given resource. If the resource has a range option, one instance is
+// registered per element of the range, and the resource's value is the collection of those instances' values.
+func (e *evaluator) evaluateResource(r *hcl2.Resource) error {
+	if r.Options == nil || r.Options.Range == nil {
+		value, urn, err := e.registerResource(r, r.Name())
+		if err != nil {
+			return err
+		}
+		e.values[r], e.deps[r] = value, []resource.URN{urn}
+		return nil
+	}
+
+	rng, err := e.evaluate(r.Options.Range)
+	if err != nil {
+		return err
+	}
+	rng, _ = unwrap(rng)
+	if rng.ContainsUnknowns() {
+		return errorf(r.Options.Range, "the range of resource %s must be known", r.Name())
+	}
+
+	var urns []resource.URN
+	register := func(key, value resource.PropertyValue, name string) (resource.PropertyValue, error) {
+		rangeValue := resource.NewObjectProperty(resource.PropertyMap{"key": key, "value": value})
+		e.rangeValue = &rangeValue
+		defer func() { e.rangeValue = nil }()
+
+		instance, urn, err := e.registerResource(r, name)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		urns = append(urns, urn)
+		return instance, nil
+	}
+
+	var value resource.PropertyValue
+	switch {
+	case rng.IsBool():
+		value = resource.NewNullProperty()
+		if rng.BoolValue() {
+			if value, err = register(rng, rng, r.Name()); err != nil {
+				return err
+			}
+		}
+	case rng.IsNumber():
+		n := int(rng.NumberValue())
+		instances := make([]resource.PropertyValue, n)
+		for i := 0; i < n; i++ {
+			idx := resource.NewNumberProperty(float64(i))
+			if instances[i], err = register(idx, idx, fmt.Sprintf("%s-%d", r.Name(), i)); err != nil {
+				return err
+			}
+		}
+		value = resource.NewArrayProperty(instances)
+	case rng.IsArray() || rng.IsObject():
+		keys, values, err := entries(r.Options.Range, rng)
+		if err != nil {
+			return err
+		}
+		instances := make([]resource.PropertyValue, len(keys))
+		for i := range keys {
+			k, err := toString(keys[i])
+			contract.AssertNoError(err)
+			if instances[i], err = register(keys[i], values[i], fmt.Sprintf("%s-%s", r.Name(), k)); err != nil {
+				return err
+			}
+		}
+		if rng.IsArray() {
+			value = resource.NewArrayProperty(instances)
+		} else {
+			obj := resource.PropertyMap{}
+			for i, k := range keys {
+				obj[resource.PropertyKey(k.StringValue())] = instances[i]
+			}
+			value = resource.NewObjectProperty(obj)
+		}
+	default:
+		return errorf(r.Options.Range, "cannot range over a %v", rng.TypeString())
+	}
+
+	e.values[r], e.deps[r] = value, urns
+	return nil
+}
+
+// registerResource registers a single instance of the given resource with the given name and returns its value, which
+// is its outputs along with its ID and URN.
+func (e *evaluator) registerResource(r *hcl2.Resource, name string) (resource.PropertyValue, resource.URN, error) {
+	req := &pulumirpc.RegisterResourceRequest{
+		Type:                 r.Token,
+		Name:                 name,
+		Custom:               true,
+		Parent:               string(e.stack),
+		PropertyDependencies: map[string]*pulumirpc.RegisterResourceRequest_PropertyDependencies{},
+	}
+
+	inputs := resource.PropertyMap{}
+	dependencies := map[resource.URN]bool{}
+	for _, attr := range r.Inputs {
+		value, err := e.evaluate(attr.Value)
+		if err != nil {
+			return resource.PropertyValue{}, "", err
+		}
+		inputs[resource.PropertyKey(attr.Name)] = value
+
+		var urns []string
+		for _, urn := range e.dependencies(attr.Value) {
+			urns, dependencies[urn] = append(urns, string(urn)), true
+		}
+		req.PropertyDependencies[attr.Name] = &pulumirpc.RegisterResourceRequest_PropertyDependencies{Urns: urns}
+	}
+
+	if err := e.evaluateOptions(r.Options, req, dependencies); err != nil {
+		return resource.PropertyValue{}, "", err
+	}
+	for urn := range dependencies {
+		req.Dependencies = append(req.Dependencies, string(urn))
+	}
+
+	urn, id, outputs, err := e.monitor.registerResource(req, inputs)
+	if err != nil {
+		return resource.PropertyValue{}, "", errors.Wrapf(err, "registering resource %s", name)
+	}
+
+	outputs = outputs.Copy()
+	switch {
+	case id != "":
+		outputs["id"] = resource.NewStringProperty(string(id))
+	case e.info.DryRun:
+		outputs["id"] = unknown
+	}
+	outputs["urn"] = resource.NewStringProperty(string(urn))
+	return resource.NewObjectProperty(outputs), urn, nil
+}
+
+// evaluateOptions evaluates the given resource options into the given request. Any explicit dependencies are added to
+// the given set.
+func (e *evaluator) evaluateOptions(options *hcl2.ResourceOptions, req *pulumirpc.RegisterResourceRequest,
+	dependencies map[resource.URN]bool) error {
+
+	if options == nil {
+		return nil
+	}
+
+	if options.Parent != nil {
+		parent, err := e.evaluate(options.Parent)
+		if err != nil {
+			return err
+		}
+		urn, err := resourceURN(options.Parent, parent)
+		if err != nil {
+			return err
+		}
+		req.Parent = urn
+	}
+
+	if options.Provider != nil {
+		provider, err := e.evaluate(options.Provider)
+		if err != nil {
+			return err
+		}
+		ref, err := providerReference(options.Provider, provider)
+		if err != nil {
+			return err
+		}
+		req.Provider = ref
+	}
+
+	if options.DependsOn != nil {
+		// Any resource referenced by the dependsOn option is a dependency, regardless of its value.
+		for _, urn := range e.dependencies(options.DependsOn) {
+			dependencies[urn] = true
+		}
+	}
+
+	if options.Protect != nil {
+		protect, err := e.evaluate(options.Protect)
+		if err != nil {
+			return err
+		}
+		protect, _ = unwrap(protect)
+		if !protect.IsBool() {
+			return errorf(options.Protect, "protect must be a known boolean")
+		}
+		req.Protect = protect.BoolValue()
+	}
+
+	if options.IgnoreChanges != nil {
+		paths, err := e.evaluate(hcl2.RewritePropertyReferences(options.IgnoreChanges))
+		if err != nil {
+			return err
+		}
+		if !paths.IsArray() {
+			return errorf(options.IgnoreChanges, "ignoreChanges must be a list of properties")
+		}
+		for _, p := range paths.ArrayValue() {
+			contract.Assert(p.IsString())
+			req.IgnoreChanges = append(req.IgnoreChanges, p.StringValue())
+		}
+	}
+
+	if options.Aliases != nil {
+		aliases, err := e.evaluate(options.Aliases)
+		if err != nil {
+			return err
+		}
+		aliases, _ = unwrap(aliases)
+		if !aliases.IsArray() {
+			return errorf(options.Aliases, "aliases must be a known list of URNs")
+		}
+		for _, a := range aliases.ArrayValue() {
+			if a, _ = unwrap(a); !a.IsString() {
+				return errorf(options.Aliases, "aliases must be a known list of URNs")
+			}
+			req.Aliases = append(req.Aliases, a.StringValue())
+		}
+	}
+
+	return nil
+}
+
+// dependencies returns the URNs of the resources referenced by the given expression, either directly or through a
+// local variable.
+func (e *evaluator) dependencies(x model.Expression) []resource.URN {
+	var urns []resource.URN
+	seen := map[resource.URN]bool{}
+	_, diags := model.VisitExpression(x, func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		if traversal, ok := x.(*model.ScopeTraversalExpression); ok {
+			for _, urn := range e.deps[traversal.Parts[0]] {
+				if !seen[urn] {
+					urns, seen[urn] = append(urns, urn), true
+				}
+			}
+		}
+		return x, nil
+	}, nil)
+	contract.Assert(len(diags) == 0)
+	return urns
+}
+
+// resourceURN returns the URN of the given resource value.
+func resourceURN(x model.Expression, v resource.PropertyValue) (string, error) {
+	v, _ = unwrap(v)
+	if v.IsObject() {
+		if urn, ok := v.ObjectValue()["urn"]; ok && urn.IsString() {
+			return urn.StringValue(), nil
+		}
+	}
+	return "", errorf(x, "expected a resource")
+}
+
+// providerReference returns a reference to the given provider, which may be either a provider resource or a string
+// of the form "urn::id".
+func providerReference(x model.Expression, v resource.PropertyValue) (string, error) {
+	v, _ = unwrap(v)
+	if v.IsString() {
+		return v.StringValue(), nil
+	}
+
+	urn, err := resourceURN(x, v)
+	if err != nil {
+		return "", errorf(x, "expected a provider resource")
+	}
+	id := plugin.UnknownStringValue
+	if v, ok := v.ObjectValue()["id"]; ok && !isUnknown(v) {
+		v, _ = unwrap(v)
+		if !v.IsString() {
+			return "", errorf(x, "expected a provider resource")
+		}
+		id = v.StringValue()
+	}
+	return urn + "::" + id, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpreter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// resourceMonitor is a client for the engine's resource monitor.
+type resourceMonitor struct {
+	ctx    context.Context
+	conn   *grpc.ClientConn
+	client pulumirpc.ResourceMonitorClient
+
+	supportsSecrets bool
+}
+
+func dialMonitor(ctx context.Context, address string) (*resourceMonitor, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure(), rpcutil.GrpcChannelOptions())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to resource monitor")
+	}
+	client := pulumirpc.NewResourceMonitorClient(conn)
+
+	resp, err := client.SupportsFeature(ctx, &pulumirpc.SupportsFeatureRequest{Id: "secrets"})
+	if err != nil {
+		contract.IgnoreError(conn.Close())
+		return nil, errors.Wrap(err, "could not determine resource monitor features")
+	}
+
+	return &resourceMonitor{
+		ctx:             ctx,
+		conn:            conn,
+		client:          client,
+		supportsSecrets: resp.GetHasSupport(),
+	}, nil
+}
+
+func (m *resourceMonitor) Close() error {
+	return m.conn.Close()
+}
+
+func (m *resourceMonitor) marshalOptions() plugin.MarshalOptions {
+	return plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: m.supportsSecrets}
+}
+
+// registerResource registers a resource with the given inputs and returns its URN, ID, and outputs. If the resource
+// is not a custom resource, or if this is a preview and the resource has not yet been created, its ID is empty.
+func (m *resourceMonitor) registerResource(req *pulumirpc.RegisterResourceRequest,
+	inputs resource.PropertyMap) (resource.URN, resource.ID, resource.PropertyMap, error) {
+
+	object, err := plugin.MarshalProperties(inputs, m.marshalOptions())
+	if err != nil {
+		return "", "", nil, errors.Wrap(err, "marshaling inputs")
+	}
+	req.Object = object
+	req.AcceptSecrets = true
+
+	resp, err := m.client.RegisterResource(m.ctx, req)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	outputs, err := plugin.UnmarshalProperties(resp.GetObject(), plugin.MarshalOptions{
+		KeepUnknowns: true,
+		KeepSecrets:  true,
+	})
+	if err != nil {
+		return "", "", nil, errors.Wrap(err, "unmarshaling outputs")
+	}
+	return resource.URN(resp.GetUrn()), resource.ID(resp.GetId()), outputs, nil
+}
+
+// registerResourceOutputs records the outputs of the given component resource.
+func (m *resourceMonitor) registerResourceOutputs(urn resource.URN, outputs resource.PropertyMap) error {
+	outs, err := plugin.MarshalProperties(outputs, m.marshalOptions())
+	if err != nil {
+		return errors.Wrap(err, "marshaling outputs")
+	}
+	_, err = m.client.RegisterResourceOutputs(m.ctx, &pulumirpc.RegisterResourceOutputsRequest{
+		Urn:     string(urn),
+		Outputs: outs,
+	})
+	return err
+}
+
+// invoke calls the function with the given token and returns its result.
+func (m *resourceMonitor) invoke(tok, provider string, args resource.PropertyMap) (resource.PropertyMap, error) {
+	marshaled, err := plugin.MarshalProperties(args, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling arguments")
+	}
+
+	resp, err := m.client.Invoke(m.ctx, &pulumirpc.InvokeRequest{
+		Tok:      tok,
+		Provider: provider,
+		Args:     marshaled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if failures := resp.GetFailures(); len(failures) != 0 {
+		reasons := make([]string, len(failures))
+		for i, f := range failures {
+			reasons[i] = f.GetReason()
+			if p := f.GetProperty(); p != "" {
+				reasons[i] = p + ": " + reasons[i]
+			}
+		}
+		return nil, errors.Errorf("invoke of %s failed: %s", tok, strings.Join(reasons, "; "))
+	}
+
+	return plugin.UnmarshalProperties(resp.GetReturn(), plugin.MarshalOptions{KeepUnknowns: true})
+}
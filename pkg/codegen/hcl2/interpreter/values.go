@@ -0,0 +1,192 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpreter
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// unknown is the value of an expression whose value is not known, e.g. because it depends on the output of a resource
+// that has not yet been created.
+var unknown = resource.MakeComputed(resource.NewStringProperty(""))
+
+// unwrap returns the given value with any secret markers removed, along with whether or not the value was secret.
+func unwrap(v resource.PropertyValue) (resource.PropertyValue, bool) {
+	secret := false
+	for v.IsSecret() {
+		v, secret = v.SecretValue().Element, true
+	}
+	return v, secret
+}
+
+// wrap marks the given value as secret if secret is true.
+func wrap(v resource.PropertyValue, secret bool) resource.PropertyValue {
+	if secret && !v.IsSecret() {
+		return resource.MakeSecret(v)
+	}
+	return v
+}
+
+// isUnknown returns true if the given value, after unwrapping any secret, is not known. Only the top level of the
+// value is checked.
+func isUnknown(v resource.PropertyValue) bool {
+	v, _ = unwrap(v)
+	return v.IsComputed() || v.IsOutput()
+}
+
+// ctyToProperty converts an HCL value to a property value.
+func ctyToProperty(v cty.Value) (resource.PropertyValue, error) {
+	switch {
+	case !v.IsKnown():
+		return unknown, nil
+	case v.IsNull():
+		return resource.NewNullProperty(), nil
+	}
+
+	typ := v.Type()
+	switch {
+	case typ == cty.Bool:
+		return resource.NewBoolProperty(v.True()), nil
+	case typ == cty.Number:
+		f, _ := v.AsBigFloat().Float64()
+		return resource.NewNumberProperty(f), nil
+	case typ == cty.String:
+		return resource.NewStringProperty(v.AsString()), nil
+	case typ.IsListType() || typ.IsTupleType() || typ.IsSetType():
+		elements := []resource.PropertyValue{}
+		for it := v.ElementIterator(); it.Next(); {
+			_, e := it.Element()
+			element, err := ctyToProperty(e)
+			if err != nil {
+				return resource.PropertyValue{}, err
+			}
+			elements = append(elements, element)
+		}
+		return resource.NewArrayProperty(elements), nil
+	case typ.IsMapType() || typ.IsObjectType():
+		obj := resource.PropertyMap{}
+		for it := v.ElementIterator(); it.Next(); {
+			k, e := it.Element()
+			element, err := ctyToProperty(e)
+			if err != nil {
+				return resource.PropertyValue{}, err
+			}
+			obj[resource.PropertyKey(k.AsString())] = element
+		}
+		return resource.NewObjectProperty(obj), nil
+	default:
+		return resource.PropertyValue{}, errors.Errorf("unsupported value of type %v", typ.FriendlyName())
+	}
+}
+
+// propertyToCty converts a property value to an HCL value. Secret markers are discarded; it is the caller's
+// responsibility to track whether or not the value was secret.
+func propertyToCty(v resource.PropertyValue) (cty.Value, error) {
+	v, _ = unwrap(v)
+	switch {
+	case isUnknown(v):
+		return cty.DynamicVal, nil
+	case v.IsNull():
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	case v.IsBool():
+		return cty.BoolVal(v.BoolValue()), nil
+	case v.IsNumber():
+		return cty.NumberVal(big.NewFloat(v.NumberValue())), nil
+	case v.IsString():
+		return cty.StringVal(v.StringValue()), nil
+	case v.IsArray():
+		elements := make([]cty.Value, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			element, err := propertyToCty(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elements[i] = element
+		}
+		return cty.TupleVal(elements), nil
+	case v.IsObject():
+		attrs := map[string]cty.Value{}
+		for k, e := range v.ObjectValue() {
+			element, err := propertyToCty(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[string(k)] = element
+		}
+		return cty.ObjectVal(attrs), nil
+	default:
+		return cty.NilVal, errors.Errorf("%v values cannot be used in expressions", v.TypeString())
+	}
+}
+
+// toString converts the given primitive value to a string for use in a template.
+func toString(v resource.PropertyValue) (string, error) {
+	switch {
+	case v.IsNull():
+		return "", nil
+	case v.IsBool():
+		return strconv.FormatBool(v.BoolValue()), nil
+	case v.IsNumber():
+		return strconv.FormatFloat(v.NumberValue(), 'f', -1, 64), nil
+	case v.IsString():
+		return v.StringValue(), nil
+	default:
+		return "", errors.Errorf("cannot convert a value of type %v to a string", v.TypeString())
+	}
+}
+
+// toPlain converts the given value to a plain Go value suitable for JSON serialization. Assets and archives are not
+// supported.
+func toPlain(v resource.PropertyValue) (interface{}, error) {
+	v, _ = unwrap(v)
+	switch {
+	case v.IsNull():
+		return nil, nil
+	case v.IsBool():
+		return v.BoolValue(), nil
+	case v.IsNumber():
+		return v.NumberValue(), nil
+	case v.IsString():
+		return v.StringValue(), nil
+	case v.IsArray():
+		elements := make([]interface{}, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			element, err := toPlain(e)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+		}
+		return elements, nil
+	case v.IsObject():
+		obj := map[string]interface{}{}
+		for k, e := range v.ObjectValue() {
+			element, err := toPlain(e)
+			if err != nil {
+				return nil, err
+			}
+			obj[string(k)] = element
+		}
+		return obj, nil
+	default:
+		return nil, errors.Errorf("%v values cannot be converted to JSON", v.TypeString())
+	}
+}
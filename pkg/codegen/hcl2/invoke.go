@@ -107,7 +107,25 @@ func (b *binder) bindInvokeSignature(args []model.Expression) (model.StaticFunct
 	} else {
 		signature.ReturnType = b.schemaTypeToType(fn.Outputs)
 	}
-	signature.ReturnType = model.NewPromiseType(signature.ReturnType)
+
+	// If the function's arguments contain outputs, bind the call to the variant of the function that accepts inputs
+	// and returns an output. Otherwise, bind the call to the variant that accepts prompt values and returns a promise.
+	if fn.NeedsOutputVersion() && len(args) > 1 && model.ContainsOutputs(args[1].Type()) {
+		signature.Parameters[1].Type = model.InputType(signature.Parameters[1].Type)
+		signature.ReturnType = model.NewOutputType(signature.ReturnType)
+	} else {
+		signature.ReturnType = model.NewPromiseType(signature.ReturnType)
+	}
 
 	return signature, nil
 }
+
+// IsOutputVersionInvokeCall returns true if the given call is an invoke that has been bound to the variant of its
+// function that accepts inputs and returns an output.
+func IsOutputVersionInvokeCall(call *model.FunctionCallExpression) bool {
+	if call.Name != Invoke {
+		return false
+	}
+	_, isOutput := call.Signature.ReturnType.(*model.OutputType)
+	return isOutput
+}
@@ -605,7 +605,7 @@ func (b *expressionBinder) bindScopeTraversalExpression(
 
 		if !b.options.allowMissingVariables {
 			diagnostics = hcl.Diagnostics{
-				undefinedVariable(rootName, syntax.Traversal.SimpleSplit().Abs.SourceRange()),
+				undefinedVariable(rootName, syntax.Traversal.SimpleSplit().Abs.SourceRange(), b.scope),
 			}
 		}
 		return &ScopeTraversalExpression{
@@ -16,8 +16,10 @@ package model
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -80,8 +82,20 @@ func tupleIndexOutOfRange(tupleLen int, indexRange hcl.Range) *hcl.Diagnostic {
 	return errorf(indexRange, "tuple index must be between 0 and %d", tupleLen)
 }
 
-func unknownObjectProperty(name string, indexRange hcl.Range) *hcl.Diagnostic {
-	return errorf(indexRange, "unknown property '%s'", name)
+func unknownObjectProperty(name string, indexRange hcl.Range, receiver *ObjectType) *hcl.Diagnostic {
+	properties := codegen.SortedKeys(receiver.Properties)
+
+	var detail []string
+	if suggestion := didYouMean(name, properties); suggestion != "" {
+		detail = append(detail, suggestion)
+	}
+	if len(properties) != 0 {
+		detail = append(detail, fmt.Sprintf("The receiver is an object with properties %s.", quotedList(properties)))
+	}
+
+	diag := errorf(indexRange, "unknown property '%s'", name)
+	diag.Detail = strings.Join(detail, " ")
+	return diag
 }
 
 func unsupportedReceiverType(receiver Type, indexRange hcl.Range) *hcl.Diagnostic {
@@ -92,8 +106,30 @@ func unsupportedCollectionType(collectionType Type, iteratorRange hcl.Range) *hc
 	return errorf(iteratorRange, "cannot iterate over a value of type %v", collectionType)
 }
 
-func undefinedVariable(variableName string, variableRange hcl.Range) *hcl.Diagnostic {
-	return errorf(variableRange, fmt.Sprintf("undefined variable %v", variableName))
+func undefinedVariable(variableName string, variableRange hcl.Range, scope *Scope) *hcl.Diagnostic {
+	diag := errorf(variableRange, fmt.Sprintf("undefined variable %v", variableName))
+	if variableName != "" && scope != nil {
+		diag.Detail = didYouMean(variableName, scope.names())
+	}
+	return diag
+}
+
+// didYouMean returns a suggestion for an unresolved name drawn from the given candidates, or the empty string if no
+// candidate is sufficiently similar.
+func didYouMean(name string, candidates []string) string {
+	similar := codegen.SimilarNames(name, candidates)
+	if len(similar) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Did you mean %s?", quotedList(similar))
+}
+
+func quotedList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = "'" + n + "'"
+	}
+	return strings.Join(quoted, ", ")
 }
 
 func internalError(rng hcl.Range, fmt string, args ...interface{}) *hcl.Diagnostic {
@@ -17,6 +17,7 @@ package model
 import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -140,13 +141,13 @@ func (s *Scope) Traverse(traverser hcl.Traverser) (Traversable, hcl.Diagnostics)
 	name, nameType := GetTraverserKey(traverser)
 	if nameType != StringType {
 		// TODO(pdg): return a better error here
-		return DynamicType, hcl.Diagnostics{undefinedVariable("", traverser.SourceRange())}
+		return DynamicType, hcl.Diagnostics{undefinedVariable("", traverser.SourceRange(), nil)}
 	}
 
 	memberName := name.AsString()
 	member, hasMember := s.BindReference(memberName)
 	if !hasMember {
-		return DynamicType, hcl.Diagnostics{undefinedVariable(memberName, traverser.SourceRange())}
+		return DynamicType, hcl.Diagnostics{undefinedVariable(memberName, traverser.SourceRange(), s)}
 	}
 	return member, nil
 }
@@ -173,6 +174,17 @@ func (s *Scope) BindReference(name string) (Definition, bool) {
 	return nil, false
 }
 
+// names returns the names defined in this scope and each of its parents.
+func (s *Scope) names() []string {
+	names := codegen.StringSet{}
+	for ; s != nil; s = s.parent {
+		for name := range s.defs {
+			names.Add(name)
+		}
+	}
+	return names.SortedValues()
+}
+
 // BindFunctionReference returns the function definition that corresponds to the given name, if any. Each parent scope
 // is checked until a definition is found or no parent scope remains.
 func (s *Scope) BindFunctionReference(name string) (*Function, bool) {
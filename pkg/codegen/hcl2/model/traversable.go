@@ -104,7 +104,7 @@ func bindTraversalParts(receiver Traversable, traversal hcl.Traversal,
 	default:
 		// TODO(pdg): improve this diagnostic
 		if !allowMissingVariables {
-			diagnostics = append(diagnostics, undefinedVariable("", traversal.SourceRange()))
+			diagnostics = append(diagnostics, undefinedVariable("", traversal.SourceRange(), nil))
 		}
 	}
 
@@ -39,6 +39,8 @@ type ResourceOptions struct {
 	Protect model.Expression
 	// A list of properties that are not considered when diffing the resource.
 	IgnoreChanges model.Expression
+	// A list of URNs by which the resource was previously known.
+	Aliases model.Expression
 }
 
 // Resource represents a resource instantiation inside of a program or component.
@@ -628,23 +628,21 @@ func (m *tokenMapper) Exit(n hclsyntax.Node) hcl.Diagnostics {
 	return nil
 }
 
-// mapTokens builds a mapping from the syntax nodes in the given source file to their tokens. The mapping is recorded
-// in the map passed in to the function.
-func mapTokens(rawTokens hclsyntax.Tokens, filename string, root hclsyntax.Node, contents []byte, tokenMap tokenMap,
-	initialPos hcl.Pos) {
-
-	// Turn the list of raw tokens into a list of trivia-carrying tokens.
+// newTokenList turns the given list of raw tokens into a list of trivia-carrying tokens. Whitespace and comments are
+// attached to the tokens they precede or, if they end the line of a token, to the tokens they follow.
+func newTokenList(rawTokens hclsyntax.Tokens, filename string, contents []byte, initialPos hcl.Pos) tokenList {
 	lastEndPos := initialPos
 	var tokens tokenList
 	trivia := TriviaList{}
-	inControlSeq := false
+	inControlSeq, lineEnded := false, false
 	for _, raw := range rawTokens {
 		// Snip whitespace out of the body and turn it in to trivia.
 		if startPos := raw.Range.Start; startPos.Byte != lastEndPos.Byte {
 			triviaBytes := contents[lastEndPos.Byte-initialPos.Byte : startPos.Byte-initialPos.Byte]
 
-			// If this trivia begins a new line, attach the current trivia to the last processed token, if any.
-			if len(tokens) > 0 {
+			// If this trivia begins a new line, attach the current trivia to the last processed token, if any. Only the
+			// trivia up to the end of the token's line is attached: any further lines belong to the next token.
+			if len(tokens) > 0 && !lineEnded {
 				if nl := bytes.IndexByte(triviaBytes, '\n'); nl != -1 {
 					trailingTriviaBytes := triviaBytes[:nl+1]
 					triviaBytes = triviaBytes[nl+1:]
@@ -654,7 +652,7 @@ func mapTokens(rawTokens hclsyntax.Tokens, filename string, root hclsyntax.Node,
 					trivia = append(trivia, Whitespace{rng: rng, bytes: trailingTriviaBytes})
 					tokens[len(tokens)-1].TrailingTrivia, trivia = trivia, TriviaList{}
 
-					lastEndPos = endPos
+					lastEndPos, lineEnded = endPos, true
 				}
 			}
 
@@ -662,6 +660,7 @@ func mapTokens(rawTokens hclsyntax.Tokens, filename string, root hclsyntax.Node,
 			trivia = append(trivia, Whitespace{rng: rng, bytes: triviaBytes})
 		}
 
+		tokenCount := len(tokens)
 		switch raw.Type {
 		case hclsyntax.TokenComment:
 			trivia = append(trivia, Comment{Lines: processComment(raw.Bytes), rng: raw.Range, bytes: raw.Bytes})
@@ -695,11 +694,25 @@ func mapTokens(rawTokens hclsyntax.Tokens, filename string, root hclsyntax.Node,
 			tokens, trivia = append(tokens, Token{Raw: raw, LeadingTrivia: trivia}), TriviaList{}
 		}
 		lastEndPos = raw.Range.End
+		if len(tokens) != tokenCount {
+			lineEnded = false
+		}
 	}
 
 	// If we had any tokens, we should have attached all trivia to something.
 	contract.Assert(len(trivia) == 0 || len(tokens) == 0)
 
+	return tokens
+}
+
+// mapTokens builds a mapping from the syntax nodes in the given source file to their tokens. The mapping is recorded
+// in the map passed in to the function.
+func mapTokens(rawTokens hclsyntax.Tokens, filename string, root hclsyntax.Node, contents []byte, tokenMap tokenMap,
+	initialPos hcl.Pos) {
+
+	// Turn the list of raw tokens into a list of trivia-carrying tokens.
+	tokens := newTokenList(rawTokens, filename, contents, initialPos)
+
 	// Now build the token map.
 	//
 	// If a node records the ranges of relevant tokens in its syntax node, the start position of those ranges is used
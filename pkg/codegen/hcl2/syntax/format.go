@@ -0,0 +1,271 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syntax
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Format returns the canonical formatting of the given HCL2 source file. Each line is indented by four spaces per
+// level of nesting, the spacing between tokens is normalized, and runs of blank lines are collapsed into a single
+// blank line. Comments are preserved, as are the contents of string literals and templates.
+//
+// If the file cannot be parsed, Format returns the parser's diagnostics.
+func Format(src []byte, filename string) ([]byte, hcl.Diagnostics) {
+	if _, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{}); diags.HasErrors() {
+		return nil, diags
+	}
+
+	rawTokens, diags := hclsyntax.LexConfig(src, filename, hcl.Pos{})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	f := &formatter{src: src}
+	f.format(newTokenList(rawTokens, filename, src, hcl.Pos{}))
+	return f.buf.Bytes(), nil
+}
+
+// A nesting records an open brace, bracket, or parenthesis.
+type nesting struct {
+	indent      int  // The indentation of the lines inside the nesting.
+	lineIndent  int  // The indentation of the line that opened the nesting.
+	line        int  // The output line that opened the nesting.
+	isFor       bool // True if the nesting contains a for expression.
+	conditional int  // The number of unterminated conditional expressions inside the nesting.
+}
+
+type formatter struct {
+	src []byte
+	buf bytes.Buffer
+
+	stack []nesting
+
+	line       int  // The current output line.
+	lineIndent int  // The indentation of the current output line.
+	lineEmpty  bool // True if nothing has been written to the current output line.
+	newlines   int  // The number of newlines seen since the last token or comment was written.
+
+	prev      hclsyntax.TokenType // The type of the last token written, or TokenNil if none has been written.
+	prevUnary bool                // True if the last token written was a unary operator.
+}
+
+func (f *formatter) format(tokens tokenList) {
+	f.lineEmpty, f.prev = true, hclsyntax.TokenNil
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		f.trivia(t.LeadingTrivia)
+
+		switch t.Raw.Type {
+		case hclsyntax.TokenEOF:
+			f.trivia(t.TrailingTrivia)
+			if f.buf.Len() > 0 {
+				f.buf.WriteByte('\n')
+			}
+			return
+		case hclsyntax.TokenOQuote, hclsyntax.TokenOHeredoc:
+			// Copy templates verbatim: their contents are significant.
+			end := closeTemplate(tokens, i)
+			f.token(t.Raw.Type, f.src[t.Raw.Range.Start.Byte:tokens[end].Raw.Range.End.Byte])
+			i, t = end, tokens[end]
+		default:
+			f.token(t.Raw.Type, t.Raw.Bytes)
+		}
+
+		f.trivia(t.TrailingTrivia)
+	}
+}
+
+// closeTemplate returns the index of the token that closes the template opened by the token at the given index.
+func closeTemplate(tokens tokenList, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch tokens[i].Raw.Type {
+		case hclsyntax.TokenOQuote, hclsyntax.TokenOHeredoc:
+			depth++
+		case hclsyntax.TokenCQuote, hclsyntax.TokenCHeredoc:
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// trivia records the newlines in the given trivia and writes any comments.
+func (f *formatter) trivia(trivia TriviaList) {
+	for _, t := range trivia {
+		switch t := t.(type) {
+		case Whitespace:
+			f.newlines += bytes.Count(t.Bytes(), []byte{'\n'})
+		case Comment:
+			text := t.Bytes()
+			if f.newlines > 0 || f.lineEmpty {
+				f.newline(f.indent(), false)
+			} else {
+				f.buf.WriteByte(' ')
+			}
+			f.buf.Write(bytes.TrimRight(text, " \t\r\n"))
+			f.lineEmpty, f.prev = false, hclsyntax.TokenComment
+
+			// Line comments include their terminating newline.
+			if bytes.HasSuffix(text, []byte{'\n'}) {
+				f.newlines++
+			}
+		}
+	}
+}
+
+// indent returns the indentation of lines inside the innermost nesting.
+func (f *formatter) indent() int {
+	if len(f.stack) == 0 {
+		return 0
+	}
+	return f.stack[len(f.stack)-1].indent
+}
+
+// newline starts a new output line with the given indentation, preserving at most one blank line. Blank lines are not
+// preserved at the start of the file, after an opening token, or before a closing token.
+func (f *formatter) newline(indent int, closing bool) {
+	if f.buf.Len() > 0 {
+		f.buf.WriteByte('\n')
+		if f.newlines > 1 && !closing && !isOpen(f.prev) {
+			f.buf.WriteByte('\n')
+		}
+	}
+	f.newlines, f.line, f.lineEmpty, f.lineIndent = 0, f.line+1, true, indent
+	f.buf.WriteString(strings.Repeat("    ", indent))
+}
+
+// token writes the given token, preceded by a line break or by the appropriate spacing.
+func (f *formatter) token(typ hclsyntax.TokenType, text []byte) {
+	if isClose(typ) && len(f.stack) > 0 {
+		n := f.stack[len(f.stack)-1]
+		f.stack = f.stack[:len(f.stack)-1]
+
+		// A closing token that begins a line is indented to match the line that opened its nesting.
+		if f.newlines > 0 || f.lineEmpty {
+			f.newline(n.lineIndent, true)
+		}
+	} else if f.newlines > 0 || f.lineEmpty {
+		f.newline(f.indent(), false)
+	}
+
+	if !f.lineEmpty && f.space(typ, text) {
+		f.buf.WriteByte(' ')
+	}
+	f.buf.Write(text)
+
+	top := f.top()
+	switch {
+	case isOpen(typ):
+		// Multiple nestings that are opened on the same line only increase the indentation once.
+		indent := f.lineIndent + 1
+		if len(f.stack) > 0 && top.line == f.line {
+			indent = top.indent
+		}
+		f.stack = append(f.stack, nesting{indent: indent, lineIndent: f.lineIndent, line: f.line})
+	case typ == hclsyntax.TokenIdent && isOpen(f.prev) && string(text) == "for":
+		top.isFor = true
+	case typ == hclsyntax.TokenQuestion:
+		top.conditional++
+	case typ == hclsyntax.TokenColon && top.conditional > 0:
+		top.conditional--
+	}
+
+	f.prevUnary = (typ == hclsyntax.TokenMinus || typ == hclsyntax.TokenBang) && f.isOperand()
+	f.prev, f.lineEmpty = typ, false
+}
+
+// top returns the innermost nesting. The file itself is the outermost nesting.
+func (f *formatter) top() *nesting {
+	if len(f.stack) == 0 {
+		f.stack = append(f.stack, nesting{line: -1})
+	}
+	return &f.stack[len(f.stack)-1]
+}
+
+// isOperand returns true if a token that follows the last token written begins an operand, e.g. because the last
+// token was an operator or an opening token.
+func (f *formatter) isOperand() bool {
+	if f.lineEmpty || f.prevUnary {
+		return true
+	}
+	switch f.prev {
+	case hclsyntax.TokenNil, hclsyntax.TokenOBrace, hclsyntax.TokenOBrack, hclsyntax.TokenOParen,
+		hclsyntax.TokenComma, hclsyntax.TokenEqual, hclsyntax.TokenColon, hclsyntax.TokenQuestion,
+		hclsyntax.TokenFatArrow, hclsyntax.TokenComment:
+		return true
+	}
+	return isBinaryOperator(f.prev)
+}
+
+// space returns true if the given token should be separated from the last token written by a space.
+func (f *formatter) space(typ hclsyntax.TokenType, text []byte) bool {
+	prev := f.prev
+
+	switch {
+	case f.prevUnary:
+		return false
+	case prev == hclsyntax.TokenOBrace:
+		// Single-line blocks and objects are padded, e.g. `{ value = x }`; empty ones are not.
+		return typ != hclsyntax.TokenCBrace
+	case typ == hclsyntax.TokenCBrace:
+		return true
+	case prev == hclsyntax.TokenOParen, prev == hclsyntax.TokenOBrack, prev == hclsyntax.TokenDot:
+		return false
+	}
+
+	switch typ {
+	case hclsyntax.TokenCParen, hclsyntax.TokenCBrack, hclsyntax.TokenComma, hclsyntax.TokenDot,
+		hclsyntax.TokenEllipsis:
+		return false
+	case hclsyntax.TokenOParen:
+		// Function calls are not separated from their names.
+		return prev != hclsyntax.TokenIdent
+	case hclsyntax.TokenOBrack:
+		// Index expressions are not separated from their receivers.
+		return f.isOperand()
+	case hclsyntax.TokenColon:
+		// Colons are spaced as operators in conditionals and for expressions, and as separators in objects.
+		top := f.top()
+		return top.conditional > 0 || top.isFor
+	}
+	return true
+}
+
+func isOpen(typ hclsyntax.TokenType) bool {
+	return typ == hclsyntax.TokenOBrace || typ == hclsyntax.TokenOBrack || typ == hclsyntax.TokenOParen
+}
+
+func isClose(typ hclsyntax.TokenType) bool {
+	return typ == hclsyntax.TokenCBrace || typ == hclsyntax.TokenCBrack || typ == hclsyntax.TokenCParen
+}
+
+func isBinaryOperator(typ hclsyntax.TokenType) bool {
+	switch typ {
+	case hclsyntax.TokenStar, hclsyntax.TokenSlash, hclsyntax.TokenPlus, hclsyntax.TokenMinus,
+		hclsyntax.TokenPercent, hclsyntax.TokenEqualOp, hclsyntax.TokenNotEqual, hclsyntax.TokenLessThan,
+		hclsyntax.TokenLessThanEq, hclsyntax.TokenGreaterThan, hclsyntax.TokenGreaterThanEq, hclsyntax.TokenAnd,
+		hclsyntax.TokenOr:
+		return true
+	}
+	return false
+}
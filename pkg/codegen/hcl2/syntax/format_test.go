@@ -0,0 +1,97 @@
+package syntax
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name:     "spacing",
+			src:      "a=1+2*-3\nb = !true ? x : y\nc=[for v in list: v*2 if v>1]\nd = foo[ 0 ].bar.*.baz\n",
+			expected: "a = 1 + 2 * -3\nb = !true ? x : y\nc = [for v in list : v * 2 if v > 1]\nd = foo[0].bar.*.baz\n",
+		},
+		{
+			name:     "objects",
+			src:      "a = {\"Name\":\"x\"}\nb = {}\nc = f({ x = 1 }, [ 1,2 ])\n",
+			expected: "a = { \"Name\": \"x\" }\nb = {}\nc = f({ x = 1 }, [1, 2])\n",
+		},
+		{
+			name: "indentation",
+			src: "resource r \"t\" {\n\toptions {\n\t\trange = 2\n\t}\n" +
+				"\tpolicy = toJSON({\n  a = [{\n b = 1\n}]\n\t})\n}\n",
+			expected: "resource r \"t\" {\n    options {\n        range = 2\n    }\n" +
+				"    policy = toJSON({\n        a = [{\n            b = 1\n        }]\n    })\n}\n",
+		},
+		{
+			name:     "blank lines",
+			src:      "\n\na = 1\n\n\n\nb = 2\nresource r \"t\" {\n\n  x = 1\n\n}\n\n",
+			expected: "a = 1\n\nb = 2\nresource r \"t\" {\n    x = 1\n}\n",
+		},
+		{
+			name: "comments",
+			src: "# header\n\na = 1 // trailing\n\n// leading\nresource r \"t\" {\n  /* block */ x = 1\n" +
+				"     // inner\n}\n// end\n",
+			expected: "# header\n\na = 1 // trailing\n\n// leading\nresource r \"t\" {\n    /* block */ x = 1\n" +
+				"    // inner\n}\n// end\n",
+		},
+		{
+			name:     "templates",
+			src:      "a = \"${ b }  %{if c}d%{endif}\"\ne = <<EOT\n  keep   this\nEOT\n",
+			expected: "a = \"${ b }  %{if c}d%{endif}\"\ne = <<EOT\n  keep   this\nEOT\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, diags := Format([]byte(c.src), c.name+".pp")
+			assert.Len(t, diags, 0)
+			assert.Equal(t, c.expected, string(actual))
+
+			again, diags := Format(actual, c.name+".pp")
+			assert.Len(t, diags, 0)
+			assert.Equal(t, string(actual), string(again))
+		})
+	}
+}
+
+func TestFormatPreservesComments(t *testing.T) {
+	contents, err := ioutil.ReadFile("./testdata/comments_all.hcl")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	formatted, diags := Format(contents, "comments_all.hcl")
+	assert.Len(t, diags, 0)
+
+	again, diags := Format(formatted, "comments_all.hcl")
+	assert.Len(t, diags, 0)
+	assert.Equal(t, string(formatted), string(again))
+
+	countComments := func(src []byte) int {
+		tokens, diags := hclsyntax.LexConfig(src, "comments_all.hcl", hcl.Pos{})
+		assert.Len(t, diags, 0)
+
+		count := 0
+		for _, tok := range tokens {
+			if tok.Type == hclsyntax.TokenComment {
+				count++
+			}
+		}
+		return count
+	}
+	assert.Equal(t, countComments(contents), countComments(formatted))
+}
+
+func TestFormatSyntaxError(t *testing.T) {
+	_, diags := Format([]byte("a = {"), "bad.pp")
+	assert.True(t, diags.HasErrors())
+}
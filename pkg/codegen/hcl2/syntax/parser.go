@@ -50,10 +50,10 @@ func (p *Parser) ParseFile(r io.Reader, filename string) error {
 		return err
 	}
 
-	hclFile, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{})
+	hclFile, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
 	if !diags.HasErrors() {
-		tokens, _ := hclsyntax.LexConfig(src, filename, hcl.Pos{})
-		mapTokens(tokens, filename, hclFile.Body.(*hclsyntax.Body), hclFile.Bytes, p.tokens, hcl.Pos{})
+		tokens, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+		mapTokens(tokens, filename, hclFile.Body.(*hclsyntax.Body), hclFile.Bytes, p.tokens, hcl.InitialPos)
 	}
 
 	p.Files = append(p.Files, &File{
@@ -15,6 +15,7 @@
 package importer
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"strings"
@@ -24,6 +25,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -36,20 +38,25 @@ var Null = &model.Variable{
 
 // GenerateHCL2Definition generates a Pulumi HCL2 definition for a given resource.
 func GenerateHCL2Definition(loader schema.Loader, state *resource.State, names NameTable) (*model.Block, error) {
-	// TODO: pull the package version from the resource's provider
-	pkg, err := loader.LoadPackage(string(state.Type.Package()), nil)
+	return generateHCL2Definition(loader, state, names, nil)
+}
+
+func generateHCL2Definition(loader schema.Loader, state *resource.State, names NameTable,
+	refs referenceFunc) (*model.Block, error) {
+
+	inputProperties, err := getInputProperties(loader, state.Type)
 	if err != nil {
 		return nil, err
 	}
 
-	r, ok := pkg.GetResource(string(state.Type))
-	if !ok {
-		return nil, fmt.Errorf("unknown resource type '%v'", r)
-	}
-
 	var items []model.BodyItem
-	for _, p := range r.InputProperties {
-		x, err := generatePropertyValue(p, state.Inputs[resource.PropertyKey(p.Name)])
+	for _, p := range inputProperties {
+		value := state.Inputs[resource.PropertyKey(p.Name)]
+		if providers.IsProviderType(state.Type) {
+			value = decodeProviderValue(p, value)
+		}
+
+		x, err := generatePropertyValue(p, value, refs)
 		if err != nil {
 			return nil, err
 		}
@@ -69,17 +76,63 @@ func GenerateHCL2Definition(loader schema.Loader, state *resource.State, names N
 		items = append(items, resourceOptions)
 	}
 
-	typ, name := state.URN.Type(), state.URN.Name()
+	typ, name := state.URN.Type(), resourceName(state, names)
 	return &model.Block{
-		Tokens: syntax.NewBlockTokens("resource", string(name), string(typ)),
+		Tokens: syntax.NewBlockTokens("resource", name, string(typ)),
 		Type:   "resource",
-		Labels: []string{string(name), string(typ)},
+		Labels: []string{name, string(typ)},
 		Body: &model.Body{
 			Items: items,
 		},
 	}, nil
 }
 
+// getInputProperties returns the input properties for the given resource type. The inputs for a provider resource are
+// described by its package's configuration variables.
+func getInputProperties(loader schema.Loader, typ tokens.Type) ([]*schema.Property, error) {
+	// TODO: pull the package version from the resource's provider
+	if providers.IsProviderType(typ) {
+		pkg, err := loader.LoadPackage(string(providers.GetProviderPackage(typ)), nil)
+		if err != nil {
+			return nil, err
+		}
+		return pkg.Config, nil
+	}
+
+	pkg, err := loader.LoadPackage(string(typ.Package()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := pkg.GetResource(string(typ))
+	if !ok {
+		return nil, fmt.Errorf("unknown resource type '%v'", typ)
+	}
+	return r.InputProperties, nil
+}
+
+// decodeProviderValue decodes the value of a provider input. Provider inputs are recorded as strings, so any value
+// whose property is not itself a string is decoded from JSON. Values that cannot be decoded are returned as-is.
+func decodeProviderValue(property *schema.Property, value resource.PropertyValue) resource.PropertyValue {
+	if !value.IsString() || property.Type == schema.StringType {
+		return value
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(value.StringValue()), &v); err != nil {
+		return value
+	}
+	return resource.NewPropertyValue(v)
+}
+
+// resourceName returns the name of the variable that holds the given resource.
+func resourceName(state *resource.State, names NameTable) string {
+	if name, ok := names[state.URN]; ok {
+		return name
+	}
+	return string(state.URN.Name())
+}
+
 func newVariableReference(name string) model.Expression {
 	return model.VariableReference(&model.Variable{
 		Name:         name,
@@ -145,6 +198,22 @@ func makeResourceOptions(state *resource.State, names NameTable) (*model.Block,
 			Value:  cty.True,
 		})
 	}
+	if len(state.Aliases) != 0 {
+		aliases := make([]model.Expression, len(state.Aliases))
+		for i, a := range state.Aliases {
+			aliases[i] = &model.TemplateExpression{
+				Parts: []model.Expression{
+					&model.LiteralValueExpression{
+						Value: cty.StringVal(string(a)),
+					},
+				},
+			}
+		}
+		resourceOptions = appendResourceOption(resourceOptions, "aliases", &model.TupleConsExpression{
+			Tokens:      syntax.NewTupleConsTokens(len(aliases)),
+			Expressions: aliases,
+		})
+	}
 	return resourceOptions, nil
 }
 
@@ -316,15 +385,15 @@ func zeroValue(t schema.Type) model.Expression {
 	}
 	switch t {
 	case schema.BoolType:
-		x, err := generateValue(t, resource.NewBoolProperty(false))
+		x, err := generateValue(t, resource.NewBoolProperty(false), nil)
 		contract.IgnoreError(err)
 		return x
 	case schema.IntType, schema.NumberType:
-		x, err := generateValue(t, resource.NewNumberProperty(0))
+		x, err := generateValue(t, resource.NewNumberProperty(0), nil)
 		contract.IgnoreError(err)
 		return x
 	case schema.StringType:
-		x, err := generateValue(t, resource.NewStringProperty(""))
+		x, err := generateValue(t, resource.NewStringProperty(""), nil)
 		contract.IgnoreError(err)
 		return x
 	case schema.ArchiveType, schema.AssetType:
@@ -340,7 +409,9 @@ func zeroValue(t schema.Type) model.Expression {
 // generatePropertyValue generates the value for the given property. If the value is absent and the property is
 // required, a zero value for the property's type is generated. If the value is absent and the property is not
 // required, no value is generated (i.e. this function returns nil).
-func generatePropertyValue(property *schema.Property, value resource.PropertyValue) (model.Expression, error) {
+func generatePropertyValue(property *schema.Property, value resource.PropertyValue,
+	refs referenceFunc) (model.Expression, error) {
+
 	if !value.HasValue() {
 		if !property.IsRequired {
 			return nil, nil
@@ -348,12 +419,13 @@ func generatePropertyValue(property *schema.Property, value resource.PropertyVal
 		return zeroValue(property.Type), nil
 	}
 
-	return generateValue(property.Type, value)
+	return generateValue(property.Type, value, refs)
 }
 
 // generateValue generates a value from the given property value. The given type may or may not match the shape of the
-// given value.
-func generateValue(typ schema.Type, value resource.PropertyValue) (model.Expression, error) {
+// given value. If refs is non-nil, it is used to replace string values with references to the outputs of other
+// resources.
+func generateValue(typ schema.Type, value resource.PropertyValue, refs referenceFunc) (model.Expression, error) {
 	switch {
 	case value.IsArchive():
 		return nil, fmt.Errorf("NYI: archives")
@@ -366,7 +438,7 @@ func generateValue(typ schema.Type, value resource.PropertyValue) (model.Express
 		arr := value.ArrayValue()
 		exprs := make([]model.Expression, len(arr))
 		for i, v := range arr {
-			x, err := generateValue(elementType, v)
+			x, err := generateValue(elementType, v, refs)
 			if err != nil {
 				return nil, err
 			}
@@ -396,7 +468,7 @@ func generateValue(typ schema.Type, value resource.PropertyValue) (model.Express
 
 		if objectType, ok := typ.(*schema.ObjectType); ok {
 			for _, p := range objectType.Properties {
-				x, err := generatePropertyValue(p, obj[resource.PropertyKey(p.Name)])
+				x, err := generatePropertyValue(p, obj[resource.PropertyKey(p.Name)], refs)
 				if err != nil {
 					return nil, err
 				}
@@ -421,7 +493,7 @@ func generateValue(typ schema.Type, value resource.PropertyValue) (model.Express
 					continue
 				}
 
-				x, err := generateValue(elementType, obj[k])
+				x, err := generateValue(elementType, obj[k], refs)
 				if err != nil {
 					return nil, err
 				}
@@ -446,7 +518,7 @@ func generateValue(typ schema.Type, value resource.PropertyValue) (model.Express
 			Items:  items,
 		}, nil
 	case value.IsSecret():
-		arg, err := generateValue(typ, value.SecretValue().Element)
+		arg, err := generateValue(typ, value.SecretValue().Element, refs)
 		if err != nil {
 			return nil, err
 		}
@@ -462,6 +534,11 @@ func generateValue(typ schema.Type, value resource.PropertyValue) (model.Express
 			Args: []model.Expression{arg},
 		}, nil
 	case value.IsString():
+		if refs != nil {
+			if x, ok := refs(value.StringValue()); ok {
+				return x, nil
+			}
+		}
 		return &model.TemplateExpression{
 			Parts: []model.Expression{
 				&model.LiteralValueExpression{
@@ -34,6 +34,19 @@ type LanguageGenerator func(w io.Writer, p *hcl2.Program) error
 // A NameTable maps URNs to language-specific variable names.
 type NameTable map[resource.URN]string
 
+// A DependencyTable maps the URN of each resource to the URNs of the resources that it depends on.
+type DependencyTable map[resource.URN][]resource.URN
+
+// contains returns true if the resource with the given URN depends on the resource with the URN dep.
+func (deps DependencyTable) contains(urn, dep resource.URN) bool {
+	for _, d := range deps[urn] {
+		if d == dep {
+			return true
+		}
+	}
+	return false
+}
+
 // A DiagnosticsError captures HCL2 diagnostics.
 type DiagnosticsError struct {
 	diagnostics         hcl.Diagnostics
@@ -64,9 +77,42 @@ func (e *DiagnosticsError) String() string {
 func GenerateLanguageDefinitions(w io.Writer, loader schema.Loader, gen LanguageGenerator, states []*resource.State,
 	names NameTable) error {
 
+	return generateLanguageDefinitions(w, loader, gen, states, names, nil)
+}
+
+// GenerateProgram generates a program that defines the given resource states. Unlike GenerateLanguageDefinitions,
+// any literal string value that exactly matches the ID or an output of exactly one other resource in the program is
+// replaced with a reference to that resource's ID or output. The returned table records the dependencies introduced by
+// these references.
+func GenerateProgram(w io.Writer, loader schema.Loader, gen LanguageGenerator, states []*resource.State,
+	names NameTable) (DependencyTable, error) {
+
+	// Resources in the program that do not have an entry in the name table are named after their URNs.
+	programNames := NameTable{}
+	for urn, name := range names {
+		programNames[urn] = name
+	}
+	for _, state := range states {
+		programNames[state.URN] = resourceName(state, names)
+	}
+	names = programNames
+
+	refs, err := newReferenceTable(loader, states, names)
+	if err != nil {
+		return nil, err
+	}
+	if err = generateLanguageDefinitions(w, loader, gen, states, names, refs); err != nil {
+		return nil, err
+	}
+	return refs.references, nil
+}
+
+func generateLanguageDefinitions(w io.Writer, loader schema.Loader, gen LanguageGenerator, states []*resource.State,
+	names NameTable, refs *referenceTable) error {
+
 	var hcl2Text bytes.Buffer
 	for i, state := range states {
-		hcl2Def, err := GenerateHCL2Definition(loader, state, names)
+		hcl2Def, err := generateHCL2Definition(loader, state, names, refs.referenceFunc(state.URN))
 		if err != nil {
 			return err
 		}
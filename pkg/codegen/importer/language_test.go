@@ -16,6 +16,7 @@ package importer
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"testing"
@@ -23,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
@@ -83,3 +85,101 @@ func TestGenerateLanguageDefinition(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateProgram(t *testing.T) {
+	loader := schema.NewPluginLoader(test.NewHost(testdataPath))
+
+	providerType := providers.MakeProviderType("kubernetes")
+	k8sURN := resource.NewURN("stack", "project", "", providerType, "k8s")
+	petURN := resource.NewURN("stack", "project", "", "random:index/randomPet:RandomPet", "pet")
+	strURN := resource.NewURN("stack", "project", "", "random:index/randomString:RandomString", "str")
+	otherURN := resource.NewURN("stack", "project", "", "random:index/randomPet:RandomPet", "other")
+
+	states := []*resource.State{
+		{
+			Type:   providerType,
+			URN:    k8sURN,
+			Custom: true,
+			ID:     "d4f8b3b0-6e7c-4c1e-9b8a-2f5d3c1a0e9f",
+			Inputs: resource.PropertyMap{
+				"namespace":    resource.NewStringProperty("default"),
+				"enableDryRun": resource.NewStringProperty("true"),
+			},
+		},
+		{
+			Type:   "random:index/randomPet:RandomPet",
+			URN:    petURN,
+			Custom: true,
+			ID:     "happy-cat",
+			Inputs: resource.PropertyMap{
+				"keepers": resource.NewObjectProperty(resource.PropertyMap{
+					"str": resource.NewStringProperty("abcdefgh"),
+				}),
+			},
+			Outputs: resource.PropertyMap{
+				"separator": resource.NewStringProperty("-"),
+			},
+		},
+		{
+			Type:   "random:index/randomString:RandomString",
+			URN:    strURN,
+			Custom: true,
+			ID:     "abcdefgh",
+			Inputs: resource.PropertyMap{
+				"length": resource.NewNumberProperty(8),
+				"keepers": resource.NewObjectProperty(resource.PropertyMap{
+					"pet": resource.NewStringProperty("happy-cat"),
+				}),
+			},
+			Protect: true,
+			Aliases: []resource.URN{"urn:pulumi:stack::project::random:index/randomString:RandomString::old"},
+		},
+		{
+			Type:   "random:index/randomPet:RandomPet",
+			URN:    otherURN,
+			Custom: true,
+			ID:     "sad-dog",
+			Inputs: resource.PropertyMap{
+				"prefix":    resource.NewStringProperty("happy-cat"),
+				"separator": resource.NewStringProperty("-"),
+			},
+			Provider: string(k8sURN) + "::d4f8b3b0-6e7c-4c1e-9b8a-2f5d3c1a0e9f",
+		},
+	}
+	names := NameTable{otherURN: "renamed"}
+
+	definitions := map[string]string{}
+	deps, err := GenerateProgram(ioutil.Discard, loader, func(_ io.Writer, p *hcl2.Program) error {
+		for _, n := range p.Nodes {
+			if r, ok := n.(*hcl2.Resource); ok {
+				definitions[r.Name()] = fmt.Sprintf("%v", r.Definition)
+			}
+		}
+		return nil
+	}, states, names)
+	if !assert.NoError(t, err) {
+		t.Fatal()
+	}
+
+	// Provider inputs are decoded according to the package's configuration schema.
+	assert.Regexp(t, `enableDryRun\s*=\s*true`, definitions["k8s"])
+	assert.Regexp(t, `namespace\s*=\s*"default"`, definitions["k8s"])
+
+	// The first reference between pet and str wins; the reverse reference would introduce a cycle.
+	assert.Regexp(t, `str\s*=\s*str\.id`, definitions["pet"])
+	assert.Regexp(t, `pet\s*=\s*"happy-cat"`, definitions["str"])
+	assert.Regexp(t, `protect\s*=\s*true`, definitions["str"])
+	assert.Regexp(t, `aliases\s*=\s*\[\s*"urn:pulumi:stack::project::random:index/randomString:RandomString::old"\]`,
+		definitions["str"])
+
+	// Resources are named by the name table, and outputs as well as IDs may be referenced.
+	assert.Regexp(t, `prefix\s*=\s*pet\.id`, definitions["renamed"])
+	assert.Regexp(t, `separator\s*=\s*pet\.separator`, definitions["renamed"])
+	assert.Regexp(t, `provider\s*=\s*k8s`, definitions["renamed"])
+
+	// Each reference is recorded as a dependency.
+	assert.Equal(t, DependencyTable{
+		petURN:   {strURN},
+		otherURN: {petURN},
+	}, deps)
+}
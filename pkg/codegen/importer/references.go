@@ -0,0 +1,208 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// A referenceFunc returns an expression that refers to the output of another resource that produces the given string
+// value, if any.
+type referenceFunc func(value string) (model.Expression, bool)
+
+// A reference names a single output of a resource.
+type reference struct {
+	urn      resource.URN
+	name     string
+	property string
+}
+
+// A referenceTable maps string values to the resource outputs that produce them. The table is used to replace literal
+// values in generated definitions with references to other resources.
+type referenceTable struct {
+	values     map[string][]reference
+	deps       map[resource.URN]map[resource.URN]bool
+	references DependencyTable
+}
+
+// newReferenceTable builds a reference table for the given resource states. A resource's ID and any of its top-level
+// string outputs that are described by its schema may be referenced.
+func newReferenceTable(loader schema.Loader, states []*resource.State, names NameTable) (*referenceTable, error) {
+	table := &referenceTable{
+		values:     map[string][]reference{},
+		deps:       map[resource.URN]map[resource.URN]bool{},
+		references: DependencyTable{},
+	}
+
+	generated := map[resource.URN]bool{}
+	for _, state := range states {
+		generated[state.URN] = true
+	}
+
+	for _, state := range states {
+		// Record the dependencies that are already implied by the resource's options so that references do not
+		// introduce cycles.
+		if generated[state.Parent] {
+			table.addDependency(state.URN, state.Parent)
+		}
+		if state.Provider != "" {
+			if ref, err := providers.ParseReference(state.Provider); err == nil && generated[ref.URN()] {
+				table.addDependency(state.URN, ref.URN())
+			}
+		}
+		for _, dep := range state.Dependencies {
+			if generated[dep] {
+				table.addDependency(state.URN, dep)
+			}
+		}
+
+		// Provider outputs are just their inputs, and provider IDs are never used as inputs.
+		if providers.IsProviderType(state.Type) || state.ID == "" {
+			continue
+		}
+
+		name := resourceName(state, names)
+		table.add(string(state.ID), reference{urn: state.URN, name: name, property: "id"})
+
+		outputs, err := referenceableOutputs(loader, state)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range state.Outputs.StableKeys() {
+			v := state.Outputs[k]
+			if !outputs[string(k)] || !v.IsString() || v.StringValue() == "" {
+				continue
+			}
+			table.add(v.StringValue(), reference{urn: state.URN, name: name, property: string(k)})
+		}
+	}
+
+	return table, nil
+}
+
+// referenceableOutputs returns the set of top-level string outputs of the given resource.
+func referenceableOutputs(loader schema.Loader, state *resource.State) (map[string]bool, error) {
+	pkg, err := loader.LoadPackage(string(state.Type.Package()), nil)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := pkg.GetResource(string(state.Type))
+	if !ok {
+		return nil, nil
+	}
+
+	outputs := map[string]bool{}
+	for _, p := range r.Properties {
+		if p.Type == schema.StringType && hclsyntax.ValidIdentifier(p.Name) && !strings.HasPrefix(p.Name, "__") {
+			outputs[p.Name] = true
+		}
+	}
+	return outputs, nil
+}
+
+func (table *referenceTable) add(value string, ref reference) {
+	table.values[value] = append(table.values[value], ref)
+}
+
+func (table *referenceTable) addDependency(from, to resource.URN) {
+	deps, ok := table.deps[from]
+	if !ok {
+		deps = map[resource.URN]bool{}
+		table.deps[from] = deps
+	}
+	deps[to] = true
+}
+
+// dependsOn returns true if the resource from transitively depends on the resource to.
+func (table *referenceTable) dependsOn(from, to resource.URN) bool {
+	visited := map[resource.URN]bool{}
+	var visit func(urn resource.URN) bool
+	visit = func(urn resource.URN) bool {
+		if urn == to {
+			return true
+		}
+		if visited[urn] {
+			return false
+		}
+		visited[urn] = true
+		for dep := range table.deps[urn] {
+			if visit(dep) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(from)
+}
+
+// lookup finds the output that produces the given value on behalf of the given resource. A value is only referenced
+// if it is produced by exactly one other resource and if the reference would not introduce a dependency cycle.
+func (table *referenceTable) lookup(urn resource.URN, value string) (reference, bool) {
+	refs := table.values[value]
+	if len(refs) == 0 {
+		return reference{}, false
+	}
+	for _, ref := range refs[1:] {
+		if ref.urn != refs[0].urn {
+			return reference{}, false
+		}
+	}
+
+	ref := refs[0]
+	if ref.urn == urn || table.dependsOn(ref.urn, urn) {
+		return reference{}, false
+	}
+	table.addDependency(urn, ref.urn)
+	if !table.references.contains(urn, ref.urn) {
+		table.references[urn] = append(table.references[urn], ref.urn)
+	}
+	return ref, true
+}
+
+// referenceFunc returns a referenceFunc for the given resource.
+func (table *referenceTable) referenceFunc(urn resource.URN) referenceFunc {
+	if table == nil {
+		return nil
+	}
+	return func(value string) (model.Expression, bool) {
+		ref, ok := table.lookup(urn, value)
+		if !ok {
+			return nil, false
+		}
+		return newPropertyReference(ref.name, ref.property), true
+	}
+}
+
+func newPropertyReference(name, property string) model.Expression {
+	return &model.ScopeTraversalExpression{
+		RootName: name,
+		Traversal: hcl.Traversal{
+			hcl.TraverseRoot{Name: name},
+			hcl.TraverseAttr{Name: property},
+		},
+		Parts: []model.Traversable{
+			&model.Variable{Name: name, VariableType: model.DynamicType},
+			model.DynamicType,
+		},
+	}
+}
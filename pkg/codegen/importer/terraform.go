@@ -0,0 +1,304 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// TerraformResourceInfo contains the Terraform-specific information for a resource that is bridged from a Terraform
+// provider. Bridged packages record this information under the "terraform" key of each resource's language metadata.
+type TerraformResourceInfo struct {
+	// The Terraform resource type that the resource bridges, e.g. "aws_s3_bucket".
+	Name string `json:"name"`
+}
+
+// TerraformState is the subset of a version 4 Terraform state file that is needed to import its resources.
+type TerraformState struct {
+	Version   int                 `json:"version"`
+	Resources []TerraformResource `json:"resources"`
+}
+
+// TerraformResource is a resource in a Terraform state file.
+type TerraformResource struct {
+	Module    string                      `json:"module,omitempty"`
+	Mode      string                      `json:"mode"`
+	Type      string                      `json:"type"`
+	Name      string                      `json:"name"`
+	Provider  string                      `json:"provider"`
+	Instances []TerraformResourceInstance `json:"instances"`
+}
+
+// TerraformResourceInstance is a single instance of a resource in a Terraform state file. Resources that use count or
+// for_each have one instance per index key.
+type TerraformResourceInstance struct {
+	IndexKey   interface{}            `json:"index_key,omitempty"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// ReadTerraformState reads a version 4 Terraform state file.
+func ReadTerraformState(r io.Reader) (*TerraformState, error) {
+	var state TerraformState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, err
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("unsupported Terraform state version %v; only version 4 is supported", state.Version)
+	}
+	return &state, nil
+}
+
+// A TerraformImport describes a Pulumi resource to import that corresponds to an instance of a Terraform resource.
+type TerraformImport struct {
+	Address string       // The address of the Terraform resource instance, e.g. module.net.aws_subnet.private[0].
+	Type    tokens.Type  // The type of the Pulumi resource.
+	Name    tokens.QName // The name of the Pulumi resource.
+	ID      resource.ID  // The ID of the resource.
+}
+
+// An UnmappedTerraformResource describes an instance of a Terraform resource that cannot be imported.
+type UnmappedTerraformResource struct {
+	Address string // The address of the Terraform resource instance.
+	Type    string // The Terraform resource type.
+	Reason  string // The reason the resource cannot be imported.
+}
+
+// terraformPackages maps the names of Terraform providers to the names of the Pulumi packages that bridge them when
+// the two differ.
+var terraformPackages = map[string]string{
+	"azurerm":     "azure",
+	"google":      "gcp",
+	"google-beta": "gcp",
+}
+
+// terraformImportIDs maps the Terraform resource types whose import IDs differ from their "id" attributes to the
+// attributes whose values, joined by "/", make up their import IDs.
+var terraformImportIDs = map[string][]string{
+	"aws_iam_role_policy_attachment": {"role", "policy_arn"},
+	"aws_route_table_association":    {"subnet_id", "route_table_id"},
+}
+
+// MapTerraformState maps the managed resources in the given Terraform state to the Pulumi resources to import. Each
+// Terraform resource type is mapped to a Pulumi type using the Terraform metadata in the schema of the Pulumi package
+// that bridges the resource's provider. A resource's import ID is its "id" attribute unless terraformImportIDs says
+// otherwise.
+//
+// Resources that cannot be mapped are returned separately. These include resources that use an aliased provider
+// configuration, which the imported resources would not otherwise be associated with. Data sources are not resources,
+// and are ignored.
+func MapTerraformState(loader schema.Loader, state *TerraformState) ([]TerraformImport, []UnmappedTerraformResource) {
+	var imports []TerraformImport
+	var unmapped []UnmappedTerraformResource
+
+	typeTables := map[string]map[string]tokens.Type{}
+	typeTableErrors := map[string]error{}
+	names := map[tokens.Type]map[tokens.QName]bool{}
+
+	for _, r := range state.Resources {
+		if r.Mode != "managed" {
+			continue
+		}
+
+		pkgName, alias := terraformProviderName(r.Provider)
+		if name, ok := terraformPackages[pkgName]; ok {
+			pkgName = name
+		}
+
+		types, ok := typeTables[pkgName]
+		if !ok {
+			var err error
+			types, err = terraformTypeTable(loader, pkgName)
+			if err != nil {
+				typeTableErrors[pkgName] = err
+			}
+			typeTables[pkgName] = types
+		}
+
+		for _, instance := range r.Instances {
+			address := terraformAddress(r, instance)
+
+			var reason string
+			typ, hasType := types[r.Type]
+			id, idErr := terraformImportID(r.Type, instance)
+			switch {
+			case alias != "":
+				reason = fmt.Sprintf("the resource uses the aliased provider configuration %q; "+
+					"import it with an explicit provider instead", pkgName+"."+alias)
+			case typeTableErrors[pkgName] != nil:
+				reason = fmt.Sprintf("could not load the schema for package %q: %v", pkgName, typeTableErrors[pkgName])
+			case !hasType:
+				reason = fmt.Sprintf("package %q does not map the Terraform resource type %q", pkgName, r.Type)
+			case idErr != "":
+				reason = idErr
+			}
+			if reason != "" {
+				unmapped = append(unmapped, UnmappedTerraformResource{Address: address, Type: r.Type, Reason: reason})
+				continue
+			}
+
+			typeNames, ok := names[typ]
+			if !ok {
+				typeNames = map[tokens.QName]bool{}
+				names[typ] = typeNames
+			}
+
+			imports = append(imports, TerraformImport{
+				Address: address,
+				Type:    typ,
+				Name:    terraformResourceName(r, instance, typeNames),
+				ID:      id,
+			})
+		}
+	}
+
+	return imports, unmapped
+}
+
+// terraformImportID returns the import ID of the given resource instance. If the instance has no import ID, the
+// reason is returned instead.
+func terraformImportID(typ string, instance TerraformResourceInstance) (resource.ID, string) {
+	attrs, ok := terraformImportIDs[typ]
+	if !ok {
+		if id, ok := instance.Attributes["id"].(string); ok && id != "" {
+			return resource.ID(id), ""
+		}
+		return "", "the resource has no ID"
+	}
+
+	parts := make([]string, len(attrs))
+	for i, attr := range attrs {
+		v, ok := instance.Attributes[attr].(string)
+		if !ok || v == "" {
+			return "", fmt.Sprintf("the resource's import ID requires the attribute %q, which is not set", attr)
+		}
+		parts[i] = v
+	}
+	return resource.ID(strings.Join(parts, "/")), ""
+}
+
+// terraformTypeTable returns a map from Terraform resource type to Pulumi type for the given package.
+func terraformTypeTable(loader schema.Loader, pkgName string) (map[string]tokens.Type, error) {
+	pkg, err := loader.LoadPackage(pkgName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[string]tokens.Type{}
+	for _, r := range pkg.Resources {
+		info, ok, err := terraformResourceInfo(r)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the Terraform metadata for %v: %w", r.Token, err)
+		}
+		if ok && info.Name != "" {
+			types[info.Name] = tokens.Type(r.Token)
+		}
+	}
+	return types, nil
+}
+
+// terraformResourceInfo returns the Terraform metadata for the given resource, if any.
+func terraformResourceInfo(r *schema.Resource) (TerraformResourceInfo, bool, error) {
+	switch v := r.Language["terraform"].(type) {
+	case TerraformResourceInfo:
+		return v, true, nil
+	case json.RawMessage:
+		var info TerraformResourceInfo
+		if err := json.Unmarshal([]byte(v), &info); err != nil {
+			return TerraformResourceInfo{}, false, err
+		}
+		return info, true, nil
+	default:
+		return TerraformResourceInfo{}, false, nil
+	}
+}
+
+// terraformProviderName returns the name of the provider in the given Terraform provider address along with the alias
+// of the provider configuration, if any, e.g. "aws" and "west" for both
+// `provider["registry.terraform.io/hashicorp/aws"].west` and `provider.aws.west`.
+func terraformProviderName(address string) (string, string) {
+	if i := strings.Index(address, `provider["`); i != -1 {
+		source, alias := address[i+len(`provider["`):], ""
+		if end := strings.Index(source, `"]`); end != -1 {
+			source, alias = source[:end], strings.TrimPrefix(source[end+len(`"]`):], ".")
+		}
+		return source[strings.LastIndex(source, "/")+1:], alias
+	}
+
+	if i := strings.LastIndex(address, "provider."); i != -1 {
+		address = address[i+len("provider."):]
+	}
+	if i := strings.Index(address, "."); i != -1 {
+		return address[:i], address[i+1:]
+	}
+	return address, ""
+}
+
+// terraformAddress returns the address of the given resource instance.
+func terraformAddress(r TerraformResource, instance TerraformResourceInstance) string {
+	address := r.Type + "." + r.Name
+	if r.Module != "" {
+		address = r.Module + "." + address
+	}
+
+	switch key := instance.IndexKey.(type) {
+	case string:
+		address += fmt.Sprintf("[%q]", key)
+	case float64:
+		address += fmt.Sprintf("[%v]", key)
+	}
+	return address
+}
+
+// terraformResourceName returns a name for the Pulumi resource that corresponds to the given resource instance. The
+// name is derived from the instance's module path, name, and index key, and is unique amongst the given names.
+func terraformResourceName(r TerraformResource, instance TerraformResourceInstance,
+	taken map[tokens.QName]bool) tokens.QName {
+
+	var parts []string
+	if r.Module != "" {
+		for _, part := range strings.Split(r.Module, ".") {
+			if part != "module" {
+				parts = append(parts, part)
+			}
+		}
+	}
+	parts = append(parts, r.Name)
+	if instance.IndexKey != nil {
+		parts = append(parts, fmt.Sprintf("%v", instance.IndexKey))
+	}
+
+	base := strings.Map(func(c rune) rune {
+		if c == '_' || c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)) {
+			return c
+		}
+		return '_'
+	}, strings.Join(parts, "_"))
+	base = strings.TrimRight(base, "_")
+
+	name := tokens.QName(base)
+	for i := 2; taken[name]; i++ {
+		name = tokens.QName(fmt.Sprintf("%s_%d", base, i))
+	}
+	taken[name] = true
+	return name
+}
@@ -0,0 +1,207 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+const terraformTestSchema = `{
+	"name": "aws",
+	"resources": {
+		"aws:s3/bucket:Bucket": {
+			"language": {"terraform": {"name": "aws_s3_bucket"}}
+		},
+		"aws:ec2/subnet:Subnet": {
+			"language": {"terraform": {"name": "aws_subnet"}}
+		},
+		"aws:ec2/vpc:Vpc": {},
+		"aws:iam/rolePolicyAttachment:RolePolicyAttachment": {
+			"language": {"terraform": {"name": "aws_iam_role_policy_attachment"}}
+		}
+	}
+}`
+
+// terraformTestLoader loads the schema for the aws test package.
+type terraformTestLoader struct{}
+
+func (terraformTestLoader) LoadPackage(pkg string, version *semver.Version) (*schema.Package, error) {
+	if pkg != "aws" {
+		return nil, fmt.Errorf("no plugin for %v", pkg)
+	}
+
+	var spec schema.PackageSpec
+	if err := json.Unmarshal([]byte(terraformTestSchema), &spec); err != nil {
+		return nil, err
+	}
+	return schema.ImportSpec(spec, nil)
+}
+
+func TestReadTerraformState(t *testing.T) {
+	_, err := ReadTerraformState(strings.NewReader(`{"version": 3, "modules": []}`))
+	assert.EqualError(t, err, "unsupported Terraform state version 3; only version 4 is supported")
+}
+
+func TestMapTerraformState(t *testing.T) {
+	state, err := ReadTerraformState(strings.NewReader(`{
+	"version": 4,
+	"terraform_version": "0.14.7",
+	"resources": [
+		{
+			"mode": "managed",
+			"type": "aws_s3_bucket",
+			"name": "logs",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [{"attributes": {"id": "my-logs", "acl": "private"}}]
+		},
+		{
+			"mode": "data",
+			"type": "aws_caller_identity",
+			"name": "current",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [{"attributes": {"id": "123456789012"}}]
+		},
+		{
+			"module": "module.network",
+			"mode": "managed",
+			"type": "aws_subnet",
+			"name": "private",
+			"provider": "module.network.provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [
+				{"index_key": 0, "attributes": {"id": "subnet-0"}},
+				{"index_key": 1, "attributes": {"id": "subnet-1"}}
+			]
+		},
+		{
+			"mode": "managed",
+			"type": "aws_subnet",
+			"name": "network_private_0",
+			"provider": "provider.aws",
+			"instances": [{"attributes": {"id": "subnet-2"}}]
+		},
+		{
+			"mode": "managed",
+			"type": "aws_s3_bucket",
+			"name": "replica",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"].west",
+			"instances": [{"attributes": {"id": "my-replica"}}]
+		},
+		{
+			"mode": "managed",
+			"type": "aws_iam_role_policy_attachment",
+			"name": "attach",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [
+				{"index_key": 0, "attributes": {"id": "role-2021", "role": "role", "policy_arn": "arn:policy"}},
+				{"index_key": 1, "attributes": {"id": "role-2022", "role": "role"}}
+			]
+		},
+		{
+			"mode": "managed",
+			"type": "aws_vpc",
+			"name": "main",
+			"provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+			"instances": [{"attributes": {"id": "vpc-0"}}]
+		},
+		{
+			"mode": "managed",
+			"type": "random_pet",
+			"name": "pet",
+			"provider": "provider[\"registry.terraform.io/hashicorp/random\"]",
+			"instances": [{"index_key": "a", "attributes": {"id": "happy-cat"}}]
+		}
+	]
+}`))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	imports, unmapped := MapTerraformState(terraformTestLoader{}, state)
+
+	assert.Equal(t, []TerraformImport{
+		{Address: "aws_s3_bucket.logs", Type: "aws:s3/bucket:Bucket", Name: "logs", ID: "my-logs"},
+		{
+			Address: "module.network.aws_subnet.private[0]",
+			Type:    "aws:ec2/subnet:Subnet",
+			Name:    "network_private_0",
+			ID:      "subnet-0",
+		},
+		{
+			Address: "module.network.aws_subnet.private[1]",
+			Type:    "aws:ec2/subnet:Subnet",
+			Name:    "network_private_1",
+			ID:      "subnet-1",
+		},
+		{
+			Address: "aws_subnet.network_private_0",
+			Type:    "aws:ec2/subnet:Subnet",
+			Name:    "network_private_0_2",
+			ID:      "subnet-2",
+		},
+		{
+			Address: "aws_iam_role_policy_attachment.attach[0]",
+			Type:    "aws:iam/rolePolicyAttachment:RolePolicyAttachment",
+			Name:    "attach_0",
+			ID:      "role/arn:policy",
+		},
+	}, imports)
+
+	assert.Equal(t, []UnmappedTerraformResource{
+		{
+			Address: "aws_s3_bucket.replica",
+			Type:    "aws_s3_bucket",
+			Reason: `the resource uses the aliased provider configuration "aws.west"; ` +
+				"import it with an explicit provider instead",
+		},
+		{
+			Address: "aws_iam_role_policy_attachment.attach[1]",
+			Type:    "aws_iam_role_policy_attachment",
+			Reason:  `the resource's import ID requires the attribute "policy_arn", which is not set`,
+		},
+		{
+			Address: "aws_vpc.main",
+			Type:    "aws_vpc",
+			Reason:  `package "aws" does not map the Terraform resource type "aws_vpc"`,
+		},
+		{
+			Address: `random_pet.pet["a"]`,
+			Type:    "random_pet",
+			Reason:  `could not load the schema for package "random": no plugin for random`,
+		},
+	}, unmapped)
+}
+
+func TestTerraformProviderName(t *testing.T) {
+	cases := map[string][2]string{
+		`provider["registry.terraform.io/hashicorp/aws"]`:                        {"aws", ""},
+		`provider["registry.terraform.io/hashicorp/google-beta"].europe`:         {"google-beta", "europe"},
+		`module.provider_config.provider["registry.terraform.io/hashicorp/aws"]`: {"aws", ""},
+		`provider.azurerm`:      {"azurerm", ""},
+		`provider.aws.west`:     {"aws", "west"},
+		`module.a.provider.aws`: {"aws", ""},
+	}
+	for address, expected := range cases {
+		name, alias := terraformProviderName(address)
+		assert.Equal(t, expected, [2]string{name, alias}, address)
+	}
+}
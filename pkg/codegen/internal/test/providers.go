@@ -59,3 +59,15 @@ func Kubernetes(schemaDirectoryPath string) (plugin.Provider, error) {
 		},
 	}, nil
 }
+
+func Example(schemaDirectoryPath string) (plugin.Provider, error) {
+	schema, err := GetSchema(schemaDirectoryPath, "example")
+	if err != nil {
+		return nil, err
+	}
+	return &deploytest.Provider{
+		GetSchemaF: func(version int) ([]byte, error) {
+			return schema, nil
+		},
+	}, nil
+}
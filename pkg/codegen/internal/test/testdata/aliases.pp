@@ -0,0 +1,11 @@
+resource pet "random:index/randomPet:RandomPet" {
+	prefix = "doggo"
+	length = 2
+
+	options {
+		aliases = [
+			"urn:pulumi:dev::proj::random:index/randomPet:RandomPet::oldPet",
+			"urn:pulumi:dev::proj::random:index/randomPet:RandomPet::olderPet",
+		]
+	}
+}
@@ -0,0 +1,22 @@
+using Pulumi;
+using Random = Pulumi.Random;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var pet = new Random.RandomPet("pet", new Random.RandomPetArgs
+        {
+            Prefix = "doggo",
+            Length = 2,
+        }, new CustomResourceOptions
+        {
+            Aliases =
+            {
+                new Alias { Urn = "urn:pulumi:dev::proj::random:index/randomPet:RandomPet::oldPet" },
+                new Alias { Urn = "urn:pulumi:dev::proj::random:index/randomPet:RandomPet::olderPet" },
+            },
+        });
+    }
+
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi-random/sdk/v2/go/random"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		_, err := random.NewRandomPet(ctx, "pet", &random.RandomPetArgs{
+			Prefix: pulumi.String("doggo"),
+			Length: pulumi.Int(2),
+		}, pulumi.Aliases([]pulumi.Alias{
+			{
+				URN: pulumi.URN("urn:pulumi:dev::proj::random:index/randomPet:RandomPet::oldPet"),
+			},
+			{
+				URN: pulumi.URN("urn:pulumi:dev::proj::random:index/randomPet:RandomPet::olderPet"),
+			},
+		}))
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
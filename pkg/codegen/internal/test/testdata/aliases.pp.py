@@ -0,0 +1,10 @@
+import pulumi
+import pulumi_random as random
+
+pet = random.RandomPet("pet",
+    prefix="doggo",
+    length=2,
+    opts=pulumi.ResourceOptions(aliases=[
+            "urn:pulumi:dev::proj::random:index/randomPet:RandomPet::oldPet",
+            "urn:pulumi:dev::proj::random:index/randomPet:RandomPet::olderPet",
+        ]))
@@ -0,0 +1,12 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as random from "@pulumi/random";
+
+const pet = new random.RandomPet("pet", {
+    prefix: "doggo",
+    length: 2,
+}, {
+    aliases: [
+        "urn:pulumi:dev::proj::random:index/randomPet:RandomPet::oldPet",
+        "urn:pulumi:dev::proj::random:index/randomPet:RandomPet::olderPet",
+    ],
+});
@@ -0,0 +1,27 @@
+config cidrBlock string {
+	default = "10.0.0.0/16"
+}
+
+resource pet "random:index/randomPet:RandomPet" {
+	prefix = "doggo"
+}
+
+encoded = toBase64("haha business")
+decoded = fromBase64(encoded)
+joinedParts = join("-", [encoded, decoded, "2"])
+message = format("%s, %s!", "Hello", replace("world", "w", "W"))
+allTags = merge({
+	app = "web"
+}, {
+	env = "dev"
+})
+
+output joined { value = joinedParts }
+output greeting { value = message }
+output settings { value = fromJSON("{\"replicas\":3}") }
+output digest { value = sha1(joinedParts) }
+output petDigest { value = sha256(pet.id) }
+output tagKeys { value = keys(allTags) }
+output tagValues { value = values(allTags) }
+output zones { value = flatten([["a", "b"], ["c"]]) }
+output subnet { value = cidrsubnet(cidrBlock, 8, 2) }
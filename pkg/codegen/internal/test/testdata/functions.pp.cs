@@ -0,0 +1,126 @@
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Linq;
+using System.Net;
+using System.Security.Cryptography;
+using System.Text;
+using System.Text.Json;
+using Pulumi;
+using Random = Pulumi.Random;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var config = new Config();
+        var cidrBlock = config.Get("cidrBlock") ?? "10.0.0.0/16";
+        var pet = new Random.RandomPet("pet", new Random.RandomPetArgs
+        {
+            Prefix = "doggo",
+        });
+        var encoded = Convert.ToBase64String(Encoding.UTF8.GetBytes("haha business"));
+        var decoded = Encoding.UTF8.GetString(Convert.FromBase64String(encoded));
+        var joinedParts = string.Join("-", new[]
+            {
+                encoded,
+                decoded,
+                "2",
+            }
+        );
+        var message = string.Format("{0}, {1}!", "Hello", "world".Replace("w", "W"));
+        var allTags = Merge(new Dictionary<string, object?>
+        {
+            { "app", "web" },
+        }, new Dictionary<string, object?>
+        {
+            { "env", "dev" },
+        });
+        this.Joined = Output.Create(joinedParts);
+        this.Greeting = Output.Create(message);
+        this.Settings = Output.Create<object>(JsonDocument.Parse("{\"replicas\":3}").RootElement);
+        this.Digest = Output.Create(ComputeSHA1(joinedParts));
+        this.PetDigest = pet.Id.Apply(id => ComputeSHA256(id));
+        this.TagKeys = Output.Create(allTags.Keys.Cast<string>().ToImmutableArray());
+        this.TagValues = Output.Create(allTags.Values.Cast<string>().ToImmutableArray());
+        this.Zones = Output.Create<object>(new[]
+            {
+                new[]
+                    {
+                        "a",
+                        "b",
+                    }
+                ,
+                new[]
+                    {
+                        "c",
+                    }
+                ,
+            }
+        .SelectMany(l => l));
+        this.Subnet = Output.Create(CidrSubnet(cidrBlock, 8, 2));
+    }
+
+    [Output("joined")]
+    public Output<string> Joined { get; set; }
+    [Output("greeting")]
+    public Output<string> Greeting { get; set; }
+    [Output("settings")]
+    public Output<object> Settings { get; set; }
+    [Output("digest")]
+    public Output<string> Digest { get; set; }
+    [Output("petDigest")]
+    public Output<string> PetDigest { get; set; }
+    [Output("tagKeys")]
+    public Output<ImmutableArray<string>> TagKeys { get; set; }
+    [Output("tagValues")]
+    public Output<ImmutableArray<string>> TagValues { get; set; }
+    [Output("zones")]
+    public Output<object> Zones { get; set; }
+    [Output("subnet")]
+    public Output<string> Subnet { get; set; }
+
+    private static string CidrSubnet(string prefix, int newbits, int netnum)
+    {
+        var parts = prefix.Split('/');
+        var bytes = IPAddress.Parse(parts[0]).GetAddressBytes();
+        var length = int.Parse(parts[1]);
+        var newLength = length + newbits;
+        if (newLength > bytes.Length * 8 || netnum >= 1L << newbits)
+        {
+            throw new ArgumentException($"cannot create subnet {netnum} of {prefix} with {newbits} additional bits");
+        }
+        for (var i = length; i < bytes.Length * 8; i++)
+        {
+            var set = i < newLength && ((long)netnum >> (newLength - i - 1) & 1) == 1;
+            var mask = (byte)(0x80 >> (i % 8));
+            bytes[i / 8] = (byte)(set ? bytes[i / 8] | mask : bytes[i / 8] & ~mask);
+        }
+        return $"{new IPAddress(bytes)}/{newLength}";
+    }
+
+    private static Dictionary<string, object?> Merge(params IDictionary<string, object?>[] maps)
+    {
+        var result = new Dictionary<string, object?>();
+        foreach (var map in maps)
+        {
+            foreach (var entry in map)
+            {
+                result[entry.Key] = entry.Value;
+            }
+        }
+        return result;
+    }
+
+    private static string ComputeSHA1(string input)
+    {
+        var hash = SHA1.Create().ComputeHash(Encoding.UTF8.GetBytes(input));
+        return BitConverter.ToString(hash).Replace("-", "").ToLowerInvariant();
+    }
+
+    private static string ComputeSHA256(string input)
+    {
+        var hash = SHA256.Create().ComputeHash(Encoding.UTF8.GetBytes(input));
+        return BitConverter.ToString(hash).Replace("-", "").ToLowerInvariant();
+    }
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi-random/sdk/v2/go/random"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		cfg := config.New(ctx, "")
+		cidrBlock := "10.0.0.0/16"
+		if param := cfg.Get("cidrBlock"); param != "" {
+			cidrBlock = param
+		}
+		pet, err := random.NewRandomPet(ctx, "pet", &random.RandomPetArgs{
+			Prefix: pulumi.String("doggo"),
+		})
+		if err != nil {
+			return err
+		}
+		encoded := base64.StdEncoding.EncodeToString([]byte("haha business"))
+		decoded0, err := fromBase64(encoded)
+		if err != nil {
+			return err
+		}
+		decoded := decoded0
+		joinedParts := strings.Join([]string{
+			encoded,
+			decoded,
+			"2",
+		}, "-")
+		message := fmt.Sprintf("%s, %s!", "Hello", strings.ReplaceAll("world", "w", "W"))
+		allTags := merge(map[string]interface{}{
+			"app": "web",
+		}, map[string]interface{}{
+			"env": "dev",
+		})
+		ctx.Export("joined", pulumi.String(joinedParts))
+		ctx.Export("greeting", pulumi.String(message))
+		parsed1, err := fromJSON("{\"replicas\":3}")
+		if err != nil {
+			return err
+		}
+		ctx.Export("settings", pulumi.Any(parsed1))
+		ctx.Export("digest", pulumi.String(fmt.Sprintf("%x", sha1.Sum([]byte(joinedParts)))))
+		ctx.Export("petDigest", pet.ID().ApplyT(func(id string) (pulumi.String, error) {
+			return fmt.Sprintf("%x", sha256.Sum256([]byte(id))), nil
+		}).(pulumi.StringOutput))
+		ctx.Export("tagKeys", pulumi.Any(keys(allTags)))
+		ctx.Export("tagValues", pulumi.Any(values(allTags)))
+		ctx.Export("zones", pulumi.Any(flatten([]interface{}{
+			[]string{
+				"a",
+				"b",
+			},
+			[]string{
+				"c",
+			},
+		})))
+		subnet2, err := cidrsubnet(cidrBlock, 8, 2)
+		if err != nil {
+			return err
+		}
+		ctx.Export("subnet", pulumi.String(subnet2))
+		return nil
+	})
+}
+
+func cidrsubnet(prefix string, newbits, netnum float64) (string, error) {
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", err
+	}
+	length, bits := network.Mask.Size()
+	newLength := length + int(newbits)
+	if newLength > bits {
+		return "", fmt.Errorf("cannot extend prefix %v by %v bits", prefix, newbits)
+	}
+	if int(netnum) >= 1<<uint(newbits) {
+		return "", fmt.Errorf("prefix extension of %v bits cannot accommodate network number %v", newbits, netnum)
+	}
+	ip := make(net.IP, len(network.IP))
+	copy(ip, network.IP)
+	for i := 0; i < int(newbits); i++ {
+		if int(netnum)&(1<<uint(int(newbits)-1-i)) != 0 {
+			bit := length + i
+			ip[bit/8] |= 1 << uint(7-bit%8)
+		}
+	}
+	subnet := net.IPNet{IP: ip, Mask: net.CIDRMask(newLength, bits)}
+	return subnet.String(), nil
+}
+
+func flatten(list interface{}) []interface{} {
+	var result []interface{}
+	outer := reflect.ValueOf(list)
+	for i := 0; i < outer.Len(); i++ {
+		inner := reflect.ValueOf(outer.Index(i).Interface())
+		for j := 0; j < inner.Len(); j++ {
+			result = append(result, inner.Index(j).Interface())
+		}
+	}
+	return result
+}
+
+func fromBase64(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func fromJSON(s string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func keys(m map[string]interface{}) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func merge(maps ...map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, m := range maps {
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func values(m map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]interface{}, len(keys))
+	for i, k := range keys {
+		result[i] = m[k]
+	}
+	return result
+}
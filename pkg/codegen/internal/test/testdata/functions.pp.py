@@ -0,0 +1,40 @@
+import pulumi
+import base64
+import hashlib
+import ipaddress
+import json
+import pulumi_random as random
+
+config = pulumi.Config()
+cidr_block = config.get("cidrBlock")
+if cidr_block is None:
+    cidr_block = "10.0.0.0/16"
+pet = random.RandomPet("pet", prefix="doggo")
+encoded = base64.b64encode("haha business".encode()).decode()
+decoded = base64.b64decode(encoded.encode()).decode()
+joined_parts = "-".join([
+    encoded,
+    decoded,
+    "2",
+])
+message = "{}, {}!".format("Hello", "world".replace("w", "W"))
+all_tags = {**{
+    "app": "web",
+}, **{
+    "env": "dev",
+}}
+pulumi.export("joined", joined_parts)
+pulumi.export("greeting", message)
+pulumi.export("settings", json.loads("{\"replicas\":3}"))
+pulumi.export("digest", hashlib.sha1(joined_parts.encode()).hexdigest())
+pulumi.export("petDigest", pet.id.apply(lambda id: hashlib.sha256(id.encode()).hexdigest()))
+pulumi.export("tagKeys", list(all_tags.keys()))
+pulumi.export("tagValues", list(all_tags.values()))
+pulumi.export("zones", [item for items in [
+    [
+        "a",
+        "b",
+    ],
+    ["c"],
+] for item in items])
+pulumi.export("subnet", (lambda network, newbits, netnum: f"{network.network_address + (netnum << network.max_prefixlen - network.prefixlen - newbits)}/{network.prefixlen + newbits}")(ipaddress.ip_network(cidr_block, strict=False), 8, 2))
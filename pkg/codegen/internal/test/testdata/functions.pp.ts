@@ -0,0 +1,59 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as crypto from "crypto";
+import * as random from "@pulumi/random";
+import * as util from "util";
+
+const config = new pulumi.Config();
+const cidrBlock = config.get("cidrBlock") || "10.0.0.0/16";
+const pet = new random.RandomPet("pet", {prefix: "doggo"});
+const encoded = Buffer.from("haha business").toString("base64");
+const decoded = Buffer.from(encoded, "base64").toString("utf8");
+const joinedParts = [
+    encoded,
+    decoded,
+    "2",
+].join("-");
+const message = util.format(`%s, %s!`, "Hello", "world".split("w").join("W"));
+const allTags = Object.assign({}, {
+    app: "web",
+}, {
+    env: "dev",
+});
+export const joined = joinedParts;
+export const greeting = message;
+export const settings = JSON.parse("{\"replicas\":3}");
+export const digest = crypto.createHash("sha1").update(joinedParts).digest("hex");
+export const petDigest = pet.id.apply(id => crypto.createHash("sha256").update(id).digest("hex"));
+export const tagKeys = Object.keys(allTags);
+export const tagValues = values(allTags);
+export const zones = flatten([
+    [
+        "a",
+        "b",
+    ],
+    ["c"],
+]);
+export const subnet = cidrsubnet(cidrBlock, 8, 2);
+
+function cidrsubnet(prefix: string, newbits: number, netnum: number): string {
+    const [address, bits] = prefix.split("/");
+    const length = parseInt(bits, 10);
+    const newLength = length + newbits;
+    if (address.includes(":")) {
+        throw new Error(`cidrsubnet does not support the IPv6 prefix ${prefix}`);
+    }
+    if (newLength > 32 || netnum >= 2 ** newbits) {
+        throw new Error(`cannot create subnet ${netnum} of ${prefix} with ${newbits} additional bits`);
+    }
+    const base = address.split(".").reduce((acc, octet) => acc * 256 + parseInt(octet, 10), 0);
+    const network = base - base % 2 ** (32 - length) + netnum * 2 ** (32 - newLength);
+    return [24, 16, 8, 0].map(shift => Math.floor(network / 2 ** shift) % 256).join(".") + "/" + newLength;
+}
+
+function flatten<T>(lists: T[][]): T[] {
+    return ([] as T[]).concat(...lists);
+}
+
+function values<T>(map: {[key: string]: T}): T[] {
+    return Object.keys(map).map(key => map[key]);
+}
@@ -0,0 +1,16 @@
+resource bucket "example:index:Bucket" {
+	name = "my-bucket"
+}
+
+// The name of the bucket is an output, so this invoke returns an output.
+policy = invoke("example:index:getPolicy", {
+	bucket = bucket.name
+})
+
+// The arguments to this invoke are prompt, so this invoke returns a promise.
+defaultPolicy = invoke("example:index:getPolicy", {
+	bucket = "default"
+})
+
+output policyDocument { value = policy.document }
+output defaultPolicyDocument { value = defaultPolicy.document }
@@ -0,0 +1,28 @@
+using Pulumi;
+using Example = Pulumi.Example;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var bucket = new Example.Bucket("bucket", new Example.BucketArgs
+        {
+            Name = "my-bucket",
+        });
+        var policy = Example.GetPolicy.Invoke(new Example.GetPolicyInvokeArgs
+        {
+            Bucket = bucket.Name,
+        });
+        var defaultPolicy = Output.Create(Example.GetPolicy.InvokeAsync(new Example.GetPolicyArgs
+        {
+            Bucket = "default",
+        }));
+        this.PolicyDocument = policy.Apply(policy => policy.Document);
+        this.DefaultPolicyDocument = defaultPolicy.Apply(defaultPolicy => defaultPolicy.Document);
+    }
+
+    [Output("policyDocument")]
+    public Output<string> PolicyDocument { get; set; }
+    [Output("defaultPolicyDocument")]
+    public Output<string> DefaultPolicyDocument { get; set; }
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi-example/sdk/go/example"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		bucket, err := example.NewBucket(ctx, "bucket", &example.BucketArgs{
+			Name: pulumi.String("my-bucket"),
+		})
+		if err != nil {
+			return err
+		}
+		policy := example.GetPolicyOutput(ctx, example.GetPolicyOutputArgs{
+			Bucket: bucket.Name,
+		}, nil)
+		defaultPolicy, err := example.GetPolicy(ctx, &example.GetPolicyArgs{
+			Bucket: "default",
+		}, nil)
+		if err != nil {
+			return err
+		}
+		ctx.Export("policyDocument", policy.ApplyT(func(policy example.GetPolicyResult) (string, error) {
+			return policy.Document, nil
+		}).(pulumi.StringOutput))
+		ctx.Export("defaultPolicyDocument", defaultPolicy.Document)
+		return nil
+	})
+}
@@ -0,0 +1,8 @@
+import pulumi
+import pulumi_example as example
+
+bucket = example.Bucket("bucket", name="my-bucket")
+policy = example.get_policy_output(bucket=bucket.name)
+default_policy = example.get_policy(bucket="default")
+pulumi.export("policyDocument", policy.document)
+pulumi.export("defaultPolicyDocument", default_policy.document)
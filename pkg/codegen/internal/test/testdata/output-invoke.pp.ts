@@ -0,0 +1,12 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as example from "@pulumi/example";
+
+const bucket = new example.Bucket("bucket", {name: "my-bucket"});
+const policy = example.getPolicyOutput({
+    bucket: bucket.name,
+});
+const defaultPolicy = example.getPolicy({
+    bucket: "default",
+});
+export const policyDocument = policy.document;
+export const defaultPolicyDocument = defaultPolicy.then(defaultPolicy => defaultPolicy.document);
@@ -24,6 +24,10 @@ class MyStack : Stack
                 "bucket",
                 "lifecycleRules[0]",
             },
+            Aliases =
+            {
+                new Alias { Urn = "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::oldBucket" },
+            },
         });
     }
 
@@ -19,6 +19,10 @@ func main() {
 		}), pulumi.Protect(true), pulumi.IgnoreChanges([]string{
 			"bucket",
 			"lifecycleRules[0]",
+		}), pulumi.Aliases([]pulumi.Alias{
+			{
+				URN: pulumi.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::oldBucket"),
+			},
 		}))
 		if err != nil {
 			return err
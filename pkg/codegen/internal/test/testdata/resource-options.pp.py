@@ -9,4 +9,5 @@ bucket1 = aws.s3.Bucket("bucket1", opts=pulumi.ResourceOptions(provider=provider
     ignore_changes=[
         "bucket",
         "lifecycleRules[0]",
-    ]))
+    ],
+    aliases=["urn:pulumi:dev::proj::aws:s3/bucket:Bucket::oldBucket"]))
@@ -10,4 +10,5 @@ const bucket1 = new aws.s3.Bucket("bucket1", {}, {
         "bucket",
         "lifecycleRules[0]",
     ],
+    aliases: ["urn:pulumi:dev::proj::aws:s3/bucket:Bucket::oldBucket"],
 });
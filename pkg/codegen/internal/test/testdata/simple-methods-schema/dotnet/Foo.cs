@@ -0,0 +1,77 @@
+// *** WARNING: this file was generated by test. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Example
+{
+    [ExampleResourceType("example::Foo")]
+    public partial class Foo : Pulumi.ComponentResource
+    {
+        /// <summary>
+        /// Create a Foo resource with the given unique name, arguments, and options.
+        /// </summary>
+        ///
+        /// <param name="name">The unique name of the resource</param>
+        /// <param name="args">The arguments used to populate this resource's properties</param>
+        /// <param name="options">A bag of options that control this resource's behavior</param>
+        public Foo(string name, FooArgs? args = null, ComponentResourceOptions? options = null)
+            : base("example::Foo", name, args ?? new FooArgs(), MakeResourceOptions(options, ""), remote: true)
+        {
+        }
+
+        private static ComponentResourceOptions MakeResourceOptions(ComponentResourceOptions? options, Input<string>? id)
+        {
+            var defaultOptions = new ComponentResourceOptions
+            {
+                Version = Utilities.Version,
+            };
+            var merged = ComponentResourceOptions.Merge(defaultOptions, options);
+            // Override the ID if one was specified for consistency with other language SDKs.
+            merged.Id = id ?? merged.Id;
+            return merged;
+        }
+
+        /// <summary>
+        /// Computes a value from the component and the given argument.
+        /// </summary>
+        public Output<FooBarResult> Bar(FooBarArgs? args = null)
+            => Pulumi.Deployment.Instance.Call<FooBarResult>("example::Foo/bar", args ?? new FooBarArgs(), this);
+
+        public void Baz()
+            => Pulumi.Deployment.Instance.Call("example::Foo/baz", InvokeArgs.Empty, this);
+    }
+
+    public sealed class FooArgs : Pulumi.ResourceArgs
+    {
+        public FooArgs()
+        {
+        }
+    }
+
+    public sealed class FooBarArgs : Pulumi.InvokeArgs
+    {
+        [Input("arg")]
+        public Input<string>? Arg { get; set; }
+
+        public FooBarArgs()
+        {
+        }
+    }
+
+    [OutputType]
+    public sealed class FooBarResult
+    {
+        public readonly string SomeValue;
+
+        [OutputConstructor]
+        private FooBarResult(string someValue)
+        {
+            SomeValue = someValue;
+        }
+    }
+}
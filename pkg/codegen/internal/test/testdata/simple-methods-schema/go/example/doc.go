@@ -0,0 +1,2 @@
+// Package example exports types, functions, subpackages for provisioning example resources.
+package example
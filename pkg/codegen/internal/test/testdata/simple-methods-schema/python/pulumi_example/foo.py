@@ -0,0 +1,85 @@
+# coding=utf-8
+# *** WARNING: this file was generated by test. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union
+from . import _utilities, _tables
+
+__all__ = ['Foo', 'FooBarResult']
+
+
+class Foo(pulumi.ComponentResource):
+    def __init__(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 __props__=None,
+                 __name__=None,
+                 __opts__=None):
+        """
+        Create a Foo resource with the given unique name, props, and options.
+        :param str resource_name: The name of the resource.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        if __name__ is not None:
+            warnings.warn("explicit use of __name__ is deprecated", DeprecationWarning)
+            resource_name = __name__
+        if __opts__ is not None:
+            warnings.warn("explicit use of __opts__ is deprecated, use 'opts' instead", DeprecationWarning)
+            opts = __opts__
+        if opts is None:
+            opts = pulumi.ResourceOptions()
+        if not isinstance(opts, pulumi.ResourceOptions):
+            raise TypeError('Expected resource options to be a ResourceOptions instance')
+        if opts.version is None:
+            opts.version = _utilities.get_version()
+        if opts.id is not None:
+            raise ValueError('ComponentResource classes do not support opts.id')
+        else:
+            if __props__ is not None:
+                raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
+            __props__ = dict()
+
+        super(Foo, __self__).__init__(
+            'example::Foo',
+            resource_name,
+            __props__,
+            opts,
+            remote=True)
+
+    def bar(__self__, *,
+            arg: Optional[pulumi.Input[str]] = None) -> pulumi.Output['FooBarResult']:
+        """
+        Computes a value from the component and the given argument.
+        """
+        __args__ = dict()
+        __args__['__self__'] = __self__
+        __args__['arg'] = arg
+        return pulumi.runtime.call('example::Foo/bar', __args__, res=__self__, typ=FooBarResult)
+
+    def baz(__self__) -> None:
+        __args__ = dict()
+        __args__['__self__'] = __self__
+        pulumi.runtime.call('example::Foo/baz', __args__, res=__self__)
+
+    def translate_output_property(self, prop):
+        return _tables.CAMEL_TO_SNAKE_CASE_TABLE.get(prop) or prop
+
+    def translate_input_property(self, prop):
+        return _tables.SNAKE_TO_CAMEL_CASE_TABLE.get(prop) or prop
+
+
+@pulumi.output_type
+class FooBarResult:
+    def __init__(__self__, some_value=None):
+        if some_value and not isinstance(some_value, str):
+            raise TypeError("Expected argument 'some_value' to be a str")
+        pulumi.set(__self__, "some_value", some_value)
+
+    @property
+    @pulumi.getter(name="someValue")
+    def some_value(self) -> str:
+        return pulumi.get(self, "some_value")
+
@@ -13,6 +13,9 @@ namespace Pulumi.Example
     {
         public static Task<ArgFunctionResult> InvokeAsync(ArgFunctionArgs? args = null, InvokeOptions? options = null)
             => Pulumi.Deployment.Instance.InvokeAsync<ArgFunctionResult>("example::argFunction", args ?? new ArgFunctionArgs(), options.WithVersion());
+
+        public static Output<ArgFunctionResult> Invoke(ArgFunctionInvokeArgs? args = null, InvokeOptions? options = null)
+            => Pulumi.Deployment.Instance.Invoke<ArgFunctionResult>("example::argFunction", args ?? new ArgFunctionInvokeArgs(), options.WithVersion());
     }
 
 
@@ -27,6 +30,17 @@ namespace Pulumi.Example
     }
 
 
+    public sealed class ArgFunctionInvokeArgs : Pulumi.InvokeArgs
+    {
+        [Input("arg1")]
+        public Input<Pulumi.Example.Resource>? Arg1 { get; set; }
+
+        public ArgFunctionInvokeArgs()
+        {
+        }
+    }
+
+
     [OutputType]
     public sealed class ArgFunctionResult
     {
@@ -4,6 +4,9 @@
 package example
 
 import (
+	"context"
+	"reflect"
+
 	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
 )
 
@@ -23,3 +26,45 @@ type ArgFunctionArgs struct {
 type ArgFunctionResult struct {
 	Result *Resource `pulumi:"result"`
 }
+
+func ArgFunctionOutput(ctx *pulumi.Context, args ArgFunctionOutputArgs, opts ...pulumi.InvokeOption) ArgFunctionResultOutput {
+	return pulumi.ToOutputWithContext(ctx.Context(), args).
+		ApplyT(func(v interface{}) (ArgFunctionResult, error) {
+			args := v.(ArgFunctionArgs)
+			r, err := ArgFunction(ctx, &args, opts...)
+			if err != nil {
+				return ArgFunctionResult{}, err
+			}
+			return *r, nil
+		}).(ArgFunctionResultOutput)
+}
+
+type ArgFunctionOutputArgs struct {
+	Arg1 ResourceInput `pulumi:"arg1"`
+}
+
+func (ArgFunctionOutputArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*ArgFunctionArgs)(nil)).Elem()
+}
+
+type ArgFunctionResultOutput struct{ *pulumi.OutputState }
+
+func (ArgFunctionResultOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*ArgFunctionResult)(nil)).Elem()
+}
+
+func (o ArgFunctionResultOutput) ToArgFunctionResultOutput() ArgFunctionResultOutput {
+	return o
+}
+
+func (o ArgFunctionResultOutput) ToArgFunctionResultOutputWithContext(ctx context.Context) ArgFunctionResultOutput {
+	return o
+}
+
+func (o ArgFunctionResultOutput) Result() ResourceOutput {
+	return o.ApplyT(func(v ArgFunctionResult) *Resource { return v.Result }).(ResourceOutput)
+}
+
+func init() {
+	pulumi.RegisterOutputType(ArgFunctionResultOutput{})
+}
@@ -13,6 +13,7 @@ __all__ = [
     'ArgFunctionResult',
     'AwaitableArgFunctionResult',
     'arg_function',
+    'arg_function_output',
 ]
 
 @pulumi.output_type
@@ -52,3 +53,11 @@ def arg_function(arg1: Optional['Resource'] = None,
 
     return AwaitableArgFunctionResult(
         result=__ret__.result)
+
+
+def arg_function_output(arg1: Optional[pulumi.Input['Resource']] = None,
+                        opts: Optional[pulumi.InvokeOptions] = None) -> pulumi.Output[ArgFunctionResult]:
+    """
+    Use this data source to access information about an existing resource.
+    """
+    return pulumi.Output.all(arg1=arg1).apply(lambda args: arg_function(**args, opts=opts))
@@ -708,8 +708,15 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 		fmt.Fprintf(w, "        super(%s.__pulumiType, name, inputs, opts);\n", name)
 	}
 
-	// Finish the class.
+	// Finish the constructor.
 	fmt.Fprintf(w, "    }\n")
+
+	// Emit the resource's methods.
+	for _, method := range r.Methods {
+		mod.genMethod(w, name, method)
+	}
+
+	// Finish the class.
 	fmt.Fprintf(w, "}\n")
 
 	// Emit the state type for get methods.
@@ -723,9 +730,95 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	argsComment := fmt.Sprintf("The set of arguments for constructing a %s resource.", name)
 	mod.genPlainType(w, argsType, argsComment, r.InputProperties, true, true, true, 0)
 
+	// Emit the argument and result types for the resource's methods.
+	if len(r.Methods) > 0 {
+		fmt.Fprintf(w, "\nexport namespace %s {\n", name)
+		first := true
+		separate := func() {
+			if !first {
+				fmt.Fprintf(w, "\n")
+			}
+			first = false
+		}
+		for _, method := range r.Methods {
+			methodName := title(method.Name)
+			if args := methodArgs(method.Function); len(args) > 0 {
+				separate()
+				comment := fmt.Sprintf("The set of arguments for the %s.%s method.", name, method.Name)
+				mod.genPlainType(w, methodName+"Args", comment, args, true, true, true, 1)
+			}
+			if method.Function.Outputs != nil {
+				separate()
+				comment := fmt.Sprintf("The results of the %s.%s method.", name, method.Name)
+				mod.genPlainType(w, methodName+"Result", comment, method.Function.Outputs.Properties,
+					false, false, true, 1)
+			}
+		}
+		fmt.Fprintf(w, "}\n")
+	}
+
 	return nil
 }
 
+// methodArgs returns the arguments of a method, excluding its `__self__` argument.
+func methodArgs(f *schema.Function) []*schema.Property {
+	if f.Inputs == nil {
+		return nil
+	}
+	var args []*schema.Property
+	for _, p := range f.Inputs.Properties {
+		if p.Name != "__self__" {
+			args = append(args, p)
+		}
+	}
+	return args
+}
+
+func (mod *modContext) genMethod(w io.Writer, resourceName string, method *schema.Method) {
+	f := method.Function
+	methodName := title(method.Name)
+	args := methodArgs(f)
+
+	fmt.Fprintf(w, "\n")
+	printComment(w, codegen.FilterExamples(f.Comment, "typescript"), f.DeprecationMessage, "    ")
+
+	// Emit the method signature.
+	var argsig string
+	argsOptional := true
+	for _, p := range args {
+		if p.IsRequired {
+			argsOptional = false
+			break
+		}
+	}
+	if len(args) > 0 {
+		optFlag := ""
+		if argsOptional {
+			optFlag = "?"
+		}
+		argsig = fmt.Sprintf("args%s: %s.%sArgs", optFlag, resourceName, methodName)
+	}
+	retty := "void"
+	if f.Outputs != nil {
+		retty = fmt.Sprintf("%s.%sResult", resourceName, methodName)
+	}
+	fmt.Fprintf(w, "    %s(%s): pulumi.Output<%s> {\n", method.Name, argsig, retty)
+
+	// Zero initialize the args if empty and necessary.
+	if len(args) > 0 && argsOptional {
+		fmt.Fprintf(w, "        args = args || {};\n")
+	}
+
+	// Call the method via the runtime, passing this resource as its `__self__` argument.
+	fmt.Fprintf(w, "        return pulumi.runtime.call(\"%s\", {\n", f.Token)
+	fmt.Fprintf(w, "            \"__self__\": this,\n")
+	for _, p := range args {
+		fmt.Fprintf(w, "            \"%[1]s\": args.%[1]s,\n", p.Name)
+	}
+	fmt.Fprintf(w, "        }, this);\n")
+	fmt.Fprintf(w, "    }\n")
+}
+
 func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) {
 	name := tokenToFunctionName(fun.Token)
 
@@ -798,6 +891,31 @@ func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) {
 		fmt.Fprintf(w, "\n")
 		mod.genPlainType(w, title(name)+"Result", fun.Outputs.Comment, fun.Outputs.Properties, false, false, true, 0)
 	}
+
+	if fun.NeedsOutputVersion() {
+		mod.genFunctionOutputVersion(w, fun, name, argsOptional)
+	}
+}
+
+// genFunctionOutputVersion emits a variant of the given function that accepts inputs and returns an output.
+func (mod *modContext) genFunctionOutputVersion(w io.Writer, fun *schema.Function, name string, argsOptional bool) {
+	optFlag := ""
+	if argsOptional {
+		optFlag = "?"
+	}
+
+	fmt.Fprintf(w, "\n")
+	printComment(w, codegen.FilterExamples(fun.Comment, "typescript"), "", "")
+	if fun.DeprecationMessage != "" {
+		fmt.Fprintf(w, "/** @deprecated %s */\n", fun.DeprecationMessage)
+	}
+	fmt.Fprintf(w, "export function %sOutput(args%s: %sOutputArgs, opts?: pulumi.InvokeOptions): pulumi.Output<%sResult> {\n",
+		name, optFlag, title(name), title(name))
+	fmt.Fprintf(w, "    return pulumi.output(args).apply(a => %s(a, opts))\n", name)
+	fmt.Fprintf(w, "}\n")
+
+	fmt.Fprintf(w, "\n")
+	mod.genPlainType(w, title(name)+"OutputArgs", fun.Inputs.Comment, fun.Inputs.Properties, true, true, true, 0)
 }
 
 func visitObjectTypes(t schema.Type, visitor func(*schema.ObjectType), seen codegen.Set) {
@@ -939,6 +1057,16 @@ func (mod *modContext) getImports(member interface{}, externalImports codegen.St
 		for _, p := range member.InputProperties {
 			needsTypes = mod.getTypeImports(p.Type, false, externalImports, imports, seen) || needsTypes
 		}
+		for _, method := range member.Methods {
+			for _, p := range methodArgs(method.Function) {
+				needsTypes = mod.getTypeImports(p.Type, false, externalImports, imports, seen) || needsTypes
+			}
+			if method.Function.Outputs != nil {
+				for _, p := range method.Function.Outputs.Properties {
+					needsTypes = mod.getTypeImports(p.Type, false, externalImports, imports, seen) || needsTypes
+				}
+			}
+		}
 		return needsTypes
 	case *schema.Function:
 		needsTypes := false
@@ -1786,7 +1914,10 @@ func generateModuleContextMap(tool string, pkg *schema.Package, info NodePackage
 
 	for _, f := range pkg.Functions {
 		mod := getModFromToken(f.Token)
-		mod.functions = append(mod.functions, f)
+		// Methods are generated as part of the resources to which they belong.
+		if !f.IsMethod {
+			mod.functions = append(mod.functions, f)
+		}
 		if f.Inputs != nil {
 			visitObjectTypes(f.Inputs, func(t *schema.ObjectType) {
 				types.details(t).inputType = true
@@ -43,6 +43,9 @@ type generator struct {
 
 	asyncMain     bool
 	configCreated bool
+
+	// The names of the builtin functions whose helpers must be emitted at the end of the program.
+	helpers codegen.StringSet
 }
 
 func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
@@ -51,6 +54,7 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 
 	g := &generator{
 		program: program,
+		helpers: codegen.NewStringSet(),
 	}
 	g.Formatter = format.NewFormatter(g)
 
@@ -112,6 +116,8 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 		g.Fgenf(&index, "}\n")
 	}
 
+	g.genHelpers(&index)
+
 	files := map[string][]byte{
 		"index.ts": index.Bytes(),
 	}
@@ -168,6 +174,9 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 				if i := g.getFunctionImports(call); i != "" {
 					importSet.Add(i)
 				}
+				if _, ok := functionHelpers[call.Name]; ok {
+					g.helpers.Add(call.Name)
+				}
 			}
 			return n, nil
 		})
@@ -180,11 +189,7 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 			continue
 		}
 		as := makeValidIdentifier(path.Base(pkg))
-		if as != pkg {
-			imports = append(imports, fmt.Sprintf("import * as %v from \"%v\";", as, pkg))
-		} else {
-			imports = append(imports, fmt.Sprintf("import * from \"%v\";", pkg))
-		}
+		imports = append(imports, fmt.Sprintf("import * as %v from \"%v\";", as, pkg))
 	}
 	sort.Strings(imports)
 
@@ -195,6 +200,13 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 	g.Fprint(w, "\n")
 }
 
+// genHelpers generates the definitions of any helper functions used by the program.
+func (g *generator) genHelpers(w io.Writer) {
+	for _, name := range g.helpers.SortedValues() {
+		g.Fprintf(w, "\n%s", functionHelpers[name])
+	}
+}
+
 func (g *generator) genNode(w io.Writer, n hcl2.Node) {
 	switch n := n.(type) {
 	case *hcl2.Resource:
@@ -282,6 +294,9 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	if opts.IgnoreChanges != nil {
 		appendOption("ignoreChanges", opts.IgnoreChanges)
 	}
+	if opts.Aliases != nil {
+		appendOption("aliases", opts.Aliases)
+	}
 
 	if object == nil {
 		return ""
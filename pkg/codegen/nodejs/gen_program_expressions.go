@@ -270,8 +270,39 @@ var functionImports = map[string]string{
 	intrinsicInterpolate: "@pulumi/pulumi",
 	"fileArchive":        "@pulumi/pulumi",
 	"fileAsset":          "@pulumi/pulumi",
+	"format":             "util",
 	"readFile":           "fs",
 	"readDir":            "fs",
+	"sha1":               "crypto",
+	"sha256":             "crypto",
+}
+
+// functionHelpers contains the definitions of the helper functions that are used to implement builtin functions that
+// have no equivalent in the ES2016 standard library. Each helper is emitted at the end of any program that uses it.
+var functionHelpers = map[string]string{
+	"cidrsubnet": `function cidrsubnet(prefix: string, newbits: number, netnum: number): string {
+    const [address, bits] = prefix.split("/");
+    const length = parseInt(bits, 10);
+    const newLength = length + newbits;
+    if (address.includes(":")) {
+        throw new Error(` + "`cidrsubnet does not support the IPv6 prefix ${prefix}`" + `);
+    }
+    if (newLength > 32 || netnum >= 2 ** newbits) {
+        throw new Error(` + "`cannot create subnet ${netnum} of ${prefix} with ${newbits} additional bits`" + `);
+    }
+    const base = address.split(".").reduce((acc, octet) => acc * 256 + parseInt(octet, 10), 0);
+    const network = base - base % 2 ** (32 - length) + netnum * 2 ** (32 - newLength);
+    return [24, 16, 8, 0].map(shift => Math.floor(network / 2 ** shift) % 256).join(".") + "/" + newLength;
+}
+`,
+	"flatten": `function flatten<T>(lists: T[][]): T[] {
+    return ([] as T[]).concat(...lists);
+}
+`,
+	"values": `function values<T>(map: {[key: string]: T}): T[] {
+    return Object.keys(map).map(key => map[key]);
+}
+`,
 }
 
 func (g *generator) getFunctionImports(x *model.FunctionCallExpression) string {
@@ -314,10 +345,27 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 			g.Fgenf(w, "Object.entries(%.v).map(([k, v])", expr.Args[0])
 		}
 		g.Fgenf(w, " => {key: k, value: v})")
+	case "cidrsubnet":
+		g.Fgenf(w, "cidrsubnet(%.v, %.v, %.v)", expr.Args[0], expr.Args[1], expr.Args[2])
 	case "fileArchive":
 		g.Fgenf(w, "new pulumi.asset.FileArchive(%.v)", expr.Args[0])
 	case "fileAsset":
 		g.Fgenf(w, "new pulumi.asset.FileAsset(%.v)", expr.Args[0])
+	case "flatten":
+		g.Fgenf(w, "flatten(%.v)", expr.Args[0])
+	case "format":
+		g.Fgen(w, "util.format(")
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgenf(w, "%.v", arg)
+		}
+		g.Fgen(w, ")")
+	case "fromBase64":
+		g.Fgenf(w, "Buffer.from(%.v, \"base64\").toString(\"utf8\")", expr.Args[0])
+	case "fromJSON":
+		g.Fgenf(w, "JSON.parse(%.v)", expr.Args[0])
 	case hcl2.Invoke:
 		pkg, module, fn, diags := functionName(expr.Args[0])
 		contract.Assert(len(diags) == 0)
@@ -325,6 +373,9 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 			module = "." + module
 		}
 		name := fmt.Sprintf("%s%s.%s", makeValidIdentifier(pkg), module, fn)
+		if hcl2.IsOutputVersionInvokeCall(expr) {
+			name += "Output"
+		}
 
 		optionsBag := ""
 		if len(expr.Args) == 3 {
@@ -334,6 +385,10 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		}
 
 		g.Fgenf(w, "%s(%.v%v)", name, expr.Args[1], optionsBag)
+	case "join":
+		g.Fgenf(w, "%.20v.join(%.v)", expr.Args[1], expr.Args[0])
+	case "keys":
+		g.Fgenf(w, "Object.keys(%.v)", expr.Args[0])
 	case "length":
 		g.Fgenf(w, "%.20v.length", expr.Args[0])
 	case "lookup":
@@ -341,18 +396,32 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		if len(expr.Args) == 3 {
 			g.Fgenf(w, " || %v", expr.Args[2])
 		}
+	case "merge":
+		g.Fgen(w, "Object.assign({}")
+		for _, arg := range expr.Args {
+			g.Fgenf(w, ", %.v", arg)
+		}
+		g.Fgen(w, ")")
 	case "range":
 		g.genRange(w, expr, false)
 	case "readFile":
 		g.Fgenf(w, "fs.readFileSync(%v)", expr.Args[0])
 	case "readDir":
 		g.Fgenf(w, "fs.readDirSync(%v)", expr.Args[0])
+	case "replace":
+		g.Fgenf(w, "%.20v.split(%.v).join(%.v)", expr.Args[0], expr.Args[1], expr.Args[2])
 	case "secret":
 		g.Fgenf(w, "pulumi.secret(%v)", expr.Args[0])
+	case "sha1", "sha256":
+		g.Fgenf(w, "crypto.createHash(\"%s\").update(%.v).digest(\"hex\")", expr.Name, expr.Args[0])
 	case "split":
 		g.Fgenf(w, "%.20v.split(%v)", expr.Args[1], expr.Args[0])
+	case "toBase64":
+		g.Fgenf(w, "Buffer.from(%.v).toString(\"base64\")", expr.Args[0])
 	case "toJSON":
 		g.Fgenf(w, "JSON.stringify(%v)", expr.Args[0])
+	case "values":
+		g.Fgenf(w, "values(%.v)", expr.Args[0])
 	default:
 		var rng hcl.Range
 		if expr.Syntax != nil {
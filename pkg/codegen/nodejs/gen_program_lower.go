@@ -229,9 +229,9 @@ func (g *generator) awaitInvokes(x model.Expression) model.Expression {
 	contract.Assert(g.asyncMain)
 
 	rewriter := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
-		// Ignore the node if it is not a call to invoke.
+		// Ignore the node if it is not a call to invoke or if it is a call to an invoke that returns an output.
 		call, ok := x.(*model.FunctionCallExpression)
-		if !ok || call.Name != hcl2.Invoke {
+		if !ok || call.Name != hcl2.Invoke || hcl2.IsOutputVersionInvokeCall(call) {
 			return x, nil
 		}
 
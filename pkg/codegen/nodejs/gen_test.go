@@ -54,6 +54,13 @@ func TestGeneratePackage(t *testing.T) {
 				"types/output.ts",
 			},
 		},
+		{
+			"Simple schema with methods",
+			"simple-methods-schema",
+			[]string{
+				"foo.ts",
+			},
+		},
 	}
 	testDir := filepath.Join("..", "internal", "test", "testdata")
 	for _, tt := range tests {
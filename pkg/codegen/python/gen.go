@@ -853,6 +853,38 @@ func awaitableTypeNames(tok string) (baseName, awaitableName string) {
 func (mod *modContext) genAwaitableType(w io.Writer, obj *schema.ObjectType) string {
 	baseName, awaitableName := awaitableTypeNames(obj.Token)
 
+	mod.genOutputType(w, baseName, obj)
+
+	// Produce an awaitable subclass.
+	fmt.Fprint(w, "\n")
+	fmt.Fprintf(w, "class %s(%s):\n", awaitableName, baseName)
+
+	// Emit __await__ and __iter__ in order to make this type awaitable.
+	//
+	// Note that we need __await__ to be an iterator, but we only want it to return one value. As such, we use
+	// `if False: yield` to construct this.
+	//
+	// We also need the result of __await__ to be a plain, non-awaitable value. We achieve this by returning a new
+	// instance of the base class.
+	fmt.Fprintf(w, "    # pylint: disable=using-constant-test\n")
+	fmt.Fprintf(w, "    def __await__(self):\n")
+	fmt.Fprintf(w, "        if False:\n")
+	fmt.Fprintf(w, "            yield self\n")
+	fmt.Fprintf(w, "        return %s(\n", baseName)
+	for i, prop := range obj.Properties {
+		if i > 0 {
+			fmt.Fprintf(w, ",\n")
+		}
+		pname := PyName(prop.Name)
+		fmt.Fprintf(w, "            %s=self.%s", pname, pname)
+	}
+	fmt.Fprintf(w, ")\n")
+
+	return awaitableName
+}
+
+// genOutputType emits an output type with the given name for the given object type.
+func (mod *modContext) genOutputType(w io.Writer, baseName string, obj *schema.ObjectType) {
 	// Produce a class definition with optional """ comment.
 	fmt.Fprint(w, "@pulumi.output_type\n")
 	fmt.Fprintf(w, "class %s:\n", baseName)
@@ -888,33 +920,6 @@ func (mod *modContext) genAwaitableType(w io.Writer, obj *schema.ObjectType) str
 		return mod.typeString(prop.Type, false /*input*/, false /*wrapInput*/, !prop.IsRequired,
 			false /*acceptMapping*/)
 	})
-
-	// Produce an awaitable subclass.
-	fmt.Fprint(w, "\n")
-	fmt.Fprintf(w, "class %s(%s):\n", awaitableName, baseName)
-
-	// Emit __await__ and __iter__ in order to make this type awaitable.
-	//
-	// Note that we need __await__ to be an iterator, but we only want it to return one value. As such, we use
-	// `if False: yield` to construct this.
-	//
-	// We also need the result of __await__ to be a plain, non-awaitable value. We achieve this by returning a new
-	// instance of the base class.
-	fmt.Fprintf(w, "    # pylint: disable=using-constant-test\n")
-	fmt.Fprintf(w, "    def __await__(self):\n")
-	fmt.Fprintf(w, "        if False:\n")
-	fmt.Fprintf(w, "            yield self\n")
-	fmt.Fprintf(w, "        return %s(\n", baseName)
-	for i, prop := range obj.Properties {
-		if i > 0 {
-			fmt.Fprintf(w, ",\n")
-		}
-		pname := PyName(prop.Name)
-		fmt.Fprintf(w, "            %s=self.%s", pname, pname)
-	}
-	fmt.Fprintf(w, ")\n")
-
-	return awaitableName
 }
 
 func (mod *modContext) genResource(res *schema.Resource) (string, error) {
@@ -954,6 +959,31 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 		})
 	}
 
+	for _, method := range res.Methods {
+		visitObjectTypesFromProperties(methodArgs(method.Function), inputSeen, func(t interface{}) {
+			switch T := t.(type) {
+			case *schema.ObjectType:
+				imports.addType(mod, T, true /*input*/)
+			case *schema.EnumType:
+				imports.addEnum(mod, T.Token)
+			case *schema.ResourceType:
+				imports.addResource(mod, T)
+			}
+		})
+		if method.Function.Outputs != nil {
+			visitObjectTypesFromProperties(method.Function.Outputs.Properties, outputSeen, func(t interface{}) {
+				switch T := t.(type) {
+				case *schema.ObjectType:
+					imports.addType(mod, T, false /*input*/)
+				case *schema.EnumType:
+					imports.addEnum(mod, T.Token)
+				case *schema.ResourceType:
+					imports.addResource(mod, T)
+				}
+			})
+		}
+	}
+
 	mod.genHeader(w, true /*needsSDK*/, imports)
 
 	name := pyClassName(tokenToName(res.Token))
@@ -962,7 +992,13 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 	}
 
 	// Export only the symbols we want exported.
-	fmt.Fprintf(w, "__all__ = ['%s']\n\n", name)
+	exports := []string{fmt.Sprintf("'%s'", name)}
+	for _, method := range res.Methods {
+		if method.Function.Outputs != nil {
+			exports = append(exports, fmt.Sprintf("'%s'", methodResultName(name, method)))
+		}
+	}
+	fmt.Fprintf(w, "__all__ = [%s]\n\n", strings.Join(exports, ", "))
 
 	var baseType string
 	switch {
@@ -1175,6 +1211,11 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 		return fmt.Sprintf("pulumi.Output[%s]", ty)
 	})
 
+	// Write out the resource's methods.
+	for _, method := range res.Methods {
+		mod.genMethod(w, name, method)
+	}
+
 	// Override translate_{input|output}_property on each resource to translate between snake case and
 	// camel case when interacting with tfbridge.
 	fmt.Fprintf(w,
@@ -1186,9 +1227,94 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 
 `)
 
+	// Write out the result types of the resource's methods.
+	for _, method := range res.Methods {
+		if method.Function.Outputs != nil {
+			fmt.Fprintf(w, "\n")
+			mod.genOutputType(w, methodResultName(name, method), method.Function.Outputs)
+		}
+	}
+
 	return w.String(), nil
 }
 
+// methodArgs returns the arguments of a method, excluding its `__self__` argument.
+func methodArgs(f *schema.Function) []*schema.Property {
+	if f.Inputs == nil {
+		return nil
+	}
+	var args []*schema.Property
+	for _, p := range f.Inputs.Properties {
+		if p.Name != "__self__" {
+			args = append(args, p)
+		}
+	}
+	return args
+}
+
+// methodResultName returns the name of the result type of the given method.
+func methodResultName(resourceName string, method *schema.Method) string {
+	return resourceName + title(method.Name) + "Result"
+}
+
+func (mod *modContext) genMethod(w io.Writer, resourceName string, method *schema.Method) {
+	f := method.Function
+	name := PyName(method.Name)
+	args := methodArgs(f)
+
+	// Write out the method signature. Arguments are keyword-only.
+	def := fmt.Sprintf("    def %s(", name)
+	indent := strings.Repeat(" ", len(def))
+	fmt.Fprintf(w, "%s__self__", def)
+	if len(args) > 0 {
+		fmt.Fprintf(w, ", *")
+	}
+	for _, arg := range args {
+		ty := mod.typeString(arg.Type, true, true /*wrapInput*/, true /*optional*/, true /*acceptMapping*/)
+		fmt.Fprintf(w, ",\n%s%s: %s = None", indent, PyName(arg.Name), ty)
+	}
+	if f.Outputs != nil {
+		fmt.Fprintf(w, ") -> pulumi.Output['%s']:\n", methodResultName(resourceName, method))
+	} else {
+		fmt.Fprintf(w, ") -> None:\n")
+	}
+
+	docs := &bytes.Buffer{}
+	if f.Comment != "" {
+		fmt.Fprintln(docs, codegen.FilterExamples(f.Comment, "python"))
+	}
+	if len(args) > 0 {
+		if f.Comment != "" {
+			fmt.Fprintln(docs, "")
+		}
+		for _, arg := range args {
+			mod.genPropDocstring(docs, PyName(arg.Name), arg, true /*wrapInputs*/, true /*acceptMapping*/)
+		}
+	}
+	if docs.Len() > 0 {
+		printComment(w, docs.String(), "        ")
+	}
+
+	if f.DeprecationMessage != "" {
+		fmt.Fprintf(w, "        pulumi.log.warn(\"%s is deprecated: %s\")\n", name, f.DeprecationMessage)
+	}
+
+	// Copy the method arguments into a dictionary, including this resource as `__self__`.
+	fmt.Fprintf(w, "        __args__ = dict()\n")
+	fmt.Fprintf(w, "        __args__['__self__'] = __self__\n")
+	for _, arg := range args {
+		fmt.Fprintf(w, "        __args__['%s'] = %s\n", arg.Name, PyName(arg.Name))
+	}
+
+	// Now call the method via the runtime.
+	if f.Outputs != nil {
+		fmt.Fprintf(w, "        return pulumi.runtime.call('%s', __args__, res=__self__, typ=%s)\n\n", f.Token,
+			methodResultName(resourceName, method))
+	} else {
+		fmt.Fprintf(w, "        pulumi.runtime.call('%s', __args__, res=__self__)\n\n", f.Token)
+	}
+}
+
 func (mod *modContext) genProperties(w io.Writer, properties []*schema.Property, setters bool,
 	propType func(prop *schema.Property) string) {
 	// Write out Python properties for each property. If there is a property named "property", it will
@@ -1293,6 +1419,9 @@ func (mod *modContext) genFunction(fun *schema.Function) (string, error) {
 		fmt.Fprintf(w, "    '%s',\n", awaitableName)
 	}
 	fmt.Fprintf(w, "    '%s',\n", name)
+	if fun.NeedsOutputVersion() {
+		fmt.Fprintf(w, "    '%s_output',\n", name)
+	}
 	fmt.Fprintf(w, "]\n\n")
 
 	if fun.DeprecationMessage != "" {
@@ -1319,7 +1448,7 @@ func (mod *modContext) genFunction(fun *schema.Function) (string, error) {
 	if len(args) > 0 {
 		indent = strings.Repeat(" ", len(def))
 	}
-	fmt.Fprintf(w, def)
+	fmt.Fprint(w, def)
 	for i, arg := range args {
 		var ind string
 		if i != 0 {
@@ -1393,9 +1522,61 @@ func (mod *modContext) genFunction(fun *schema.Function) (string, error) {
 		}
 	}
 
+	if fun.NeedsOutputVersion() {
+		fmt.Fprintf(w, "\n\n")
+		mod.genFunctionOutputVersion(w, fun, name, baseName)
+	}
+
 	return w.String(), nil
 }
 
+// genFunctionOutputVersion emits a variant of the given function that accepts inputs and returns an output.
+func (mod *modContext) genFunctionOutputVersion(w io.Writer, fun *schema.Function, name, resultName string) {
+	// Sort required args first, as they have no default value.
+	args := make([]*schema.Property, len(fun.Inputs.Properties))
+	copy(args, fun.Inputs.Properties)
+	sort.SliceStable(args, func(i, j int) bool {
+		return args[i].IsRequired && !args[j].IsRequired
+	})
+
+	def := fmt.Sprintf("def %s_output(", name)
+	indent := strings.Repeat(" ", len(def))
+	fmt.Fprint(w, def)
+	for i, arg := range args {
+		var ind string
+		if i != 0 {
+			ind = indent
+		}
+		ty := mod.typeString(arg.Type, true, true /*wrapInput*/, !arg.IsRequired, true /*acceptMapping*/)
+		if arg.IsRequired {
+			fmt.Fprintf(w, "%s%s: %s,\n", ind, PyName(arg.Name), ty)
+		} else {
+			fmt.Fprintf(w, "%s%s: %s = None,\n", ind, PyName(arg.Name), ty)
+		}
+	}
+	fmt.Fprintf(w, "%sopts: Optional[pulumi.InvokeOptions] = None) -> pulumi.Output[%s]:\n", indent, resultName)
+
+	docs := &bytes.Buffer{}
+	if fun.Comment != "" {
+		fmt.Fprintln(docs, codegen.FilterExamples(fun.Comment, "python"))
+	} else {
+		fmt.Fprintln(docs, "Use this data source to access information about an existing resource.")
+	}
+	fmt.Fprintln(docs, "")
+	for _, arg := range args {
+		mod.genPropDocstring(docs, PyName(arg.Name), arg, true /*wrapInputs*/, true /*acceptMapping*/)
+	}
+	printComment(w, docs.String(), "    ")
+
+	// Wait for all of the inputs to resolve, then call the function with their values.
+	var kwargs []string
+	for _, arg := range args {
+		kwargs = append(kwargs, fmt.Sprintf("%[1]s=%[1]s", PyName(arg.Name)))
+	}
+	fmt.Fprintf(w, "    return pulumi.Output.all(%s).apply(lambda args: %s(**args, opts=opts))\n",
+		strings.Join(kwargs, ", "), name)
+}
+
 func (mod *modContext) genEnums(w io.Writer, enums []*schema.EnumType) error {
 	// Header
 	mod.genHeader(w, false /*needsSDK*/, nil)
@@ -2316,7 +2497,10 @@ func generateModuleContextMap(tool string, pkg *schema.Package, info PackageInfo
 	// Find input and output types referenced by functions.
 	for _, f := range pkg.Functions {
 		mod := getModFromToken(f.Token, f.Package)
-		mod.functions = append(mod.functions, f)
+		// Methods are generated as part of the resources to which they belong.
+		if !f.IsMethod {
+			mod.functions = append(mod.functions, f)
+		}
 		if f.Inputs != nil {
 			visitObjectTypes(f.Inputs, inputSeen, func(t interface{}) {
 				switch T := t.(type) {
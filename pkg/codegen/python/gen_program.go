@@ -301,6 +301,9 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 	if opts.IgnoreChanges != nil {
 		appendOption("ignore_changes", opts.IgnoreChanges)
 	}
+	if opts.Aliases != nil {
+		appendOption("aliases", opts.Aliases)
+	}
 
 	return block, temps
 }
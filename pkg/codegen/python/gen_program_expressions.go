@@ -60,8 +60,19 @@ func (g *generator) GetPrecedence(expr model.Expression) int {
 		}
 	case *model.UnaryOpExpression:
 		return 13
-	case *model.FunctionCallExpression, *model.IndexExpression, *model.RelativeTraversalExpression,
-		*model.TemplateJoinExpression:
+	case *model.FunctionCallExpression:
+		switch expr.Name {
+		case "format":
+			if _, _, ok := translateFormatCall(expr); !ok {
+				return 12
+			}
+			return 16
+		case "flatten", "merge":
+			return 17
+		default:
+			return 16
+		}
+	case *model.IndexExpression, *model.RelativeTraversalExpression, *model.TemplateJoinExpression:
 		return 16
 	case *model.ForExpression, *model.ObjectConsExpression, *model.SplatExpression, *model.TupleConsExpression:
 		return 17
@@ -182,13 +193,29 @@ func functionName(tokenArg model.Expression) (string, string, string, hcl.Diagno
 }
 
 var functionImports = map[string]string{
+	"cidrsubnet":  "ipaddress",
 	"fileArchive": "pulumi",
 	"fileAsset":   "pulumi",
+	"fromBase64":  "base64",
+	"fromJSON":    "json",
 	"readDir":     "os",
+	"sha1":        "hashlib",
+	"sha256":      "hashlib",
+	"toBase64":    "base64",
 	"toJSON":      "json",
 }
 
 func (g *generator) getFunctionImports(x *model.FunctionCallExpression) string {
+	if x.Name == "format" {
+		if _, args, ok := translateFormatCall(x); ok {
+			for _, arg := range args {
+				if strings.HasPrefix(arg.conversion, "json.") {
+					return "json"
+				}
+			}
+		}
+		return ""
+	}
 	if x.Name != hcl2.Invoke {
 		return functionImports[x.Name]
 	}
@@ -211,12 +238,49 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.genApply(w, expr)
 	case "element":
 		g.Fgenf(w, "%.16v[%.v]", expr.Args[0], expr.Args[1])
+	case "cidrsubnet":
+		g.Fgen(w, "(lambda network, newbits, netnum: ")
+		g.Fgen(w, "f\"{network.network_address + (netnum << network.max_prefixlen - network.prefixlen - newbits)}")
+		g.Fgen(w, "/{network.prefixlen + newbits}\")")
+		g.Fgenf(w, "(ipaddress.ip_network(%.v, strict=False), %.v, %.v)", expr.Args[0], expr.Args[1], expr.Args[2])
 	case "entries":
 		g.Fgenf(w, `[{"key": k, "value": v} for k, v in %.v]`, expr.Args[0])
 	case "fileArchive":
 		g.Fgenf(w, "pulumi.FileArchive(%.v)", expr.Args[0])
 	case "fileAsset":
 		g.Fgenf(w, "pulumi.FileAsset(%.v)", expr.Args[0])
+	case "flatten":
+		g.Fgenf(w, "[item for items in %.v for item in items]", expr.Args[0])
+	case "format":
+		if format, args, ok := translateFormatCall(expr); ok {
+			g.genStringLiteral(w, `"`, format)
+			g.Fgen(w, ".format(")
+			for i, arg := range args {
+				if i > 0 {
+					g.Fgen(w, ", ")
+				}
+				g.Fgenf(w, arg.conversion, expr.Args[arg.index+1])
+			}
+			g.Fgen(w, ")")
+			return
+		}
+
+		// The format string is not a literal, so fall back to the % operator, which supports most of the same verbs.
+		g.Fgenf(w, "%.13v %% (", expr.Args[0])
+		for i, arg := range expr.Args[1:] {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgenf(w, "%.v", arg)
+		}
+		if len(expr.Args) == 2 {
+			g.Fgen(w, ",")
+		}
+		g.Fgen(w, ")")
+	case "fromBase64":
+		g.Fgenf(w, "base64.b64decode(%.16v.encode()).decode()", expr.Args[0])
+	case "fromJSON":
+		g.Fgenf(w, "json.loads(%.v)", expr.Args[0])
 	case hcl2.Invoke:
 		pkg, module, fn, diags := functionName(expr.Args[0])
 		contract.Assert(len(diags) == 0)
@@ -224,6 +288,9 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 			module = "." + module
 		}
 		name := fmt.Sprintf("%s%s.%s", pkg, module, PyName(fn))
+		if hcl2.IsOutputVersionInvokeCall(expr) {
+			name += "_output"
+		}
 
 		optionsBag := ""
 		if len(expr.Args) == 3 {
@@ -263,6 +330,10 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		}
 
 		g.Fgenf(w, "%v)", optionsBag)
+	case "join":
+		g.Fgenf(w, "%.16v.join(%.v)", expr.Args[0], expr.Args[1])
+	case "keys":
+		g.Fgenf(w, "list(%.16v.keys())", expr.Args[0])
 	case "length":
 		g.Fgenf(w, "len(%.v)", expr.Args[0])
 	case "lookup":
@@ -272,6 +343,15 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		} else {
 			g.Fgenf(w, "%.16v[%.v]", expr.Args[0], expr.Args[1])
 		}
+	case "merge":
+		g.Fgen(w, "{")
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgenf(w, "**%.13v", arg)
+		}
+		g.Fgen(w, "}")
 	case "range":
 		g.Fprint(w, "range(")
 		for i, arg := range expr.Args {
@@ -285,12 +365,20 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgenf(w, "(lambda path: open(path).read())(%.v)", expr.Args[0])
 	case "readDir":
 		g.Fgenf(w, "os.listdir(%.v)", expr.Args[0])
+	case "replace":
+		g.Fgenf(w, "%.16v.replace(%.v, %.v)", expr.Args[0], expr.Args[1], expr.Args[2])
 	case "secret":
 		g.Fgenf(w, "pulumi.secret(%v)", expr.Args[0])
+	case "sha1", "sha256":
+		g.Fgenf(w, "hashlib.%s(%.16v.encode()).hexdigest()", expr.Name, expr.Args[0])
 	case "split":
 		g.Fgenf(w, "%.16v.split(%.v)", expr.Args[1], expr.Args[0])
+	case "toBase64":
+		g.Fgenf(w, "base64.b64encode(%.16v.encode()).decode()", expr.Args[0])
 	case "toJSON":
 		g.Fgenf(w, "json.dumps(%.v)", expr.Args[0])
+	case "values":
+		g.Fgenf(w, "list(%.16v.values())", expr.Args[0])
 	default:
 		var rng hcl.Range
 		if expr.Syntax != nil {
@@ -0,0 +1,186 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// formatArgument describes the argument that is substituted for a single verb of a format string.
+type formatArgument struct {
+	// The index of the argument in the call to format, not counting the format string itself.
+	index int
+	// The conversion to apply to the argument, as a format string for the generator, or "%.v" if the argument is used
+	// as-is.
+	conversion string
+}
+
+// formatVerbs maps each verb supported by format to the Python format specification type that implements it and the
+// conversion that must be applied to the verb's argument first.
+var formatVerbs = map[byte]struct {
+	typ        string
+	conversion string
+}{
+	'v': {"", "%.v"},
+	's': {"", "%.v"},
+	'q': {"", "json.dumps(%.v)"},
+	't': {"", "str(%.v).lower()"},
+	'd': {"d", "int(%.v)"},
+	'b': {"b", "int(%.v)"},
+	'o': {"o", "int(%.v)"},
+	'x': {"x", "int(%.v)"},
+	'X': {"X", "int(%.v)"},
+	'e': {"e", "%.v"},
+	'E': {"E", "%.v"},
+	'f': {"f", "%.v"},
+	'g': {"g", "%.v"},
+	'G': {"G", "%.v"},
+}
+
+// translateFormatString translates a format string for the format function into a Python format string for
+// str.format and the arguments that must be passed for it. It returns false if the format string uses features that
+// cannot be translated, such as widths that are taken from arguments.
+func translateFormatString(format string, argCount int) (string, []formatArgument, bool) {
+	var result strings.Builder
+	var args []formatArgument
+	next := 0
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch c {
+		case '{', '}':
+			result.WriteByte(c)
+			result.WriteByte(c)
+			continue
+		case '%':
+			// Handled below.
+		default:
+			result.WriteByte(c)
+			continue
+		}
+
+		i++
+		if i < len(format) && format[i] == '%' {
+			result.WriteByte('%')
+			continue
+		}
+
+		// Parse the flags, explicit argument index, width, and precision that may precede the verb.
+		align, sign, alternate, zero := "", "", "", ""
+	flags:
+		for ; i < len(format); i++ {
+			switch format[i] {
+			case '-':
+				align = "<"
+			case '+':
+				sign = "+"
+			case ' ':
+				if sign == "" {
+					sign = " "
+				}
+			case '#':
+				alternate = "#"
+			case '0':
+				zero = "0"
+			default:
+				break flags
+			}
+		}
+		if i < len(format) && format[i] == '[' {
+			end := strings.IndexByte(format[i:], ']')
+			if end == -1 {
+				return "", nil, false
+			}
+			n, err := strconv.Atoi(format[i+1 : i+end])
+			if err != nil || n < 1 {
+				return "", nil, false
+			}
+			next, i = n-1, i+end+1
+		}
+		start := i
+		for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+			i++
+		}
+		width := format[start:i]
+		precision := ""
+		if i < len(format) && format[i] == '.' {
+			start = i
+			for i++; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+			}
+			precision = format[start:i]
+		}
+		if i == len(format) {
+			return "", nil, false
+		}
+
+		verb, ok := formatVerbs[format[i]]
+		if !ok || next >= argCount {
+			return "", nil, false
+		}
+		conversion := verb.conversion
+		if format[i] == 'v' && alternate != "" {
+			// %#v formats its argument as JSON.
+			conversion, alternate = "json.dumps(%.v)", ""
+		}
+		if align != "" {
+			// Padding with zeroes only applies to right-justified values.
+			zero = ""
+		}
+
+		spec := align + sign + alternate + zero + width + precision + verb.typ
+		if spec != "" {
+			spec = ":" + spec
+		}
+		result.WriteString("{" + spec + "}")
+		args = append(args, formatArgument{index: next, conversion: conversion})
+		next++
+	}
+	return result.String(), args, true
+}
+
+// literalFormatString returns the value of the given format string if it is a literal.
+func literalFormatString(x model.Expression) (string, bool) {
+	switch x := x.(type) {
+	case *model.LiteralValueExpression:
+		if x.Value.Type() == cty.String {
+			return x.Value.AsString(), true
+		}
+	case *model.TemplateExpression:
+		// Templates may split a literal into several parts, e.g. at each percent sign.
+		var format strings.Builder
+		for _, part := range x.Parts {
+			s, ok := literalFormatString(part)
+			if !ok {
+				return "", false
+			}
+			format.WriteString(s)
+		}
+		return format.String(), true
+	}
+	return "", false
+}
+
+// translateFormatCall translates a call to format into a Python format string and the arguments to pass to it. It
+// returns false if the call cannot be translated, in which case the call is generated using the % operator.
+func translateFormatCall(x *model.FunctionCallExpression) (string, []formatArgument, bool) {
+	format, ok := literalFormatString(x.Args[0])
+	if !ok {
+		return "", nil, false
+	}
+	return translateFormatString(format, len(x.Args)-1)
+}
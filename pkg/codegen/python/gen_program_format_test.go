@@ -0,0 +1,37 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateFormatString(t *testing.T) {
+	cases := []struct {
+		format   string
+		argCount int
+		expected string
+		args     []formatArgument
+	}{
+		{"%s, %s!", 2, "{}, {}!", []formatArgument{{0, "%.v"}, {1, "%.v"}}},
+		{"100%% {done}", 0, "100% {{done}}", nil},
+		{"%-5s|%05d|%+.2f", 3, "{:<5}|{:05d}|{:+.2f}", []formatArgument{{0, "%.v"}, {1, "int(%.v)"}, {2, "%.v"}}},
+		{"%#x %q %#v %t", 4, "{:#x} {} {} {}", []formatArgument{
+			{0, "int(%.v)"}, {1, "json.dumps(%.v)"}, {2, "json.dumps(%.v)"}, {3, "str(%.v).lower()"},
+		}},
+		{"%[2]s %s %[1]d", 3, "{} {} {:d}", []formatArgument{{1, "%.v"}, {2, "%.v"}, {0, "int(%.v)"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			actual, args, ok := translateFormatString(c.format, c.argCount)
+			assert.True(t, ok)
+			assert.Equal(t, c.expected, actual)
+			assert.Equal(t, c.args, args)
+		})
+	}
+
+	for _, format := range []string{"%s", "%*d", "%y", "%[x]s", "trailing %"} {
+		_, _, ok := translateFormatString(format, 0)
+		assert.False(t, ok, format)
+	}
+}
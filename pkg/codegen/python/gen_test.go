@@ -48,6 +48,13 @@ func TestGeneratePackage(t *testing.T) {
 				filepath.Join("pulumi_example", "arg_function.py"),
 			},
 		},
+		{
+			"Simple schema with methods",
+			"simple-methods-schema",
+			[]string{
+				filepath.Join("pulumi_example", "foo.py"),
+			},
+		},
 		{
 			"External resource schema",
 			"external-resource-schema",
@@ -0,0 +1,74 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build ignore
+
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("").Parse(`// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by generate.go from pulumi.json; DO NOT EDIT.
+
+// nolint: lll
+package schema
+
+// metaSchema is the JSON schema that describes Pulumi package schemas.
+const metaSchema = ` + "`{{.}}`" + `
+`))
+
+// main embeds the metaschema in pulumi.json into metaschema.go so that it is available to package validation.
+func main() {
+	contents, err := ioutil.ReadFile("pulumi.json")
+	if err != nil {
+		log.Fatalf("reading pulumi.json: %v", err)
+	}
+	if strings.Contains(string(contents), "`") {
+		log.Fatal("pulumi.json must not contain backquotes")
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, string(contents)); err != nil {
+		log.Fatalf("executing template: %v", err)
+	}
+
+	data, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+
+	if err = ioutil.WriteFile("metaschema.go", data, 0600); err != nil {
+		log.Fatalf("writing metaschema.go: %v", err)
+	}
+}
@@ -315,6 +315,19 @@ type Resource struct {
 	Language map[string]interface{}
 	// IsComponent indicates whether the resource is a ComponentResource.
 	IsComponent bool
+	// Methods is the list of the resource's methods.
+	Methods []*Method
+	// ListFunction is the function that lists the existing resources of this type, if any.
+	ListFunction *Function
+}
+
+// Method describes a method on a resource. A method is a function whose first argument, `__self__`, is the resource
+// on which the method is called.
+type Method struct {
+	// Name is the name of the method.
+	Name string
+	// Function is the function that implements the method.
+	Function *Function
 }
 
 // Function describes a Pulumi function.
@@ -333,6 +346,15 @@ type Function struct {
 	DeprecationMessage string
 	// Language specifies additional language-specific data about the function.
 	Language map[string]interface{}
+	// IsMethod indicates whether the function is a method of a resource.
+	IsMethod bool
+}
+
+// NeedsOutputVersion returns true if the SDKs for the function's package should include a variant of the function
+// that accepts inputs and returns an output. Such a variant is only useful for functions that take arguments and
+// return a result; methods already accept inputs and return outputs.
+func (fun *Function) NeedsOutputVersion() bool {
+	return !fun.IsMethod && fun.Inputs != nil && len(fun.Inputs.Properties) > 0 && fun.Outputs != nil
 }
 
 // Package describes a Pulumi package.
@@ -746,6 +768,12 @@ type ResourceSpec struct {
 	Language map[string]json.RawMessage `json:"language,omitempty"`
 	// IsComponent indicates whether the resource is a ComponentResource.
 	IsComponent bool `json:"isComponent,omitempty"`
+	// Methods maps method names to functions in this schema.
+	Methods map[string]string `json:"methods,omitempty"`
+	// ListFunction is the token of a function in this schema that lists the existing resources of this type. The
+	// function's inputs filter the resources that are listed. Its outputs must include a `resources` property that is a
+	// list of objects, each of which has a string `id` property and may have a string `name` property.
+	ListFunction string `json:"listFunction,omitempty"`
 }
 
 // FunctionSpec is the serializable form of a function description.
@@ -887,6 +915,18 @@ func importSpec(spec PackageSpec, languages map[string]Language, loader Loader)
 		return nil, errors.Wrap(err, "binding functions")
 	}
 
+	if err := bindMethods(spec.Provider, provider, functionTable); err != nil {
+		return nil, errors.Wrap(err, "binding provider methods")
+	}
+	for token, resourceSpec := range spec.Resources {
+		if err := bindMethods(resourceSpec, resourceTable[token], functionTable); err != nil {
+			return nil, errors.Wrapf(err, "binding methods of resource %v", token)
+		}
+		if err := bindListFunction(resourceSpec, resourceTable[token], functionTable); err != nil {
+			return nil, errors.Wrapf(err, "binding list function of resource %v", token)
+		}
+	}
+
 	// Build the type list.
 	var typeList []Type
 	for _, t := range types.resources {
@@ -955,6 +995,13 @@ func ImportSpec(spec PackageSpec, languages map[string]Language) (*Package, erro
 	return importSpec(spec, languages, nil)
 }
 
+// ImportSpecWithLoader converts a serializable PackageSpec into a Package, using the given loader to resolve
+// references to other packages.
+func ImportSpecWithLoader(spec PackageSpec, languages map[string]Language, loader Loader) (*Package, error) {
+	contract.Require(loader != nil, "loader")
+	return importSpec(spec, languages, loader)
+}
+
 // types facilitates interning (only storing a single reference to an object) during schema processing. The fields
 // correspond to fields in the schema, and are populated during the binding process.
 type types struct {
@@ -1685,6 +1732,92 @@ func bindFunction(token string, spec FunctionSpec, types *types) (*Function, err
 	}, nil
 }
 
+// bindMethods binds the methods of a resource to the functions that implement them. Each function that implements
+// a method must accept the resource as its `__self__` input and may implement at most one method.
+func bindMethods(spec ResourceSpec, res *Resource, functionTable map[string]*Function) error {
+	names := make([]string, 0, len(spec.Methods))
+	for name := range spec.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		token := spec.Methods[name]
+		f, ok := functionTable[token]
+		if !ok {
+			return errors.Errorf("unknown function %v for method %v", token, name)
+		}
+		if f.IsMethod {
+			return errors.Errorf("function %v for method %v is already a method", token, name)
+		}
+		if !hasSelfInput(f, res) {
+			return errors.Errorf("function %v for method %v must have a __self__ input of type %v", token, name,
+				res.Token)
+		}
+		f.IsMethod = true
+		res.Methods = append(res.Methods, &Method{Name: name, Function: f})
+	}
+	return nil
+}
+
+// bindListFunction binds the function that lists the existing resources of a resource's type. The function must
+// return a `resources` property that is a list of objects with a string `id` property.
+func bindListFunction(spec ResourceSpec, res *Resource, functionTable map[string]*Function) error {
+	if spec.ListFunction == "" {
+		return nil
+	}
+
+	f, ok := functionTable[spec.ListFunction]
+	if !ok {
+		return errors.Errorf("unknown list function %v", spec.ListFunction)
+	}
+	if !hasResourceListOutput(f) {
+		return errors.Errorf("list function %v must return a `resources` list of objects with a string `id` property",
+			spec.ListFunction)
+	}
+	res.ListFunction = f
+	return nil
+}
+
+// hasResourceListOutput returns true if the given function returns a `resources` property that is a list of objects
+// with a string `id` property.
+func hasResourceListOutput(f *Function) bool {
+	if f.Outputs == nil {
+		return false
+	}
+	for _, p := range f.Outputs.Properties {
+		if p.Name != "resources" {
+			continue
+		}
+		arr, ok := p.Type.(*ArrayType)
+		if !ok {
+			return false
+		}
+		obj, ok := arr.ElementType.(*ObjectType)
+		if !ok {
+			return false
+		}
+		id, ok := obj.Property("id")
+		return ok && id.Type == StringType
+	}
+	return false
+}
+
+// hasSelfInput returns true if the given function accepts a `__self__` input that refers to the given resource.
+func hasSelfInput(f *Function, res *Resource) bool {
+	if f.Inputs == nil {
+		return false
+	}
+	for _, p := range f.Inputs.Properties {
+		if p.Name != "__self__" {
+			continue
+		}
+		t, ok := p.Type.(*ResourceType)
+		return ok && t.Token == res.Token
+	}
+	return false
+}
+
 func bindFunctions(specs map[string]FunctionSpec, types *types) ([]*Function, map[string]*Function, error) {
 	functionTable := map[string]*Function{}
 	var functions []*Function
@@ -323,3 +323,98 @@ func Test_parseTypeSpecRef(t *testing.T) {
 		})
 	}
 }
+
+func TestMethods(t *testing.T) {
+	pkgSpec := readSchemaFile(filepath.Join("simple-methods-schema", "schema.json"))
+
+	pkg, err := ImportSpec(pkgSpec, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var res *Resource
+	for _, r := range pkg.Resources {
+		if r.Token == "example::Foo" {
+			res = r
+		}
+	}
+	if !assert.NotNil(t, res) {
+		return
+	}
+
+	assert.Len(t, res.Methods, 2)
+	assert.Equal(t, "bar", res.Methods[0].Name)
+	assert.Equal(t, "example::Foo/bar", res.Methods[0].Function.Token)
+	assert.True(t, res.Methods[0].Function.IsMethod)
+	assert.Equal(t, "baz", res.Methods[1].Name)
+
+	// Methods must take the resource as their `__self__` argument.
+	delete(pkgSpec.Functions["example::Foo/baz"].Inputs.Properties, "__self__")
+	_, err = ImportSpec(pkgSpec, nil)
+	assert.Error(t, err)
+}
+
+func TestListFunction(t *testing.T) {
+	var pkgSpec PackageSpec
+	err := json.Unmarshal([]byte(`{
+		"name": "example",
+		"types": {
+			"example::BucketSummary": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "string"},
+					"name": {"type": "string"}
+				}
+			}
+		},
+		"resources": {
+			"example::Bucket": {
+				"listFunction": "example::listBuckets"
+			}
+		},
+		"functions": {
+			"example::listBuckets": {
+				"inputs": {
+					"properties": {
+						"tags": {"type": "object", "additionalProperties": {"type": "string"}}
+					}
+				},
+				"outputs": {
+					"properties": {
+						"resources": {
+							"type": "array",
+							"items": {"$ref": "#/types/example::BucketSummary"}
+						}
+					}
+				}
+			}
+		}
+	}`), &pkgSpec)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	pkg, err := ImportSpec(pkgSpec, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	res, ok := pkg.GetResource("example::Bucket")
+	if assert.True(t, ok) && assert.NotNil(t, res.ListFunction) {
+		assert.Equal(t, "example::listBuckets", res.ListFunction.Token)
+	}
+
+	// The list function must return a list of resources with IDs.
+	summary := pkgSpec.Types["example::BucketSummary"]
+	id := summary.Properties["id"]
+	delete(summary.Properties, "id")
+	_, err = ImportSpec(pkgSpec, nil)
+	assert.Error(t, err)
+	summary.Properties["id"] = id
+
+	// The list function must exist.
+	bucket := pkgSpec.Resources["example::Bucket"]
+	bucket.ListFunction = "example::listThings"
+	pkgSpec.Resources["example::Bucket"] = bucket
+	_, err = ImportSpec(pkgSpec, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,489 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run generate.go
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// A Diagnostic describes a problem with a package schema.
+type Diagnostic struct {
+	// Severity is the severity of the problem: diag.Error for problems that prevent the schema from being used, or
+	// diag.Warning for problems that degrade the quality of the generated SDKs and documentation.
+	Severity diag.Severity `json:"severity"`
+	// Pointer is a JSON pointer (RFC 6901) to the value in the schema document that the problem refers to.
+	Pointer string `json:"pointer"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("%v: #%v: %v", d.Severity, d.Pointer, d.Message)
+}
+
+// Diagnostics is a list of diagnostics.
+type Diagnostics []*Diagnostic
+
+// HasErrors returns true if the list contains at least one error.
+func (ds Diagnostics) HasErrors() bool {
+	for _, d := range ds {
+		if d.Severity == diag.Error {
+			return true
+		}
+	}
+	return false
+}
+
+var metaSchemaOnce sync.Once
+var compiledMetaSchema *gojsonschema.Schema
+
+// ValidateSpec checks the given package schema document and returns all of the problems that it finds, sorted by their
+// location in the document.
+//
+// The document is first checked against the package metaschema, then against a set of semantic rules that the
+// metaschema cannot express, e.g. that all references to local types resolve and that all tokens belong to the
+// package. References to other packages are not resolved, so validation does not require any plugins.
+func ValidateSpec(document []byte) Diagnostics {
+	var raw interface{}
+	if err := json.Unmarshal(document, &raw); err != nil {
+		return Diagnostics{{Severity: diag.Error, Message: fmt.Sprintf("invalid JSON: %v", err)}}
+	}
+
+	v := &validator{}
+	v.validateMetaSchema(raw)
+
+	// Values that do not conform to the metaschema may prevent the document from decoding into a PackageSpec. If
+	// that happens, there is nothing more to check.
+	var spec PackageSpec
+	if err := json.Unmarshal(document, &spec); err == nil {
+		v.validatePackage(&spec)
+	} else if !v.diags.HasErrors() {
+		v.errorf("", "%v", err)
+	}
+
+	sort.SliceStable(v.diags, func(i, j int) bool {
+		return v.diags[i].Pointer < v.diags[j].Pointer
+	})
+	return v.diags
+}
+
+// jsonPointer returns a JSON pointer that refers to the value at the given path.
+func jsonPointer(path ...string) string {
+	var sb strings.Builder
+	for _, p := range path {
+		sb.WriteByte('/')
+		sb.WriteString(strings.ReplaceAll(strings.ReplaceAll(p, "~", "~0"), "/", "~1"))
+	}
+	return sb.String()
+}
+
+type validator struct {
+	spec  *PackageSpec
+	types *types
+	diags Diagnostics
+
+	moduleFormat *regexp.Regexp
+
+	// references maps the token of each local type to the tokens of the local types that it references. The
+	// references of resources, functions, and configuration variables are recorded under the empty token.
+	references map[string]map[string]bool
+}
+
+func (v *validator) errorf(pointer, format string, args ...interface{}) {
+	v.diags = append(v.diags, &Diagnostic{Severity: diag.Error, Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warningf(pointer, format string, args ...interface{}) {
+	v.diags = append(v.diags, &Diagnostic{Severity: diag.Warning, Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validateMetaSchema(document interface{}) {
+	metaSchemaOnce.Do(func() {
+		s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(metaSchema))
+		contract.AssertNoErrorf(err, "invalid package metaschema")
+		compiledMetaSchema = s
+	})
+
+	result, err := compiledMetaSchema.Validate(gojsonschema.NewGoLoader(document))
+	if err != nil {
+		v.errorf("", "%v", err)
+		return
+	}
+	for _, e := range result.Errors() {
+		// The context of an error is the path from the root of the document to the offending value. The first element
+		// of the path is always "(root)".
+		path := strings.Split(e.Context().String("\x00"), "\x00")[1:]
+		v.errorf(jsonPointer(path...), "%v", e.Description())
+	}
+}
+
+func (v *validator) validatePackage(spec *PackageSpec) {
+	v.spec = spec
+	v.types = &types{pkg: &Package{Name: spec.Name}}
+	v.references = map[string]map[string]bool{}
+
+	if spec.Version != "" {
+		version, err := semver.ParseTolerant(spec.Version)
+		if err != nil {
+			v.errorf("/version", "invalid version: %v", err)
+		} else {
+			v.types.pkg.Version = &version
+		}
+	}
+	if spec.Description == "" {
+		v.warningf("/description", "missing package description")
+	}
+
+	moduleFormat := "(.*)"
+	if spec.Meta != nil && spec.Meta.ModuleFormat != "" {
+		moduleFormat = spec.Meta.ModuleFormat
+	}
+	re, err := regexp.Compile(moduleFormat)
+	switch {
+	case err != nil:
+		v.errorf("/meta/moduleFormat", "invalid module format: %v", err)
+	case re.NumSubexp() < 1:
+		v.errorf("/meta/moduleFormat", "the module format must define a capturing group that contains the module name")
+	default:
+		v.moduleFormat = re
+	}
+
+	for _, name := range sortedKeys(spec.Config.Variables) {
+		v.validateProperty(jsonPointer("config", "variables", name), "", spec.Config.Variables[name])
+	}
+	for i, name := range spec.Config.Required {
+		if _, ok := spec.Config.Variables[name]; !ok {
+			v.errorf(jsonPointer("config", "defaults", strconv.Itoa(i)),
+				"required configuration variable %q is not defined in variables", name)
+		}
+	}
+
+	v.validateResource("/provider", "pulumi:providers:"+spec.Name, &spec.Provider)
+	for _, token := range sortedKeys(spec.Resources) {
+		pointer := jsonPointer("resources", token)
+		v.validateToken(pointer, token)
+		res := spec.Resources[token]
+		v.validateResource(pointer, token, &res)
+	}
+
+	for _, token := range sortedKeys(spec.Functions) {
+		pointer := jsonPointer("functions", token)
+		v.validateToken(pointer, token)
+		fn := spec.Functions[token]
+		if fn.Description == "" {
+			v.warningf(pointer, "missing function description")
+		}
+		if fn.Inputs != nil {
+			v.validateObject(pointer+"/inputs", "", fn.Inputs)
+		}
+		if fn.Outputs != nil {
+			v.validateObject(pointer+"/outputs", "", fn.Outputs)
+		}
+	}
+
+	for _, token := range sortedKeys(spec.Types) {
+		pointer := jsonPointer("types", token)
+		v.validateToken(pointer, token)
+		v.validateType(pointer, token, spec.Types[token])
+	}
+
+	v.validateTypeUsage()
+}
+
+// validateToken checks that the given token is of the form "pkg:module:member", that it belongs to the package, and
+// that its module matches the package's module format.
+func (v *validator) validateToken(pointer, token string) {
+	components := strings.Split(token, ":")
+	if len(components) != 3 {
+		v.errorf(pointer, "invalid token %q: tokens must be of the form <package>:<module>:<member>", token)
+		return
+	}
+
+	pkg, module, member := components[0], components[1], components[2]
+	if pkg != v.spec.Name {
+		v.errorf(pointer, "the package of token %q does not match the package name %q", token, v.spec.Name)
+	}
+	if member == "" {
+		v.errorf(pointer, "the member of token %q must not be empty", token)
+	}
+	if module != "providers" && v.moduleFormat != nil && !v.moduleFormat.MatchString(module) {
+		v.errorf(pointer, "the module of token %q does not match the module format %q", token,
+			v.moduleFormat.String())
+	}
+}
+
+func (v *validator) validateResource(pointer, token string, spec *ResourceSpec) {
+	isProvider := pointer == "/provider"
+	if spec.Description == "" && !isProvider {
+		v.warningf(pointer, "missing resource description")
+	}
+
+	v.validateObject(pointer, "", &spec.ObjectTypeSpec)
+
+	for _, name := range sortedKeys(spec.InputProperties) {
+		v.validateProperty(pointer+jsonPointer("inputProperties", name), "", spec.InputProperties[name])
+	}
+	v.validateRequired(pointer+"/requiredInputs", spec.RequiredInputs, spec.InputProperties)
+
+	// The outputs of a custom resource are expected to include its inputs, as the provider reports the resource's
+	// complete state. Components and providers are not held to this rule.
+	if !spec.IsComponent && !isProvider {
+		for _, name := range sortedKeys(spec.InputProperties) {
+			output, ok := spec.Properties[name]
+			switch {
+			case !ok:
+				v.warningf(pointer+jsonPointer("inputProperties", name),
+					"input property %q is not an output property of resource %q", name, token)
+			case !reflect.DeepEqual(output.TypeSpec, spec.InputProperties[name].TypeSpec):
+				v.warningf(pointer+jsonPointer("inputProperties", name),
+					"input property %q has a different type than the output property of the same name", name)
+			}
+		}
+	}
+
+	if spec.StateInputs != nil {
+		v.validateObject(pointer+"/stateInputs", "", spec.StateInputs)
+	}
+
+	for _, name := range sortedKeys(spec.Methods) {
+		if _, ok := v.spec.Functions[spec.Methods[name]]; !ok {
+			v.errorf(pointer+jsonPointer("methods", name), "method %q refers to undefined function %q", name,
+				spec.Methods[name])
+		}
+	}
+	if spec.ListFunction != "" {
+		if _, ok := v.spec.Functions[spec.ListFunction]; !ok {
+			v.errorf(pointer+"/listFunction", "list function %q is not defined", spec.ListFunction)
+		}
+	}
+}
+
+func (v *validator) validateType(pointer, token string, spec ComplexTypeSpec) {
+	if spec.Description == "" {
+		v.warningf(pointer, "missing type description")
+	}
+
+	if len(spec.Enum) == 0 {
+		if spec.Type != "object" {
+			v.errorf(pointer+"/type", "types without enum values must be objects")
+			return
+		}
+		v.validateObject(pointer, token, &spec.ObjectTypeSpec)
+		return
+	}
+
+	if len(spec.Properties) != 0 {
+		v.errorf(pointer+"/properties", "enum types must not have properties")
+	}
+
+	typ, err := v.types.bindPrimitiveType(spec.Type)
+	if err != nil {
+		v.errorf(pointer+"/type", "enum types must be booleans, integers, numbers, or strings")
+		return
+	}
+
+	values := map[interface{}]int{}
+	for i, value := range spec.Enum {
+		valuePointer := pointer + "/enum/" + strconv.Itoa(i)
+		if _, err := v.types.bindEnumValues([]*EnumValueSpec{{Value: value.Value}}, typ); err != nil {
+			v.errorf(valuePointer+"/value", "%v", err)
+			continue
+		}
+		if j, ok := values[value.Value]; ok {
+			v.errorf(valuePointer+"/value", "duplicate enum value %v (see #%v/enum/%v)", value.Value, pointer, j)
+			continue
+		}
+		values[value.Value] = i
+	}
+}
+
+// validateObject checks the properties of an object. The owner is the token of the type that the object belongs to, if
+// any, and is used to track references between types.
+func (v *validator) validateObject(pointer, owner string, spec *ObjectTypeSpec) {
+	for _, name := range sortedKeys(spec.Properties) {
+		v.validateProperty(pointer+jsonPointer("properties", name), owner, spec.Properties[name])
+	}
+	v.validateRequired(pointer+"/required", spec.Required, spec.Properties)
+}
+
+func (v *validator) validateRequired(pointer string, required []string, properties map[string]PropertySpec) {
+	for i, name := range required {
+		if _, ok := properties[name]; !ok {
+			v.errorf(pointer+"/"+strconv.Itoa(i), "required property %q is not defined", name)
+		}
+	}
+}
+
+func (v *validator) validateProperty(pointer, owner string, spec PropertySpec) {
+	// The receiver of a method is not documented.
+	if spec.Description == "" && !strings.HasSuffix(pointer, "/__self__") {
+		v.warningf(pointer, "missing property description")
+	}
+
+	v.validateTypeSpec(pointer, owner, spec.TypeSpec)
+
+	// Constant and default values are only checked for primitive types. Defaults for enums are checked against the
+	// enum's element type.
+	typ, err := v.types.bindPrimitiveType(spec.Type)
+	if err != nil || spec.Ref != "" || spec.OneOf != nil {
+		typ = nil
+	}
+	if spec.Const != nil && typ != nil {
+		if _, err := bindConstValue(spec.Const, typ); err != nil {
+			v.errorf(pointer+"/const", "%v", err)
+		}
+	}
+	if spec.Default != nil && typ != nil {
+		if _, err := bindDefaultValue(spec.Default, nil, typ); err != nil {
+			v.errorf(pointer+"/default", "%v", err)
+		}
+	}
+}
+
+func (v *validator) validateTypeSpec(pointer, owner string, spec TypeSpec) {
+	if spec.Ref != "" {
+		v.validateRef(pointer, owner, spec)
+		return
+	}
+
+	if spec.OneOf != nil {
+		if len(spec.OneOf) < 2 {
+			v.errorf(pointer+"/oneOf", "oneOf should list at least two types")
+		}
+		if spec.Type != "" {
+			if _, err := v.types.bindPrimitiveType(spec.Type); err != nil {
+				v.errorf(pointer+"/type", "the default type of a union must be a boolean, integer, number, or string")
+			}
+		}
+		for i, element := range spec.OneOf {
+			v.validateTypeSpec(pointer+"/oneOf/"+strconv.Itoa(i), owner, element)
+		}
+		return
+	}
+
+	switch spec.Type {
+	case "boolean", "integer", "number", "string":
+	case "array":
+		if spec.Items == nil {
+			v.errorf(pointer, "array types must specify their element type with \"items\"")
+			return
+		}
+		v.validateTypeSpec(pointer+"/items", owner, *spec.Items)
+	case "object":
+		if spec.AdditionalProperties != nil {
+			v.validateTypeSpec(pointer+"/additionalProperties", owner, *spec.AdditionalProperties)
+		}
+	case "":
+		v.errorf(pointer, "type references must specify a \"type\", a \"$ref\", or \"oneOf\"")
+	default:
+		v.errorf(pointer+"/type", "unknown type kind %v", spec.Type)
+	}
+}
+
+func (v *validator) validateRef(pointer, owner string, spec TypeSpec) {
+	pointer += "/$ref"
+
+	if strings.HasPrefix(spec.Ref, "pulumi.json#") {
+		switch spec.Ref {
+		case "pulumi.json#/Archive", "pulumi.json#/Asset", "pulumi.json#/Json", "pulumi.json#/Any":
+		default:
+			v.errorf(pointer, "unknown built-in type %q", spec.Ref)
+		}
+		return
+	}
+
+	ref, err := v.types.parseTypeSpecRef(spec.Ref)
+	if err != nil {
+		v.errorf(pointer, "%v", err)
+		return
+	}
+
+	// References to other packages are not resolved so that validation does not need to load their schemas.
+	if ref.Package != v.spec.Name || !versionEquals(ref.Version, v.types.pkg.Version) {
+		return
+	}
+
+	switch ref.Kind {
+	case typesRef:
+		if _, ok := v.spec.Types[ref.Token]; ok {
+			refs, ok := v.references[owner]
+			if !ok {
+				refs = map[string]bool{}
+				v.references[owner] = refs
+			}
+			refs[ref.Token] = true
+			return
+		}
+
+		// References to undefined types are bound as opaque token types.
+		if spec.Type == "" {
+			v.errorf(pointer, "type %q is not defined", ref.Token)
+		} else {
+			v.warningf(pointer, "type %q is not defined and will be treated as an opaque token type with underlying "+
+				"type %v", ref.Token, spec.Type)
+		}
+	case resourcesRef:
+		if _, ok := v.spec.Resources[ref.Token]; !ok {
+			v.errorf(pointer, "resource %q is not defined", ref.Token)
+		}
+	}
+}
+
+// validateTypeUsage warns about types that are not reachable from any resource, function, or configuration variable.
+func (v *validator) validateTypeUsage() {
+	used := map[string]bool{}
+	var visit func(owner string)
+	visit = func(owner string) {
+		for token := range v.references[owner] {
+			if !used[token] {
+				used[token] = true
+				visit(token)
+			}
+		}
+	}
+	visit("")
+
+	for _, token := range sortedKeys(v.spec.Types) {
+		if !used[token] {
+			v.warningf(jsonPointer("types", token), "type %q is not used by any resource, function, or configuration "+
+				"variable", token)
+		}
+	}
+}
+
+// sortedKeys returns the keys of the given map in sorted order.
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.String()
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,225 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint: lll
+package schema
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaSchemaIsUpToDate(t *testing.T) {
+	contents, err := ioutil.ReadFile("pulumi.json")
+	if err != nil {
+		t.Fatalf("failed to read metaschema: %v", err)
+	}
+	assert.Equal(t, string(contents), metaSchema, "metaschema.go is out of date; run `go generate`")
+}
+
+func TestValidateSpecTestData(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "internal", "test", "testdata", "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list schemas: %v", err)
+	}
+	schemas, err := filepath.Glob(filepath.Join("..", "internal", "test", "testdata", "*", "schema.json"))
+	if err != nil {
+		t.Fatalf("failed to list schemas: %v", err)
+	}
+
+	for _, path := range append(files, schemas...) {
+		t.Run(path, func(t *testing.T) {
+			contents, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read schema: %v", err)
+			}
+
+			for _, d := range ValidateSpec(contents) {
+				assert.NotEqual(t, diag.Error, d.Severity, "%v", d)
+			}
+		})
+	}
+}
+
+// validPackage is a package schema that produces no diagnostics. Test cases modify copies of it.
+const validPackage = `{
+	"name": "example",
+	"version": "1.0.0",
+	"description": "An example package.",
+	"resources": {
+		"example:index:Resource": {
+			"description": "A resource.",
+			"properties": {
+				"size": {"$ref": "#/types/example:index:Size", "description": "The size."}
+			},
+			"required": ["size"],
+			"inputProperties": {
+				"size": {"$ref": "#/types/example:index:Size", "description": "The size."}
+			},
+			"requiredInputs": ["size"]
+		}
+	},
+	"types": {
+		"example:index:Size": {
+			"description": "A size.",
+			"type": "string",
+			"enum": [{"value": "small"}, {"value": "large"}]
+		}
+	}
+}`
+
+func TestValidateSpec(t *testing.T) {
+	cases := []struct {
+		name     string
+		document string
+		expected []string
+	}{
+		{
+			name:     "valid",
+			document: validPackage,
+		},
+		{
+			name:     "invalid JSON",
+			document: `{"name": }`,
+			expected: []string{"error: #: invalid JSON: invalid character '}' looking for beginning of value"},
+		},
+		{
+			name:     "metaschema",
+			document: `{"version": 1, "description": "d", "resources": {"example:index:Resource": {"description": "d", "isComponent": "yes"}}}`,
+			expected: []string{
+				"error: #: name is required",
+				"error: #/resources/example:index:Resource/isComponent: Invalid type. Expected: boolean, given: string",
+				"error: #/version: Invalid type. Expected: string, given: integer",
+			},
+		},
+		{
+			name:     "unknown property",
+			document: `{"name": "example", "description": "d", "provider": {"inputs": {}}}`,
+			expected: []string{"error: #/provider: Additional property inputs is not allowed"},
+		},
+		{
+			name: "version and module format",
+			document: `{"name": "example", "version": "one", "description": "d", "meta": {"moduleFormat": "index"},
+				"functions": {"example:index:f": {"description": "d"}}}`,
+			expected: []string{
+				"error: #/meta/moduleFormat: the module format must define a capturing group that contains the module name",
+				"error: #/version: invalid version: Invalid character(s) found in major number \"one\"",
+			},
+		},
+		{
+			name: "tokens",
+			document: `{"name": "example", "description": "d", "meta": {"moduleFormat": "(index|s3)"},
+				"functions": {"other:index:f": {"description": "d"}, "example:ec2:g": {"description": "d"}, "h": {"description": "d"}}}`,
+			expected: []string{
+				"error: #/functions/example:ec2:g: the module of token \"example:ec2:g\" does not match the module format \"(index|s3)\"",
+				"error: #/functions/h: invalid token \"h\": tokens must be of the form <package>:<module>:<member>",
+				"error: #/functions/other:index:f: the package of token \"other:index:f\" does not match the package name \"example\"",
+			},
+		},
+		{
+			name: "references",
+			document: `{"name": "example", "description": "d", "resources": {"example:index:Resource": {"description": "d",
+				"isComponent": true,
+				"properties": {
+					"a": {"$ref": "#/types/example:index:Missing", "description": "d"},
+					"b": {"$ref": "#/types/example:index:Token", "type": "string", "description": "d"},
+					"c": {"$ref": "#/resources/example:index:Missing", "description": "d"},
+					"d": {"$ref": "pulumi.json#/Thing", "description": "d"},
+					"e": {"$ref": "/other/v1.0.0/schema.json#/types/other:index:Thing", "description": "d"},
+					"f": {"$ref": "#/things/example:index:Thing", "description": "d"},
+					"g": {"type": "array", "description": "d"},
+					"h": {"type": "object", "additionalProperties": {"oneOf": [{"type": "string"}]}, "description": "d"}
+				}}}}`,
+			expected: []string{
+				"error: #/resources/example:index:Resource/properties/a/$ref: type \"example:index:Missing\" is not defined",
+				"warning: #/resources/example:index:Resource/properties/b/$ref: type \"example:index:Token\" is not defined and will be treated as an opaque token type with underlying type string",
+				"error: #/resources/example:index:Resource/properties/c/$ref: resource \"example:index:Missing\" is not defined",
+				"error: #/resources/example:index:Resource/properties/d/$ref: unknown built-in type \"pulumi.json#/Thing\"",
+				"error: #/resources/example:index:Resource/properties/f/$ref: invalid type reference '#/things/example:index:Thing'",
+				"error: #/resources/example:index:Resource/properties/g: array types must specify their element type with \"items\"",
+				"error: #/resources/example:index:Resource/properties/h/additionalProperties/oneOf: oneOf should list at least two types",
+			},
+		},
+		{
+			name: "properties",
+			document: `{"name": "example", "description": "d", "config": {"variables": {"region": {"type": "string", "description": "d"}}, "defaults": ["zone"]},
+				"resources": {"example:index:Resource": {"description": "d",
+					"properties": {
+						"a": {"type": "string", "description": "d"},
+						"b": {"type": "integer", "description": "d"}
+					},
+					"required": ["c"],
+					"inputProperties": {
+						"a": {"type": "integer", "description": "d", "default": 1.5},
+						"d": {"type": "string", "const": true}
+					},
+					"requiredInputs": ["e"],
+					"methods": {"m": "example:index:Resource/m"}
+				}}}`,
+			expected: []string{
+				"error: #/config/defaults/0: required configuration variable \"zone\" is not defined in variables",
+				"error: #/resources/example:index:Resource/inputProperties/a/default: invalid default of type number for integer property",
+				"warning: #/resources/example:index:Resource/inputProperties/a: input property \"a\" has a different type than the output property of the same name",
+				"warning: #/resources/example:index:Resource/inputProperties/d: missing property description",
+				"error: #/resources/example:index:Resource/inputProperties/d/const: invalid constant of type bool for string property",
+				"warning: #/resources/example:index:Resource/inputProperties/d: input property \"d\" is not an output property of resource \"example:index:Resource\"",
+				"error: #/resources/example:index:Resource/methods/m: method \"m\" refers to undefined function \"example:index:Resource/m\"",
+				"error: #/resources/example:index:Resource/required/0: required property \"c\" is not defined",
+				"error: #/resources/example:index:Resource/requiredInputs/0: required property \"e\" is not defined",
+			},
+		},
+		{
+			name: "types",
+			document: `{"name": "example", "description": "d", "types": {
+				"example:index:Size": {"description": "d", "type": "integer", "enum": [{"value": 1}, {"value": 1.5}, {"value": 1}]},
+				"example:index:Shape": {"description": "d", "type": "string"},
+				"example:index/thing:Thing": {"type": "object", "properties": {"shape": {"$ref": "#/types/example:index:Shape", "description": "d"}}}
+			}}`,
+			expected: []string{
+				"warning: #/types/example:index~1thing:Thing: missing type description",
+				"warning: #/types/example:index~1thing:Thing: type \"example:index/thing:Thing\" is not used by any resource, function, or configuration variable",
+				"error: #/types/example:index:Shape/type: types without enum values must be objects",
+				"error: #/types/example:index:Size/enum/1/value: cannot assign enum value of type 'number' to enum of type 'integer'",
+				"error: #/types/example:index:Size/enum/2/value: duplicate enum value 1 (see #/types/example:index:Size/enum/0)",
+				"warning: #/types/example:index:Size: type \"example:index:Size\" is not used by any resource, function, or configuration variable",
+				"warning: #/types/example:index:Shape: type \"example:index:Shape\" is not used by any resource, function, or configuration variable",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var actual []string
+			for _, d := range ValidateSpec([]byte(c.document)) {
+				actual = append(actual, d.String())
+			}
+			assert.ElementsMatch(t, c.expected, actual)
+		})
+	}
+}
+
+func TestValidateSpecPointers(t *testing.T) {
+	diags := ValidateSpec([]byte(`{"name": "example", "description": "d", "functions": {
+		"example:index/getThing:getThing": {"inputs": {"properties": {"a~b": {"type": "string", "description": "d"}}, "required": ["c"]}}
+	}}`))
+	assert.Equal(t, Diagnostics{
+		{Severity: diag.Warning, Pointer: "/functions/example:index~1getThing:getThing", Message: "missing function description"},
+		{Severity: diag.Error, Pointer: "/functions/example:index~1getThing:getThing/inputs/required/0", Message: "required property \"c\" is not defined"},
+	}, diags)
+	assert.True(t, diags.HasErrors())
+	assert.Equal(t, "/a~0b/c~1d", jsonPointer("a~b", "c/d"))
+}
@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"sort"
 
+	"github.com/agext/levenshtein"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 )
 
@@ -87,6 +88,24 @@ func SortedKeys(m interface{}) []string {
 	return keys
 }
 
+// SimilarNames returns the sorted list of candidates that are within a small edit distance of the given name. It is
+// used to offer suggestions when a name cannot be resolved.
+func SimilarNames(name string, candidates []string) []string {
+	const maxDistance = 2
+
+	var similar []string
+	for _, c := range candidates {
+		if c == name {
+			continue
+		}
+		if levenshtein.Distance(name, c, nil) <= maxDistance {
+			similar = append(similar, c)
+		}
+	}
+	sort.Strings(similar)
+	return similar
+}
+
 // CleanDir removes all existing files from a directory except those in the exclusions list.
 // Note: The exclusions currently don't function recursively, so you cannot exclude a single file
 // in a subdirectory, only entire subdirectories. This function will need improvements to be able to
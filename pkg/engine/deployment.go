@@ -125,6 +125,9 @@ type deploymentOptions struct {
 	// true if we should trust the dependency graph reported by the language host. Not all Pulumi-supported languages
 	// correctly report their dependencies, in which case this will be false.
 	trustDependencies bool
+
+	// the project's plugin lock, if the project has a directory in which to keep one.
+	pluginLock *pluginLock
 }
 
 // deploymentSourceFunc is a callback that will be used to prepare for, and evaluate, the "new" state for a stack.
@@ -151,6 +154,18 @@ func newDeployment(ctx *Context, info *deploymentContext, opts deploymentOptions
 	}
 
 	opts.trustDependencies = proj.TrustResourceDependencies()
+
+	// Load the project's plugin lock so that plugins are resolved to the locked versions. Unless the lock is being
+	// updated, plugin lookups that do not request a particular version also resolve to the locked versions.
+	opts.pluginLock, err = loadPluginLock(info.Update.GetRoot(), opts.UpdateLock)
+	if err != nil {
+		contract.IgnoreClose(plugctx)
+		return nil, err
+	}
+	if opts.pluginLock != nil && !opts.UpdateLock {
+		plugctx.PluginLock = opts.pluginLock.lock
+	}
+
 	// Now create the state source.  This may issue an error if it can't create the source.  This entails,
 	// for example, loading any plugins which will be required to execute a program, among other things.
 	source, err := opts.SourceFunc(ctx.BackendClient, opts, proj, pwd, main, target, plugctx, dryRun)
@@ -167,7 +182,7 @@ func newDeployment(ctx *Context, info *deploymentContext, opts deploymentOptions
 			plugctx, target, target.Snapshot, source, localPolicyPackPaths, dryRun, ctx.BackendClient)
 	} else {
 		_, defaultProviderVersions, pluginErr := installPlugins(proj, pwd, main, target, plugctx,
-			false /*returnInstallErrors*/)
+			false /*returnInstallErrors*/, opts.pluginLock)
 		if pluginErr != nil {
 			return nil, pluginErr
 		}
@@ -248,6 +263,7 @@ func (deployment *deployment) run(cancelCtx *Context, actions runActions, policy
 			TrustDependencies:         deployment.Options.trustDependencies,
 			UseLegacyDiff:             deployment.Options.UseLegacyDiff,
 			DisableResourceReferences: deployment.Options.DisableResourceReferences,
+			ImportDependencies:        deployment.Options.ImportDependencies,
 		}
 		walkResult = deployment.Deployment.Execute(ctx, opts, preview)
 		close(done)
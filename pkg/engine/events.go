@@ -60,6 +60,8 @@ func NewEvent(typ EventType, payload interface{}) Event {
 		_, ok = payload.(ResourceOperationFailedPayload)
 	case PolicyViolationEvent:
 		_, ok = payload.(PolicyViolationEventPayload)
+	case PolicyRemediationEvent:
+		_, ok = payload.(PolicyRemediationEventPayload)
 	default:
 		contract.Failf("unknown event type %v", typ)
 	}
@@ -83,6 +85,7 @@ const (
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
 	PolicyViolationEvent    EventType = "policy-violation"
+	PolicyRemediationEvent  EventType = "policy-remediation"
 )
 
 func (e Event) Payload() interface{} {
@@ -116,6 +119,17 @@ type PolicyViolationEventPayload struct {
 	Prefix            string
 }
 
+// PolicyRemediationEventPayload is the payload for an event with type `policy-remediation`.
+type PolicyRemediationEventPayload struct {
+	ResourceURN       resource.URN
+	Color             colors.Colorization
+	PolicyName        string
+	PolicyPackName    string
+	PolicyPackVersion string
+	Before            resource.PropertyMap
+	After             resource.PropertyMap
+}
+
 type StdoutEventPayload struct {
 	Message string
 	Color   colors.Colorization
@@ -571,6 +585,22 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 	})
 }
 
+func (e *eventEmitter) policyRemediationEvent(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap) {
+
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.ch <- NewEvent(PolicyRemediationEvent, PolicyRemediationEventPayload{
+		ResourceURN:       urn,
+		Color:             colors.Raw,
+		PolicyName:        t.PolicyName,
+		PolicyPackName:    t.PolicyPackName,
+		PolicyPackVersion: t.PolicyPackVersion,
+		Before:            filterPropertyMap(before, false),
+		After:             filterPropertyMap(after, false),
+	})
+}
+
 func diagEvent(e *eventEmitter, d *diag.Diag, prefix, msg string, sev diag.Severity,
 	ephemeral bool) {
 	contract.Requiref(e != nil, "e", "!= nil")
@@ -0,0 +1,346 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// testAnalyzer is a minimal in-process analyzer whose behavior is supplied by the test.
+type testAnalyzer struct {
+	AnalyzeF      func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error)
+	AnalyzeStackF func(resources []plugin.AnalyzerStackResource) ([]plugin.AnalyzeDiagnostic, error)
+	RemediateF    func(r plugin.AnalyzerResource) ([]plugin.Remediation, error)
+}
+
+func (a *testAnalyzer) Close() error                                           { return nil }
+func (a *testAnalyzer) Name() tokens.QName                                     { return "test-analyzer" }
+func (a *testAnalyzer) Configure(map[string]plugin.AnalyzerPolicyConfig) error { return nil }
+
+func (a *testAnalyzer) Analyze(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+	if a.AnalyzeF == nil {
+		return nil, nil
+	}
+	return a.AnalyzeF(r)
+}
+
+func (a *testAnalyzer) AnalyzeStack(resources []plugin.AnalyzerStackResource) ([]plugin.AnalyzeDiagnostic, error) {
+	if a.AnalyzeStackF == nil {
+		return nil, nil
+	}
+	return a.AnalyzeStackF(resources)
+}
+
+func (a *testAnalyzer) Remediate(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+	if a.RemediateF == nil {
+		return nil, nil
+	}
+	return a.RemediateF(r)
+}
+
+func (a *testAnalyzer) GetAnalyzerInfo() (plugin.AnalyzerInfo, error) {
+	return plugin.AnalyzerInfo{Name: "test-analyzer"}, nil
+}
+
+func (a *testAnalyzer) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{Name: "test-analyzer", Kind: workspace.AnalyzerPlugin}, nil
+}
+
+// analyzerHost wraps a plugin host so that it reports the given analyzers.
+type analyzerHost struct {
+	plugin.Host
+	analyzers []plugin.Analyzer
+}
+
+func (host *analyzerHost) ListAnalyzers() []plugin.Analyzer {
+	return host.analyzers
+}
+
+// Test that remediations returned by an analyzer replace the resource's inputs before the resource is created, that
+// later analysis observes the remediated inputs, and that each remediation is reported as an engine event.
+func TestAnalyzerRemediation(t *testing.T) {
+	var created resource.PropertyMap
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					created = news
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+				"foo": "bar",
+			}),
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	var analyzed resource.PropertyMap
+	analyzer := &testAnalyzer{
+		RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+			if r.Type != "pkgA:m:typA" {
+				return nil, nil
+			}
+			props := r.Properties.Copy()
+			props["tags"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]interface{}{
+				"owner": "platform",
+			}))
+			return []plugin.Remediation{{
+				PolicyName:        "auto-tag",
+				PolicyPackName:    "tags",
+				PolicyPackVersion: "1.0.0",
+				Properties:        props,
+			}}, nil
+		},
+		AnalyzeF: func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+			if r.Type != "pkgA:m:typA" {
+				return nil, nil
+			}
+			analyzed = r.Properties
+			return nil, nil
+		},
+	}
+
+	host := &analyzerHost{
+		Host:      deploytest.NewPluginHost(nil, nil, program, loaders...),
+		analyzers: []plugin.Analyzer{analyzer},
+	}
+
+	expected := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "bar",
+		"tags": map[string]interface{}{
+			"owner": "platform",
+		},
+	})
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps: []TestStep{{
+			Op:          Update,
+			SkipPreview: true,
+			Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+				evts []Event, res result.Result) result.Result {
+
+				var remediations []PolicyRemediationEventPayload
+				for _, evt := range evts {
+					if evt.Type == PolicyRemediationEvent {
+						remediations = append(remediations, evt.Payload().(PolicyRemediationEventPayload))
+					}
+				}
+
+				if assert.Len(t, remediations, 1) {
+					assert.Equal(t, "auto-tag", remediations[0].PolicyName)
+					assert.Equal(t, "tags", remediations[0].PolicyPackName)
+					assert.Equal(t, resource.PropertyMap{"foo": resource.NewStringProperty("bar")},
+						remediations[0].Before)
+					assert.Equal(t, expected, remediations[0].After)
+				}
+				return res
+			},
+		}},
+	}
+
+	p.Run(t, nil)
+
+	assert.Equal(t, expected, created)
+	assert.Equal(t, expected, analyzed)
+}
+
+// Test that remediated inputs are checked by the provider before they are analyzed or used to create the resource,
+// and that check failures caused by a remediation fail the update.
+func TestAnalyzerRemediationIsChecked(t *testing.T) {
+	var checked []resource.PropertyMap
+	var created resource.PropertyMap
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CheckF: func(urn resource.URN, olds,
+					news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+					checked = append(checked, news)
+					if size, ok := news["size"]; ok && size.IsString() && size.StringValue() == "huge" {
+						return nil, []plugin.CheckFailure{{Property: "size", Reason: "size must not be huge"}}, nil
+					}
+					results := news.Copy()
+					results["checked"] = resource.NewBoolProperty(true)
+					return results, nil, nil
+				},
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					created = news
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	size := "large"
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+				"foo": "bar",
+			}),
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	var analyzed resource.PropertyMap
+	analyzer := &testAnalyzer{
+		RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+			props := r.Properties.Copy()
+			props["size"] = resource.NewStringProperty(size)
+			return []plugin.Remediation{{PolicyName: "size", PolicyPackName: "sizes", Properties: props}}, nil
+		},
+		AnalyzeF: func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+			analyzed = r.Properties
+			return nil, nil
+		},
+	}
+
+	host := &analyzerHost{
+		Host:      deploytest.NewPluginHost(nil, nil, program, loaders...),
+		analyzers: []plugin.Analyzer{analyzer},
+	}
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps:   []TestStep{{Op: Update, SkipPreview: true}},
+	}
+	p.Run(t, nil)
+
+	expected := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo":     "bar",
+		"size":    "large",
+		"checked": true,
+	})
+	if assert.Len(t, checked, 2) {
+		assert.Equal(t, resource.NewPropertyMapFromMap(map[string]interface{}{"foo": "bar"}), checked[0])
+		assert.Equal(t, resource.NewPropertyMapFromMap(map[string]interface{}{
+			"foo":     "bar",
+			"checked": true,
+			"size":    "large",
+		}), checked[1])
+	}
+	assert.Equal(t, expected, analyzed)
+	assert.Equal(t, expected, created)
+
+	// A remediation that produces invalid inputs must fail the update.
+	size, checked, created = "huge", nil, nil
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true, ExpectFailure: true}}
+	p.Run(t, nil)
+	assert.Len(t, checked, 2)
+	assert.Nil(t, created)
+}
+
+// Test that AnalyzeSnapshot runs resource policies against the inputs and stack policies against the outputs of the
+// live resources in a snapshot, masking secrets, without loading any providers.
+func TestAnalyzeSnapshot(t *testing.T) {
+	t.Parallel()
+
+	providerURN := resource.NewURN("test", "test", "", "pulumi:providers:pkgA", "default")
+	provider := &resource.State{
+		Type:    "pulumi:providers:pkgA",
+		URN:     providerURN,
+		Custom:  true,
+		ID:      "id-0",
+		Inputs:  resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")},
+		Outputs: resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")},
+	}
+	resA := &resource.State{
+		Type:     "pkgA:m:typA",
+		URN:      resource.NewURN("test", "test", "", "pkgA:m:typA", "resA"),
+		Custom:   true,
+		ID:       "id-1",
+		Provider: string(providerURN) + "::id-0",
+		Inputs: resource.PropertyMap{
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		},
+		Outputs: resource.PropertyMap{
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+			"arn":      resource.NewStringProperty("arn:resA"),
+		},
+	}
+	pendingDelete := &resource.State{
+		Type:   "pkgA:m:typA",
+		URN:    resource.NewURN("test", "test", "", "pkgA:m:typA", "resB"),
+		Custom: true,
+		ID:     "id-2",
+		Delete: true,
+	}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{provider, resA, pendingDelete}, nil)
+
+	var analyzed []plugin.AnalyzerResource
+	var stackResources []plugin.AnalyzerStackResource
+	analyzer := &testAnalyzer{
+		AnalyzeF: func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+			analyzed = append(analyzed, r)
+			if r.Type != "pkgA:m:typA" {
+				return nil, nil
+			}
+			return []plugin.AnalyzeDiagnostic{{PolicyName: "resource-policy", EnforcementLevel: "mandatory"}}, nil
+		},
+		AnalyzeStackF: func(resources []plugin.AnalyzerStackResource) ([]plugin.AnalyzeDiagnostic, error) {
+			stackResources = resources
+			return []plugin.AnalyzeDiagnostic{{PolicyName: "stack-policy", EnforcementLevel: "advisory"}}, nil
+		},
+	}
+	host := &analyzerHost{Host: deploytest.NewPluginHost(nil, nil, nil), analyzers: []plugin.Analyzer{analyzer}}
+	ctx := &plugin.Context{Host: host}
+
+	diags, err := AnalyzeSnapshot(ctx, snap, nil, &plugin.PolicyAnalyzerOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []plugin.AnalyzeDiagnostic{
+		{PolicyName: "resource-policy", EnforcementLevel: "mandatory", URN: resA.URN},
+		{PolicyName: "stack-policy", EnforcementLevel: "advisory"},
+	}, diags)
+
+	masked := resource.MakeSecret(resource.NewStringProperty("[secret]"))
+	if assert.Len(t, analyzed, 2) {
+		assert.Equal(t, resA.URN, analyzed[1].URN)
+		assert.Equal(t, resource.PropertyMap{"password": masked}, analyzed[1].Properties)
+		if assert.NotNil(t, analyzed[1].Provider) {
+			assert.Equal(t, providerURN, analyzed[1].Provider.URN)
+			assert.Equal(t, provider.Inputs, analyzed[1].Provider.Properties)
+		}
+	}
+	if assert.Len(t, stackResources, 2) {
+		assert.Equal(t, resource.PropertyMap{
+			"password": masked,
+			"arn":      resource.NewStringProperty("arn:resA"),
+		}, stackResources[1].Properties)
+	}
+}
@@ -0,0 +1,185 @@
+//nolint:goconst
+package lifecycletest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	. "github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi/provider"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+type testComponent struct {
+	pulumi.ResourceState
+
+	Foo pulumi.StringOutput `pulumi:"foo"`
+}
+
+// componentProviderPlugin serves a ComponentProvider over gRPC and stops serving when the provider is closed.
+type componentProviderPlugin struct {
+	plugin.Provider
+
+	cancel chan bool
+}
+
+func (p *componentProviderPlugin) Close() error {
+	close(p.cancel)
+	return p.Provider.Close()
+}
+
+func serveComponentProvider(pkg tokens.Package, prov *provider.ComponentProvider) (plugin.Provider, error) {
+	cancel := make(chan bool)
+	port, _, err := rpcutil.Serve(0, cancel, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, prov)
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithInsecure(), rpcutil.GrpcChannelOptions())
+	if err != nil {
+		close(cancel)
+		return nil, err
+	}
+	client := pulumirpc.NewResourceProviderClient(conn)
+	return &componentProviderPlugin{
+		Provider: plugin.NewProviderWithClient(nil, pkg, client, false),
+		cancel:   cancel,
+	}, nil
+}
+
+func TestComponentProviderGolangLifecycle(t *testing.T) {
+	construct := func(ctx *pulumi.Context, typ, name string, inputs pulumi.ConstructInputs,
+		options pulumi.ResourceOption) (*pulumi.ConstructResult, error) {
+
+		var args struct {
+			Bar pulumi.StringInput `pulumi:"bar"`
+		}
+		if err := inputs.CopyTo(&args); err != nil {
+			return nil, err
+		}
+
+		var comp testComponent
+		if err := ctx.RegisterComponentResource(typ, name, &comp, options); err != nil {
+			return nil, err
+		}
+
+		var child testResource
+		err := ctx.RegisterResource("pkgB:m:typB", "resB", &testResourceInputs{Foo: args.Bar}, &child,
+			pulumi.Parent(&comp))
+		if err != nil {
+			return nil, err
+		}
+
+		state := pulumi.Map{"foo": child.Foo}
+		if err := ctx.RegisterResourceOutputs(&comp, state); err != nil {
+			return nil, err
+		}
+		return &pulumi.ConstructResult{URN: comp.URN(), State: state}, nil
+	}
+
+	getFoo := func(ctx *pulumi.Context, tok string, args pulumi.CallArgs,
+		self pulumi.Resource) (*pulumi.CallResult, error) {
+
+		var callArgs struct {
+			Suffix string `pulumi:"suffix"`
+		}
+		if err := args.CopyTo(&callArgs); err != nil {
+			return nil, err
+		}
+		if self == nil {
+			return nil, fmt.Errorf("%s must be called on a resource", tok)
+		}
+		return &pulumi.CallResult{
+			Return: pulumi.Map{
+				"urn": self.URN(),
+				"foo": pulumi.String("foo" + callArgs.Suffix),
+			},
+		}, nil
+	}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return serveComponentProvider("pkgA", provider.NewComponentProvider("", "pkgA", "1.0.0", nil,
+				map[string]pulumi.ConstructFunc{"pkgA:m:typA": construct},
+				map[string]pulumi.CallFunc{"pkgA:m:typA/getFoo": getFoo}))
+		}),
+		deploytest.NewProviderLoader("pkgB", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return "created-id", news, resource.StatusOK, nil
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+					return plugin.ReadResult{Inputs: inputs, Outputs: state}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+			Project:     info.Project,
+			Stack:       info.Stack,
+			Parallel:    info.Parallel,
+			DryRun:      info.DryRun,
+			MonitorAddr: info.MonitorAddress,
+		})
+		assert.NoError(t, err)
+
+		return pulumi.RunWithContext(ctx, func(ctx *pulumi.Context) error {
+			var comp testComponent
+			err := ctx.RegisterRemoteComponentResource("pkgA:m:typA", "resA", pulumi.Map{
+				"bar": pulumi.String("baz"),
+			}, &comp)
+			assert.NoError(t, err)
+
+			result, err := ctx.Call("pkgA:m:typA/getFoo", pulumi.Map{"suffix": pulumi.String("bar")},
+				pulumi.MapOutput{}, &comp)
+			assert.NoError(t, err)
+
+			if !ctx.DryRun() {
+				foo, ret := make(chan string, 1), make(chan map[string]interface{}, 1)
+				comp.Foo.ApplyT(func(v string) string {
+					foo <- v
+					return v
+				})
+				result.(pulumi.MapOutput).ApplyT(func(v map[string]interface{}) map[string]interface{} {
+					ret <- v
+					return v
+				})
+
+				assert.Equal(t, "baz", <-foo)
+				assert.Equal(t, map[string]interface{}{
+					"urn": string(resource.NewURN(tokens.QName(info.Stack), tokens.PackageName(info.Project), "", "pkgA:m:typA", "resA")),
+					"foo": "foobar",
+				}, <-ret)
+			}
+			return nil
+		})
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps:   MakeBasicLifecycleSteps(t, 5),
+	}
+	p.Run(t, nil)
+}
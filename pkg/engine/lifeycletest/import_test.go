@@ -558,3 +558,68 @@ func TestImportIgnoreChanges(t *testing.T) {
 	assert.Len(t, snap.Resources, 2)
 	assert.Equal(t, resource.NewStringProperty("bar"), snap.Resources[1].Outputs["foo"])
 }
+
+func TestImportDependencies(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				GetSchemaF: func(version int) ([]byte, error) {
+					return []byte(importSchema), nil
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+
+					return plugin.ReadResult{
+						Inputs: resource.PropertyMap{
+							"foo": resource.NewStringProperty(string(id)),
+						},
+						Outputs: resource.PropertyMap{
+							"foo": resource.NewStringProperty(string(id)),
+						},
+					}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{}
+	project := p.GetProject()
+	urnA, urnC := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typA", "resC", "")
+
+	// resA depends on resC, which is imported after it, and on a resource that is not part of the stack.
+	p.Options = UpdateOptions{
+		Host: host,
+		ImportDependencies: func(states []*resource.State) (map[resource.URN][]resource.URN, error) {
+			assert.Len(t, states, 3)
+			for _, state := range states {
+				assert.Equal(t, resource.NewStringProperty(string(state.ID)), state.Outputs["foo"])
+			}
+			return map[resource.URN][]resource.URN{
+				urnA: {urnC, p.NewURN("pkgA:m:typA", "missing", "")},
+			}, nil
+		},
+	}
+
+	snap, res := ImportOp([]deploy.Import{
+		{Type: "pkgA:m:typA", Name: "resA", ID: "id-a"},
+		{Type: "pkgA:m:typA", Name: "resB", ID: "id-b"},
+		{Type: "pkgA:m:typA", Name: "resC", ID: "id-c"},
+	}).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	// The dependency is recorded, and resC is imported before resA.
+	indices := map[resource.URN]int{}
+	for i, r := range snap.Resources {
+		indices[r.URN] = i
+		if r.URN == urnA {
+			assert.Equal(t, []resource.URN{urnC}, r.Dependencies)
+		}
+	}
+	assert.Len(t, snap.Resources, 5)
+	assert.Less(t, indices[urnC], indices[urnA])
+}
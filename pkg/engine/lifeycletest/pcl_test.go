@@ -0,0 +1,311 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/interpreter"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	. "github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+const pclTestSchema = `{
+	"name": "pkgA",
+	"resources": {
+		"pkgA:m:typA": {
+			"inputProperties": {
+				"foo": {"type": "string"},
+				"bar": {"type": "string"}
+			},
+			"properties": {
+				"foo": {"type": "string"},
+				"bar": {"type": "string"}
+			}
+		}
+	},
+	"functions": {
+		"pkgA:m:getThing": {
+			"inputs": {
+				"properties": {
+					"name": {"type": "string"}
+				}
+			},
+			"outputs": {
+				"properties": {
+					"value": {"type": "string"}
+				}
+			}
+		}
+	}
+}`
+
+// pclTestLoader loads the schema for the pkgA test package.
+type pclTestLoader struct{}
+
+func (pclTestLoader) LoadPackage(pkg string, version *semver.Version) (*schema.Package, error) {
+	var spec schema.PackageSpec
+	if err := json.Unmarshal([]byte(pclTestSchema), &spec); err != nil {
+		return nil, err
+	}
+	return schema.ImportSpec(spec, nil)
+}
+
+func bindPCLProgram(t *testing.T, source string) *hcl2.Program {
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(source), "main.pp")
+	assert.NoError(t, err)
+	assert.Len(t, parser.Diagnostics, 0)
+
+	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.Loader(pclTestLoader{}))
+	assert.NoError(t, err)
+	assert.Len(t, diags, 0)
+	return program
+}
+
+func TestPCLProgramLifecycle(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return "created-id", news, resource.StatusOK, nil
+				},
+				InvokeF: func(tok tokens.ModuleMember,
+					inputs resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+					assert.Equal(t, tokens.ModuleMember("pkgA:m:getThing"), tok)
+					return resource.PropertyMap{
+						"value": resource.NewStringProperty("thing-" + inputs["name"].StringValue()),
+					}, nil, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := bindPCLProgram(t, `
+config prefix string {
+	default = "default"
+}
+
+thing = invoke("pkgA:m:getThing", { name = prefix })
+
+resource resA "pkgA:m:typA" {
+	foo = "${prefix}-a"
+	bar = secret(thing.value)
+}
+
+resource resB "pkgA:m:typA" {
+	foo = resA.foo
+
+	options {
+		protect = true
+	}
+}
+
+resource many "pkgA:m:typA" {
+	options {
+		range = ["x", "y"]
+	}
+	foo = "many-${range.value}"
+}
+
+output aFoo {
+	value = resA.foo
+}
+
+output manyFoos {
+	value = [for m in many: m.foo]
+}
+
+output configured {
+	value = prefix == "configured" ? "yes" : "no"
+}
+`)
+
+	runtime := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		return interpreter.Run(context.Background(), program, info)
+	})
+	host := deploytest.NewPluginHost(nil, nil, runtime, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Config: config.Map{
+			config.MustMakeKey("test", "prefix"): config.NewValue("configured"),
+		},
+	}
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+			_ []Event, res result.Result) result.Result {
+
+			assert.Nil(t, res)
+			return res
+		},
+	}}
+	snap := p.Run(t, nil)
+
+	resources := map[string]*resource.State{}
+	for _, r := range snap.Resources {
+		resources[string(r.URN.Name())] = r
+	}
+
+	resA := resources["resA"]
+	if !assert.NotNil(t, resA) {
+		t.FailNow()
+	}
+	assert.Equal(t, "configured-a", resA.Inputs["foo"].StringValue())
+	assert.True(t, resA.Inputs["bar"].IsSecret())
+	assert.Equal(t, "thing-configured", resA.Inputs["bar"].SecretValue().Element.StringValue())
+
+	resB := resources["resB"]
+	if !assert.NotNil(t, resB) {
+		t.FailNow()
+	}
+	assert.Equal(t, "configured-a", resB.Inputs["foo"].StringValue())
+	assert.True(t, resB.Protect)
+	assert.Equal(t, []resource.URN{resA.URN}, resB.Dependencies)
+	assert.Equal(t, []resource.URN{resA.URN}, resB.PropertyDependencies["foo"])
+
+	assert.Equal(t, "many-x", resources["many-0"].Inputs["foo"].StringValue())
+	assert.Equal(t, "many-y", resources["many-1"].Inputs["foo"].StringValue())
+
+	stack := resources["test-test"]
+	if !assert.NotNil(t, stack) {
+		t.FailNow()
+	}
+	assert.Equal(t, resource.RootStackType, stack.Type)
+	assert.Equal(t, resource.NewPropertyMapFromMap(map[string]interface{}{
+		"aFoo":       "configured-a",
+		"manyFoos":   []interface{}{"many-x", "many-y"},
+		"configured": "yes",
+	}), stack.Outputs)
+}
+
+func TestPCLProgramMissingConfig(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := bindPCLProgram(t, `
+config prefix string {
+}
+
+resource resA "pkgA:m:typA" {
+	foo = prefix
+}
+`)
+
+	runtime := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		err := interpreter.Run(context.Background(), program, info)
+		assert.EqualError(t, err, "missing required configuration variable 'test:prefix'; "+
+			"run `pulumi config set prefix <value>`")
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, runtime, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps:   []TestStep{{Op: Update, ExpectFailure: true, SkipPreview: true}},
+	}
+	p.Run(t, nil)
+}
+
+func TestPCLProgramRegistersIndependentResourcesConcurrently(t *testing.T) {
+	// Each create waits until the other has started, so the update only succeeds if both resources are registered at
+	// the same time.
+	var m sync.Mutex
+	creating := 0
+	bothCreating := make(chan struct{})
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					m.Lock()
+					if creating++; creating == 2 {
+						close(bothCreating)
+					}
+					m.Unlock()
+
+					select {
+					case <-bothCreating:
+						return "created-id", news, resource.StatusOK, nil
+					case <-time.After(time.Minute):
+						return "", nil, resource.StatusOK, errors.New("resources were not created concurrently")
+					}
+				},
+			}, nil
+		}),
+	}
+
+	program := bindPCLProgram(t, `
+resource resA "pkgA:m:typA" {
+	foo = "a"
+}
+
+resource resB "pkgA:m:typA" {
+	foo = "b"
+}
+
+resource resC "pkgA:m:typA" {
+	foo = resA.foo
+}
+`)
+
+	runtime := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		return interpreter.Run(context.Background(), program, info)
+	})
+	host := deploytest.NewPluginHost(nil, nil, runtime, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host, Parallel: 4},
+		Steps:   []TestStep{{Op: Update, SkipPreview: true}},
+	}
+	snap := p.Run(t, nil)
+
+	resources := map[string]*resource.State{}
+	for _, r := range snap.Resources {
+		resources[string(r.URN.Name())] = r
+	}
+	if !assert.NotNil(t, resources["resC"]) {
+		t.FailNow()
+	}
+	assert.Equal(t, []resource.URN{resources["resA"].URN}, resources["resC"].Dependencies)
+}
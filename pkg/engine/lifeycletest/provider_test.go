@@ -842,3 +842,143 @@ func TestProviderVersionInputAndOption(t *testing.T) {
 
 	assert.Equal(t, "1.0.0", version)
 }
+
+func newResourceDefaultsProvider(t *testing.T, created map[resource.URN]resource.PropertyMap,
+	timeouts map[resource.URN]float64) *deploytest.ProviderLoader {
+
+	const schema = `{
+		"name": "pkgA",
+		"resources": {
+			"pkgA:m:typA": {
+				"inputProperties": {
+					"tags": {"type": "object", "additionalProperties": {"type": "string"}},
+					"name": {"type": "string"}
+				}
+			}
+		}
+	}`
+
+	return deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+		return &deploytest.Provider{
+			GetSchemaF: func(version int) ([]byte, error) {
+				return []byte(schema), nil
+			},
+			CheckConfigF: func(urn resource.URN, olds, news resource.PropertyMap,
+				allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+				_, has := news[providers.ResourceDefaultsKey]
+				assert.False(t, has)
+				return news, nil, nil
+			},
+			ConfigureF: func(news resource.PropertyMap) error {
+				_, has := news[providers.ResourceDefaultsKey]
+				assert.False(t, has)
+				return nil
+			},
+			CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+				preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+				created[urn], timeouts[urn] = news, timeout
+				return "created-id", news, resource.StatusOK, nil
+			},
+		}, nil
+	})
+}
+
+func TestExplicitProviderResourceDefaults(t *testing.T) {
+	created, timeouts := map[resource.URN]resource.PropertyMap{}, map[resource.URN]float64{}
+	loaders := []*deploytest.ProviderLoader{newResourceDefaultsProvider(t, created, timeouts)}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		provURN, provID, _, err := monitor.RegisterResource(providers.MakeProviderType("pkgA"), "provA", true,
+			deploytest.ResourceOptions{
+				Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+					"region": "us-west-2",
+					"resourceDefaults": map[string]interface{}{
+						"properties": map[string]interface{}{
+							"tags":    map[string]interface{}{"team": "platform", "env": "dev"},
+							"name":    "default-name",
+							"unknown": "not-in-schema",
+						},
+						"customTimeouts": map[string]interface{}{"create": "2m"},
+						"ignoreChanges":  []interface{}{"name"},
+					},
+				}),
+			})
+		assert.NoError(t, err)
+
+		if provID == "" {
+			provID = providers.UnknownID
+		}
+		provRef, err := providers.NewReference(provURN, provID)
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Provider: provRef.String(),
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+				"tags": map[string]interface{}{"env": "prod"},
+			}),
+		})
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typB", "resB", true, deploytest.ResourceOptions{
+			Provider: provRef.String(),
+		})
+		assert.NoError(t, err)
+
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	// Map-typed properties are merged, other properties are defaulted, and properties that the resource's type does
+	// not declare are skipped.
+	resA, resB := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typB", "resB", "")
+	assert.Equal(t, resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags": map[string]interface{}{"team": "platform", "env": "prod"},
+		"name": "default-name",
+	}), created[resA])
+	assert.Equal(t, resource.PropertyMap{}, created[resB])
+	assert.Equal(t, float64(120), timeouts[resA])
+	assert.Equal(t, float64(120), timeouts[resB])
+
+	// The defaults are recorded in the provider's state.
+	for _, res := range snap.Resources {
+		if providers.IsProviderType(res.Type) {
+			_, has := res.Inputs[providers.ResourceDefaultsKey]
+			assert.True(t, has)
+		}
+	}
+}
+
+func TestDefaultProviderResourceDefaultsFromConfig(t *testing.T) {
+	created, timeouts := map[resource.URN]resource.PropertyMap{}, map[resource.URN]float64{}
+	loaders := []*deploytest.ProviderLoader{newResourceDefaultsProvider(t, created, timeouts)}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Config: config.Map{
+			config.MustMakeKey("pkgA", "resourceDefaults"): config.NewObjectValue(
+				`{"properties":{"tags":{"team":"platform"}}}`),
+		},
+	}
+	project := p.GetProject()
+	_, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+	assert.Equal(t, resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags": map[string]interface{}{"team": "platform"},
+	}), created[resA])
+}
@@ -1787,6 +1787,77 @@ func TestSingleComponentDefaultProviderLifecycle(t *testing.T) {
 	p.Run(t, nil)
 }
 
+func TestSingleComponentMethodDefaultProviderLifecycle(t *testing.T) {
+	urnA := resource.URN("urn:pulumi:test::test::pkgA:m:typA::resA")
+	urnB := resource.URN("urn:pulumi:test::test::pkgA:m:typB::resB")
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			call := func(monitor *deploytest.ResourceMonitor, tok tokens.ModuleMember, args resource.PropertyMap,
+				info plugin.CallInfo, options plugin.CallOptions) (plugin.CallResult, error) {
+
+				assert.Equal(t, tokens.ModuleMember("pkgA:m:typA/getValue"), tok)
+				assert.Equal(t, resource.PropertyMap{
+					"__self__": resource.MakeComponentResourceReference(urnA, ""),
+					"arg":      resource.NewStringProperty("bar"),
+				}, args)
+				assert.Equal(t, map[resource.PropertyKey][]resource.URN{
+					"arg": {urnB},
+				}, options.ArgDependencies)
+				assert.Equal(t, "test", info.Project)
+				assert.Equal(t, "test", info.Stack)
+				assert.NotEmpty(t, info.MonitorAddress)
+
+				return plugin.CallResult{
+					Return: resource.PropertyMap{
+						"value": resource.NewStringProperty("foo" + args["arg"].StringValue()),
+					},
+					ReturnDependencies: map[resource.PropertyKey][]resource.URN{
+						"value": {urnA, urnB},
+					},
+				}, nil
+			}
+
+			return &deploytest.Provider{
+				CallF: call,
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urn, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", false)
+		assert.NoError(t, err)
+		assert.Equal(t, urnA, urn)
+
+		urn, _, _, err = monitor.RegisterResource("pkgA:m:typB", "resB", true)
+		assert.NoError(t, err)
+		assert.Equal(t, urnB, urn)
+
+		ret, retDeps, failures, err := monitor.Call("pkgA:m:typA/getValue", resource.PropertyMap{
+			"__self__": resource.MakeComponentResourceReference(urnA, ""),
+			"arg":      resource.NewStringProperty("bar"),
+		}, map[resource.PropertyKey][]resource.URN{
+			"arg": {urnB},
+		}, "", "")
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+		assert.Equal(t, resource.PropertyMap{
+			"value": resource.NewStringProperty("foobar"),
+		}, ret)
+		assert.Equal(t, map[resource.PropertyKey][]resource.URN{
+			"value": {urnA, urnB},
+		}, retDeps)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps:   MakeBasicLifecycleSteps(t, 3),
+	}
+	p.Run(t, nil)
+}
+
 type updateContext struct {
 	*deploytest.ResourceMonitor
 
@@ -0,0 +1,223 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// pluginLock enforces and maintains a project's plugin lock file. A nil *pluginLock enforces nothing.
+type pluginLock struct {
+	path   string                // the path of the project's lock file.
+	lock   *workspace.PluginLock // the lock loaded from path, or nil if the project has no lock file yet.
+	update bool                  // true if the lock should be updated to match the deployment rather than enforced.
+}
+
+// loadPluginLock loads the plugin lock file for the project rooted at the given directory. If root is empty, there
+// is no project directory in which to keep a lock file and loadPluginLock returns nil.
+func loadPluginLock(root string, update bool) (*pluginLock, error) {
+	if root == "" {
+		return nil, nil
+	}
+	path := filepath.Join(root, workspace.PluginLockFile)
+	lock, err := workspace.LoadPluginLock(path)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginLock{path: path, lock: lock, update: update}, nil
+}
+
+// mismatchError returns an error describing a plugin whose version or checksum does not match the lock file.
+func (l *pluginLock) mismatchError(plug workspace.PluginInfo, detail string) error {
+	return errors.Errorf("%s plugin %s %s; rerun with --update-lock to update %s",
+		plug.Kind, plug.Name, detail, l.path)
+}
+
+// pin pins the versions of the given plugins to the versions recorded in the lock file. Plugins that were not
+// given a version are assigned the locked version, and plugins whose versions differ from the locked version produce
+// an error unless the lock is being updated. Pinned plugins are also assigned their locked checksums, which are
+// verified if the plugins need to be downloaded.
+func (l *pluginLock) pin(plugins pluginSet) (pluginSet, error) {
+	if l == nil || l.lock == nil || l.update {
+		return plugins, nil
+	}
+
+	pinned := newPluginSet()
+	for _, plug := range plugins.Values() {
+		if locked, ok := l.lock.Get(plug.Kind, plug.Name); ok && plug.Kind == workspace.ResourcePlugin {
+			lockedInfo, err := locked.PluginInfo()
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case plug.Version == nil:
+				logging.V(preparePluginLog).Infof("pluginLock.pin(): pinning %s to locked version %s",
+					plug.Name, lockedInfo.Version)
+				plug.Version = lockedInfo.Version
+				if plug.ServerURL == "" {
+					plug.ServerURL = lockedInfo.ServerURL
+				}
+			case !plug.Version.EQ(*lockedInfo.Version):
+				return nil, l.mismatchError(plug, fmt.Sprintf("v%s is required by the program, but v%s is locked",
+					plug.Version, lockedInfo.Version))
+			}
+			plug.Checksum = locked.Checksum
+		}
+		pinned.Add(plug)
+	}
+	return pinned, nil
+}
+
+// pinDefaultProviders assigns the locked version to each default provider that would otherwise use the newest
+// installed version of its plugin.
+func (l *pluginLock) pinDefaultProviders(defaultProviderVersions map[tokens.Package]*semver.Version) error {
+	if l == nil || l.lock == nil || l.update {
+		return nil
+	}
+	for pkg, version := range defaultProviderVersions {
+		if version != nil {
+			continue
+		}
+		if locked, ok := l.lock.Get(workspace.ResourcePlugin, pkg.String()); ok {
+			info, err := locked.PluginInfo()
+			if err != nil {
+				return err
+			}
+			defaultProviderVersions[pkg] = info.Version
+		}
+	}
+	return nil
+}
+
+// verify checks that each installed plugin that is used by a default provider at its locked version matches the
+// checksum recorded in the lock file.
+func (l *pluginLock) verify(defaultProviderVersions map[tokens.Package]*semver.Version) error {
+	if l == nil || l.lock == nil || l.update {
+		return nil
+	}
+	for pkg, version := range defaultProviderVersions {
+		locked, ok := l.lock.Get(workspace.ResourcePlugin, pkg.String())
+		if !ok || locked.Checksum == "" {
+			continue
+		}
+		info, err := locked.PluginInfo()
+		if err != nil {
+			return err
+		}
+		if version == nil || !version.EQ(*info.Version) {
+			continue
+		}
+
+		checksum, err := workspace.PluginChecksum(info)
+		if err != nil {
+			if os.IsNotExist(errors.Cause(err)) {
+				// The plugin is not installed in the plugin cache (e.g. it is on the $PATH), so there is nothing to
+				// verify. If it is missing entirely, loading it will fail with a more useful error.
+				continue
+			}
+			return err
+		}
+		if checksum != locked.Checksum {
+			return l.mismatchError(info, fmt.Sprintf("v%s has checksum %s, but %s is locked",
+				info.Version, checksum, locked.Checksum))
+		}
+	}
+	return nil
+}
+
+// save records the plugins used by the given default providers in the lock file, if they differ from those already
+// recorded. Download URLs are taken from the given plugins, or from the existing lock file.
+func (l *pluginLock) save(plugins pluginSet, defaultProviderVersions map[tokens.Package]*semver.Version) error {
+	if l == nil {
+		return nil
+	}
+
+	newLock := &workspace.PluginLock{}
+	for pkg, version := range defaultProviderVersions {
+		info := workspace.PluginInfo{Name: pkg.String(), Kind: workspace.ResourcePlugin, Version: version}
+		if info.Version == nil {
+			// The newest installed version of the plugin will be used, so that is the version to lock.
+			newest, err := newestInstalledPlugin(info.Kind, info.Name)
+			if err != nil {
+				return err
+			}
+			if newest == nil {
+				logging.V(preparePluginLog).Infof("pluginLock.save(): skipping %s, no installed version", pkg)
+				continue
+			}
+			info.Version = newest.Version
+		}
+
+		for _, plug := range plugins.Values() {
+			if plug.Kind == info.Kind && plug.Name == info.Name && plug.Version != nil &&
+				plug.Version.EQ(*info.Version) && plug.ServerURL != "" {
+				info.ServerURL = plug.ServerURL
+			}
+		}
+		if locked, ok := l.lock.Get(info.Kind, info.Name); ok && info.ServerURL == "" &&
+			locked.Version == info.Version.String() {
+			info.ServerURL = locked.ServerURL
+		}
+
+		checksum, err := workspace.PluginChecksum(info)
+		if err != nil && !os.IsNotExist(errors.Cause(err)) {
+			return err
+		}
+
+		newLock.Set(workspace.LockedPlugin{
+			Name:      info.Name,
+			Kind:      info.Kind,
+			Version:   info.Version.String(),
+			Checksum:  checksum,
+			ServerURL: info.ServerURL,
+		})
+	}
+
+	if l.lock == nil && len(newLock.Plugins) == 0 || l.lock != nil && reflect.DeepEqual(l.lock, newLock) {
+		return nil
+	}
+	logging.V(preparePluginLog).Infof("pluginLock.save(): writing %s", l.path)
+	if err := newLock.Save(l.path); err != nil {
+		return errors.Wrapf(err, "saving plugin lock file %s", l.path)
+	}
+	l.lock = newLock
+	return nil
+}
+
+// newestInstalledPlugin returns the newest installed version of the given plugin, or nil if none is installed.
+func newestInstalledPlugin(kind workspace.PluginKind, name string) (*workspace.PluginInfo, error) {
+	plugins, err := workspace.GetPlugins()
+	if err != nil {
+		return nil, err
+	}
+	var newest *workspace.PluginInfo
+	for i, plug := range plugins {
+		if plug.Kind == kind && plug.Name == name && plug.Version != nil &&
+			(newest == nil || plug.Version.GT(*newest.Version)) {
+			newest = &plugins[i]
+		}
+	}
+	return newest, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newTestPluginLock(update bool) *pluginLock {
+	lock := &workspace.PluginLock{}
+	lock.Set(workspace.LockedPlugin{
+		Name:      "aws",
+		Kind:      workspace.ResourcePlugin,
+		Version:   "3.0.0",
+		Checksum:  "sha256:abc",
+		ServerURL: "https://example.com/aws",
+	})
+	return &pluginLock{path: workspace.PluginLockFile, lock: lock, update: update}
+}
+
+func TestPluginLockPin(t *testing.T) {
+	plugins := newPluginSet()
+	plugins.Add(workspace.PluginInfo{Name: "aws", Kind: workspace.ResourcePlugin})
+	plugins.Add(workspace.PluginInfo{Name: "gcp", Kind: workspace.ResourcePlugin})
+	plugins.Add(workspace.PluginInfo{Name: "nodejs", Kind: workspace.LanguagePlugin})
+
+	pinned, err := newTestPluginLock(false).pin(plugins)
+	assert.NoError(t, err)
+	assert.Len(t, pinned, 3)
+	for _, plug := range pinned.Values() {
+		if plug.Name == "aws" {
+			assert.Equal(t, mustMakeVersion("3.0.0"), plug.Version)
+			assert.Equal(t, "https://example.com/aws", plug.ServerURL)
+			assert.Equal(t, "sha256:abc", plug.Checksum)
+		} else {
+			assert.Nil(t, plug.Version)
+		}
+	}
+}
+
+func TestPluginLockPinMismatch(t *testing.T) {
+	plugins := newPluginSet()
+	plugins.Add(workspace.PluginInfo{Name: "aws", Kind: workspace.ResourcePlugin, Version: mustMakeVersion("3.1.0")})
+
+	_, err := newTestPluginLock(false).pin(plugins)
+	assert.Error(t, err)
+
+	// When the lock is being updated, the program's version wins.
+	pinned, err := newTestPluginLock(true).pin(plugins)
+	assert.NoError(t, err)
+	assert.Equal(t, plugins, pinned)
+
+	// A missing lock enforces nothing.
+	var lock *pluginLock
+	pinned, err = lock.pin(plugins)
+	assert.NoError(t, err)
+	assert.Equal(t, plugins, pinned)
+}
+
+func TestPluginLockPinDefaultProviders(t *testing.T) {
+	versions := map[tokens.Package]*semver.Version{
+		"aws": nil,
+		"gcp": nil,
+	}
+	assert.NoError(t, newTestPluginLock(false).pinDefaultProviders(versions))
+	assert.Equal(t, mustMakeVersion("3.0.0"), versions["aws"])
+	assert.Nil(t, versions["gcp"])
+}
@@ -16,7 +16,9 @@ package engine
 
 import (
 	"fmt"
+	"os"
 	"sort"
+	"strconv"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -126,12 +128,32 @@ func gatherPluginsFromSnapshot(plugctx *plugin.Context, target *deploy.Target) (
 	return set, nil
 }
 
+// PluginInstallConcurrencyEnvVar is the environment variable that overrides the number of plugins that are
+// downloaded and installed at once.
+const PluginInstallConcurrencyEnvVar = "PULUMI_PLUGIN_INSTALL_CONCURRENCY"
+
+// defaultPluginInstallConcurrency is the number of plugins that are downloaded and installed at once by default.
+const defaultPluginInstallConcurrency = 4
+
+// PluginInstallConcurrency returns the number of plugins to download and install at once: the value of the
+// PULUMI_PLUGIN_INSTALL_CONCURRENCY environment variable if it is a positive integer, or four otherwise.
+func PluginInstallConcurrency() int {
+	if v := os.Getenv(PluginInstallConcurrencyEnvVar); v != "" {
+		n, err := strconv.Atoi(v)
+		if err == nil && n > 0 {
+			return n
+		}
+		logging.V(preparePluginLog).Infof("ignoring invalid %s=%q", PluginInstallConcurrencyEnvVar, v)
+	}
+	return defaultPluginInstallConcurrency
+}
+
 // ensurePluginsAreInstalled inspects all plugins in the plugin set and, if any plugins are not currently installed,
 // uses the given backend client to install them. Installations are processed in parallel, though
 // ensurePluginsAreInstalled does not return until all installations are completed.
 func ensurePluginsAreInstalled(plugins pluginSet) error {
 	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): beginning")
-	var installTasks errgroup.Group
+	var installs []workspace.PluginInfo
 	for _, plug := range plugins.Values() {
 		_, path, err := workspace.GetPluginPath(plug.Kind, plug.Name, plug.Version)
 		if err == nil && path != "" {
@@ -140,18 +162,44 @@ func ensurePluginsAreInstalled(plugins pluginSet) error {
 			continue
 		}
 
+		logging.V(preparePluginLog).Infof(
+			"ensurePluginsAreInstalled(): plugin %s %s not installed, doing install", plug.Name, plug.Version)
+		installs = append(installs, plug)
+	}
+
+	err := InstallPlugins(installs, PluginInstallConcurrency())
+	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): completed")
+	return err
+}
+
+// InstallPlugins downloads and installs the given plugins, at most concurrency at a time. If the output is a
+// terminal, the progress of the downloads is displayed together. InstallPlugins does not return until all
+// installations are completed, and returns the first error encountered, if any. Concurrent installs of the same
+// plugin, whether by this process or another, are serialized by the plugin cache's file locks.
+func InstallPlugins(plugins []workspace.PluginInfo, concurrency int) error {
+	contract.Requiref(concurrency > 0, "concurrency", "must be positive")
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	var progress *workspace.ProgressBarPool
+	if cmdutil.InteractiveTerminal() {
+		progress = workspace.NewProgressBarPool(os.Stdout, cmdutil.GetGlobalColorization())
+		defer contract.IgnoreClose(progress)
+	}
+
+	var installTasks errgroup.Group
+	sem := make(chan struct{}, concurrency)
+	for _, plug := range plugins {
 		// Launch an install task asynchronously and add it to the current error group.
 		info := plug // don't close over the loop induction variable
 		installTasks.Go(func() error {
-			logging.V(preparePluginLog).Infof(
-				"ensurePluginsAreInstalled(): plugin %s %s not installed, doing install", info.Name, info.Version)
-			return installPlugin(info)
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			return installPlugin(info, progress)
 		})
 	}
-
-	err := installTasks.Wait()
-	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): completed")
-	return err
+	return installTasks.Wait()
 }
 
 // ensurePluginsAreLoaded ensures that all of the plugins in the given plugin set that match the given plugin flags are
@@ -160,8 +208,10 @@ func ensurePluginsAreLoaded(plugctx *plugin.Context, plugins pluginSet, kinds pl
 	return plugctx.Host.EnsurePlugins(plugins.Values(), kinds)
 }
 
-// installPlugin installs a plugin from the given backend client.
-func installPlugin(plugin workspace.PluginInfo) error {
+// installPlugin installs a plugin from the given backend client, displaying the progress of its download in the given
+// progress bar pool. If the pool is nil or the size of the download is unknown, installPlugin prints a line when the
+// install begins and ends instead, through the pool if there is one so that the line is not drawn over its bars.
+func installPlugin(plugin workspace.PluginInfo, progress *workspace.ProgressBarPool) error {
 	logging.V(preparePluginLog).Infof("installPlugin(%s, %s): beginning install", plugin.Name, plugin.Version)
 	if plugin.Kind == workspace.LanguagePlugin {
 		logging.V(preparePluginLog).Infof(
@@ -170,14 +220,23 @@ func installPlugin(plugin workspace.PluginInfo) error {
 	}
 
 	logging.V(preparePluginVerboseLog).Infof(
-		"installPlugin(%s, %s): initiating download", plugin.Name, plugin.Version)
-	stream, size, err := plugin.Download()
+		"installPlugin(%s, %s): initiating download (sources: %v)", plugin.Name, plugin.Version,
+		workspace.GetPluginSources())
+	stream, size, warnings, err := plugin.DownloadWithWarnings()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("[%s plugin %s-%s] installing\n", plugin.Kind, plugin.Name, plugin.Version)
-	stream = workspace.ReadCloserProgressBar(stream, size, "Downloading plugin", cmdutil.GetGlobalColorization())
+	label := fmt.Sprintf("[%s plugin %s-%s]", plugin.Kind, plugin.Name, plugin.Version)
+	for _, warning := range warnings {
+		printInstallWarning(progress, "%s warning: %s", label, warning)
+	}
+	showBar := progress != nil && size != -1
+	if showBar {
+		stream = progress.ReadCloser(stream, size, label+" downloading")
+	} else {
+		printInstallStatus(progress, "%s installing", label)
+	}
 
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): extracting tarball to installation directory", plugin.Name, plugin.Version)
@@ -186,10 +245,33 @@ func installPlugin(plugin workspace.PluginInfo) error {
 			plugin.Kind, plugin.Name, plugin.Version)
 	}
 
+	if !showBar {
+		printInstallStatus(progress, "%s installed", label)
+	}
 	logging.V(7).Infof("installPlugin(%s, %s): successfully installed", plugin.Name, plugin.Version)
 	return nil
 }
 
+// printInstallStatus prints a line describing the status of a plugin install, above the bars of the given progress
+// bar pool if it is non-nil or to stdout otherwise.
+func printInstallStatus(progress *workspace.ProgressBarPool, format string, args ...interface{}) {
+	if progress != nil {
+		progress.Printf(format, args...)
+	} else {
+		fmt.Printf(format+"\n", args...)
+	}
+}
+
+// printInstallWarning prints a warning about a plugin install, above the bars of the given progress bar pool if it is
+// non-nil or to stderr otherwise.
+func printInstallWarning(progress *workspace.ProgressBarPool, format string, args ...interface{}) {
+	if progress != nil {
+		progress.Printf(format, args...)
+	} else {
+		fmt.Fprintf(os.Stderr, format+"\n", args...)
+	}
+}
+
 // computeDefaultProviderPlugins computes, for every resource plugin, a mapping from packages to semver versions
 // reflecting the version of a provider that should be used as the "default" resource when registering resources. This
 // function takes two sets of plugins: a set of plugins given to us from the language host and the full set of plugins.
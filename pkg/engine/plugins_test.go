@@ -15,6 +15,17 @@
 package engine
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/blang/semver"
@@ -124,3 +135,105 @@ func TestDefaultProvidersSnapshotOverrides(t *testing.T) {
 	assert.NotNil(t, awsVer)
 	assert.Equal(t, "0.17.0", awsVer.String())
 }
+
+func TestInstallPluginsBoundsConcurrency(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("plugin downloads are only supported on amd64")
+	}
+
+	home, err := ioutil.TempDir("", "pulumi-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(workspace.PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, home))
+	defer os.Setenv(workspace.PulumiHomeEnvVar, oldHome)
+
+	// Serve a tarball for any plugin, tracking the number of concurrent downloads. Each download announces itself on
+	// started and then waits for the test to release it.
+	const concurrency = 2
+	started, release := make(chan struct{}), make(chan struct{})
+	var m sync.Mutex
+	active, maxActive := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ".tar.gz") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		m.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		m.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		m.Lock()
+		active--
+		m.Unlock()
+
+		var buffer bytes.Buffer
+		gw := gzip.NewWriter(&buffer)
+		writer := tar.NewWriter(gw)
+		name := strings.Split(r.URL.Path[1:], "-v")[0]
+		assert.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0700}))
+		assert.NoError(t, writer.Close())
+		assert.NoError(t, gw.Close())
+		_, err := w.Write(buffer.Bytes())
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	var plugins []workspace.PluginInfo
+	for i := 0; i < 6; i++ {
+		plugins = append(plugins, workspace.PluginInfo{
+			Name:      fmt.Sprintf("test%d", i),
+			Kind:      workspace.ResourcePlugin,
+			Version:   mustMakeVersion("1.0.0"),
+			ServerURL: server.URL,
+		})
+	}
+	errs := make(chan error)
+	go func() { errs <- InstallPlugins(plugins, concurrency) }()
+
+	// Wait for the first batch of downloads to start, then release the downloads one at a time. Each release frees a
+	// slot for the next download, so at most concurrency downloads are ever in flight.
+	for i := 0; i < concurrency; i++ {
+		<-started
+	}
+	for i := range plugins {
+		release <- struct{}{}
+		if i+concurrency < len(plugins) {
+			<-started
+		}
+	}
+	assert.NoError(t, <-errs)
+
+	assert.Equal(t, concurrency, maxActive)
+	for _, plug := range plugins {
+		assert.True(t, workspace.HasPlugin(plug))
+	}
+}
+
+func TestPluginInstallConcurrency(t *testing.T) {
+	old, set := os.LookupEnv(PluginInstallConcurrencyEnvVar)
+	defer func() {
+		if set {
+			os.Setenv(PluginInstallConcurrencyEnvVar, old)
+		} else {
+			os.Unsetenv(PluginInstallConcurrencyEnvVar)
+		}
+	}()
+
+	assert.NoError(t, os.Unsetenv(PluginInstallConcurrencyEnvVar))
+	assert.Equal(t, 4, PluginInstallConcurrency())
+
+	assert.NoError(t, os.Setenv(PluginInstallConcurrencyEnvVar, "8"))
+	assert.Equal(t, 8, PluginInstallConcurrency())
+
+	for _, invalid := range []string{"0", "-1", "many"} {
+		assert.NoError(t, os.Setenv(PluginInstallConcurrencyEnvVar, invalid))
+		assert.Equal(t, 4, PluginInstallConcurrency())
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+)
+
+// AnalyzeSnapshot runs the given local Policy Packs against the resources in an existing snapshot. Neither the
+// program nor any resource providers are run: resource policies are evaluated against each resource's last known
+// inputs and stack policies against the resources' last known outputs. Secret values are masked before they are sent
+// to the Policy Packs. The Policy Packs are loaded using the given plugin context's host, and the names of the given
+// local Policy Packs are updated once the packs have been loaded.
+func AnalyzeSnapshot(plugctx *plugin.Context, snap *deploy.Snapshot, localPolicyPacks []LocalPolicyPack,
+	opts *plugin.PolicyAnalyzerOptions) ([]plugin.AnalyzeDiagnostic, error) {
+
+	if err := installAndLoadPolicyPlugins(plugctx, plugctx.Diag, nil, localPolicyPacks, opts); err != nil {
+		return nil, err
+	}
+
+	var states []*resource.State
+	if snap != nil {
+		for _, s := range snap.Resources {
+			// Resources that are pending deletion are no longer part of the stack.
+			if !s.Delete {
+				states = append(states, s)
+			}
+		}
+	}
+
+	byURN := make(map[resource.URN]*resource.State, len(states))
+	for _, s := range states {
+		byURN[s.URN] = s
+	}
+
+	resources := make([]plugin.AnalyzerStackResource, len(states))
+	for i, s := range states {
+		r := plugin.AnalyzerResource{
+			URN:        s.URN,
+			Type:       s.Type,
+			Name:       s.URN.Name(),
+			Properties: maskSecrets(s.Inputs),
+			Options: plugin.AnalyzerResourceOptions{
+				Protect:                 s.Protect,
+				AdditionalSecretOutputs: s.AdditionalSecretOutputs,
+				Aliases:                 s.Aliases,
+				CustomTimeouts:          s.CustomTimeouts,
+			},
+		}
+		if s.Provider != "" {
+			ref, err := providers.ParseReference(s.Provider)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing provider reference for %s", s.URN)
+			}
+			if p, ok := byURN[ref.URN()]; ok {
+				r.Provider = &plugin.AnalyzerProviderResource{
+					URN:        p.URN,
+					Type:       p.Type,
+					Name:       p.URN.Name(),
+					Properties: maskSecrets(p.Inputs),
+				}
+			}
+		}
+
+		resources[i] = plugin.AnalyzerStackResource{
+			AnalyzerResource:     r,
+			Parent:               s.Parent,
+			Dependencies:         s.Dependencies,
+			PropertyDependencies: s.PropertyDependencies,
+		}
+	}
+
+	var diagnostics []plugin.AnalyzeDiagnostic
+	for _, analyzer := range plugctx.Host.ListAnalyzers() {
+		for _, r := range resources {
+			diags, err := analyzer.Analyze(r.AnalyzerResource)
+			if err != nil {
+				return nil, errors.Wrapf(err, "analyzing %s", r.URN)
+			}
+			for _, d := range diags {
+				if d.URN == "" {
+					d.URN = r.URN
+				}
+				diagnostics = append(diagnostics, d)
+			}
+		}
+
+		// Unlike Analyze, AnalyzeStack is called on the outputs of each resource.
+		stackResources := make([]plugin.AnalyzerStackResource, len(resources))
+		for i, r := range resources {
+			r.Properties = maskSecrets(byURN[r.URN].Outputs)
+			stackResources[i] = r
+		}
+		diags, err := analyzer.AnalyzeStack(stackResources)
+		if err != nil {
+			return nil, errors.Wrap(err, "analyzing stack")
+		}
+		diagnostics = append(diagnostics, diags...)
+	}
+
+	return diagnostics, nil
+}
+
+// maskSecrets returns a copy of the given property map with the value of each secret replaced by "[secret]". The
+// values remain marked as secrets so that policies can still tell that they are present.
+func maskSecrets(props resource.PropertyMap) resource.PropertyMap {
+	masked := make(resource.PropertyMap, len(props))
+	for k, v := range props {
+		masked[k] = maskSecretValue(v)
+	}
+	return masked
+}
+
+func maskSecretValue(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsArray():
+		elems := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			elems[i] = maskSecretValue(e)
+		}
+		return resource.NewArrayProperty(elems)
+	case v.IsObject():
+		return resource.NewObjectProperty(maskSecrets(v.ObjectValue()))
+	case v.IsSecret():
+		return resource.MakeSecret(resource.NewStringProperty("[secret]"))
+	default:
+		return v
+	}
+}
@@ -137,6 +137,13 @@ type UpdateOptions struct {
 	// true if the engine should disable resource reference support.
 	DisableResourceReferences bool
 
+	// true if the project's plugin lock file should be updated to match the plugins used by the update rather than
+	// enforced.
+	UpdateLock bool
+
+	// an optional function that computes the dependencies between the resources of an import.
+	ImportDependencies deploy.ImportDependenciesFunc
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -191,13 +198,13 @@ func Update(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (Resour
 // RunInstallPlugins calls installPlugins and just returns the error (avoids having to export pluginSet).
 func RunInstallPlugins(
 	proj *workspace.Project, pwd, main string, target *deploy.Target, plugctx *plugin.Context) error {
-	_, _, err := installPlugins(proj, pwd, main, target, plugctx, true /*returnInstallErrors*/)
+	_, _, err := installPlugins(proj, pwd, main, target, plugctx, true /*returnInstallErrors*/, nil /*lock*/)
 	return err
 }
 
 func installPlugins(
-	proj *workspace.Project, pwd, main string, target *deploy.Target,
-	plugctx *plugin.Context, returnInstallErrors bool) (pluginSet, map[tokens.Package]*semver.Version, error) {
+	proj *workspace.Project, pwd, main string, target *deploy.Target, plugctx *plugin.Context,
+	returnInstallErrors bool, lock *pluginLock) (pluginSet, map[tokens.Package]*semver.Version, error) {
 
 	// Before launching the source, ensure that we have all of the plugins that we need in order to proceed.
 	//
@@ -224,6 +231,12 @@ func installPlugins(
 		return nil, nil, err
 	}
 
+	// Pin the plugins required by the program to the versions recorded in the project's plugin lock, if any.
+	languagePlugins, err = lock.pin(languagePlugins)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	allPlugins := languagePlugins.Union(snapshotPlugins)
 
 	// If there are any plugins that are not available, we can attempt to install them here.
@@ -238,8 +251,15 @@ func installPlugins(
 		logging.V(7).Infof("newUpdateSource(): failed to install missing plugins: %v", err)
 	}
 
-	// Collect the version information for default providers.
+	// Collect the version information for default providers, and make sure that the plugins they use match the
+	// plugin lock.
 	defaultProviderVersions := computeDefaultProviderPlugins(languagePlugins, allPlugins)
+	if err := lock.pinDefaultProviders(defaultProviderVersions); err != nil {
+		return nil, nil, err
+	}
+	if err := lock.verify(defaultProviderVersions); err != nil {
+		return nil, nil, err
+	}
 
 	return allPlugins, defaultProviderVersions, nil
 }
@@ -362,11 +382,18 @@ func newUpdateSource(
 	//
 
 	allPlugins, defaultProviderVersions, err := installPlugins(proj, pwd, main, target,
-		plugctx, false /*returnInstallErrors*/)
+		plugctx, false /*returnInstallErrors*/, opts.pluginLock)
 	if err != nil {
 		return nil, err
 	}
 
+	// Record the plugins used by the update in the project's plugin lock.
+	if !dryRun && !opts.isRefresh && !opts.isImport {
+		if err := opts.pluginLock.save(allPlugins, defaultProviderVersions); err != nil {
+			return nil, err
+		}
+	}
+
 	// Once we've installed all of the plugins we need, make sure that all analyzers and language plugins are
 	// loaded up and ready to go. Provider plugins are loaded lazily by the provider registry and thus don't
 	// need to be loaded here.
@@ -618,6 +645,11 @@ func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeD
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
 
+func (acts *updateActions) OnPolicyRemediation(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap) {
+	acts.Opts.Events.policyRemediationEvent(urn, t, before, after)
+}
+
 func (acts *updateActions) MaybeCorrupt() bool {
 	return acts.maybeCorrupt
 }
@@ -735,6 +767,11 @@ func (acts *previewActions) OnPolicyViolation(urn resource.URN, d plugin.Analyze
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
 
+func (acts *previewActions) OnPolicyRemediation(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap) {
+	acts.Opts.Events.policyRemediationEvent(urn, t, before, after)
+}
+
 func (acts *previewActions) MaybeCorrupt() bool {
 	return false
 }
@@ -13,6 +13,7 @@ require (
 	cloud.google.com/go/storage v1.9.0
 	github.com/Azure/go-ansiterm v0.0.0-20170929234023-d6e3b3328b78 // indirect
 	github.com/Sirupsen/logrus v1.0.5 // indirect
+	github.com/agext/levenshtein v1.2.1
 	github.com/aws/aws-sdk-go v1.31.13
 	github.com/blang/semver v3.5.1+incompatible
 	github.com/djherbis/times v1.2.0
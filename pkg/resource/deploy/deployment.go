@@ -61,6 +61,10 @@ type Options struct {
 	TrustDependencies         bool           // whether or not to trust the resource dependency graph.
 	UseLegacyDiff             bool           // whether or not to use legacy diffing behavior.
 	DisableResourceReferences bool           // true to disable resource reference support.
+
+	// ImportDependencies, if set, computes the dependencies between the resources of an import deployment. It is
+	// called with the states of the resources to import, as read from their providers, before they are imported.
+	ImportDependencies ImportDependenciesFunc
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -87,6 +91,7 @@ type StepExecutorEvents interface {
 // PolicyEvents is an interface that can be used to hook policy events.
 type PolicyEvents interface {
 	OnPolicyViolation(resource.URN, plugin.AnalyzeDiagnostic)
+	OnPolicyRemediation(resource.URN, plugin.Remediation, resource.PropertyMap, resource.PropertyMap)
 }
 
 // Events is an interface that can be used to hook interesting engine events.
@@ -443,9 +443,10 @@ func (ex *deploymentExecutor) importResources(callerCtx context.Context, opts Op
 	stepExec := newStepExecutor(ctx, cancel, ex.deployment, opts, preview, true)
 
 	importer := &importer{
-		deployment: ex.deployment,
-		executor:   stepExec,
-		preview:    preview,
+		deployment:   ex.deployment,
+		executor:     stepExec,
+		preview:      preview,
+		dependencies: opts.ImportDependencies,
 	}
 	res := importer.importResources(ctx)
 	stepExec.SignalCompletion()
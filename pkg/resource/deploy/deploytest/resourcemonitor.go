@@ -304,6 +304,68 @@ func (rm *ResourceMonitor) Invoke(tok tokens.ModuleMember, inputs resource.Prope
 	return outs, nil, nil
 }
 
+func (rm *ResourceMonitor) Call(tok tokens.ModuleMember, args resource.PropertyMap,
+	argDependencies map[resource.PropertyKey][]resource.URN, provider string,
+	version string) (resource.PropertyMap, map[resource.PropertyKey][]resource.URN, []*pulumirpc.CheckFailure, error) {
+
+	// marshal args
+	margs, err := plugin.MarshalProperties(args, plugin.MarshalOptions{
+		KeepUnknowns:  true,
+		KeepResources: true,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// marshal arg dependencies
+	argDeps := map[string]*pulumirpc.CallRequest_ArgumentDependencies{}
+	for name, deps := range argDependencies {
+		urns := make([]string, len(deps))
+		for i, urn := range deps {
+			urns[i] = string(urn)
+		}
+		argDeps[string(name)] = &pulumirpc.CallRequest_ArgumentDependencies{Urns: urns}
+	}
+
+	// submit request
+	resp, err := rm.resmon.Call(context.Background(), &pulumirpc.CallRequest{
+		Tok:             string(tok),
+		Args:            margs,
+		ArgDependencies: argDeps,
+		Provider:        provider,
+		Version:         version,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// handle failures
+	if len(resp.Failures) != 0 {
+		return nil, nil, resp.Failures, nil
+	}
+
+	// unmarshal return
+	ret, err := plugin.UnmarshalProperties(resp.Return, plugin.MarshalOptions{
+		KeepUnknowns:  true,
+		KeepResources: true,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// unmarshal return dependencies
+	retDeps := map[resource.PropertyKey][]resource.URN{}
+	for name, deps := range resp.ReturnDependencies {
+		urns := make([]resource.URN, len(deps.Urns))
+		for i, urn := range deps.Urns {
+			urns[i] = resource.URN(urn)
+		}
+		retDeps[resource.PropertyKey(name)] = urns
+	}
+
+	return ret, retDeps, nil, nil
+}
+
 func prepareTestTimeout(timeout float64) string {
 	mins := int(timeout) / 60
 
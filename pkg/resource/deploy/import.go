@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/blang/semver"
+	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
@@ -40,6 +42,10 @@ type Import struct {
 	Protect  bool            // Whether to mark the resource as protected after import
 }
 
+// ImportDependenciesFunc computes the dependencies between the resources to import, given their states. It returns a
+// map from the URN of each resource to the URNs of the resources that it depends on.
+type ImportDependenciesFunc func(states []*resource.State) (map[resource.URN][]resource.URN, error)
+
 // ImportOptions controls the import process.
 type ImportOptions struct {
 	Events   Events // an optional events callback interface.
@@ -114,9 +120,10 @@ func (noopOutputsEvent) Outputs() resource.PropertyMap { return resource.Propert
 func (noopOutputsEvent) Done()                         {}
 
 type importer struct {
-	deployment *Deployment
-	executor   *stepExecutor
-	preview    bool
+	deployment   *Deployment
+	executor     *stepExecutor
+	preview      bool
+	dependencies ImportDependenciesFunc
 }
 
 func (i *importer) executeSerial(ctx context.Context, steps ...Step) bool {
@@ -292,9 +299,9 @@ func (i *importer) importResources(ctx context.Context) result.Result {
 		return res
 	}
 
-	// Create a step per resource to import and execute them in parallel. If there are duplicates, fail the import.
+	// Create a step per resource to import. If there are duplicates, fail the import.
 	urns := map[resource.URN]struct{}{}
-	steps := make([]Step, 0, len(i.deployment.imports))
+	steps := make([]*ImportStep, 0, len(i.deployment.imports))
 	for _, imp := range i.deployment.imports {
 		parent := imp.Parent
 		if parent == "" {
@@ -334,11 +341,20 @@ func (i *importer) importResources(ctx context.Context) result.Result {
 		// Create the new desired state. Note that the resource is protected.
 		new := resource.NewState(urn.Type(), urn, true, false, imp.ID, resource.PropertyMap{}, nil, parent, imp.Protect,
 			false, nil, nil, provider, nil, false, nil, nil, nil, "")
-		steps = append(steps, newImportDeploymentStep(i.deployment, new))
+		steps = append(steps, newImportDeploymentStep(i.deployment, new).(*ImportStep))
 	}
 
-	if !i.executeParallel(ctx, steps...) {
-		return nil
+	// Record the dependencies between the resources to import, and execute the steps in waves so that each resource
+	// is imported after the resources that it depends on. Without dependencies, all of the steps run in parallel.
+	if i.dependencies != nil {
+		if err := i.addDependencies(steps); err != nil {
+			return result.FromError(err)
+		}
+	}
+	for _, wave := range importWaves(steps) {
+		if !i.executeParallel(ctx, wave...) {
+			return nil
+		}
 	}
 
 	if createdStack {
@@ -347,3 +363,107 @@ func (i *importer) importResources(ctx context.Context) result.Result {
 
 	return nil
 }
+
+// addDependencies reads the current state of each resource to import and records the dependencies that the importer's
+// dependencies function computes for them in the steps that import them. Resources that cannot be read are left
+// without dependencies; their steps report the failure when they are executed.
+func (i *importer) addDependencies(steps []*ImportStep) error {
+	states := make([]*resource.State, len(steps))
+	var wg sync.WaitGroup
+	wg.Add(len(steps))
+	for idx, step := range steps {
+		go func(idx int, step *ImportStep) {
+			defer wg.Done()
+
+			// Apply a preview of a copy of the step to read the resource without recording it.
+			state := *step.new
+			state.Inputs = resource.PropertyMap{}
+			if _, _, err := newImportDeploymentStep(i.deployment, &state).Apply(true); err == nil {
+				states[idx] = &state
+			}
+		}(idx, step)
+	}
+	wg.Wait()
+
+	var read []*resource.State
+	for _, state := range states {
+		if state != nil {
+			read = append(read, state)
+		}
+	}
+	if len(read) == 0 {
+		return nil
+	}
+
+	deps, err := i.dependencies(read)
+	if err != nil {
+		return errors.Wrap(err, "computing dependencies between imported resources")
+	}
+
+	// Only record dependencies on resources that will be in the snapshot once the import completes.
+	known := map[resource.URN]bool{}
+	for urn, old := range i.deployment.olds {
+		known[urn] = !old.Delete
+	}
+	for _, step := range steps {
+		known[step.new.URN] = true
+	}
+	for _, step := range steps {
+		for _, dep := range deps[step.new.URN] {
+			if known[dep] && dep != step.new.URN && !containsURN(step.new.Dependencies, dep) {
+				step.new.Dependencies = append(step.new.Dependencies, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// importWaves groups the given import steps into waves, each of which only depends on the resources imported by the
+// waves before it. Steps that are part of a dependency cycle are placed in the final wave.
+func importWaves(steps []*ImportStep) [][]Step {
+	pending := map[resource.URN]bool{}
+	for _, step := range steps {
+		pending[step.new.URN] = true
+	}
+
+	var waves [][]Step
+	remaining := steps
+	for len(remaining) > 0 {
+		var wave []Step
+		var next []*ImportStep
+		for _, step := range remaining {
+			ready := true
+			for _, dep := range step.new.Dependencies {
+				if pending[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				wave = append(wave, step)
+			} else {
+				next = append(next, step)
+			}
+		}
+		if len(wave) == 0 {
+			for _, step := range next {
+				wave = append(wave, step)
+			}
+			next = nil
+		}
+		for _, step := range wave {
+			pending[step.New().URN] = false
+		}
+		waves, remaining = append(waves, wave), next
+	}
+	return waves
+}
+
+func containsURN(urns []resource.URN, urn resource.URN) bool {
+	for _, u := range urns {
+		if u == urn {
+			return true
+		}
+	}
+	return false
+}
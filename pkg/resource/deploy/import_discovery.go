@@ -0,0 +1,225 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// An ImportQuery describes a set of existing resources of a single type to discover and import.
+type ImportQuery struct {
+	Type    tokens.Type          // The type token for the resources. Required.
+	Filter  resource.PropertyMap // The inputs to the type's list function, which filter the resources that are listed.
+	Version *semver.Version      // The provider version to use to list and import the resources, if any.
+	Protect bool                 // Whether to mark the resources as protected after import.
+}
+
+// DiscoverImports lists the existing resources described by the given queries and returns an Import for each resource
+// that is not already managed by the target stack. The resources of each type are listed by calling the list function
+// named by the type's schema using the default provider for the type's package, configured using the target's
+// configuration.
+//
+// Each discovered resource is named after the name returned by the list function, or after its ID if no name was
+// returned. Names are adjusted as necessary so that each is a valid identifier and is unique amongst the resources of
+// its type.
+func DiscoverImports(host plugin.Host, target *Target, projectName tokens.PackageName,
+	queries []ImportQuery) ([]Import, error) {
+
+	loader := schema.NewPluginLoader(host)
+
+	// Record the names and IDs of the resources that are already managed by the stack.
+	names := map[tokens.Type]map[tokens.QName]bool{}
+	ids := map[tokens.Type]map[resource.ID]bool{}
+	if target.Snapshot != nil {
+		for _, r := range target.Snapshot.Resources {
+			if r.Delete {
+				continue
+			}
+			addName(names, r.Type, r.URN.Name())
+			if _, ok := ids[r.Type]; !ok {
+				ids[r.Type] = map[resource.ID]bool{}
+			}
+			ids[r.Type][r.ID] = true
+			if r.ImportID != "" {
+				ids[r.Type][r.ImportID] = true
+			}
+		}
+	}
+
+	var imports []Import
+	for _, q := range queries {
+		listed, err := listResources(host, loader, target, projectName, q)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing resources of type %v", q.Type)
+		}
+
+		for _, r := range listed {
+			if ids[q.Type][r.id] {
+				continue
+			}
+			if _, ok := ids[q.Type]; !ok {
+				ids[q.Type] = map[resource.ID]bool{}
+			}
+			ids[q.Type][r.id] = true
+
+			name := r.name
+			if name == "" {
+				name = string(r.id)
+			}
+			imports = append(imports, Import{
+				Type:    q.Type,
+				Name:    uniqueImportName(names, q.Type, name),
+				ID:      r.id,
+				Version: q.Version,
+				Protect: q.Protect,
+			})
+		}
+	}
+	return imports, nil
+}
+
+// A listedResource is a resource returned by a list function.
+type listedResource struct {
+	id   resource.ID
+	name string
+}
+
+// listResources calls the list function for the given query's type and returns the resources that it lists.
+func listResources(host plugin.Host, loader schema.Loader, target *Target, projectName tokens.PackageName,
+	q ImportQuery) ([]listedResource, error) {
+
+	pkgName := q.Type.Package()
+	if pkgName == "" {
+		return nil, errors.New("incorrect package type specified")
+	}
+
+	pkg, err := loader.LoadPackage(string(pkgName), q.Version)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := pkg.GetResource(string(q.Type))
+	if !ok {
+		return nil, errors.Errorf("unknown resource type %v", q.Type)
+	}
+	if res.ListFunction == nil {
+		return nil, errors.Errorf("package %v does not define a list function for %v", pkgName, q.Type)
+	}
+
+	prov, err := host.Provider(pkgName, q.Version)
+	if err != nil {
+		return nil, err
+	}
+	if prov == nil {
+		return nil, errors.Errorf("could not load the provider for package %v", pkgName)
+	}
+
+	// Configure the provider in the same way as the default provider for the package.
+	inputs, err := target.GetPackageConfig(pkgName)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching provider config")
+	}
+	urn := resource.NewURN(target.Name, projectName, "", providers.MakeProviderType(pkgName), "default")
+	inputs, failures, err := prov.CheckConfig(urn, nil, inputs, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "validating provider config")
+	}
+	if len(failures) != 0 {
+		return nil, errors.Errorf("invalid provider config: %v", failures[0].Reason)
+	}
+	if err = prov.Configure(inputs); err != nil {
+		return nil, errors.Wrap(err, "configuring provider")
+	}
+
+	filter := q.Filter
+	if filter == nil {
+		filter = resource.PropertyMap{}
+	}
+	outputs, failures, err := prov.Invoke(tokens.ModuleMember(res.ListFunction.Token), filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(failures) != 0 {
+		return nil, errors.Errorf("invalid filter: %v", failures[0].Reason)
+	}
+
+	list := outputs["resources"]
+	if list.IsSecret() {
+		list = list.SecretValue().Element
+	}
+	if !list.IsArray() {
+		return nil, errors.Errorf("list function %v did not return a list of resources", res.ListFunction.Token)
+	}
+
+	var listed []listedResource
+	for _, v := range list.ArrayValue() {
+		if !v.IsObject() {
+			return nil, errors.Errorf("list function %v returned a %v instead of a resource",
+				res.ListFunction.Token, v.TypeString())
+		}
+		obj := v.ObjectValue()
+		id := obj["id"]
+		if !id.IsString() || id.StringValue() == "" {
+			return nil, errors.Errorf("list function %v returned a resource without an ID", res.ListFunction.Token)
+		}
+
+		r := listedResource{id: resource.ID(id.StringValue())}
+		if name := obj["name"]; name.IsString() {
+			r.name = name.StringValue()
+		}
+		listed = append(listed, r)
+	}
+	return listed, nil
+}
+
+func addName(names map[tokens.Type]map[tokens.QName]bool, typ tokens.Type, name tokens.QName) {
+	typeNames, ok := names[typ]
+	if !ok {
+		typeNames = map[tokens.QName]bool{}
+		names[typ] = typeNames
+	}
+	typeNames[name] = true
+}
+
+// uniqueImportName returns a name for a resource of the given type that is derived from the given name, is a valid
+// identifier, and is not already taken.
+func uniqueImportName(names map[tokens.Type]map[tokens.QName]bool, typ tokens.Type, name string) tokens.QName {
+	base := strings.Map(func(c rune) rune {
+		if c == '_' || c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)) {
+			return c
+		}
+		return '_'
+	}, name)
+	if base == "" || unicode.IsDigit(rune(base[0])) {
+		base = "_" + base
+	}
+
+	unique := tokens.QName(base)
+	for i := 2; names[typ][unique]; i++ {
+		unique = tokens.QName(fmt.Sprintf("%s_%d", base, i))
+	}
+	addName(names, typ, unique)
+	return unique
+}
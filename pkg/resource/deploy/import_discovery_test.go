@@ -0,0 +1,191 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+const discoverySchema = `{
+	"name": "pkgA",
+	"types": {
+		"pkgA:index:BucketSummary": {
+			"type": "object",
+			"properties": {
+				"id": {"type": "string"},
+				"name": {"type": "string"}
+			}
+		}
+	},
+	"resources": {
+		"pkgA:index:Bucket": {
+			"listFunction": "pkgA:index:listBuckets"
+		},
+		"pkgA:index:Queue": {}
+	},
+	"functions": {
+		"pkgA:index:listBuckets": {
+			"inputs": {
+				"properties": {
+					"tag": {"type": "string"}
+				}
+			},
+			"outputs": {
+				"properties": {
+					"resources": {
+						"type": "array",
+						"items": {"$ref": "#/types/pkgA:index:BucketSummary"}
+					}
+				}
+			}
+		}
+	}
+}`
+
+func newDiscoveryHost(t *testing.T, buckets []resource.PropertyValue) plugin.Host {
+	loader := deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+		configured := false
+		return &deploytest.Provider{
+			GetSchemaF: func(version int) ([]byte, error) {
+				return []byte(discoverySchema), nil
+			},
+			ConfigureF: func(news resource.PropertyMap) error {
+				configured = true
+				return nil
+			},
+			InvokeF: func(tok tokens.ModuleMember,
+				inputs resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+				assert.True(t, configured)
+				assert.Equal(t, tokens.ModuleMember("pkgA:index:listBuckets"), tok)
+
+				var listed []resource.PropertyValue
+				for _, b := range buckets {
+					if tag, ok := inputs["tag"]; ok && !b.ObjectValue()["tag"].DeepEquals(tag) {
+						continue
+					}
+					listed = append(listed, b)
+				}
+				return resource.PropertyMap{
+					"resources": resource.NewArrayProperty(listed),
+				}, nil, nil
+			},
+		}, nil
+	})
+	return deploytest.NewPluginHost(nil, nil, nil, loader)
+}
+
+func newBucketSummary(id, name, tag string) resource.PropertyValue {
+	summary := resource.PropertyMap{
+		"id":  resource.NewStringProperty(id),
+		"tag": resource.NewStringProperty(tag),
+	}
+	if name != "" {
+		summary["name"] = resource.NewStringProperty(name)
+	}
+	return resource.NewObjectProperty(summary)
+}
+
+func TestDiscoverImports(t *testing.T) {
+	host := newDiscoveryHost(t, []resource.PropertyValue{
+		newBucketSummary("bucket-1", "logs", "prod"),
+		newBucketSummary("bucket-2", "logs", "prod"),
+		newBucketSummary("bucket-3", "", "prod"),
+		newBucketSummary("bucket-4", "my-assets.example.com", "prod"),
+		newBucketSummary("bucket-5", "scratch", "dev"),
+		newBucketSummary("bucket-6", "managed", "prod"),
+	})
+
+	bucketType := tokens.Type("pkgA:index:Bucket")
+	target := &Target{
+		Name: "stack",
+		Snapshot: &Snapshot{
+			Resources: []*resource.State{{
+				Type:   bucketType,
+				URN:    resource.NewURN("stack", "project", "", bucketType, "managed"),
+				Custom: true,
+				ID:     "bucket-6",
+			}},
+		},
+	}
+
+	imports, err := DiscoverImports(host, target, "project", []ImportQuery{{
+		Type:    bucketType,
+		Filter:  resource.PropertyMap{"tag": resource.NewStringProperty("prod")},
+		Protect: true,
+	}})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	expected := []Import{
+		{Type: bucketType, Name: "logs", ID: "bucket-1", Protect: true},
+		{Type: bucketType, Name: "logs_2", ID: "bucket-2", Protect: true},
+		{Type: bucketType, Name: "bucket_3", ID: "bucket-3", Protect: true},
+		{Type: bucketType, Name: "my_assets_example_com", ID: "bucket-4", Protect: true},
+	}
+	assert.Equal(t, expected, imports)
+}
+
+func TestDiscoverImportsNameConflicts(t *testing.T) {
+	host := newDiscoveryHost(t, []resource.PropertyValue{
+		newBucketSummary("bucket-1", "logs", "prod"),
+		newBucketSummary("bucket-2", "1st", "prod"),
+	})
+
+	bucketType := tokens.Type("pkgA:index:Bucket")
+	target := &Target{
+		Name: "stack",
+		Snapshot: &Snapshot{
+			Resources: []*resource.State{{
+				Type:   bucketType,
+				URN:    resource.NewURN("stack", "project", "", bucketType, "logs"),
+				Custom: true,
+				ID:     "bucket-0",
+			}},
+		},
+	}
+
+	// Listing the same type twice must not import a resource twice.
+	query := ImportQuery{Type: bucketType}
+	imports, err := DiscoverImports(host, target, "project", []ImportQuery{query, query})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	expected := []Import{
+		{Type: bucketType, Name: "logs_2", ID: "bucket-1"},
+		{Type: bucketType, Name: "_1st", ID: "bucket-2"},
+	}
+	assert.Equal(t, expected, imports)
+}
+
+func TestDiscoverImportsNoListFunction(t *testing.T) {
+	host := newDiscoveryHost(t, nil)
+
+	_, err := DiscoverImports(host, &Target{Name: "stack"}, "project", []ImportQuery{{Type: "pkgA:index:Queue"}})
+	assert.Error(t, err)
+
+	_, err = DiscoverImports(host, &Target{Name: "stack"}, "project", []ImportQuery{{Type: "pkgA:index:Topic"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// ResourceDefaultsKey is the name of the provider input property that holds the defaults applied to each resource
+// managed by the provider. The property may be set on an explicit provider resource or, for a default provider, in
+// stack configuration as `<package>:resourceDefaults`. It is interpreted by the engine and is never passed to the
+// provider plugin itself.
+//
+// The value is an object (or, when it comes from stack configuration, a JSON string) of the form
+//
+//	{
+//	    "properties": { "tags": { "team": "platform" } },
+//	    "customTimeouts": { "create": "30m", "update": "30m", "delete": "30m" },
+//	    "ignoreChanges": [ "tags.lastModified" ]
+//	}
+//
+// All fields are optional.
+const ResourceDefaultsKey resource.PropertyKey = "resourceDefaults"
+
+// ResourceDefaults are the defaults that a provider applies to each of the resources it manages.
+type ResourceDefaults struct {
+	// Properties are default values for resource input properties. A property that the resource sets is left as-is,
+	// unless it is map-typed according to the provider's schema, in which case the default entries are merged into it.
+	Properties resource.PropertyMap
+	// CustomTimeouts are the timeouts used for operations that the resource does not set a timeout for.
+	CustomTimeouts resource.CustomTimeouts
+	// IgnoreChanges are property paths whose changes are ignored, in addition to any the resource ignores.
+	IgnoreChanges []string
+}
+
+// IsEmpty returns true if the defaults have no effect.
+func (d *ResourceDefaults) IsEmpty() bool {
+	return d == nil ||
+		len(d.Properties) == 0 && !d.CustomTimeouts.IsNotEmpty() && len(d.IgnoreChanges) == 0
+}
+
+// GetResourceDefaults fetches and parses the resource defaults from the given provider inputs. If the inputs do not
+// contain any defaults, or if the defaults are not yet known, this function returns nil.
+func GetResourceDefaults(inputs resource.PropertyMap) (*ResourceDefaults, error) {
+	value, ok := inputs[ResourceDefaultsKey]
+	if !ok || value.IsNull() || value.ContainsUnknowns() {
+		return nil, nil
+	}
+
+	secret := value.IsSecret()
+	if secret {
+		value = value.SecretValue().Element
+	}
+	if value.IsString() {
+		// Values from stack configuration arrive as JSON strings.
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(value.StringValue()), &v); err != nil {
+			return nil, errors.Wrapf(err, "'%s' must be an object", ResourceDefaultsKey)
+		}
+		value = resource.NewObjectProperty(resource.NewPropertyMapFromMap(v))
+	}
+	if !value.IsObject() {
+		return nil, errors.Errorf("'%s' must be an object", ResourceDefaultsKey)
+	}
+
+	var defaults ResourceDefaults
+	for k, v := range value.ObjectValue() {
+		switch k {
+		case "properties":
+			if v.IsSecret() {
+				secret, v = true, v.SecretValue().Element
+			}
+			if !v.IsObject() {
+				return nil, errors.Errorf("'%s.properties' must be an object", ResourceDefaultsKey)
+			}
+			defaults.Properties = v.ObjectValue().Copy()
+		case "customTimeouts":
+			timeouts, err := parseCustomTimeouts(v)
+			if err != nil {
+				return nil, err
+			}
+			defaults.CustomTimeouts = timeouts
+		case "ignoreChanges":
+			if !v.IsArray() {
+				return nil, errors.Errorf("'%s.ignoreChanges' must be an array of strings", ResourceDefaultsKey)
+			}
+			for _, path := range v.ArrayValue() {
+				if !path.IsString() {
+					return nil, errors.Errorf("'%s.ignoreChanges' must be an array of strings", ResourceDefaultsKey)
+				}
+				if _, err := resource.ParsePropertyPath(path.StringValue()); err != nil {
+					return nil, errors.Wrapf(err, "'%s.ignoreChanges'", ResourceDefaultsKey)
+				}
+				defaults.IgnoreChanges = append(defaults.IgnoreChanges, path.StringValue())
+			}
+		default:
+			return nil, errors.Errorf("unknown field '%s.%s'", ResourceDefaultsKey, k)
+		}
+	}
+
+	// If the defaults were secret, so are the default property values.
+	if secret {
+		for k, v := range defaults.Properties {
+			if !v.IsSecret() {
+				defaults.Properties[k] = resource.MakeSecret(v)
+			}
+		}
+	}
+	return &defaults, nil
+}
+
+// parseCustomTimeouts parses an object whose create, update and delete fields are durations such as "30m".
+func parseCustomTimeouts(v resource.PropertyValue) (resource.CustomTimeouts, error) {
+	var timeouts resource.CustomTimeouts
+	if !v.IsObject() {
+		return timeouts, errors.Errorf("'%s.customTimeouts' must be an object", ResourceDefaultsKey)
+	}
+	for k, d := range v.ObjectValue() {
+		if !d.IsString() {
+			return timeouts, errors.Errorf("'%s.customTimeouts.%s' must be a duration", ResourceDefaultsKey, k)
+		}
+		duration, err := time.ParseDuration(d.StringValue())
+		if err != nil {
+			return timeouts, errors.Wrapf(err, "'%s.customTimeouts.%s'", ResourceDefaultsKey, k)
+		}
+		switch k {
+		case "create":
+			timeouts.Create = duration.Seconds()
+		case "update":
+			timeouts.Update = duration.Seconds()
+		case "delete":
+			timeouts.Delete = duration.Seconds()
+		default:
+			return timeouts, errors.Errorf("unknown field '%s.customTimeouts.%s'", ResourceDefaultsKey, k)
+		}
+	}
+	return timeouts, nil
+}
+
+// providerConfig returns the given provider inputs without the properties that are interpreted by the engine rather
+// than by the provider plugin.
+func providerConfig(inputs resource.PropertyMap) resource.PropertyMap {
+	if _, ok := inputs[ResourceDefaultsKey]; !ok {
+		return inputs
+	}
+	config := inputs.Copy()
+	delete(config, ResourceDefaultsKey)
+	return config
+}
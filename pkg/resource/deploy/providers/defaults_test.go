@@ -0,0 +1,66 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestGetResourceDefaults(t *testing.T) {
+	// Inputs without defaults have none.
+	defaults, err := GetResourceDefaults(resource.PropertyMap{})
+	assert.NoError(t, err)
+	assert.True(t, defaults.IsEmpty())
+
+	// Defaults from stack configuration are JSON strings, and secret configuration produces secret defaults.
+	defaults, err = GetResourceDefaults(resource.PropertyMap{
+		ResourceDefaultsKey: resource.MakeSecret(resource.NewStringProperty(
+			`{"properties":{"tags":{"team":"platform"}},"customTimeouts":{"delete":"1h"},"ignoreChanges":["tags"]}`)),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &ResourceDefaults{
+		Properties: resource.PropertyMap{
+			"tags": resource.MakeSecret(resource.NewObjectProperty(resource.PropertyMap{
+				"team": resource.NewStringProperty("platform"),
+			})),
+		},
+		CustomTimeouts: resource.CustomTimeouts{Delete: 3600},
+		IgnoreChanges:  []string{"tags"},
+	}, defaults)
+
+	// Unknown defaults are ignored until they are known.
+	defaults, err = GetResourceDefaults(resource.PropertyMap{
+		ResourceDefaultsKey: resource.MakeComputed(resource.NewStringProperty("")),
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, defaults)
+
+	// Malformed defaults are rejected.
+	for _, invalid := range []string{
+		`[]`,
+		`{"properties":"tags"}`,
+		`{"customTimeouts":{"create":"soon"}}`,
+		`{"customTimeouts":{"read":"1m"}}`,
+		`{"ignoreChanges":[1]}`,
+		`{"transformations":[]}`,
+	} {
+		_, err = GetResourceDefaults(resource.PropertyMap{ResourceDefaultsKey: resource.NewStringProperty(invalid)})
+		assert.Error(t, err, invalid)
+	}
+}
@@ -125,7 +125,7 @@ func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool,
 		if provider == nil {
 			return nil, errors.Errorf("could not find plugin for %v provider '%v' at version %v", providerPkg, urn, version)
 		}
-		if err := provider.Configure(res.Inputs); err != nil {
+		if err := provider.Configure(providerConfig(res.Inputs)); err != nil {
 			closeErr := host.CloseProvider(provider)
 			contract.IgnoreError(closeErr)
 			return nil, errors.Errorf("could not configure provider '%v': %v", urn, err)
@@ -232,6 +232,9 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	if err != nil {
 		return nil, []plugin.CheckFailure{{Property: "version", Reason: err.Error()}}, nil
 	}
+	if _, err := GetResourceDefaults(news); err != nil {
+		return nil, []plugin.CheckFailure{{Property: ResourceDefaultsKey, Reason: err.Error()}}, nil
+	}
 	provider, err := loadProvider(GetProviderPackage(urn.Type()), version, r.host, r.builtins)
 	if err != nil {
 		return nil, nil, err
@@ -241,13 +244,19 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	}
 
 	// Check the provider's config. If the check fails, unload the provider.
-	inputs, failures, err := provider.CheckConfig(urn, olds, news, allowUnknowns)
+	inputs, failures, err := provider.CheckConfig(urn, providerConfig(olds), providerConfig(news), allowUnknowns)
 	if len(failures) != 0 || err != nil {
 		closeErr := r.host.CloseProvider(provider)
 		contract.IgnoreError(closeErr)
 		return nil, failures, err
 	}
 
+	// Retain the resource defaults, which the provider never sees, so that they are recorded in the provider's state.
+	if defaults, ok := news[ResourceDefaultsKey]; ok {
+		inputs = inputs.Copy()
+		inputs[ResourceDefaultsKey] = defaults
+	}
+
 	// Create a provider reference using the URN and the unknown ID and register the provider.
 	r.setProvider(mustNewReference(urn, UnknownID), provider)
 
@@ -263,6 +272,9 @@ func (r *Registry) Diff(urn resource.URN, id resource.ID, olds, news resource.Pr
 	label := fmt.Sprintf("%s.Diff(%s,%s)", r.label(), urn, id)
 	logging.V(7).Infof("%s: executing (#olds=%d,#news=%d)", label, len(olds), len(news))
 
+	// Changes to the resource defaults only affect the provider's resources, not the provider itself.
+	olds, news = providerConfig(olds), providerConfig(news)
+
 	// Create a reference using the URN and the unknown ID and fetch the provider.
 	provider, ok := r.GetProvider(mustNewReference(urn, UnknownID))
 	if !ok {
@@ -316,7 +328,7 @@ func (r *Registry) Create(urn resource.URN, news resource.PropertyMap, timeout f
 	provider, ok := r.GetProvider(mustNewReference(urn, UnknownID))
 	contract.Assertf(ok, "'Check' must be called before 'Create' (%v)", urn)
 
-	if err := provider.Configure(news); err != nil {
+	if err := provider.Configure(providerConfig(news)); err != nil {
 		return "", nil, resource.StatusOK, err
 	}
 
@@ -349,7 +361,7 @@ func (r *Registry) Update(urn resource.URN, id resource.ID, olds, news resource.
 	provider, ok := r.GetProvider(mustNewReference(urn, UnknownID))
 	contract.Assertf(ok, "'Check' and 'Diff' must be called before 'Update' (%v)", urn)
 
-	if err := provider.Configure(news); err != nil {
+	if err := provider.Configure(providerConfig(news)); err != nil {
 		return nil, resource.StatusUnknown, err
 	}
 
@@ -392,6 +404,15 @@ func (r *Registry) Invoke(tok tokens.ModuleMember,
 	return nil, nil, errors.New("the provider registry is not invokable")
 }
 
+func (r *Registry) Call(tok tokens.ModuleMember, args resource.PropertyMap, info plugin.CallInfo,
+	options plugin.CallOptions) (plugin.CallResult, error) {
+
+	// It is the responsibility of the eval source to ensure that we never attempt a call using the provider
+	// registry.
+	contract.Fail()
+	return plugin.CallResult{}, errors.New("the provider registry is not callable")
+}
+
 func (r *Registry) StreamInvoke(
 	tok tokens.ModuleMember, args resource.PropertyMap,
 	onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error) {
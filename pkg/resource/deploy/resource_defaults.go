@@ -0,0 +1,183 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"encoding/json"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+// applyResourceDefaults returns the given goal with the resource defaults of its provider applied. Default property
+// values are only applied to the input properties that the resource's type declares in the provider's schema. If the
+// provider has no defaults, the goal is returned unchanged.
+func (sg *stepGenerator) applyResourceDefaults(urn resource.URN, goal *resource.Goal,
+	prov plugin.Provider) (*resource.Goal, result.Result) {
+
+	if !goal.Custom || providers.IsProviderType(goal.Type) || goal.Provider == "" {
+		return goal, nil
+	}
+
+	providerResource := sg.getProviderResource(urn, goal.Provider)
+	defaults, err := providers.GetResourceDefaults(providerResource.Inputs)
+	if err != nil {
+		// The provider's Check will have already reported the error.
+		logging.V(7).Infof("ignoring invalid resource defaults of provider %v: %v", providerResource.URN, err)
+		return goal, nil
+	}
+	if defaults.IsEmpty() {
+		return goal, nil
+	}
+
+	applied := *goal
+	if len(defaults.Properties) != 0 {
+		if inputs, ok := sg.getResourceInputs(providerResource.URN, goal.Provider, goal.Type, prov); ok {
+			applied.Properties = applyDefaultProperties(goal.Properties, defaults.Properties, inputs)
+		}
+	}
+
+	if applied.CustomTimeouts.Create == 0 {
+		applied.CustomTimeouts.Create = defaults.CustomTimeouts.Create
+	}
+	if applied.CustomTimeouts.Update == 0 {
+		applied.CustomTimeouts.Update = defaults.CustomTimeouts.Update
+	}
+	if applied.CustomTimeouts.Delete == 0 {
+		applied.CustomTimeouts.Delete = defaults.CustomTimeouts.Delete
+	}
+
+	if len(defaults.IgnoreChanges) != 0 {
+		ignoreChanges := append([]string{}, goal.IgnoreChanges...)
+		for _, path := range defaults.IgnoreChanges {
+			if !containsString(ignoreChanges, path) {
+				ignoreChanges = append(ignoreChanges, path)
+			}
+		}
+		applied.IgnoreChanges = ignoreChanges
+	}
+
+	return &applied, nil
+}
+
+// getResourceInputs returns the input properties of the given resource type according to its provider's schema. If
+// the provider does not publish a schema, or the schema does not describe the type, getResourceInputs returns false.
+func (sg *stepGenerator) getResourceInputs(providerURN resource.URN, provider string, typ tokens.Type,
+	prov plugin.Provider) (map[string]schema.PropertySpec, bool) {
+
+	spec, ok := sg.schemas[provider]
+	if !ok {
+		spec = loadProviderSchema(prov)
+		if spec == nil {
+			sg.deployment.Diag().Warningf(diag.Message(providerURN,
+				"the default resource properties of provider %v will not be applied: the provider does not "+
+					"publish a schema"), providerURN)
+		}
+		sg.schemas[provider] = spec
+	}
+	if spec == nil {
+		return nil, false
+	}
+
+	res, ok := spec.Resources[string(typ)]
+	if !ok {
+		return nil, false
+	}
+	return res.InputProperties, true
+}
+
+// loadProviderSchema fetches and parses the given provider's schema. If the provider does not publish a schema, or
+// the schema cannot be parsed, loadProviderSchema returns nil.
+func loadProviderSchema(prov plugin.Provider) *schema.PackageSpec {
+	b, err := prov.GetSchema(0)
+	if err != nil {
+		logging.V(7).Infof("failed to get the schema of provider %v: %v", prov.Pkg(), err)
+		return nil
+	}
+	var spec schema.PackageSpec
+	if err = json.Unmarshal(b, &spec); err != nil {
+		logging.V(7).Infof("failed to parse the schema of provider %v: %v", prov.Pkg(), err)
+		return nil
+	}
+	if len(spec.Resources) == 0 {
+		return nil
+	}
+	return &spec
+}
+
+// applyDefaultProperties returns the given properties with the given defaults applied. Only defaults for the given
+// input properties are applied. A default for a map-typed property is merged into the property's value, with the
+// property's own entries taking precedence; any other default is only used if the property is not set.
+func applyDefaultProperties(props, defaults resource.PropertyMap,
+	inputs map[string]schema.PropertySpec) resource.PropertyMap {
+
+	result := props.Copy()
+	for k, def := range defaults {
+		input, ok := inputs[string(k)]
+		if !ok {
+			continue
+		}
+
+		value, has := result[k]
+		switch {
+		case !has || value.IsNull():
+			result[k] = def
+		case input.Type == "object" && input.AdditionalProperties != nil:
+			result[k] = mergeDefaultMap(value, def)
+		}
+	}
+	return result
+}
+
+// mergeDefaultMap merges the entries of the default map into the given map value. Entries in the value take
+// precedence over those in the default. If either map is secret, so is the result. If either map is not yet known,
+// the value is returned unchanged.
+func mergeDefaultMap(value, def resource.PropertyValue) resource.PropertyValue {
+	original, secret := value, false
+	if value.IsSecret() {
+		secret, value = true, value.SecretValue().Element
+	}
+	if def.IsSecret() {
+		secret, def = true, def.SecretValue().Element
+	}
+	if !value.IsObject() || !def.IsObject() {
+		return original
+	}
+
+	merged := def.ObjectValue().Copy()
+	for k, v := range value.ObjectValue() {
+		merged[k] = v
+	}
+	result := resource.NewObjectProperty(merged)
+	if secret {
+		return resource.MakeSecret(result)
+	}
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
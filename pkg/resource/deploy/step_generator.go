@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/v2/resource/graph"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
@@ -66,6 +67,10 @@ type stepGenerator struct {
 
 	// a map from old names (aliased URNs) to the new URN that aliased to them.
 	aliased map[resource.URN]resource.URN
+
+	// a cache of provider schemas, keyed by provider reference, used to apply provider resource defaults. A nil
+	// schema indicates that the provider does not publish one.
+	schemas map[string]*schema.PackageSpec
 }
 
 func (sg *stepGenerator) isTargetedUpdate() bool {
@@ -233,6 +238,18 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		}
 	}
 
+	// Fetch the provider for this resource.
+	prov, res := sg.loadResourceProvider(urn, goal.Custom, goal.Provider, goal.Type)
+	if res != nil {
+		return nil, res
+	}
+
+	// Apply the resource defaults of the resource's provider, if any, before the goal is used.
+	goal, res = sg.applyResourceDefaults(urn, goal, prov)
+	if res != nil {
+		return nil, res
+	}
+
 	// Create the desired inputs from the goal state
 	inputs := goal.Properties
 	if hasOld {
@@ -257,12 +274,6 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		sg.providers[urn] = new
 	}
 
-	// Fetch the provider for this resource.
-	prov, res := sg.loadResourceProvider(urn, goal.Custom, goal.Provider, goal.Type)
-	if res != nil {
-		return nil, res
-	}
-
 	// We only allow unknown property values to be exposed to the provider if we are performing an update preview.
 	allowUnknowns := sg.deployment.preview
 
@@ -321,46 +332,101 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		new.Inputs = inputs
 	}
 
-	// Send the resource off to any Analyzers before being operated on.
+	// Send the resource off to any Analyzers before being operated on. We do two passes: first we perform
+	// remediations, and *then* we do analysis, since we want analyzers to run on the final resource states.
 	analyzers := sg.deployment.ctx.Host.ListAnalyzers()
-	for _, analyzer := range analyzers {
-		r := plugin.AnalyzerResource{
-			URN:        new.URN,
-			Type:       new.Type,
-			Name:       new.URN.Name(),
-			Properties: inputs,
-			Options: plugin.AnalyzerResourceOptions{
-				Protect:                 new.Protect,
-				IgnoreChanges:           goal.IgnoreChanges,
-				DeleteBeforeReplace:     goal.DeleteBeforeReplace,
-				AdditionalSecretOutputs: new.AdditionalSecretOutputs,
-				Aliases:                 new.Aliases,
-				CustomTimeouts:          new.CustomTimeouts,
-			},
-		}
-		providerResource := sg.getProviderResource(new.URN, new.Provider)
-		if providerResource != nil {
-			r.Provider = &plugin.AnalyzerProviderResource{
-				URN:        providerResource.URN,
-				Type:       providerResource.Type,
-				Name:       providerResource.URN.Name(),
-				Properties: providerResource.Inputs,
+	remediated := false
+	for _, remediate := range []bool{true, false} {
+		// Remediations produce new inputs that the provider has not yet seen, so check them again before they are
+		// analyzed or passed to the provider's other methods.
+		if !remediate && remediated && prov != nil {
+			olds := oldInputs
+			if recreating || wasExternal || sg.isTargetedReplace(urn) {
+				olds = nil
 			}
-		}
 
-		diagnostics, err := analyzer.Analyze(r)
-		if err != nil {
-			return nil, result.FromError(err)
+			var failures []plugin.CheckFailure
+			inputs, failures, err = prov.Check(urn, olds, inputs, allowUnknowns)
+			if err != nil {
+				return nil, result.FromError(err)
+			} else if issueCheckErrors(sg.deployment, new, urn, failures) {
+				invalid = true
+			}
+			new.Inputs = inputs
 		}
-		for _, d := range diagnostics {
-			if d.EnforcementLevel == apitype.Mandatory {
-				if !sg.deployment.preview {
-					invalid = true
+
+		for _, analyzer := range analyzers {
+			r := plugin.AnalyzerResource{
+				URN:        new.URN,
+				Type:       new.Type,
+				Name:       new.URN.Name(),
+				Properties: inputs,
+				Options: plugin.AnalyzerResourceOptions{
+					Protect:                 new.Protect,
+					IgnoreChanges:           goal.IgnoreChanges,
+					DeleteBeforeReplace:     goal.DeleteBeforeReplace,
+					AdditionalSecretOutputs: new.AdditionalSecretOutputs,
+					Aliases:                 new.Aliases,
+					CustomTimeouts:          new.CustomTimeouts,
+				},
+			}
+			providerResource := sg.getProviderResource(new.URN, new.Provider)
+			if providerResource != nil {
+				r.Provider = &plugin.AnalyzerProviderResource{
+					URN:        providerResource.URN,
+					Type:       providerResource.Type,
+					Name:       providerResource.URN.Name(),
+					Properties: providerResource.Inputs,
 				}
-				sg.sawError = true
 			}
-			// For now, we always use the URN we have here rather than a URN specified with the diagnostic.
-			sg.opts.Events.OnPolicyViolation(new.URN, d)
+
+			if remediate {
+				// During the first pass, perform remediations. This ensures subsequent analyzers run against
+				// the transformed properties, ensuring nothing circumvents the analysis checks.
+				tresults, err := analyzer.Remediate(r)
+				if err != nil {
+					return nil, result.FromError(err)
+				}
+				for _, tresult := range tresults {
+					if tresult.Diagnostic != "" {
+						// If there is a diagnostic, we have a warning to display.
+						sg.opts.Events.OnPolicyViolation(new.URN, plugin.AnalyzeDiagnostic{
+							PolicyName:        tresult.PolicyName,
+							PolicyPackName:    tresult.PolicyPackName,
+							PolicyPackVersion: tresult.PolicyPackVersion,
+							Description:       tresult.Description,
+							Message:           tresult.Diagnostic,
+							EnforcementLevel:  apitype.Advisory,
+							URN:               new.URN,
+						})
+					} else if tresult.Properties != nil {
+						// Emit a nice message so users know what was remediated.
+						sg.opts.Events.OnPolicyRemediation(new.URN, tresult, inputs, tresult.Properties)
+						// Use the transformed inputs rather than the old ones from this point onwards.
+						inputs = tresult.Properties
+						new.Inputs = tresult.Properties
+						remediated = true
+					}
+				}
+				continue
+			}
+
+			// During the second pass, perform analysis. This happens after remediations so that analyzers see
+			// properties as they were after the transformations have occurred.
+			diagnostics, err := analyzer.Analyze(r)
+			if err != nil {
+				return nil, result.FromError(err)
+			}
+			for _, d := range diagnostics {
+				if d.EnforcementLevel == apitype.Mandatory {
+					if !sg.deployment.preview {
+						invalid = true
+					}
+					sg.sawError = true
+				}
+				// For now, we always use the URN we have here rather than a URN specified with the diagnostic.
+				sg.opts.Events.OnPolicyViolation(new.URN, d)
+			}
 		}
 	}
 
@@ -1370,5 +1436,6 @@ func newStepGenerator(
 		providers:            make(map[resource.URN]*resource.State),
 		dependentReplaceKeys: make(map[resource.URN][]resource.PropertyKey),
 		aliased:              make(map[resource.URN]resource.URN),
+		schemas:              make(map[string]*schema.PackageSpec),
 	}
 }
@@ -0,0 +1,341 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stacktest provides helpers for writing Go tests against ephemeral stacks driven by the Automation API.
+//
+// Each stack created by this package is backed by its own temporary `file://` backend and $PULUMI_HOME, so tests
+// are fully isolated from each other and from the user's environment and can run offline. Provider plugins are
+// resolved from the directories in Options.PluginDirs (which are prepended to $PATH) before the temporary plugin
+// cache is consulted. Stacks are always destroyed and removed when the test completes.
+//
+// A typical test looks like:
+//
+//	func TestBucket(t *testing.T) {
+//		s := stacktest.Run(t, func(ctx *pulumi.Context) error {
+//			ctx.Export("name", pulumi.String("bucket"))
+//			return nil
+//		}, stacktest.Options{})
+//
+//		var name string
+//		s.Output("name", &name)
+//		assert.Equal(t, "bucket", name)
+//		assert.Len(t, s.Snapshot().Resources, 1)
+//	}
+package stacktest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v2/secrets"
+	"github.com/pulumi/pulumi/pkg/v2/secrets/passphrase"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/x/auto"
+	"github.com/pulumi/pulumi/sdk/v2/go/x/auto/optpreview"
+)
+
+const (
+	// defaultProjectName is the project name used when Options.ProjectName is empty.
+	defaultProjectName = "stacktest"
+	// defaultPassphrase is the passphrase used to encrypt secrets when Options.Passphrase is empty.
+	defaultPassphrase = "stacktest"
+)
+
+// Options controls the creation of an ephemeral test stack.
+type Options struct {
+	// ProjectName is the name of the inline project. Defaults to "stacktest".
+	ProjectName string
+	// StackPrefix is prepended to the generated stack name. Defaults to a sanitized form of the test's name.
+	StackPrefix string
+	// Config is the stack configuration to set before the first update.
+	Config auto.ConfigMap
+	// Passphrase is used to encrypt secrets in the stack's state. Defaults to "stacktest".
+	Passphrase string
+	// PluginDirs is a list of directories containing plugin binaries (e.g. `pulumi-resource-aws`). These are
+	// prepended to $PATH for all commands so that local plugins are used instead of downloading them.
+	PluginDirs []string
+	// EnvVars is a set of additional environment variables passed to every command run against the stack.
+	EnvVars map[string]string
+	// SkipIdempotencyCheck disables the no-changes preview that Run performs after the initial update.
+	SkipIdempotencyCheck bool
+	// KeepDirs leaves the temporary backend and home directories on disk after the test completes.
+	KeepDirs bool
+}
+
+// Stack is an ephemeral stack whose lifetime is bound to a single test.
+type Stack struct {
+	auto.Stack
+
+	t          testing.TB
+	ctx        context.Context
+	passphrase string
+
+	// Result is the result of the most recent call to Up.
+	Result auto.UpResult
+}
+
+// Run creates an ephemeral stack for the given program, deploys it, and asserts that a subsequent preview reports
+// no changes. The stack is destroyed and removed when the test completes.
+func Run(t testing.TB, program pulumi.RunFunc, opts Options) *Stack {
+	s := New(t, program, opts)
+	s.Up()
+	if !opts.SkipIdempotencyCheck {
+		s.AssertNoChanges()
+	}
+	return s
+}
+
+// New creates, but does not deploy, an ephemeral stack for the given program. The stack is destroyed and removed
+// when the test completes.
+func New(t testing.TB, program pulumi.RunFunc, opts Options) *Stack {
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "pulumi-stacktest")
+	if err != nil {
+		t.Fatalf("creating temporary directory: %v", err)
+	}
+	if !opts.KeepDirs {
+		t.Cleanup(func() {
+			if err := os.RemoveAll(dir); err != nil {
+				t.Logf("removing temporary directory %v: %v", dir, err)
+			}
+		})
+	}
+
+	backendDir, homeDir, workDir := filepath.Join(dir, "state"), filepath.Join(dir, "home"), filepath.Join(dir, "work")
+	for _, d := range []string{backendDir, homeDir, workDir} {
+		if err = os.MkdirAll(d, 0700); err != nil {
+			t.Fatalf("creating directory %v: %v", d, err)
+		}
+	}
+
+	projectName := opts.ProjectName
+	if projectName == "" {
+		projectName = defaultProjectName
+	}
+	pass := opts.Passphrase
+	if pass == "" {
+		pass = defaultPassphrase
+	}
+
+	env := map[string]string{
+		workspace.PulumiBackendURLEnvVar: "file://" + filepath.ToSlash(backendDir),
+		"PULUMI_CONFIG_PASSPHRASE":       pass,
+		"PULUMI_SKIP_UPDATE_CHECK":       "true",
+	}
+	if len(opts.PluginDirs) > 0 {
+		paths := append(append([]string{}, opts.PluginDirs...), os.Getenv("PATH"))
+		env["PATH"] = strings.Join(paths, string(os.PathListSeparator))
+	}
+	for k, v := range opts.EnvVars {
+		env[k] = v
+	}
+
+	proj := workspace.Project{
+		Name:    tokens.PackageName(invalidStackNameChars.ReplaceAllString(projectName, "-")),
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+	}
+
+	ws, err := auto.NewLocalWorkspace(ctx,
+		auto.WorkDir(workDir),
+		auto.PulumiHome(homeDir),
+		auto.Program(program),
+		auto.Project(proj),
+		auto.SecretsProvider("passphrase"),
+		auto.EnvVars(env))
+	if err != nil {
+		t.Fatalf("creating workspace: %v", err)
+	}
+
+	stackName := UniqueStackName(opts.StackPrefix, t.Name())
+	st, err := auto.NewStack(ctx, stackName, ws)
+	if err != nil {
+		t.Fatalf("creating stack %v: %v", stackName, err)
+	}
+
+	s := &Stack{
+		Stack:      st,
+		t:          t,
+		ctx:        ctx,
+		passphrase: pass,
+	}
+
+	// Cleanups run in LIFO order, so this will run before the temporary directory is removed.
+	t.Cleanup(s.destroy)
+
+	if len(opts.Config) > 0 {
+		if err = st.SetAllConfig(ctx, opts.Config); err != nil {
+			t.Fatalf("setting config: %v", err)
+		}
+	}
+
+	return s
+}
+
+// Up deploys the stack's program, failing the test on error.
+func (s *Stack) Up() auto.UpResult {
+	s.t.Helper()
+
+	res, err := s.Stack.Up(s.ctx)
+	if err != nil {
+		s.t.Fatalf("update failed: %v", err)
+	}
+	s.Result = res
+	return res
+}
+
+// Preview runs a preview of the stack's program, failing the test on error.
+func (s *Stack) Preview() auto.PreviewResult {
+	s.t.Helper()
+
+	res, err := s.Stack.Preview(s.ctx)
+	if err != nil {
+		s.t.Fatalf("preview failed: %v", err)
+	}
+	return res
+}
+
+// AssertNoChanges runs a preview of the stack's program and fails the test if the preview reports any changes.
+func (s *Stack) AssertNoChanges() {
+	s.t.Helper()
+
+	res, err := s.Stack.Preview(s.ctx, optpreview.ExpectNoChanges())
+	if err != nil {
+		s.t.Fatalf("expected no changes: %v", err)
+	}
+	for op, count := range res.ChangeSummary {
+		if op != "same" && count != 0 {
+			s.t.Fatalf("expected no changes, but preview reported %d %v operation(s)", count, op)
+		}
+	}
+}
+
+// Output decodes the stack output with the given name into the value pointed to by v. The test fails if the output
+// does not exist or cannot be decoded into v.
+func (s *Stack) Output(name string, v interface{}) {
+	s.t.Helper()
+
+	if err := decodeOutput(s.Result.Outputs, name, v); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// IsSecretOutput returns true if the stack output with the given name is a secret.
+func (s *Stack) IsSecretOutput(name string) bool {
+	s.t.Helper()
+
+	out, ok := s.Result.Outputs[name]
+	if !ok {
+		s.t.Fatalf("stack output %q does not exist", name)
+	}
+	return out.Secret
+}
+
+// Snapshot exports the stack's current state and deserializes it into a deploy.Snapshot. Secrets in the snapshot are
+// decrypted using the stack's passphrase.
+func (s *Stack) Snapshot() *deploy.Snapshot {
+	s.t.Helper()
+
+	deployment, err := s.Stack.Export(s.ctx)
+	if err != nil {
+		s.t.Fatalf("exporting stack: %v", err)
+	}
+	snap, err := stack.DeserializeUntypedDeployment(&deployment, secretsProvider{passphrase: s.passphrase})
+	if err != nil {
+		s.t.Fatalf("deserializing stack: %v", err)
+	}
+	return snap
+}
+
+// destroy destroys all of the stack's resources and removes the stack.
+func (s *Stack) destroy() {
+	if _, err := s.Stack.Destroy(s.ctx); err != nil {
+		s.t.Errorf("destroying stack %v; resources may have leaked: %v", s.Name(), err)
+		return
+	}
+	if err := s.Workspace().RemoveStack(s.ctx, s.Name()); err != nil {
+		s.t.Errorf("removing stack %v: %v", s.Name(), err)
+	}
+}
+
+// decodeOutput decodes the named output in outputs into the value pointed to by v.
+func decodeOutput(outputs auto.OutputMap, name string, v interface{}) error {
+	out, ok := outputs[name]
+	if !ok {
+		return errors.Errorf("stack output %q does not exist", name)
+	}
+	bytes, err := json.Marshal(out.Value)
+	if err != nil {
+		return errors.Wrapf(err, "marshaling stack output %q", name)
+	}
+	if err = json.Unmarshal(bytes, v); err != nil {
+		return errors.Wrapf(err, "decoding stack output %q", name)
+	}
+	return nil
+}
+
+var invalidStackNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// UniqueStackName returns a stack name that is unique to this process and time, prefixed by the given prefix or, if
+// the prefix is empty, by a sanitized form of the test name.
+func UniqueStackName(prefix, testName string) string {
+	if prefix == "" {
+		prefix = strings.Trim(invalidStackNameChars.ReplaceAllString(testName, "-"), "-")
+	}
+	if len(prefix) > 64 {
+		prefix = prefix[:64]
+	}
+	if prefix == "" {
+		prefix = "test"
+	}
+	return fmt.Sprintf("%s-%d-%x", prefix, os.Getpid(), time.Now().UnixNano())
+}
+
+// secretsProvider decrypts passphrase-encrypted state using a fixed passphrase instead of reading it from the
+// process environment, and defers to stack.DefaultSecretsProvider for all other secrets managers.
+type secretsProvider struct {
+	passphrase string
+}
+
+func (p secretsProvider) OfType(ty string, state json.RawMessage) (secrets.Manager, error) {
+	if ty != passphrase.Type {
+		return stack.DefaultSecretsProvider.OfType(ty, state)
+	}
+
+	var s struct {
+		Salt string `json:"salt"`
+	}
+	if err := json.Unmarshal(state, &s); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling state")
+	}
+	sm, err := passphrase.NewPassphaseSecretsManager(p.passphrase, s.Salt)
+	if err != nil {
+		return nil, err
+	}
+	return stack.NewCachingSecretsManager(sm), nil
+}
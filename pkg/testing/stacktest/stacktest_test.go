@@ -0,0 +1,153 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacktest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/secrets/passphrase"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/x/auto"
+)
+
+func TestUniqueStackName(t *testing.T) {
+	a := UniqueStackName("", "TestFoo/sub test#1")
+	b := UniqueStackName("", "TestFoo/sub test#1")
+	assert.Regexp(t, `^TestFoo-sub-test-1-\d+-[0-9a-f]+$`, a)
+	assert.NotEqual(t, a, b)
+
+	assert.Regexp(t, `^prefix-\d+-[0-9a-f]+$`, UniqueStackName("prefix", "TestFoo"))
+	assert.Regexp(t, `^test-\d+-[0-9a-f]+$`, UniqueStackName("", "///"))
+}
+
+func TestDecodeOutput(t *testing.T) {
+	outputs := auto.OutputMap{
+		"str": {Value: "hello"},
+		"num": {Value: float64(42)},
+		"obj": {Value: map[string]interface{}{"name": "bucket", "tags": []interface{}{"a", "b"}}},
+	}
+
+	var str string
+	assert.NoError(t, decodeOutput(outputs, "str", &str))
+	assert.Equal(t, "hello", str)
+
+	var num int
+	assert.NoError(t, decodeOutput(outputs, "num", &num))
+	assert.Equal(t, 42, num)
+
+	var obj struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	assert.NoError(t, decodeOutput(outputs, "obj", &obj))
+	assert.Equal(t, "bucket", obj.Name)
+	assert.Equal(t, []string{"a", "b"}, obj.Tags)
+
+	assert.Error(t, decodeOutput(outputs, "missing", &str))
+	assert.Error(t, decodeOutput(outputs, "str", &num))
+}
+
+func TestSecretsProvider(t *testing.T) {
+	salt := []byte("saltsalt")
+	check, err := config.NewSymmetricCrypterFromPassphrase("pass", salt).EncryptValue("pulumi")
+	assert.NoError(t, err)
+	sm, err := passphrase.NewPassphaseSecretsManager("pass",
+		fmt.Sprintf("v1:%s:%s", base64.StdEncoding.EncodeToString(salt), check))
+	assert.NoError(t, err)
+	state, err := json.Marshal(sm.State())
+	assert.NoError(t, err)
+
+	enc, err := sm.Encrypter()
+	assert.NoError(t, err)
+	ciphertext, err := enc.EncryptValue("secret")
+	assert.NoError(t, err)
+
+	provider := secretsProvider{passphrase: "pass"}
+	decoded, err := provider.OfType(passphrase.Type, state)
+	assert.NoError(t, err)
+	dec, err := decoded.Decrypter()
+	assert.NoError(t, err)
+	plaintext, err := dec.DecryptValue(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+}
+
+func TestRunDestroysAndRemovesStack(t *testing.T) {
+	if _, err := exec.LookPath("pulumi"); err != nil {
+		t.Skip("the pulumi CLI is not on $PATH")
+	}
+
+	// The program creates no resources, so no plugins are needed and the test runs offline.
+	program := func(ctx *pulumi.Context) error {
+		ctx.Export("greeting", pulumi.String("hello"))
+		ctx.Export("password", pulumi.ToSecret(pulumi.String("hunter2")))
+		return nil
+	}
+
+	pluginDir, err := ioutil.TempDir("", "pulumi-stacktest-plugins")
+	assert.NoError(t, err)
+	defer os.RemoveAll(pluginDir)
+
+	var s *Stack
+	t.Run("run", func(t *testing.T) {
+		s = Run(t, program, Options{PluginDirs: []string{pluginDir}, KeepDirs: true})
+
+		// The stack lives in a local backend and resolves plugins from the given directories first.
+		env := s.Workspace().GetEnvVars()
+		assert.True(t, strings.HasPrefix(env[workspace.PulumiBackendURLEnvVar], "file://"))
+		assert.True(t, strings.HasPrefix(env["PATH"], pluginDir+string(os.PathListSeparator)))
+
+		var greeting string
+		s.Output("greeting", &greeting)
+		assert.Equal(t, "hello", greeting)
+		assert.False(t, s.IsSecretOutput("greeting"))
+		assert.True(t, s.IsSecretOutput("password"))
+
+		// The only resource is the stack itself.
+		snap := s.Snapshot()
+		assert.Len(t, snap.Resources, 1)
+		assert.Equal(t, "pulumi:pulumi:Stack", string(snap.Resources[0].Type))
+
+		res := s.Preview()
+		assert.Equal(t, 1, res.ChangeSummary["same"])
+
+		// Nothing was downloaded into the temporary plugin cache.
+		plugins, err := s.Workspace().ListPlugins(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, plugins)
+	})
+	if s == nil {
+		return
+	}
+	defer os.RemoveAll(filepath.Dir(s.Workspace().WorkDir()))
+
+	// The subtest's cleanup destroyed and removed the stack.
+	stacks, err := s.Workspace().ListStacks(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, stacks)
+}
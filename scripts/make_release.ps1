@@ -29,10 +29,12 @@ function CopyPackage($pathToModule, $moduleName) {
 }
 
 RunGoBuild "github.com/pulumi/pulumi/pkg/v2/cmd/pulumi" "pkg" "pulumi.exe"
+RunGoBuild "github.com/pulumi/pulumi/pkg/v2/cmd/pulumi-language-pcl" "pkg" "pulumi-language-pcl.exe"
 RunGoBuild "github.com/pulumi/pulumi/sdk/v2/nodejs/cmd/pulumi-language-nodejs" "sdk" "pulumi-language-nodejs.exe"
 RunGoBuild "github.com/pulumi/pulumi/sdk/v2/python/cmd/pulumi-language-python" "sdk" "pulumi-language-python.exe"
 RunGoBuild "github.com/pulumi/pulumi/sdk/v2/dotnet/cmd/pulumi-language-dotnet" "sdk" "pulumi-language-dotnet.exe"
 RunGoBuild "github.com/pulumi/pulumi/sdk/v2/go/pulumi-language-go" "sdk" "pulumi-language-go.exe"
+RunGoBuild "github.com/pulumi/pulumi/sdk/v2/go/pulumi-analyzer-policy-go" "sdk" "pulumi-analyzer-policy-go.exe"
 CopyPackage "$Root\sdk\nodejs\bin" "pulumi"
 
 Copy-Item "$Root\sdk\nodejs\dist\pulumi-resource-pulumi-nodejs.cmd" "$PublishDir\bin"
@@ -0,0 +1,95 @@
+// Copyright 2016-2020, Pulumi Corporation
+
+using System;
+using System.Collections.Immutable;
+using System.Linq;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+using Pulumi.Testing;
+using Xunit;
+
+namespace Pulumi.Tests.Mocks
+{
+    class MethodMocks : IMocks, IMethodMocks
+    {
+        public string? Self { get; private set; }
+
+        public Task<object> CallAsync(string token, ImmutableDictionary<string, object> args, string? provider)
+            => throw new Exception($"Unknown function {token}");
+
+        public Task<object> MethodCallAsync(string token, ImmutableDictionary<string, object> args, string? self, string? provider)
+        {
+            if (token != "test:index:component/getValue")
+            {
+                throw new Exception($"Unknown method {token}");
+            }
+
+            this.Self = self;
+            return Task.FromResult<object>(ImmutableDictionary<string, object>.Empty
+                .Add("value", $"{args["arg"]}!"));
+        }
+
+        public Task<(string? id, object state)> NewResourceAsync(string type, string name, ImmutableDictionary<string, object> inputs, string? provider, string? id)
+            => Task.FromResult<(string?, object)>((null, ImmutableDictionary<string, object>.Empty));
+    }
+
+    public sealed class GetValueArgs : InvokeArgs
+    {
+        [Input("arg")]
+        public Input<string>? Arg { get; set; }
+    }
+
+    [OutputType]
+    public sealed class GetValueResult
+    {
+        public readonly string Value;
+
+        [OutputConstructor]
+        private GetValueResult(string value)
+        {
+            Value = value;
+        }
+    }
+
+    public class MethodComponent : ComponentResource
+    {
+        public MethodComponent(string name)
+            : base("test:index:component", name)
+        {
+        }
+
+        public Output<GetValueResult> GetValue(GetValueArgs args)
+            => Deployment.Instance.Call<GetValueResult>("test:index:component/getValue", args, this);
+    }
+
+    public class CallStack : Stack
+    {
+        [Output("componentUrn")]
+        public Output<string> ComponentUrn { get; private set; }
+
+        [Output("value")]
+        public Output<string> Value { get; private set; }
+
+        public CallStack()
+        {
+            var component = new MethodComponent("component");
+            this.ComponentUrn = component.Urn;
+            this.Value = component.GetValue(new GetValueArgs { Arg = "hello" }).Apply(result => result.Value);
+        }
+    }
+
+    public class CallTests
+    {
+        [Fact]
+        public async Task CallPassesTheResourceAndItsArguments()
+        {
+            var mocks = new MethodMocks();
+            var resources = await Deployment.TestAsync<CallStack>(mocks, new TestOptions { IsPreview = false });
+            var stack = resources.OfType<CallStack>().Single();
+
+            var value = await stack.Value.GetValueAsync();
+            Assert.Equal("hello!", value);
+            Assert.Equal(await stack.ComponentUrn.GetValueAsync(), mocks.Self);
+        }
+    }
+}
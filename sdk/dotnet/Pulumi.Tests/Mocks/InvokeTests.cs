@@ -0,0 +1,98 @@
+// Copyright 2016-2020, Pulumi Corporation
+
+using System;
+using System.Collections.Immutable;
+using System.Linq;
+using System.Threading;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+using Pulumi.Testing;
+using Xunit;
+
+namespace Pulumi.Tests.Mocks
+{
+    class EchoMocks : IMocks
+    {
+        private int _calls;
+
+        public int Calls => _calls;
+
+        public Task<object> CallAsync(string token, ImmutableDictionary<string, object> args, string? provider)
+        {
+            Interlocked.Increment(ref _calls);
+            return Task.FromResult<object>(args);
+        }
+
+        public Task<(string? id, object state)> NewResourceAsync(string type, string name, ImmutableDictionary<string, object> inputs, string? provider, string? id)
+            => throw new Exception($"Unknown resource {type}");
+    }
+
+    public sealed class EchoArgs : InvokeArgs
+    {
+        [Input("value")]
+        public Input<string>? Value { get; set; }
+    }
+
+    [OutputType]
+    public sealed class EchoResult
+    {
+        public readonly string Value;
+
+        [OutputConstructor]
+        private EchoResult(string value)
+        {
+            Value = value;
+        }
+    }
+
+    public class InvokeStack : Stack
+    {
+        [Output("known")]
+        public Output<string> Known { get; private set; }
+
+        [Output("secret")]
+        public Output<string> Secret { get; private set; }
+
+        [Output("unknown")]
+        public Output<string> Unknown { get; private set; }
+
+        public InvokeStack()
+        {
+            this.Known = Echo(Output.Create("known"));
+            this.Secret = Echo(Output.CreateSecret("secret"));
+            this.Unknown = Echo(new Output<string>(Task.FromResult(OutputData.Create(
+                ImmutableHashSet<Resource>.Empty, "", isKnown: false, isSecret: false))));
+        }
+
+        private static Output<string> Echo(Input<string> value)
+            => Deployment.Instance.Invoke<EchoResult>("test:index:echo", new EchoArgs { Value = value })
+                .Apply(result => result.Value);
+    }
+
+    public class InvokeTests
+    {
+        [Fact]
+        public async Task InvokePropagatesUnknownsAndSecrets()
+        {
+            var mocks = new EchoMocks();
+            var resources = await Deployment.TestAsync<InvokeStack>(mocks, new TestOptions { IsPreview = false });
+            var stack = resources.OfType<InvokeStack>().Single();
+
+            var known = await stack.Known.DataTask.ConfigureAwait(false);
+            Assert.True(known.IsKnown);
+            Assert.False(known.IsSecret);
+            Assert.Equal("known", known.Value);
+
+            // The secretness of the arguments carries over to the result.
+            var secret = await stack.Secret.DataTask.ConfigureAwait(false);
+            Assert.True(secret.IsKnown);
+            Assert.True(secret.IsSecret);
+            Assert.Equal("secret", secret.Value);
+
+            // If any argument is unknown, the result is unknown and the function is not invoked.
+            var unknown = await stack.Unknown.DataTask.ConfigureAwait(false);
+            Assert.False(unknown.IsKnown);
+            Assert.Equal(2, mocks.Calls);
+        }
+    }
+}
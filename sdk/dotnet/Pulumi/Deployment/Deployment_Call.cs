@@ -0,0 +1,89 @@
+// Copyright 2016-2020, Pulumi Corporation
+
+using System;
+using System.Collections.Immutable;
+using System.Linq;
+using System.Threading.Tasks;
+using Google.Protobuf.WellKnownTypes;
+using Pulumi.Serialization;
+using Pulumirpc;
+
+namespace Pulumi
+{
+    public sealed partial class Deployment
+    {
+        void IDeployment.Call(string token, InvokeArgs args, Resource? self)
+            => _runner.RegisterTask($"Call: {token}", RawCall<object>(token, args, self));
+
+        Output<T> IDeployment.Call<T>(string token, InvokeArgs args, Resource? self)
+            => new Output<T>(RawCall<T>(token, args, self));
+
+        private async Task<OutputData<T>> RawCall<T>(string token, InvokeArgs args, Resource? self)
+        {
+            var label = $"Calling function: token={token} asynchronously";
+            Log.Debug(label);
+
+            // Be resilient to misbehaving callers.
+            args ??= InvokeArgs.Empty;
+
+            // Wait for all values to be available, keeping track of the resources they depend upon. The resource the
+            // method is bound to is passed as the `__self__` argument.
+            var argsDict = await args.ToDictionaryAsync().ConfigureAwait(false);
+            if (self != null)
+            {
+                argsDict = argsDict.SetItem("__self__", self);
+            }
+            var (serialized, propertyToDependentResources) = await SerializeFilteredPropertiesAsync(
+                $"call:{token}", argsDict, _ => true,
+                await this.MonitorSupportsResourceReferences().ConfigureAwait(false)).ConfigureAwait(false);
+            Log.Debug($"Call RPC prepared: token={token}" +
+                (_excessiveDebugOutput ? $", obj={serialized}" : ""));
+
+            var request = new CallRequest
+            {
+                Tok = token,
+                Args = serialized,
+            };
+
+            // Methods are served by the provider of the resource they are bound to.
+            var provider = await ProviderResource.RegisterAsync(self?.GetProvider(token)).ConfigureAwait(false);
+            if (provider != null)
+            {
+                request.Provider = provider;
+            }
+
+            foreach (var (key, resources) in propertyToDependentResources)
+            {
+                var urns = await Task.WhenAll(resources.Select(r => r.Urn.GetValueAsync())).ConfigureAwait(false);
+                var deps = new CallRequest.Types.ArgumentDependencies();
+                deps.Urns.AddRange(urns.Distinct());
+                request.ArgDependencies.Add(key, deps);
+            }
+
+            var result = await this.Monitor.CallAsync(request).ConfigureAwait(false);
+            if (result.Failures.Count > 0)
+            {
+                var reasons = string.Join("; ", result.Failures.Select(f => $"{f.Reason} ({f.Property})"));
+                throw new CallException($"Call of '{token}' failed: {reasons}");
+            }
+
+            // The result depends on the resources that the provider reported for any of its properties.
+            var dependencies = result.ReturnDependencies.Values
+                .SelectMany(d => d.Urns)
+                .Distinct()
+                .Select(urn => (Resource)new DependencyResource(urn))
+                .ToImmutableHashSet();
+
+            var data = Converter.ConvertValue<T>($"{token} result", new Value { StructValue = result.Return ?? new Struct() });
+            return new OutputData<T>(dependencies, data.Value, data.IsKnown, data.IsSecret);
+        }
+
+        private class CallException : Exception
+        {
+            public CallException(string error)
+                : base(error)
+            {
+            }
+        }
+    }
+}
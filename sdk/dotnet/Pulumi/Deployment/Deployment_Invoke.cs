@@ -1,6 +1,8 @@
 ﻿// Copyright 2016-2019, Pulumi Corporation
 
 using System;
+using System.Collections.Immutable;
+using System.Linq;
 using System.Threading.Tasks;
 using Google.Protobuf.WellKnownTypes;
 using Pulumi.Serialization;
@@ -16,10 +18,58 @@ namespace Pulumi
         Task<T> IDeployment.InvokeAsync<T>(string token, InvokeArgs args, InvokeOptions? options)
             => InvokeAsync<T>(token, args, options, convertResult: true);
 
+        Output<T> IDeployment.Invoke<T>(string token, InvokeArgs args, InvokeOptions? options)
+            => new Output<T>(RawInvoke<T>(token, args, options));
+
+        private async Task<OutputData<T>> RawInvoke<T>(string token, InvokeArgs args, InvokeOptions? options)
+        {
+            // Be resilient to misbehaving callers.
+            args ??= InvokeArgs.Empty;
+
+            // Wait for all values to be available, keeping track of the resources they depend upon.
+            var argsDict = await args.ToDictionaryAsync().ConfigureAwait(false);
+            var (serialized, propertyToDependentResources) = await SerializeFilteredPropertiesAsync(
+                $"invoke:{token}", argsDict, _ => true,
+                await this.MonitorSupportsResourceReferences().ConfigureAwait(false)).ConfigureAwait(false);
+
+            var resources = propertyToDependentResources.Values.SelectMany(r => r).ToImmutableHashSet();
+            var isSecret = ContainsSecrets(new Value { StructValue = serialized });
+
+            // If any of the arguments are unknown, the result is unknown.
+            if (ContainsUnknowns(new Value { StructValue = serialized }))
+            {
+                return new OutputData<T>(resources, default!, isKnown: false, isSecret);
+            }
+
+            var result = await InvokeRawAsync(token, serialized, options).ConfigureAwait(false);
+            var data = Converter.ConvertValue<T>($"{token} result", new Value { StructValue = result });
+            return new OutputData<T>(resources, data.Value, data.IsKnown, isSecret || data.IsSecret);
+        }
+
+        private static bool ContainsUnknowns(Value value)
+            => value.KindCase switch
+            {
+                Value.KindOneofCase.StringValue => value.StringValue == Constants.UnknownValue,
+                Value.KindOneofCase.ListValue => value.ListValue.Values.Any(ContainsUnknowns),
+                Value.KindOneofCase.StructValue => value.StructValue.Fields.Values.Any(ContainsUnknowns),
+                _ => false,
+            };
+
+        private static bool ContainsSecrets(Value value)
+            => value.KindCase switch
+            {
+                Value.KindOneofCase.ListValue => value.ListValue.Values.Any(ContainsSecrets),
+                Value.KindOneofCase.StructValue =>
+                    (value.StructValue.Fields.TryGetValue(Constants.SpecialSigKey, out var sig) &&
+                        sig.StringValue == Constants.SpecialSecretSig) ||
+                    value.StructValue.Fields.Values.Any(ContainsSecrets),
+                _ => false,
+            };
+
         private async Task<T> InvokeAsync<T>(
             string token, InvokeArgs args, InvokeOptions? options, bool convertResult)
         {
-            var result = await InvokeRawAsync(token, args, options);
+            var result = await InvokeRawAsync(token, args, options).ConfigureAwait(false);
             
             if (!convertResult)
             {
@@ -43,6 +93,12 @@ namespace Pulumi
             var serialized = await SerializeAllPropertiesAsync(
     				$"invoke:{token}",
     				argsDict, await this.MonitorSupportsResourceReferences().ConfigureAwait(false)).ConfigureAwait(false);
+
+            return await InvokeRawAsync(token, serialized, options).ConfigureAwait(false);
+        }
+
+        private async Task<Struct> InvokeRawAsync(string token, Struct serialized, InvokeOptions? options)
+        {
             Log.Debug($"Invoke RPC prepared: token={token}" +
                 (_excessiveDebugOutput ? $", obj={serialized}" : ""));
 
@@ -38,5 +38,40 @@ namespace Pulumi
         /// return value is ignored.
         /// </summary>
         Task InvokeAsync(string token, InvokeArgs args, InvokeOptions? options = null);
+
+        /// <summary>
+        /// Dynamically invokes the function '<paramref name="token"/>', which is offered by a
+        /// provider plugin.
+        /// <para/>
+        /// The result of <see cref="Invoke"/> will be a <see cref="Output{T}"/> resolved to the
+        /// result value of the provider plugin. The function is not invoked until all of the
+        /// <paramref name="args"/> inputs are known, and the result depends on the resources
+        /// that the inputs depend on.
+        /// <para/>
+        /// The <paramref name="args"/> inputs can be a bag of computed values(including, `T`s,
+        /// <see cref="Task{TResult}"/>s, <see cref="Output{T}"/>s etc.).
+        /// </summary>
+        Output<T> Invoke<T>(string token, InvokeArgs args, InvokeOptions? options = null);
+
+        /// <summary>
+        /// Dynamically calls the method '<paramref name="token"/>', which is offered by a provider
+        /// plugin.
+        /// <para/>
+        /// The result of <see cref="Call{T}"/> will be a <see cref="Output{T}"/> resolved to the
+        /// result value of the method. The result depends on the resources that the provider
+        /// reports as dependencies of the method's return value. If <paramref name="self"/> is
+        /// given, it is the resource to which the method is bound, and its provider is used to
+        /// serve the call.
+        /// <para/>
+        /// The <paramref name="args"/> inputs can be a bag of computed values(including, `T`s,
+        /// <see cref="Task{TResult}"/>s, <see cref="Output{T}"/>s etc.).
+        /// </summary>
+        Output<T> Call<T>(string token, InvokeArgs args, Resource? self = null);
+
+        /// <summary>
+        /// Same as <see cref="Call{T}(string, InvokeArgs, Resource)"/>, however the return value
+        /// is ignored.
+        /// </summary>
+        void Call(string token, InvokeArgs args, Resource? self = null);
     }
 }
@@ -10,6 +10,8 @@ namespace Pulumi
         Task<SupportsFeatureResponse> SupportsFeatureAsync(SupportsFeatureRequest request);
 
         Task<InvokeResponse> InvokeAsync(InvokeRequest request);
+
+        Task<CallResponse> CallAsync(CallRequest request);
         
         Task<ReadResourceResponse> ReadResourceAsync(Resource resource, ReadResourceRequest request);
         
@@ -0,0 +1,26 @@
+// Copyright 2016-2020, Pulumi Corporation
+
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+
+namespace Pulumi.Testing
+{
+    /// <summary>
+    /// Hooks to mock the methods of resources. May be implemented by an <see cref="IMocks"/>; calls
+    /// to methods fail if the mocks do not implement this interface.
+    /// </summary>
+    public interface IMethodMocks
+    {
+        /// <summary>
+        /// Invoked when the program calls a method of a resource.
+        /// </summary>
+        /// <param name="token">Method token.</param>
+        /// <param name="args">Dictionary of input arguments, not including the resource the method
+        /// is called on.</param>
+        /// <param name="self">URN of the resource the method is called on, if any.</param>
+        /// <param name="provider">Provider.</param>
+        /// <returns>Call result, can be either a POCO or a dictionary bag.</returns>
+        Task<object> MethodCallAsync(string token, ImmutableDictionary<string, object> args, string? self,
+            string? provider);
+    }
+}
@@ -53,6 +53,29 @@ namespace Pulumi.Testing
             return new InvokeResponse { Return = await SerializeAsync(result).ConfigureAwait(false) };
         }
 
+        public async Task<CallResponse> CallAsync(CallRequest request)
+        {
+            if (!(_mocks is IMethodMocks methods))
+            {
+                throw new InvalidOperationException(
+                    $"Cannot call method {request.Tok}: mocks do not implement {nameof(IMethodMocks)}");
+            }
+
+            // The resource the method is called on is passed to the mocks by URN.
+            string? self = null;
+            if (request.Args.Fields.TryGetValue("__self__", out var selfValue))
+            {
+                self = selfValue.KindCase == Value.KindOneofCase.StructValue
+                    ? selfValue.StructValue.Fields[Constants.ResourceUrnName].StringValue
+                    : selfValue.StringValue;
+            }
+
+            var args = ToDictionary(request.Args).Remove("__self__");
+            var result = await methods.MethodCallAsync(request.Tok, args, self, request.Provider)
+                .ConfigureAwait(false);
+            return new CallResponse { Return = await SerializeAsync(result).ConfigureAwait(false) };
+        }
+
         public async Task<ReadResourceResponse> ReadResourceAsync(Resource resource, ReadResourceRequest request)
         {
             var (id, state) = await _mocks.NewResourceAsync(request.Type, request.Name,
@@ -59,6 +59,18 @@ type PolicyEvent struct {
 	EnforcementLevel string `json:"enforcementLevel"`
 }
 
+// PolicyRemediationEvent is emitted whenever a policy remediation transforms a resource's inputs.
+type PolicyRemediationEvent struct {
+	ResourceURN          string                 `json:"resourceUrn,omitempty"`
+	Color                string                 `json:"color"`
+	PolicyName           string                 `json:"policyName"`
+	PolicyPackName       string                 `json:"policyPackName"`
+	PolicyPackVersion    string                 `json:"policyPackVersion"`
+	PolicyPackVersionTag string                 `json:"policyPackVersionTag"`
+	Before               map[string]interface{} `json:"before,omitempty"`
+	After                map[string]interface{} `json:"after,omitempty"`
+}
+
 // PreludeEvent is emitted at the start of an update.
 type PreludeEvent struct {
 	// Config contains the keys and values for the update.
@@ -207,6 +219,8 @@ type EngineEvent struct {
 	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
 	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
 	PolicyEvent      *PolicyEvent       `json:"policyEvent,omitempty"`
+
+	PolicyRemediationEvent *PolicyRemediationEvent `json:"policyRemediationEvent,omitempty"`
 }
 
 // EngineEventBatch is a group of engine events.
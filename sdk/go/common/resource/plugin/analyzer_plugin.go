@@ -54,14 +54,15 @@ var _ Analyzer = (*analyzer)(nil)
 // could not be found by name on the PATH, or an error occurs while creating the child process, an error is returned.
 func NewAnalyzer(host Host, ctx *Context, name tokens.QName) (Analyzer, error) {
 	// Load the plugin's path by using the standard workspace logic.
-	_, path, err := workspace.GetPluginPath(
+	path, version, err := ctx.getPluginPath(
 		workspace.AnalyzerPlugin, strings.Replace(string(name), tokens.QNameDelimiter, "_", -1), nil)
 	if err != nil {
 		return nil, rpcerror.Convert(err)
 	} else if path == "" {
 		return nil, workspace.NewMissingError(workspace.PluginInfo{
-			Kind: workspace.AnalyzerPlugin,
-			Name: string(name),
+			Kind:    workspace.AnalyzerPlugin,
+			Name:    string(name),
+			Version: version,
 		})
 	}
 
@@ -98,8 +99,7 @@ func NewPolicyAnalyzer(
 	}
 
 	// Load the policy-booting analyzer plugin (i.e., `pulumi-analyzer-${policyAnalyzerName}`).
-	_, pluginPath, err := workspace.GetPluginPath(
-		workspace.AnalyzerPlugin, policyAnalyzerName, nil)
+	pluginPath, _, err := ctx.getPluginPath(workspace.AnalyzerPlugin, policyAnalyzerName, nil)
 	if err != nil {
 		return nil, rpcerror.Convert(err)
 	} else if pluginPath == "" {
@@ -274,6 +274,55 @@ func (a *analyzer) AnalyzeStack(resources []AnalyzerStackResource) ([]AnalyzeDia
 	return diags, nil
 }
 
+// Remediate is given the opportunity to transform a single resource's properties. It returns the remediations that
+// were applied, in order; the last remediation's properties are the resource's new inputs.
+func (a *analyzer) Remediate(r AnalyzerResource) ([]Remediation, error) {
+	urn, t, name, props := r.URN, r.Type, r.Name, r.Properties
+
+	label := fmt.Sprintf("%s.Remediate(%s)", a.label(), t)
+	logging.V(7).Infof("%s executing (#props=%d)", label, len(props))
+	mprops, err := MarshalProperties(props,
+		MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipInternalKeys: true})
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := marshalProvider(r.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Remediate(a.ctx.Request(), &pulumirpc.AnalyzeRequest{
+		Urn:        string(urn),
+		Type:       string(t),
+		Name:       string(name),
+		Properties: mprops,
+		Options:    marshalResourceOptions(r.Options),
+		Provider:   provider,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		// Handle the case where the policy pack doesn't implement a recent enough AnalyzerService to support the
+		// Remediate method. Ignore the error as it just means the analyzer has no remediations to apply.
+		if rpcError.Code() == codes.Unimplemented {
+			logging.V(7).Infof("%s is unimplemented, skipping: err=%v", label, rpcError)
+			return nil, nil
+		}
+
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+		return nil, rpcError
+	}
+
+	remediations := resp.GetRemediations()
+	logging.V(7).Infof("%s success: remediations=#%d", label, len(remediations))
+
+	results, err := convertRemediations(remediations, a.version)
+	if err != nil {
+		return nil, errors.Wrap(err, "converting remediation results")
+	}
+	return results, nil
+}
+
 // GetAnalyzerInfo returns metadata about the policies contained in this analyzer plugin.
 func (a *analyzer) GetAnalyzerInfo() (AnalyzerInfo, error) {
 	label := fmt.Sprintf("%s.GetAnalyzerInfo()", a.label())
@@ -644,6 +693,40 @@ func convertDiagnostics(protoDiagnostics []*pulumirpc.AnalyzeDiagnostic, version
 	return diagnostics, nil
 }
 
+func convertRemediations(protoRemediations []*pulumirpc.Remediation, version string) ([]Remediation, error) {
+	remediations := make([]Remediation, len(protoRemediations))
+	for idx := range protoRemediations {
+		protoR := protoRemediations[idx]
+
+		// The version from PulumiPolicy.yaml is used, if set, over the version from the remediation.
+		policyPackVersion := protoR.PolicyPackVersion
+		if version != "" {
+			policyPackVersion = version
+		}
+
+		var props resource.PropertyMap
+		if protoR.Properties != nil {
+			var err error
+			props, err = UnmarshalProperties(protoR.Properties,
+				MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipInternalKeys: true})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		remediations[idx] = Remediation{
+			PolicyName:        protoR.PolicyName,
+			PolicyPackName:    protoR.PolicyPackName,
+			PolicyPackVersion: policyPackVersion,
+			Description:       protoR.Description,
+			Properties:        props,
+			Diagnostic:        protoR.Diagnostic,
+		}
+	}
+
+	return remediations, nil
+}
+
 // constructEnv creates a slice of key/value pairs to be used as the environment for the policy pack process. Each entry
 // is of the form "key=value". Config is passed as an environment variable (including unecrypted secrets), similar to
 // how config is passed to each language runtime plugin.
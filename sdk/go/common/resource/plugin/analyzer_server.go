@@ -0,0 +1,334 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+type analyzerServer struct {
+	analyzer Analyzer
+}
+
+// NewAnalyzerServer returns a gRPC server that serves the given analyzer.
+func NewAnalyzerServer(analyzer Analyzer) pulumirpc.AnalyzerServer {
+	return &analyzerServer{analyzer: analyzer}
+}
+
+func (a *analyzerServer) unmarshalOptions(label string) MarshalOptions {
+	return MarshalOptions{
+		Label:        label,
+		KeepUnknowns: true,
+		KeepSecrets:  true,
+	}
+}
+
+func (a *analyzerServer) unmarshalResource(label string, urn, typ, name string, props *structpb.Struct,
+	opts *pulumirpc.AnalyzerResourceOptions, provider *pulumirpc.AnalyzerProviderResource) (AnalyzerResource, error) {
+
+	properties, err := UnmarshalProperties(props, a.unmarshalOptions(label+".properties"))
+	if err != nil {
+		return AnalyzerResource{}, err
+	}
+
+	r := AnalyzerResource{
+		URN:        resource.URN(urn),
+		Type:       tokens.Type(typ),
+		Name:       tokens.QName(name),
+		Properties: properties,
+		Options:    unmarshalResourceOptions(opts),
+	}
+
+	if provider != nil {
+		providerProps, err := UnmarshalProperties(provider.GetProperties(),
+			a.unmarshalOptions(label+".provider.properties"))
+		if err != nil {
+			return AnalyzerResource{}, err
+		}
+		r.Provider = &AnalyzerProviderResource{
+			URN:        resource.URN(provider.GetUrn()),
+			Type:       tokens.Type(provider.GetType()),
+			Name:       tokens.QName(provider.GetName()),
+			Properties: providerProps,
+		}
+	}
+
+	return r, nil
+}
+
+func (a *analyzerServer) Analyze(ctx context.Context,
+	req *pulumirpc.AnalyzeRequest) (*pulumirpc.AnalyzeResponse, error) {
+
+	r, err := a.unmarshalResource("Analyze", req.GetUrn(), req.GetType(), req.GetName(), req.GetProperties(),
+		req.GetOptions(), req.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	diags, err := a.analyzer.Analyze(r)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.AnalyzeResponse{Diagnostics: marshalDiagnostics(diags)}, nil
+}
+
+func (a *analyzerServer) AnalyzeStack(ctx context.Context,
+	req *pulumirpc.AnalyzeStackRequest) (*pulumirpc.AnalyzeResponse, error) {
+
+	resources := make([]AnalyzerStackResource, len(req.GetResources()))
+	for i, res := range req.GetResources() {
+		r, err := a.unmarshalResource("AnalyzeStack", res.GetUrn(), res.GetType(), res.GetName(),
+			res.GetProperties(), res.GetOptions(), res.GetProvider())
+		if err != nil {
+			return nil, err
+		}
+
+		dependencies := make([]resource.URN, len(res.GetDependencies()))
+		for j, dep := range res.GetDependencies() {
+			dependencies[j] = resource.URN(dep)
+		}
+
+		var propertyDependencies map[resource.PropertyKey][]resource.URN
+		if len(res.GetPropertyDependencies()) != 0 {
+			propertyDependencies = make(map[resource.PropertyKey][]resource.URN)
+			for k, deps := range res.GetPropertyDependencies() {
+				urns := make([]resource.URN, len(deps.GetUrns()))
+				for j, urn := range deps.GetUrns() {
+					urns[j] = resource.URN(urn)
+				}
+				propertyDependencies[resource.PropertyKey(k)] = urns
+			}
+		}
+
+		resources[i] = AnalyzerStackResource{
+			AnalyzerResource:     r,
+			Parent:               resource.URN(res.GetParent()),
+			Dependencies:         dependencies,
+			PropertyDependencies: propertyDependencies,
+		}
+	}
+
+	diags, err := a.analyzer.AnalyzeStack(resources)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.AnalyzeResponse{Diagnostics: marshalDiagnostics(diags)}, nil
+}
+
+func (a *analyzerServer) Remediate(ctx context.Context,
+	req *pulumirpc.AnalyzeRequest) (*pulumirpc.RemediateResponse, error) {
+
+	r, err := a.unmarshalResource("Remediate", req.GetUrn(), req.GetType(), req.GetName(), req.GetProperties(),
+		req.GetOptions(), req.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	remediations, err := a.analyzer.Remediate(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcRemediations := make([]*pulumirpc.Remediation, len(remediations))
+	for i, rem := range remediations {
+		var props *structpb.Struct
+		if rem.Properties != nil {
+			props, err = MarshalProperties(rem.Properties, MarshalOptions{
+				Label:        "Remediate.properties",
+				KeepUnknowns: true,
+				KeepSecrets:  true,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rpcRemediations[i] = &pulumirpc.Remediation{
+			PolicyName:        rem.PolicyName,
+			PolicyPackName:    rem.PolicyPackName,
+			PolicyPackVersion: rem.PolicyPackVersion,
+			Description:       rem.Description,
+			Properties:        props,
+			Diagnostic:        rem.Diagnostic,
+		}
+	}
+	return &pulumirpc.RemediateResponse{Remediations: rpcRemediations}, nil
+}
+
+func (a *analyzerServer) GetAnalyzerInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.AnalyzerInfo, error) {
+	info, err := a.analyzer.GetAnalyzerInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]*pulumirpc.PolicyInfo, len(info.Policies))
+	for i, p := range info.Policies {
+		var configSchema *pulumirpc.PolicyConfigSchema
+		if p.ConfigSchema != nil {
+			props, err := marshalJSONMap(p.ConfigSchema.Properties)
+			if err != nil {
+				return nil, errors.Wrapf(err, "marshaling config schema for policy %s", p.Name)
+			}
+			configSchema = &pulumirpc.PolicyConfigSchema{
+				Properties: props,
+				Required:   p.ConfigSchema.Required,
+			}
+		}
+
+		policies[i] = &pulumirpc.PolicyInfo{
+			Name:             p.Name,
+			DisplayName:      p.DisplayName,
+			Description:      p.Description,
+			Message:          p.Message,
+			EnforcementLevel: marshalEnforcementLevel(p.EnforcementLevel),
+			ConfigSchema:     configSchema,
+		}
+	}
+
+	initialConfig := make(map[string]*pulumirpc.PolicyConfig)
+	for k, v := range info.InitialConfig {
+		props, err := marshalJSONMap(v.Properties)
+		if err != nil {
+			return nil, errors.Wrapf(err, "marshaling initial config for policy %s", k)
+		}
+		initialConfig[k] = &pulumirpc.PolicyConfig{
+			EnforcementLevel: marshalEnforcementLevel(v.EnforcementLevel),
+			Properties:       props,
+		}
+	}
+
+	return &pulumirpc.AnalyzerInfo{
+		Name:           info.Name,
+		DisplayName:    info.DisplayName,
+		Version:        info.Version,
+		SupportsConfig: info.SupportsConfig,
+		Policies:       policies,
+		InitialConfig:  initialConfig,
+	}, nil
+}
+
+func (a *analyzerServer) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	info, err := a.analyzer.GetPluginInfo()
+	if err != nil {
+		return nil, err
+	}
+	var version string
+	if info.Version != nil {
+		version = info.Version.String()
+	}
+	return &pulumirpc.PluginInfo{Version: version}, nil
+}
+
+func (a *analyzerServer) Configure(ctx context.Context,
+	req *pulumirpc.ConfigureAnalyzerRequest) (*pbempty.Empty, error) {
+
+	policyConfig := make(map[string]AnalyzerPolicyConfig)
+	for k, v := range req.GetPolicyConfig() {
+		enforcementLevel, err := convertEnforcementLevel(v.GetEnforcementLevel())
+		if err != nil {
+			return nil, err
+		}
+		policyConfig[k] = AnalyzerPolicyConfig{
+			EnforcementLevel: enforcementLevel,
+			Properties:       unmarshalMap(v.GetProperties()),
+		}
+	}
+
+	if err := a.analyzer.Configure(policyConfig); err != nil {
+		return nil, err
+	}
+	return &pbempty.Empty{}, nil
+}
+
+func unmarshalResourceOptions(opts *pulumirpc.AnalyzerResourceOptions) AnalyzerResourceOptions {
+	if opts == nil {
+		return AnalyzerResourceOptions{}
+	}
+
+	secs := make([]resource.PropertyKey, len(opts.GetAdditionalSecretOutputs()))
+	for i, k := range opts.GetAdditionalSecretOutputs() {
+		secs[i] = resource.PropertyKey(k)
+	}
+
+	aliases := make([]resource.URN, len(opts.GetAliases()))
+	for i, a := range opts.GetAliases() {
+		aliases[i] = resource.URN(a)
+	}
+
+	var deleteBeforeReplace *bool
+	if opts.GetDeleteBeforeReplaceDefined() {
+		dbr := opts.GetDeleteBeforeReplace()
+		deleteBeforeReplace = &dbr
+	}
+
+	result := AnalyzerResourceOptions{
+		Protect:                 opts.GetProtect(),
+		IgnoreChanges:           opts.GetIgnoreChanges(),
+		DeleteBeforeReplace:     deleteBeforeReplace,
+		AdditionalSecretOutputs: secs,
+		Aliases:                 aliases,
+	}
+	if timeouts := opts.GetCustomTimeouts(); timeouts != nil {
+		result.CustomTimeouts = resource.CustomTimeouts{
+			Create: timeouts.GetCreate(),
+			Update: timeouts.GetUpdate(),
+			Delete: timeouts.GetDelete(),
+		}
+	}
+	return result
+}
+
+func marshalDiagnostics(diags []AnalyzeDiagnostic) []*pulumirpc.AnalyzeDiagnostic {
+	result := make([]*pulumirpc.AnalyzeDiagnostic, len(diags))
+	for i, d := range diags {
+		result[i] = &pulumirpc.AnalyzeDiagnostic{
+			PolicyName:        d.PolicyName,
+			PolicyPackName:    d.PolicyPackName,
+			PolicyPackVersion: d.PolicyPackVersion,
+			Description:       d.Description,
+			Message:           d.Message,
+			Tags:              d.Tags,
+			EnforcementLevel:  marshalEnforcementLevel(d.EnforcementLevel),
+			Urn:               string(d.URN),
+		}
+	}
+	return result
+}
+
+// marshalJSONMap marshals an arbitrary JSON-compatible map (e.g. a JSON schema) into a protobuf struct. The map is
+// first round-tripped through JSON so that its values are normalized to the types understood by marshalMap.
+func marshalJSONMap(m interface{}) (*structpb.Struct, error) {
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err = json.Unmarshal(bytes, &normalized); err != nil {
+		return nil, err
+	}
+	if normalized == nil {
+		return nil, nil
+	}
+	return marshalMap(normalized), nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+type testAnalyzer struct {
+	analyzed   []AnalyzerResource
+	configured map[string]AnalyzerPolicyConfig
+}
+
+func (a *testAnalyzer) Close() error       { return nil }
+func (a *testAnalyzer) Name() tokens.QName { return "test" }
+func (a *testAnalyzer) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{Name: "test"}, nil
+}
+
+func (a *testAnalyzer) Analyze(r AnalyzerResource) ([]AnalyzeDiagnostic, error) {
+	a.analyzed = append(a.analyzed, r)
+	return []AnalyzeDiagnostic{{
+		PolicyName:       "policy",
+		PolicyPackName:   "test",
+		Message:          "violation",
+		EnforcementLevel: apitype.Mandatory,
+		URN:              r.URN,
+	}}, nil
+}
+
+func (a *testAnalyzer) AnalyzeStack(resources []AnalyzerStackResource) ([]AnalyzeDiagnostic, error) {
+	return nil, nil
+}
+
+func (a *testAnalyzer) Remediate(r AnalyzerResource) ([]Remediation, error) {
+	props := r.Properties.Copy()
+	props["tags"] = resource.NewStringProperty("tagged")
+	return []Remediation{{PolicyName: "policy", PolicyPackName: "test", Properties: props}}, nil
+}
+
+func (a *testAnalyzer) GetAnalyzerInfo() (AnalyzerInfo, error) {
+	return AnalyzerInfo{
+		Name:           "test",
+		SupportsConfig: true,
+		Policies: []AnalyzerPolicyInfo{{
+			Name:             "policy",
+			EnforcementLevel: apitype.Advisory,
+			ConfigSchema: &AnalyzerPolicyConfigSchema{
+				Properties: map[string]JSONSchema{"max": {"type": "number", "default": 1}},
+			},
+		}},
+	}, nil
+}
+
+func (a *testAnalyzer) Configure(policyConfig map[string]AnalyzerPolicyConfig) error {
+	a.configured = policyConfig
+	return nil
+}
+
+func TestAnalyzerServer(t *testing.T) {
+	a := &testAnalyzer{}
+	server := NewAnalyzerServer(a)
+	ctx := context.Background()
+
+	props, err := MarshalProperties(resource.PropertyMap{
+		"name": resource.NewStringProperty("res"),
+	}, MarshalOptions{})
+	assert.NoError(t, err)
+
+	req := &pulumirpc.AnalyzeRequest{
+		Urn:        "urn:pulumi:stack::proj::pkg:m:typ::res",
+		Type:       "pkg:m:typ",
+		Name:       "res",
+		Properties: props,
+		Options:    &pulumirpc.AnalyzerResourceOptions{Protect: true},
+	}
+
+	resp, err := server.Analyze(ctx, req)
+	assert.NoError(t, err)
+	if assert.Len(t, resp.GetDiagnostics(), 1) {
+		diag := resp.GetDiagnostics()[0]
+		assert.Equal(t, "violation", diag.GetMessage())
+		assert.Equal(t, pulumirpc.EnforcementLevel_MANDATORY, diag.GetEnforcementLevel())
+		assert.Equal(t, req.Urn, diag.GetUrn())
+	}
+	if assert.Len(t, a.analyzed, 1) {
+		assert.Equal(t, tokens.Type("pkg:m:typ"), a.analyzed[0].Type)
+		assert.Equal(t, resource.NewStringProperty("res"), a.analyzed[0].Properties["name"])
+		assert.True(t, a.analyzed[0].Options.Protect)
+	}
+
+	remediations, err := server.Remediate(ctx, req)
+	assert.NoError(t, err)
+	if assert.Len(t, remediations.GetRemediations(), 1) {
+		remediated, err := UnmarshalProperties(remediations.GetRemediations()[0].GetProperties(), MarshalOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, resource.PropertyMap{
+			"name": resource.NewStringProperty("res"),
+			"tags": resource.NewStringProperty("tagged"),
+		}, remediated)
+	}
+
+	info, err := server.GetAnalyzerInfo(ctx, &pbempty.Empty{})
+	assert.NoError(t, err)
+	assert.True(t, info.GetSupportsConfig())
+	if assert.Len(t, info.GetPolicies(), 1) {
+		schema := info.GetPolicies()[0].GetConfigSchema()
+		assert.Equal(t, map[string]interface{}{"type": "number", "default": 1.0},
+			unmarshalMap(schema.GetProperties())["max"])
+	}
+
+	_, err = server.Configure(ctx, &pulumirpc.ConfigureAnalyzerRequest{
+		PolicyConfig: map[string]*pulumirpc.PolicyConfig{
+			"policy": {EnforcementLevel: pulumirpc.EnforcementLevel_DISABLED},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, apitype.Disabled, a.configured["policy"].EnforcementLevel)
+}
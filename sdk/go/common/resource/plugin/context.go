@@ -18,11 +18,14 @@ import (
 	"context"
 	"io/ioutil"
 
+	"github.com/blang/semver"
 	"github.com/opentracing/opentracing-go"
 
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
 )
 
 // Context is used to group related operations together so that associated OS resources can be cached, shared, and
@@ -33,6 +36,9 @@ type Context struct {
 	Host       Host      // the host that can be used to fetch providers.
 	Pwd        string    // the working directory to spawn all plugins in.
 
+	// PluginLock pins the versions of plugins that are loaded without requesting a particular version, if non-nil.
+	PluginLock *workspace.PluginLock
+
 	tracingSpan opentracing.Span // the OpenTracing span to parent requests within.
 }
 
@@ -72,6 +78,28 @@ func (ctx *Context) Request() context.Context {
 	return opentracing.ContextWithSpan(context.Background(), ctx.tracingSpan)
 }
 
+// getPluginPath finds a plugin's path by its kind, name, and optional version. If no version is requested and the
+// context's plugin lock pins the plugin, only the locked version is used. The version that was searched for, if any,
+// is returned alongside the path.
+func (ctx *Context) getPluginPath(kind workspace.PluginKind, name string,
+	version *semver.Version) (string, *semver.Version, error) {
+
+	if version == nil && ctx != nil {
+		if locked, ok := ctx.PluginLock.Get(kind, name); ok {
+			info, err := locked.PluginInfo()
+			if err != nil {
+				return "", nil, err
+			}
+			logging.V(6).Infof("getPluginPath(%s, %s): using version %s from plugin lock", kind, name, info.Version)
+			_, path, err := workspace.GetExactPluginPath(kind, name, *info.Version)
+			return path, info.Version, err
+		}
+	}
+
+	_, path, err := workspace.GetPluginPath(kind, name, version)
+	return path, version, err
+}
+
 // Close reclaims all resources associated with this context.
 func (ctx *Context) Close() error {
 	if ctx.tracingSpan != nil {
@@ -101,6 +101,9 @@ func NewDefaultHost(ctx *Context, config ConfigSource, runtimeOptions map[string
 		disableProviderPreview:  disableProviderPreview,
 	}
 
+	// If a provider daemon has been configured, lease resource plugins from it rather than launching them.
+	host.providerDaemon = os.Getenv(ProviderDaemonEnvVar)
+
 	// Fire up a gRPC server to listen for requests.  This acts as a RPC interface that plugins can use
 	// to "phone home" in case there are things the host must do on behalf of the plugins (like log, etc).
 	svr, err := newHostServer(host, ctx)
@@ -144,6 +147,7 @@ type defaultHost struct {
 	loadRequests            chan pluginLoadRequest           // a channel used to satisfy plugin load requests.
 	server                  *hostServer                      // the server's RPC machinery.
 	disableProviderPreview  bool                             // true if provider plugins should disable provider preview
+	providerDaemon          string                           // the provider daemon to lease resource plugins from.
 }
 
 var _ Host = (*defaultHost)(nil)
@@ -260,7 +264,14 @@ func (host *defaultHost) ListAnalyzers() []Analyzer {
 func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (Provider, error) {
 	plugin, err := host.loadPlugin(func() (interface{}, error) {
 		// Try to load and bind to a plugin.
-		plug, err := NewProvider(host, host.ctx, pkg, version, host.runtimeOptions, host.disableProviderPreview)
+		var plug Provider
+		var err error
+		if host.providerDaemon != "" {
+			plug, err = newDaemonProvider(host.providerDaemon, host, host.ctx, pkg, version, host.runtimeOptions,
+				host.disableProviderPreview)
+		} else {
+			plug, err = NewProvider(host, host.ctx, pkg, version, host.runtimeOptions, host.disableProviderPreview)
+		}
 		if err == nil && plug != nil {
 			info, infoerr := plug.GetPluginInfo()
 			if infoerr != nil {
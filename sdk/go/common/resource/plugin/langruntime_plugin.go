@@ -44,14 +44,15 @@ type langhost struct {
 func NewLanguageRuntime(host Host, ctx *Context, runtime string,
 	options map[string]interface{}) (LanguageRuntime, error) {
 
-	_, path, err := workspace.GetPluginPath(
+	path, version, err := ctx.getPluginPath(
 		workspace.LanguagePlugin, strings.Replace(runtime, tokens.QNameDelimiter, "_", -1), nil)
 	if err != nil {
 		return nil, err
 	} else if path == "" {
 		return nil, workspace.NewMissingError(workspace.PluginInfo{
-			Kind: workspace.LanguagePlugin,
-			Name: runtime,
+			Kind:    workspace.LanguagePlugin,
+			Name:    runtime,
+			Version: version,
 		})
 	}
 
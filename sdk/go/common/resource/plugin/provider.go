@@ -87,6 +87,9 @@ type Provider interface {
 		tok tokens.ModuleMember,
 		args resource.PropertyMap,
 		onNext func(resource.PropertyMap) error) ([]CheckFailure, error)
+	// Call dynamically executes a method in the provider associated with a component resource.
+	Call(tok tokens.ModuleMember, args resource.PropertyMap, info CallInfo,
+		options CallOptions) (CallResult, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
 
@@ -253,3 +256,29 @@ type ConstructResult struct {
 	// The resources that each output property depends on.
 	OutputDependencies map[resource.PropertyKey][]resource.URN
 }
+
+// CallInfo contains all of the information required to register resources as part of a call to Call.
+type CallInfo struct {
+	Project        string                // the project name housing the program being run.
+	Stack          string                // the stack name being evaluated.
+	Config         map[config.Key]string // the configuration variables to apply before running.
+	DryRun         bool                  // true if we are performing a dry-run (preview).
+	Parallel       int                   // the degree of parallelism for resource operations (<=1 for serial).
+	MonitorAddress string                // the RPC address to the host resource monitor.
+}
+
+// CallOptions captures options for a call to Call.
+type CallOptions struct {
+	// ArgDependencies is a map from argument keys to a list of resources that the argument depends on.
+	ArgDependencies map[resource.PropertyKey][]resource.URN
+}
+
+// CallResult is the result of a call to Call.
+type CallResult struct {
+	// The returned values, if the call was successful.
+	Return resource.PropertyMap
+	// A map from return value keys to the dependencies of the return value.
+	ReturnDependencies map[resource.PropertyKey][]resource.URN
+	// The failures if any arguments didn't pass verification.
+	Failures []CheckFailure
+}
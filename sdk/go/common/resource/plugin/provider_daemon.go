@@ -0,0 +1,553 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// ProviderDaemonEnvVar is the environment variable that holds the address of a provider daemon, as started by
+// `pulumi plugin daemon`. When it is set, hosts created with NewDefaultHost lease their resource providers from the
+// daemon rather than launching provider plugins themselves.
+const ProviderDaemonEnvVar = "PULUMI_PROVIDER_DAEMON"
+
+// providerLeasePath is the path at which a provider daemon leases providers.
+const providerLeasePath = "/providers"
+
+// maxProviderLeaseRequestSize is the maximum size of the body of a lease request.
+const maxProviderLeaseRequestSize = 1 << 20
+
+// providerLeaseRequest asks a provider daemon to lease a provider.
+type providerLeaseRequest struct {
+	Package                string                 `json:"package"`
+	Version                string                 `json:"version,omitempty"`
+	Pwd                    string                 `json:"pwd"`
+	Options                map[string]interface{} `json:"options,omitempty"`
+	DisableProviderPreview bool                   `json:"disableProviderPreview,omitempty"`
+	Environ                []string               `json:"environ,omitempty"`
+	Engine                 string                 `json:"engine"` // the address of the lessee's host.
+}
+
+// providerLeaseResponse describes a provider leased from a provider daemon.
+type providerLeaseResponse struct {
+	Address string `json:"address,omitempty"` // the address of the leased provider's RPC server.
+	Error   string `json:"error,omitempty"`   // the reason the provider could not be leased, if any.
+}
+
+// ProviderDaemon is a long-lived process that keeps provider plugins running between the deployments that use them,
+// so that deployments run by separate CLI invocations can reuse them rather than relaunch them. The daemon launches
+// provider plugins from a ProviderPool on behalf of its clients, and serves each leased provider over its own RPC
+// server.
+//
+// A client leases a provider by posting a request to the daemon, which responds with the address of the provider's
+// RPC server and holds the lease until the client closes the connection. Messages logged by the provider during the
+// lease are forwarded to the client's host. Providers are launched with the client's working directory and
+// environment, and are only shared between clients whose working directory, environment, and provider configuration
+// are identical.
+//
+// The daemon only listens on loopback addresses. When it starts, it writes a random token to a file that only the
+// current user can read (see ProviderDaemonTokenPath), and rejects any lease request that does not carry the token.
+// Variables that change how a process is loaded, such as LD_PRELOAD, are removed from the environment of every
+// provider that the daemon launches.
+type ProviderDaemon struct {
+	pool      *ProviderPool
+	listener  net.Listener
+	server    *http.Server
+	token     string
+	tokenPath string
+
+	m      sync.Mutex
+	leases sync.WaitGroup // the leases that are in progress.
+	closed bool           // true once the daemon has been closed.
+}
+
+// NewProviderDaemon creates a provider daemon that listens on the given loopback address and shuts down provider
+// plugins once they have been idle for the given duration. The daemon does not accept leases until Serve is called.
+func NewProviderDaemon(address string, idleTimeout time.Duration) (*ProviderDaemon, error) {
+	if !isLoopbackAddress(address) {
+		return nil, errors.Errorf("%s is not a loopback address; the provider daemon only listens on loopback addresses",
+			address)
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listening on %s", address)
+	}
+
+	token, tokenPath, err := writeProviderDaemonToken(listener.Addr().String())
+	if err != nil {
+		contract.IgnoreClose(listener)
+		return nil, err
+	}
+
+	d := &ProviderDaemon{
+		pool:      NewProviderPool(idleTimeout),
+		listener:  listener,
+		token:     token,
+		tokenPath: tokenPath,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(providerLeasePath, d.lease)
+	d.server = &http.Server{Handler: mux}
+	return d, nil
+}
+
+// ProviderDaemonTokenPath returns the path of the file that holds the token of the provider daemon that listens on
+// the given address. The address must be the one that the daemon reports (see ProviderDaemon.Addr).
+func ProviderDaemonTokenPath(address string) (string, error) {
+	name := strings.NewReplacer(":", "_", "[", "", "]", "", "%", "_").Replace(address) + ".token"
+	return workspace.GetPulumiPath("provider-daemons", name)
+}
+
+// writeProviderDaemonToken generates a token for the provider daemon that listens on the given address and writes it
+// to a file that only the current user can read. It returns the token and the path of the file.
+func writeProviderDaemonToken(address string) (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", "", errors.Wrap(err, "generating provider daemon token")
+	}
+	token := hex.EncodeToString(b)
+
+	path, err := ProviderDaemonTokenPath(address)
+	if err != nil {
+		return "", "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", "", errors.Wrap(err, "creating provider daemon token directory")
+	}
+
+	// Remove any token left behind by a daemon that exited without cleaning up, so that the new file is created with
+	// the right permissions rather than inheriting those of the old one.
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return "", "", errors.Wrap(err, "removing stale provider daemon token")
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", "", errors.Wrap(err, "writing provider daemon token")
+	}
+	_, err = f.WriteString(token)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		contract.IgnoreError(os.Remove(path))
+		return "", "", errors.Wrap(err, "writing provider daemon token")
+	}
+	return token, path, nil
+}
+
+// readProviderDaemonToken reads the token of the provider daemon that listens on the given address.
+func readProviderDaemonToken(address string) (string, error) {
+	path, err := ProviderDaemonTokenPath(address)
+	if err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "reading provider daemon token")
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// isLoopbackAddress returns true if the host of the given host:port address is a loopback address.
+func isLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// scrubProviderEnviron returns the given environment without the variables that change how a process is loaded or
+// started, such as LD_PRELOAD, which could otherwise be used to run arbitrary code in the providers a daemon launches.
+func scrubProviderEnviron(environ []string) []string {
+	var scrubbed []string
+	for _, kv := range environ {
+		name := kv
+		if i := strings.Index(kv, "="); i >= 0 {
+			name = kv[:i]
+		}
+		upper := strings.ToUpper(name)
+		switch {
+		case strings.HasPrefix(upper, "LD_"), strings.HasPrefix(upper, "DYLD_"):
+			continue
+		case upper == "BASH_ENV" || upper == "ENV" || upper == "NODE_OPTIONS" || upper == "PYTHONSTARTUP":
+			continue
+		}
+		scrubbed = append(scrubbed, kv)
+	}
+	return scrubbed
+}
+
+// Addr returns the address on which the daemon listens.
+func (d *ProviderDaemon) Addr() string {
+	return d.listener.Addr().String()
+}
+
+// Serve accepts leases until the daemon is closed.
+func (d *ProviderDaemon) Serve() error {
+	if err := d.server.Serve(d.listener); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Close stops accepting leases, ends the leases in progress, and shuts down the daemon's provider plugins.
+func (d *ProviderDaemon) Close() error {
+	d.m.Lock()
+	d.closed = true
+	d.m.Unlock()
+
+	// Closing the server closes its connections, which ends their leases.
+	err := d.server.Close()
+	d.leases.Wait()
+	if poolErr := d.pool.Close(); err == nil {
+		err = poolErr
+	}
+	if tokenErr := os.Remove(d.tokenPath); err == nil && !os.IsNotExist(tokenErr) {
+		err = tokenErr
+	}
+	return err
+}
+
+// lease leases a provider to the client that sent the request. The lease lasts until the client closes the
+// connection, at which point the provider is returned to the pool.
+func (d *ProviderDaemon) lease(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	auth := r.Header.Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(auth), []byte("Bearer "+d.token)) != 1 {
+		http.Error(w, "invalid provider daemon token", http.StatusUnauthorized)
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil ||
+		mediaType != "application/json" {
+		http.Error(w, "lease requests must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	d.m.Lock()
+	if d.closed {
+		d.m.Unlock()
+		http.Error(w, "the provider daemon is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	d.leases.Add(1)
+	d.m.Unlock()
+	defer d.leases.Done()
+
+	var req providerLeaseRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxProviderLeaseRequestSize)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid lease request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if !filepath.IsAbs(req.Pwd) {
+		http.Error(w, fmt.Sprintf("invalid lease request: %q is not an absolute path", req.Pwd), http.StatusBadRequest)
+		return
+	}
+	if !isLoopbackAddress(req.Engine) {
+		http.Error(w, fmt.Sprintf("invalid lease request: %q is not a loopback address", req.Engine),
+			http.StatusBadRequest)
+		return
+	}
+
+	address, release, err := d.leaseProvider(req)
+	if err != nil {
+		logging.V(7).Infof("could not lease %s provider: %v", req.Package, err)
+		writeProviderLeaseResponse(w, providerLeaseResponse{Error: err.Error()})
+		return
+	}
+	defer release()
+
+	logging.V(7).Infof("leased %s provider at %s", req.Package, address)
+	writeProviderLeaseResponse(w, providerLeaseResponse{Address: address})
+	<-r.Context().Done()
+	logging.V(7).Infof("returning %s provider at %s", req.Package, address)
+}
+
+// leaseProvider leases a provider from the daemon's pool and serves it over a new RPC server. It returns the address
+// of the server and a function that stops the server and returns the provider to the pool.
+func (d *ProviderDaemon) leaseProvider(req providerLeaseRequest) (string, func(), error) {
+	var version *semver.Version
+	if req.Version != "" {
+		v, err := semver.ParseTolerant(req.Version)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "invalid version %q", req.Version)
+		}
+		version = &v
+	}
+
+	conn, err := grpc.Dial(
+		req.Engine,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(rpcutil.OpenTracingClientInterceptor()),
+		rpcutil.GrpcChannelOptions(),
+	)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "could not dial lessee at %s", req.Engine)
+	}
+	lessee := newEngineLessee(pulumirpc.NewEngineClient(conn))
+
+	prov, err := d.pool.leaseProvider(lessee, req.Pwd, tokens.Package(req.Package), version, req.Options,
+		req.DisableProviderPreview, scrubProviderEnviron(req.Environ))
+	if err != nil {
+		contract.IgnoreClose(conn)
+		return "", nil, err
+	}
+
+	cancel := make(chan bool)
+	port, done, err := rpcutil.Serve(0, cancel, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, NewProviderServer(prov))
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		contract.IgnoreClose(prov)
+		contract.IgnoreClose(conn)
+		return "", nil, err
+	}
+
+	release := func() {
+		// Let any calls that are in progress finish before returning the provider to the pool.
+		close(cancel)
+		if err := <-done; err != nil {
+			logging.V(5).Infof("error serving leased %s provider: %v", req.Package, err)
+		}
+		contract.IgnoreClose(prov)
+		contract.IgnoreClose(conn)
+	}
+	return fmt.Sprintf("127.0.0.1:%d", port), release, nil
+}
+
+func writeProviderLeaseResponse(w http.ResponseWriter, resp providerLeaseResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logging.V(5).Infof("could not write lease response: %v", err)
+		return
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// engineLessee is a lessee in another process, which receives messages through its host's RPC interface.
+type engineLessee struct {
+	engine     pulumirpc.EngineClient
+	diag       diag.Sink
+	statusDiag diag.Sink
+}
+
+func newEngineLessee(engine pulumirpc.EngineClient) *engineLessee {
+	l := &engineLessee{engine: engine}
+	l.diag, l.statusDiag = &engineSink{lessee: l}, &engineSink{lessee: l, ephemeral: true}
+	return l
+}
+
+func (l *engineLessee) log(sev diag.Severity, urn resource.URN, msg string, streamID int32, ephemeral bool) {
+	var rpcsev pulumirpc.LogSeverity
+	switch sev {
+	case diag.Debug:
+		rpcsev = pulumirpc.LogSeverity_DEBUG
+	case diag.Warning:
+		rpcsev = pulumirpc.LogSeverity_WARNING
+	case diag.Error:
+		rpcsev = pulumirpc.LogSeverity_ERROR
+	default:
+		rpcsev = pulumirpc.LogSeverity_INFO
+	}
+
+	_, err := l.engine.Log(context.Background(), &pulumirpc.LogRequest{
+		Severity:  rpcsev,
+		Message:   msg,
+		Urn:       string(urn),
+		StreamId:  streamID,
+		Ephemeral: ephemeral,
+	})
+	if err != nil {
+		logging.V(7).Infof("could not forward message to lessee: %v", err)
+	}
+}
+
+func (l *engineLessee) Log(sev diag.Severity, urn resource.URN, msg string, streamID int32) {
+	l.log(sev, urn, msg, streamID, false)
+}
+
+func (l *engineLessee) LogStatus(sev diag.Severity, urn resource.URN, msg string, streamID int32) {
+	l.log(sev, urn, msg, streamID, true)
+}
+
+func (l *engineLessee) Diag() diag.Sink       { return l.diag }
+func (l *engineLessee) StatusDiag() diag.Sink { return l.statusDiag }
+
+// engineSink forwards the output of a leased process to a lessee in another process.
+type engineSink struct {
+	lessee    *engineLessee
+	ephemeral bool
+}
+
+func (s *engineSink) Logf(sev diag.Severity, d *diag.Diag, args ...interface{}) {
+	msg := d.Message
+	if !d.Raw && len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	s.lessee.log(sev, d.URN, strings.TrimRight(msg, "\n"), d.StreamID, s.ephemeral)
+}
+func (s *engineSink) Debugf(d *diag.Diag, args ...interface{})   { s.Logf(diag.Debug, d, args...) }
+func (s *engineSink) Infof(d *diag.Diag, args ...interface{})    { s.Logf(diag.Info, d, args...) }
+func (s *engineSink) Infoerrf(d *diag.Diag, args ...interface{}) { s.Logf(diag.Infoerr, d, args...) }
+func (s *engineSink) Errorf(d *diag.Diag, args ...interface{})   { s.Logf(diag.Error, d, args...) }
+func (s *engineSink) Warningf(d *diag.Diag, args ...interface{}) { s.Logf(diag.Warning, d, args...) }
+func (s *engineSink) Stringify(sev diag.Severity, d *diag.Diag, args ...interface{}) (string, string) {
+	return discardSink.Stringify(sev, d, args...)
+}
+
+// newDaemonProvider leases a provider for the given package from the provider daemon at the given loopback address.
+// Messages logged by the provider are sent to the given host.
+func newDaemonProvider(daemon string, host Host, ctx *Context, pkg tokens.Package, version *semver.Version,
+	options map[string]interface{}, disableProviderPreview bool) (Provider, error) {
+
+	label := fmt.Sprintf("leasing %s provider from the provider daemon at %s", pkg, daemon)
+	if !isLoopbackAddress(daemon) {
+		return nil, errors.Errorf("%s: %s is not a loopback address", label, daemon)
+	}
+	token, err := readProviderDaemonToken(daemon)
+	if err != nil {
+		return nil, errors.Wrap(err, label)
+	}
+	pwd, err := filepath.Abs(ctx.Pwd)
+	if err != nil {
+		return nil, errors.Wrap(err, label)
+	}
+
+	// The daemon does not know about the context's plugin lock, so resolve the locked version here.
+	name := strings.Replace(string(pkg), tokens.QNameDelimiter, "_", -1)
+	if locked, ok := ctx.PluginLock.Get(workspace.ResourcePlugin, name); ok && version == nil {
+		info, err := locked.PluginInfo()
+		if err != nil {
+			return nil, err
+		}
+		version = info.Version
+	}
+
+	// Variables that the daemon would discard anyway are not sent to it.
+	req := providerLeaseRequest{
+		Package:                string(pkg),
+		Pwd:                    pwd,
+		Options:                options,
+		DisableProviderPreview: disableProviderPreview,
+		Environ:                scrubProviderEnviron(os.Environ()),
+		Engine:                 host.ServerAddr(),
+	}
+	if version != nil {
+		req.Version = version.String()
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, "http://"+daemon+providerLeasePath, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, label)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, label)
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		contract.IgnoreClose(resp.Body)
+		return nil, errors.Errorf("%s: %s", label, strings.TrimSpace(string(msg)))
+	}
+
+	// The response's body stays open for as long as the lease is held.
+	var lease providerLeaseResponse
+	if err = json.NewDecoder(resp.Body).Decode(&lease); err != nil {
+		contract.IgnoreClose(resp.Body)
+		return nil, errors.Wrap(err, label)
+	}
+	if lease.Error != "" {
+		contract.IgnoreClose(resp.Body)
+		return nil, errors.Errorf("%s: %s", label, lease.Error)
+	}
+
+	conn, err := grpc.Dial(
+		lease.Address,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(rpcutil.OpenTracingClientInterceptor()),
+		rpcutil.GrpcChannelOptions(),
+	)
+	if err != nil {
+		contract.IgnoreClose(resp.Body)
+		return nil, errors.Wrap(err, label)
+	}
+
+	return &daemonProvider{
+		Provider: NewProviderWithClient(ctx, pkg, pulumirpc.NewResourceProviderClient(conn), disableProviderPreview),
+		conn:     conn,
+		lease:    resp.Body,
+	}, nil
+}
+
+// daemonProvider is a provider leased from a provider daemon. Closing the provider ends its lease.
+type daemonProvider struct {
+	Provider
+
+	conn  *grpc.ClientConn
+	lease io.Closer
+}
+
+func (p *daemonProvider) Close() error {
+	contract.IgnoreClose(p.conn)
+	return p.lease.Close()
+}
@@ -0,0 +1,262 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// recordingEngine is an engine RPC server that records the messages logged to it.
+type recordingEngine struct {
+	pulumirpc.UnimplementedEngineServer
+
+	m        sync.Mutex
+	messages []string
+}
+
+func (e *recordingEngine) Log(ctx context.Context, req *pulumirpc.LogRequest) (*pbempty.Empty, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.messages = append(e.messages, req.Message)
+	return &pbempty.Empty{}, nil
+}
+
+func (e *recordingEngine) logged() []string {
+	e.m.Lock()
+	defer e.m.Unlock()
+	return append([]string(nil), e.messages...)
+}
+
+// daemonTestHost is a host whose engine RPC server is a recording engine.
+type daemonTestHost struct {
+	Host
+
+	addr string
+}
+
+func (h *daemonTestHost) ServerAddr() string { return h.addr }
+
+func newDaemonTestHost(t *testing.T, engine *recordingEngine) (*daemonTestHost, func()) {
+	cancel := make(chan bool)
+	port, done, err := rpcutil.Serve(0, cancel, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterEngineServer(srv, engine)
+			return nil
+		},
+	}, nil)
+	assert.NoError(t, err)
+	return &daemonTestHost{addr: fmt.Sprintf("127.0.0.1:%d", port)}, func() {
+		close(cancel)
+		assert.NoError(t, <-done)
+	}
+}
+
+// withDaemonTestHome points PULUMI_HOME, where provider daemons write their tokens, at a temporary directory for the
+// duration of the test.
+func withDaemonTestHome(t *testing.T) {
+	home, err := ioutil.TempDir("", "provider-daemon")
+	assert.NoError(t, err)
+	old, hadOld := os.LookupEnv(workspace.PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, home))
+	t.Cleanup(func() {
+		if hadOld {
+			assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, old))
+		} else {
+			assert.NoError(t, os.Unsetenv(workspace.PulumiHomeEnvVar))
+		}
+		assert.NoError(t, os.RemoveAll(home))
+	})
+}
+
+func newDaemonTestDaemon(t *testing.T, launcher *fakeLauncher) (*ProviderDaemon, chan error) {
+	withDaemonTestHome(t)
+	daemon, err := NewProviderDaemon("127.0.0.1:0", time.Minute)
+	assert.NoError(t, err)
+	daemon.pool.newProvider = func(host Host, ctx *Context, pkg tokens.Package, version *semver.Version,
+		options map[string]interface{}, disableProviderPreview bool, environ []string) (Provider, error) {
+		return launcher.launch(host, ctx)
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- daemon.Serve() }()
+	return daemon, served
+}
+
+func idleProcesses(pool *ProviderPool) int {
+	pool.m.Lock()
+	defer pool.m.Unlock()
+	n := 0
+	for _, instances := range pool.idle {
+		n += len(instances)
+	}
+	return n
+}
+
+func TestProviderDaemonReusesProcesses(t *testing.T) {
+	launcher := &fakeLauncher{}
+	daemon, served := newDaemonTestDaemon(t, launcher)
+	engine := &recordingEngine{}
+	host, stop := newDaemonTestHost(t, engine)
+	defer stop()
+
+	config := resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}
+	ctx := &Context{Pwd: "."}
+
+	// The first lease launches and configures a process, which is kept running once the lease ends.
+	first, err := newDaemonProvider(daemon.Addr(), host, ctx, "test", nil, nil, false)
+	assert.NoError(t, err)
+	assert.NoError(t, first.Configure(config))
+	assert.Equal(t, 1, launcher.launched())
+	process := launcher.providers[0]
+
+	// Providers configure themselves in the background, so wait for the configuration to reach the process.
+	assert.Eventually(t, func() bool { return process.configured() == 1 }, time.Minute, time.Millisecond)
+
+	// Messages logged by the process are forwarded to the lessee's engine.
+	process.host.Log(diag.Info, "", "hello", 0)
+	assert.Equal(t, []string{"hello"}, engine.logged())
+
+	// The daemon notices that a lease has ended once its connection closes, so wait for the process to be returned.
+	assert.NoError(t, first.Close())
+	assert.Eventually(t, func() bool { return idleProcesses(daemon.pool) == 1 }, time.Minute, time.Millisecond)
+
+	// A second lease with identical configuration reuses the process without reconfiguring it.
+	second, err := newDaemonProvider(daemon.Addr(), host, ctx, "test", nil, nil, false)
+	assert.NoError(t, err)
+	assert.NoError(t, second.Configure(config))
+	assert.Equal(t, 1, launcher.launched())
+	assert.NoError(t, second.Close())
+	assert.Eventually(t, func() bool { return idleProcesses(daemon.pool) == 1 }, time.Minute, time.Millisecond)
+	assert.Equal(t, 1, process.configured())
+
+	// Closing the daemon shuts down its processes.
+	assert.NoError(t, daemon.Close())
+	assert.NoError(t, <-served)
+	assert.True(t, process.isClosed())
+}
+
+func TestProviderDaemonRejectsLeasesOnceClosed(t *testing.T) {
+	daemon, served := newDaemonTestDaemon(t, &fakeLauncher{})
+	addr := daemon.Addr()
+	assert.NoError(t, daemon.Close())
+	assert.NoError(t, <-served)
+
+	host, stop := newDaemonTestHost(t, &recordingEngine{})
+	defer stop()
+	_, err := newDaemonProvider(addr, host, &Context{Pwd: "."}, "test", nil, nil, false)
+	assert.Error(t, err)
+}
+
+func TestProviderDaemonRequiresLoopbackAddress(t *testing.T) {
+	withDaemonTestHome(t)
+	for _, address := range []string{":0", "0.0.0.0:0", "[::]:0", "example.com:0"} {
+		_, err := NewProviderDaemon(address, time.Minute)
+		assert.Error(t, err, address)
+	}
+
+	_, err := newDaemonProvider("192.0.2.1:1234", nil, &Context{Pwd: "."}, "test", nil, nil, false)
+	assert.Error(t, err)
+}
+
+func TestProviderDaemonRejectsUnauthenticatedLeases(t *testing.T) {
+	daemon, served := newDaemonTestDaemon(t, &fakeLauncher{})
+
+	// The daemon's token is only readable by the current user.
+	path, err := ProviderDaemonTokenPath(daemon.Addr())
+	assert.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		stat, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+	}
+
+	post := func(token, contentType string) int {
+		req, err := http.NewRequest(http.MethodPost, "http://"+daemon.Addr()+providerLeasePath,
+			strings.NewReader(`{"package":"test","pwd":"/"}`))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		assert.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, post("", "application/json"))
+	assert.Equal(t, http.StatusUnauthorized, post("wrong", "application/json"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, post(daemon.token, "text/plain"))
+
+	// Once the daemon is closed, its token is removed.
+	assert.NoError(t, daemon.Close())
+	assert.NoError(t, <-served)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestScrubProviderEnviron(t *testing.T) {
+	environ := []string{"PATH=/bin", "LD_PRELOAD=/tmp/evil.so", "DYLD_INSERT_LIBRARIES=/tmp/evil.dylib",
+		"NODE_OPTIONS=--require /tmp/evil.js", "AWS_REGION=us-west-2"}
+	assert.Equal(t, []string{"PATH=/bin", "AWS_REGION=us-west-2"}, scrubProviderEnviron(environ))
+}
+
+func TestPooledHostDoesNotPanic(t *testing.T) {
+	lessee := &hostLessee{host: &recordingHost{}, ctx: &Context{}}
+	instance, err := newPooledProvider("test", lessee, ".", (&fakeLauncher{}).launch)
+	assert.NoError(t, err)
+	host := instance.host
+
+	_, err = host.Analyzer("test")
+	assert.Error(t, err)
+	_, err = host.PolicyAnalyzer("test", ".", nil)
+	assert.Error(t, err)
+	_, err = host.Provider("test", nil)
+	assert.Error(t, err)
+	assert.Error(t, host.CloseProvider(nil))
+	_, err = host.LanguageRuntime("go")
+	assert.Error(t, err)
+	assert.Nil(t, host.ListAnalyzers())
+	assert.Nil(t, host.ListPlugins())
+	assert.NoError(t, host.EnsurePlugins(nil, 0))
+	assert.NoError(t, host.SignalCancellation())
+	assert.NotEmpty(t, host.ServerAddr())
+	host.Log(diag.Info, "", "hello", 0)
+	host.LogStatus(diag.Info, "", "hello", 0)
+
+	// Closing the process also closes its host.
+	instance.close()
+}
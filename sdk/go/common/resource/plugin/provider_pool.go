@@ -0,0 +1,650 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// DefaultProviderPoolIdleTimeout is how long an unused pooled provider plugin is kept running by default.
+const DefaultProviderPoolIdleTimeout = 5 * time.Minute
+
+// ProviderPool keeps provider plugin processes running between the deployments that use them so that they can be
+// reused rather than relaunched. Each process is leased to a single provider at a time, so a process is only ever
+// used by one deployment at once; when the provider is closed, its process is returned to the pool. A provider
+// reuses a pooled process if the process was launched for the same package, version and working directory and was
+// configured with identical configuration, or if it has not yet been configured.
+//
+// Processes that were asked to cancel, or whose configuration failed or contained unknown values, are never reused.
+// Processes that go unused for longer than the pool's idle timeout are shut down.
+type ProviderPool struct {
+	idleTimeout time.Duration
+
+	// newProvider launches a new provider plugin process. It is only replaced by tests.
+	newProvider func(host Host, ctx *Context, pkg tokens.Package, version *semver.Version,
+		options map[string]interface{}, disableProviderPreview bool, environ []string) (Provider, error)
+
+	m      sync.Mutex
+	idle   map[string][]*pooledProvider // idle processes, keyed by launch key and ordered by release.
+	closed bool                         // true once the pool has been closed.
+}
+
+// NewProviderPool creates a new, empty provider pool that shuts down processes once they have been idle for the
+// given duration.
+func NewProviderPool(idleTimeout time.Duration) *ProviderPool {
+	return &ProviderPool{
+		idleTimeout: idleTimeout,
+		newProvider: newProvider,
+		idle:        make(map[string][]*pooledProvider),
+	}
+}
+
+// providerLauncher launches a new provider plugin process that reports to the given host.
+type providerLauncher func(host Host, ctx *Context) (Provider, error)
+
+// Provider returns a provider for the given package that is backed by a pooled plugin process. Messages logged by the
+// process while the provider is open are sent to the given host and context.
+func (pool *ProviderPool) Provider(host Host, ctx *Context, pkg tokens.Package, version *semver.Version,
+	options map[string]interface{}, disableProviderPreview bool) (Provider, error) {
+
+	return pool.leaseProvider(&hostLessee{host: host, ctx: ctx}, ctx.Pwd, pkg, version, options,
+		disableProviderPreview, os.Environ())
+}
+
+// leaseProvider returns a provider for the given package that is backed by a pooled plugin process launched in the
+// given directory with the given environment. Messages logged by the process while the provider is open are sent to
+// the given lessee.
+func (pool *ProviderPool) leaseProvider(lessee providerLessee, pwd string, pkg tokens.Package,
+	version *semver.Version, options map[string]interface{}, disableProviderPreview bool,
+	environ []string) (Provider, error) {
+
+	opts, err := json.Marshal(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling runtime options")
+	}
+
+	// Processes launched with different environments may behave differently, so only share processes between
+	// lessees with identical environments.
+	sorted := append([]string(nil), environ...)
+	sort.Strings(sorted)
+	env := sha256.Sum256([]byte(strings.Join(sorted, "\x00")))
+
+	key := fmt.Sprintf("%s@%v|%s|%s|%v|%x", pkg, version, pwd, opts, disableProviderPreview, env)
+	return pool.provider(lessee, pwd, pkg, key, func(host Host, ctx *Context) (Provider, error) {
+		return pool.newProvider(host, ctx, pkg, version, options, disableProviderPreview, environ)
+	})
+}
+
+func (pool *ProviderPool) provider(lessee providerLessee, pwd string, pkg tokens.Package, key string,
+	launch providerLauncher) (Provider, error) {
+
+	p := &sharedProvider{
+		pool:   pool,
+		lessee: lessee,
+		pwd:    pwd,
+		pkg:    pkg,
+		key:    key,
+		launch: launch,
+	}
+
+	// Lease whichever process for this key was released most recently. It will be exchanged for a better match, if
+	// necessary, once the provider is configured.
+	instance, err := pool.acquire(key, lessee, pwd, launch, func(*pooledProvider) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	p.instance = instance
+	return p, nil
+}
+
+// acquire leases the most recently released idle process for the given key that satisfies the given predicate. If
+// there is no such process, a new one is launched.
+func (pool *ProviderPool) acquire(key string, lessee providerLessee, pwd string, launch providerLauncher,
+	match func(instance *pooledProvider) bool) (*pooledProvider, error) {
+
+	pool.m.Lock()
+	if pool.closed {
+		pool.m.Unlock()
+		return nil, errors.New("the provider pool has been closed")
+	}
+	idle := pool.idle[key]
+	for i := len(idle) - 1; i >= 0; i-- {
+		if instance := idle[i]; match(instance) {
+			pool.idle[key] = append(idle[:i:i], idle[i+1:]...)
+			instance.timer.Stop()
+			instance.host.setLessee(lessee)
+			pool.m.Unlock()
+			logging.V(7).Infof("reusing shared provider process %s", key)
+			return instance, nil
+		}
+	}
+	pool.m.Unlock()
+
+	logging.V(7).Infof("launching shared provider process %s", key)
+	return newPooledProvider(key, lessee, pwd, launch)
+}
+
+// release returns a leased process to the pool, or shuts it down if it cannot be reused.
+func (pool *ProviderPool) release(instance *pooledProvider) {
+	instance.host.setLessee(nil)
+
+	pool.m.Lock()
+	if pool.closed || instance.unshareable {
+		pool.m.Unlock()
+		instance.close()
+		return
+	}
+	pool.idle[instance.key] = append(pool.idle[instance.key], instance)
+	instance.timer = time.AfterFunc(pool.idleTimeout, func() {
+		pool.expire(instance)
+	})
+	pool.m.Unlock()
+}
+
+// expire shuts down the given process if it is still idle.
+func (pool *ProviderPool) expire(instance *pooledProvider) {
+	pool.m.Lock()
+	idle := pool.idle[instance.key]
+	for i, candidate := range idle {
+		if candidate == instance {
+			pool.idle[instance.key] = append(idle[:i:i], idle[i+1:]...)
+			pool.m.Unlock()
+			logging.V(7).Infof("shutting down idle shared provider process %s", instance.key)
+			instance.close()
+			return
+		}
+	}
+	pool.m.Unlock()
+}
+
+// markUnshareable prevents the given process from being returned to the pool once it is released.
+func (pool *ProviderPool) markUnshareable(instance *pooledProvider) {
+	pool.m.Lock()
+	defer pool.m.Unlock()
+	instance.unshareable = true
+}
+
+// Close shuts down the pool's idle processes. Processes that are currently leased are shut down when they are
+// released.
+func (pool *ProviderPool) Close() error {
+	pool.m.Lock()
+	pool.closed = true
+	var idle []*pooledProvider
+	for _, instances := range pool.idle {
+		for _, instance := range instances {
+			instance.timer.Stop()
+			idle = append(idle, instance)
+		}
+	}
+	pool.idle = make(map[string][]*pooledProvider)
+	pool.m.Unlock()
+
+	for _, instance := range idle {
+		instance.close()
+	}
+	return nil
+}
+
+// pooledProvider is a provider plugin process owned by a provider pool.
+type pooledProvider struct {
+	key    string      // the key that identifies how the process was launched.
+	plugin Provider    // the provider plugin process.
+	host   *pooledHost // the host that the process reports to.
+	timer  *time.Timer // the timer that shuts down the process when it is idle.
+
+	configured  bool   // true if the process has been configured.
+	config      string // the canonical form of the process's configuration, once configured.
+	unshareable bool   // true if the process must not be reused; protected by the pool's lock.
+}
+
+func newPooledProvider(key string, lessee providerLessee, pwd string,
+	launch providerLauncher) (*pooledProvider, error) {
+
+	host := &pooledHost{lessee: lessee}
+	host.ctx = &Context{
+		Diag:       &pooledSink{host: host},
+		StatusDiag: &pooledSink{host: host, status: true},
+		Host:       host,
+		Pwd:        pwd,
+	}
+
+	server, err := newHostServer(host, host.ctx)
+	if err != nil {
+		return nil, err
+	}
+	host.server = server
+
+	plug, err := launch(host, host.ctx)
+	if err != nil {
+		contract.IgnoreError(server.Cancel())
+		return nil, err
+	}
+	return &pooledProvider{key: key, plugin: plug, host: host}, nil
+}
+
+// close shuts down the process and the host that it reports to.
+func (instance *pooledProvider) close() {
+	if err := instance.plugin.Close(); err != nil {
+		logging.V(5).Infof("Error closing shared '%s' resource plugin; ignoring: %v", instance.plugin.Pkg(), err)
+	}
+	contract.IgnoreError(instance.host.Close())
+}
+
+// providerLessee receives the messages logged by a leased process.
+type providerLessee interface {
+	// Log logs a message from the process.
+	Log(sev diag.Severity, urn resource.URN, msg string, streamID int32)
+	// LogStatus logs a status message from the process.
+	LogStatus(sev diag.Severity, urn resource.URN, msg string, streamID int32)
+	// Diag returns the sink for the process's output.
+	Diag() diag.Sink
+	// StatusDiag returns the sink for the process's status output.
+	StatusDiag() diag.Sink
+}
+
+// hostLessee is a lessee in the same process as the pool, which receives messages through its host and context.
+type hostLessee struct {
+	host Host
+	ctx  *Context
+}
+
+func (l *hostLessee) Log(sev diag.Severity, urn resource.URN, msg string, streamID int32) {
+	l.host.Log(sev, urn, msg, streamID)
+}
+
+func (l *hostLessee) LogStatus(sev diag.Severity, urn resource.URN, msg string, streamID int32) {
+	l.host.LogStatus(sev, urn, msg, streamID)
+}
+
+func (l *hostLessee) Diag() diag.Sink       { return l.ctx.Diag }
+func (l *hostLessee) StatusDiag() diag.Sink { return l.ctx.StatusDiag }
+
+// errPooledHost is returned by the methods of a pooled process's host that would load plugins on behalf of the
+// process. Pooled processes outlive the deployments that lease them, so they must not hold on to other plugins.
+var errPooledHost = errors.New("the host of a pooled provider plugin cannot load plugins")
+
+// pooledHost is the host that a pooled process reports to. It forwards the messages that the process logs to the
+// provider that currently leases the process; messages logged while the process is idle are discarded. The pooled
+// host does not load plugins.
+type pooledHost struct {
+	server *hostServer
+	ctx    *Context
+
+	m      sync.RWMutex
+	lessee providerLessee
+}
+
+var _ Host = (*pooledHost)(nil)
+
+func (host *pooledHost) setLessee(lessee providerLessee) {
+	host.m.Lock()
+	defer host.m.Unlock()
+	host.lessee = lessee
+}
+
+func (host *pooledHost) getLessee() providerLessee {
+	host.m.RLock()
+	defer host.m.RUnlock()
+	return host.lessee
+}
+
+func (host *pooledHost) ServerAddr() string {
+	return host.server.Address()
+}
+
+func (host *pooledHost) Log(sev diag.Severity, urn resource.URN, msg string, streamID int32) {
+	if lessee := host.getLessee(); lessee != nil {
+		lessee.Log(sev, urn, msg, streamID)
+	}
+}
+
+func (host *pooledHost) LogStatus(sev diag.Severity, urn resource.URN, msg string, streamID int32) {
+	if lessee := host.getLessee(); lessee != nil {
+		lessee.LogStatus(sev, urn, msg, streamID)
+	}
+}
+
+func (host *pooledHost) Analyzer(nm tokens.QName) (Analyzer, error) {
+	return nil, errPooledHost
+}
+
+func (host *pooledHost) PolicyAnalyzer(name tokens.QName, path string,
+	opts *PolicyAnalyzerOptions) (Analyzer, error) {
+	return nil, errPooledHost
+}
+
+func (host *pooledHost) ListAnalyzers() []Analyzer {
+	return nil
+}
+
+func (host *pooledHost) Provider(pkg tokens.Package, version *semver.Version) (Provider, error) {
+	return nil, errPooledHost
+}
+
+func (host *pooledHost) CloseProvider(provider Provider) error {
+	return errPooledHost
+}
+
+func (host *pooledHost) LanguageRuntime(runtime string) (LanguageRuntime, error) {
+	return nil, errPooledHost
+}
+
+func (host *pooledHost) ListPlugins() []workspace.PluginInfo {
+	return nil
+}
+
+func (host *pooledHost) EnsurePlugins(plugins []workspace.PluginInfo, kinds Flags) error {
+	if len(plugins) == 0 {
+		return nil
+	}
+	return errPooledHost
+}
+
+// SignalCancellation does nothing: cancellation is signalled to a pooled process by the provider that leases it.
+func (host *pooledHost) SignalCancellation() error {
+	return nil
+}
+
+// Close stops the host's RPC server.
+func (host *pooledHost) Close() error {
+	return host.server.Cancel()
+}
+
+// discardSink is used for the output of pooled processes that are not leased.
+var discardSink = diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+
+// pooledSink forwards the output of a pooled process to the diagnostics sinks of the process's current lessee.
+type pooledSink struct {
+	host   *pooledHost
+	status bool
+}
+
+func (s *pooledSink) target() diag.Sink {
+	lessee := s.host.getLessee()
+	if lessee == nil {
+		return discardSink
+	}
+	sink := lessee.Diag()
+	if s.status {
+		sink = lessee.StatusDiag()
+	}
+	if sink == nil {
+		return discardSink
+	}
+	return sink
+}
+
+func (s *pooledSink) Logf(sev diag.Severity, d *diag.Diag, args ...interface{}) {
+	s.target().Logf(sev, d, args...)
+}
+func (s *pooledSink) Debugf(d *diag.Diag, args ...interface{})   { s.target().Debugf(d, args...) }
+func (s *pooledSink) Infof(d *diag.Diag, args ...interface{})    { s.target().Infof(d, args...) }
+func (s *pooledSink) Infoerrf(d *diag.Diag, args ...interface{}) { s.target().Infoerrf(d, args...) }
+func (s *pooledSink) Errorf(d *diag.Diag, args ...interface{})   { s.target().Errorf(d, args...) }
+func (s *pooledSink) Warningf(d *diag.Diag, args ...interface{}) { s.target().Warningf(d, args...) }
+func (s *pooledSink) Stringify(sev diag.Severity, d *diag.Diag, args ...interface{}) (string, string) {
+	return s.target().Stringify(sev, d, args...)
+}
+
+// sharedProvider is a provider backed by a process leased from a provider pool. The provider holds its lease until it
+// is closed. When it is configured, it exchanges its process for an idle one that was configured identically, if
+// there is one, in which case the configuration step is skipped.
+type sharedProvider struct {
+	pool   *ProviderPool
+	lessee providerLessee
+	pwd    string
+	pkg    tokens.Package
+	key    string
+	launch providerLauncher
+
+	m        sync.RWMutex
+	instance *pooledProvider // the leased process, or nil once the provider has been closed.
+}
+
+var _ Provider = (*sharedProvider)(nil)
+
+// configKey returns the canonical form of the given configuration, and false if the configuration contains unknown
+// values and so cannot be shared.
+func configKey(inputs resource.PropertyMap) (string, bool) {
+	if inputs.ContainsUnknowns() {
+		return "", false
+	}
+	b, err := json.Marshal(inputs.Mappable())
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// plugin returns the provider's process, or an error if the provider has been closed or has lost its process.
+func (p *sharedProvider) plugin() (Provider, error) {
+	p.m.RLock()
+	defer p.m.RUnlock()
+	if p.instance == nil {
+		return nil, errors.Errorf("the %s provider has been closed", p.pkg)
+	}
+	return p.instance.plugin, nil
+}
+
+func (p *sharedProvider) Pkg() tokens.Package {
+	return p.pkg
+}
+
+func (p *sharedProvider) GetSchema(version int) ([]byte, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return nil, err
+	}
+	return plug.GetSchema(version)
+}
+
+func (p *sharedProvider) CheckConfig(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool) (resource.PropertyMap, []CheckFailure, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return nil, nil, err
+	}
+	return plug.CheckConfig(urn, olds, news, allowUnknowns)
+}
+
+func (p *sharedProvider) DiffConfig(urn resource.URN, olds, news resource.PropertyMap, allowUnknowns bool,
+	ignoreChanges []string) (DiffResult, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return DiffResult{}, err
+	}
+	return plug.DiffConfig(urn, olds, news, allowUnknowns, ignoreChanges)
+}
+
+func (p *sharedProvider) Configure(inputs resource.PropertyMap) error {
+	config, shareable := configKey(inputs)
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	instance := p.instance
+	if instance == nil {
+		return errors.Errorf("the %s provider has been closed", p.pkg)
+	}
+	if instance.configured {
+		if shareable && instance.config == config {
+			return nil
+		}
+
+		// A configured process cannot be reconfigured, so exchange it for one that was configured identically or has
+		// not been configured at all.
+		p.pool.release(instance)
+		replacement, err := p.pool.acquire(p.key, p.lessee, p.pwd, p.launch, func(candidate *pooledProvider) bool {
+			return !candidate.configured || shareable && candidate.config == config
+		})
+		if err != nil {
+			// The provider's process has already been released, so it has nothing left to close.
+			p.instance = nil
+			return err
+		}
+		p.instance, instance = replacement, replacement
+		if instance.configured {
+			return nil
+		}
+	}
+
+	err := instance.plugin.Configure(inputs)
+	instance.configured, instance.config = true, config
+	if err != nil || !shareable {
+		p.pool.markUnshareable(instance)
+	}
+	return err
+}
+
+func (p *sharedProvider) Check(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool) (resource.PropertyMap, []CheckFailure, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return nil, nil, err
+	}
+	return plug.Check(urn, olds, news, allowUnknowns)
+}
+
+func (p *sharedProvider) Diff(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+	news resource.PropertyMap, allowUnknowns bool, ignoreChanges []string) (DiffResult, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return DiffResult{}, err
+	}
+	return plug.Diff(urn, id, olds, news, allowUnknowns, ignoreChanges)
+}
+
+func (p *sharedProvider) Create(urn resource.URN, news resource.PropertyMap, timeout float64,
+	preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return "", nil, resource.StatusOK, err
+	}
+	return plug.Create(urn, news, timeout, preview)
+}
+
+func (p *sharedProvider) Read(urn resource.URN, id resource.ID,
+	inputs, state resource.PropertyMap) (ReadResult, resource.Status, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return ReadResult{}, resource.StatusOK, err
+	}
+	return plug.Read(urn, id, inputs, state)
+}
+
+func (p *sharedProvider) Update(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+	news resource.PropertyMap, timeout float64, ignoreChanges []string,
+	preview bool) (resource.PropertyMap, resource.Status, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return nil, resource.StatusOK, err
+	}
+	return plug.Update(urn, id, olds, news, timeout, ignoreChanges, preview)
+}
+
+func (p *sharedProvider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return resource.StatusOK, err
+	}
+	return plug.Delete(urn, id, props, timeout)
+}
+
+func (p *sharedProvider) Construct(info ConstructInfo, typ tokens.Type, name tokens.QName, parent resource.URN,
+	inputs resource.PropertyMap, options ConstructOptions) (ConstructResult, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return ConstructResult{}, err
+	}
+	return plug.Construct(info, typ, name, parent, inputs, options)
+}
+
+func (p *sharedProvider) Invoke(tok tokens.ModuleMember,
+	args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return nil, nil, err
+	}
+	return plug.Invoke(tok, args)
+}
+
+func (p *sharedProvider) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]CheckFailure, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return nil, err
+	}
+	return plug.StreamInvoke(tok, args, onNext)
+}
+
+func (p *sharedProvider) Call(tok tokens.ModuleMember, args resource.PropertyMap, info CallInfo,
+	options CallOptions) (CallResult, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return CallResult{}, err
+	}
+	return plug.Call(tok, args, info, options)
+}
+
+func (p *sharedProvider) GetPluginInfo() (workspace.PluginInfo, error) {
+	plug, err := p.plugin()
+	if err != nil {
+		return workspace.PluginInfo{}, err
+	}
+	return plug.GetPluginInfo()
+}
+
+// SignalCancellation cancels the operations of the provider's process. Because the process may be left in an
+// unknown state, it is shut down rather than reused once the provider is closed.
+func (p *sharedProvider) SignalCancellation() error {
+	p.m.RLock()
+	defer p.m.RUnlock()
+	if p.instance == nil {
+		return nil
+	}
+	p.pool.markUnshareable(p.instance)
+	return p.instance.plugin.SignalCancellation()
+}
+
+// Close returns the provider's process to its pool.
+func (p *sharedProvider) Close() error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if p.instance != nil {
+		p.pool.release(p.instance)
+		p.instance = nil
+	}
+	return nil
+}
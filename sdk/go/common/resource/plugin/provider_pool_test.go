@@ -0,0 +1,256 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// fakePooledProvider records the calls made to a provider process launched by a provider pool.
+type fakePooledProvider struct {
+	Provider
+
+	host       Host
+	configures int
+	cancelled  bool
+
+	m      sync.Mutex
+	closed bool
+}
+
+func (p *fakePooledProvider) Pkg() tokens.Package { return "test" }
+
+func (p *fakePooledProvider) Configure(inputs resource.PropertyMap) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.configures++
+	return nil
+}
+
+func (p *fakePooledProvider) configured() int {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.configures
+}
+
+func (p *fakePooledProvider) SignalCancellation() error {
+	p.cancelled = true
+	return nil
+}
+
+func (p *fakePooledProvider) Close() error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.closed = true
+	return nil
+}
+
+func (p *fakePooledProvider) isClosed() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.closed
+}
+
+// fakeLauncher launches fake provider processes and records them.
+type fakeLauncher struct {
+	m         sync.Mutex
+	providers []*fakePooledProvider
+	fail      bool
+}
+
+func (l *fakeLauncher) launch(host Host, ctx *Context) (Provider, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.fail {
+		return nil, errors.New("launch failed")
+	}
+	p := &fakePooledProvider{host: host}
+	l.providers = append(l.providers, p)
+	return p, nil
+}
+
+func (l *fakeLauncher) launched() int {
+	l.m.Lock()
+	defer l.m.Unlock()
+	return len(l.providers)
+}
+
+// recordingHost records the messages logged to it.
+type recordingHost struct {
+	Host
+
+	messages []string
+}
+
+func (h *recordingHost) Log(sev diag.Severity, urn resource.URN, msg string, streamID int32) {
+	h.messages = append(h.messages, msg)
+}
+
+func (h *recordingHost) LogStatus(sev diag.Severity, urn resource.URN, msg string, streamID int32) {
+	h.messages = append(h.messages, msg)
+}
+
+func newPoolTestProvider(t *testing.T, pool *ProviderPool, launcher *fakeLauncher, host Host) Provider {
+	prov, err := pool.provider(&hostLessee{host: host, ctx: &Context{}}, ".", "test", "test", launcher.launch)
+	assert.NoError(t, err)
+	return prov
+}
+
+func poolTestProcess(prov Provider) *fakePooledProvider {
+	plug, err := prov.(*sharedProvider).plugin()
+	if err != nil {
+		return nil
+	}
+	return plug.(*fakePooledProvider)
+}
+
+func TestProviderPoolReusesIdenticalConfig(t *testing.T) {
+	pool := NewProviderPool(time.Minute)
+	launcher := &fakeLauncher{}
+	config := resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}
+
+	// The first deployment launches and configures a process, which is kept running once it is closed.
+	first := newPoolTestProvider(t, pool, launcher, nil)
+	assert.NoError(t, first.Configure(config))
+	process := poolTestProcess(first)
+	assert.NoError(t, first.Close())
+	assert.False(t, process.isClosed())
+
+	// A second deployment with identical configuration reuses the process without reconfiguring it.
+	second := newPoolTestProvider(t, pool, launcher, nil)
+	assert.NoError(t, second.Configure(resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}))
+	assert.Equal(t, process, poolTestProcess(second))
+	assert.Equal(t, 1, process.configures)
+
+	// While the process is leased, a concurrent deployment gets a process of its own...
+	third := newPoolTestProvider(t, pool, launcher, nil)
+	assert.NotEqual(t, process, poolTestProcess(third))
+	assert.Equal(t, 2, launcher.launched())
+
+	// ...and a deployment with different configuration cannot reuse a configured process.
+	assert.NoError(t, second.Close())
+	assert.NoError(t, third.Configure(resource.PropertyMap{"region": resource.NewStringProperty("us-east-1")}))
+	assert.Equal(t, 1, process.configures)
+	assert.Equal(t, 1, poolTestProcess(third).configures)
+	assert.NoError(t, third.Close())
+
+	// Closing the pool shuts down its idle processes.
+	assert.NoError(t, pool.Close())
+	for _, p := range launcher.providers {
+		assert.True(t, p.isClosed())
+	}
+}
+
+func TestProviderPoolDoesNotReuseUnshareableProcesses(t *testing.T) {
+	pool := NewProviderPool(time.Minute)
+	defer func() { assert.NoError(t, pool.Close()) }()
+	launcher := &fakeLauncher{}
+
+	// A process that was asked to cancel is shut down once it is released.
+	cancelled := newPoolTestProvider(t, pool, launcher, nil)
+	assert.NoError(t, cancelled.Configure(resource.PropertyMap{}))
+	assert.NoError(t, cancelled.SignalCancellation())
+	process := poolTestProcess(cancelled)
+	assert.True(t, process.cancelled)
+	assert.NoError(t, cancelled.Close())
+	assert.True(t, process.isClosed())
+
+	// So is a process whose configuration contains unknowns.
+	unknown := newPoolTestProvider(t, pool, launcher, nil)
+	assert.NoError(t, unknown.Configure(resource.PropertyMap{
+		"region": resource.MakeComputed(resource.NewStringProperty("")),
+	}))
+	process = poolTestProcess(unknown)
+	assert.NoError(t, unknown.Close())
+	assert.True(t, process.isClosed())
+	assert.Equal(t, 2, launcher.launched())
+}
+
+func TestProviderPoolReconfigureLaunchFailure(t *testing.T) {
+	pool := NewProviderPool(time.Minute)
+	defer func() { assert.NoError(t, pool.Close()) }()
+	launcher := &fakeLauncher{}
+
+	prov := newPoolTestProvider(t, pool, launcher, nil)
+	assert.NoError(t, prov.Configure(resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}))
+	process := poolTestProcess(prov)
+
+	// Reconfiguring the provider releases its process. If no replacement can be launched, the provider is left
+	// without a process rather than holding on to the one it released.
+	launcher.fail = true
+	assert.Error(t, prov.Configure(resource.PropertyMap{"region": resource.NewStringProperty("us-east-1")}))
+	assert.Nil(t, poolTestProcess(prov))
+	_, _, err := prov.Check("", nil, nil, false)
+	assert.Error(t, err)
+	assert.Error(t, prov.Configure(resource.PropertyMap{}))
+	assert.NoError(t, prov.SignalCancellation())
+	assert.False(t, process.cancelled)
+
+	// Closing the provider does not release the process a second time, so the pool holds a single idle copy of it.
+	assert.NoError(t, prov.Close())
+	assert.NoError(t, prov.Close())
+	assert.Len(t, pool.idle["test"], 1)
+	assert.False(t, process.isClosed())
+}
+
+func TestProviderPoolIdleTimeout(t *testing.T) {
+	pool := NewProviderPool(10 * time.Millisecond)
+	defer func() { assert.NoError(t, pool.Close()) }()
+	launcher := &fakeLauncher{}
+
+	prov := newPoolTestProvider(t, pool, launcher, nil)
+	process := poolTestProcess(prov)
+	assert.NoError(t, prov.Close())
+
+	assert.Eventually(t, process.isClosed, time.Second, 5*time.Millisecond)
+
+	// Once the idle process has been shut down, a new one is launched.
+	prov = newPoolTestProvider(t, pool, launcher, nil)
+	assert.NotEqual(t, process, poolTestProcess(prov))
+	assert.NoError(t, prov.Close())
+}
+
+func TestProviderPoolForwardsLogsToLessee(t *testing.T) {
+	pool := NewProviderPool(time.Minute)
+	defer func() { assert.NoError(t, pool.Close()) }()
+	launcher := &fakeLauncher{}
+
+	first, second := &recordingHost{}, &recordingHost{}
+
+	prov := newPoolTestProvider(t, pool, launcher, first)
+	process := poolTestProcess(prov)
+	process.host.Log(diag.Info, "", "one", 0)
+	assert.NoError(t, prov.Close())
+
+	// Messages logged while the process is idle are dropped.
+	process.host.Log(diag.Info, "", "dropped", 0)
+
+	prov = newPoolTestProvider(t, pool, launcher, second)
+	assert.Equal(t, process, poolTestProcess(prov))
+	process.host.Log(diag.Info, "", "two", 0)
+	assert.NoError(t, prov.Close())
+
+	assert.Equal(t, []string{"one"}, first.messages)
+	assert.Equal(t, []string{"two"}, second.messages)
+}
@@ -526,3 +526,69 @@ func (p *providerServer) StreamInvoke(req *pulumirpc.InvokeRequest,
 
 	return server.Send(&pulumirpc.InvokeResponse{Failures: rpcFailures})
 }
+
+func (p *providerServer) Call(ctx context.Context, req *pulumirpc.CallRequest) (*pulumirpc.CallResponse, error) {
+	args, err := UnmarshalProperties(req.GetArgs(), p.unmarshalOptions("args"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := map[config.Key]string{}
+	for k, v := range req.GetConfig() {
+		configKey, err := config.ParseKey(k)
+		if err != nil {
+			return nil, err
+		}
+		cfg[configKey] = v
+	}
+	info := CallInfo{
+		Project:        req.GetProject(),
+		Stack:          req.GetStack(),
+		Config:         cfg,
+		DryRun:         req.GetDryRun(),
+		Parallel:       int(req.GetParallel()),
+		MonitorAddress: req.GetMonitorEndpoint(),
+	}
+
+	argDependencies := map[resource.PropertyKey][]resource.URN{}
+	for name, deps := range req.GetArgDependencies() {
+		urns := make([]resource.URN, len(deps.Urns))
+		for i, urn := range deps.Urns {
+			urns[i] = resource.URN(urn)
+		}
+		argDependencies[resource.PropertyKey(name)] = urns
+	}
+	options := CallOptions{
+		ArgDependencies: argDependencies,
+	}
+
+	result, err := p.provider.Call(tokens.ModuleMember(req.GetTok()), args, info, options)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcResult, err := MarshalProperties(result.Return, p.marshalOptions("return"))
+	if err != nil {
+		return nil, err
+	}
+
+	returnDependencies := map[string]*pulumirpc.CallResponse_ReturnDependencies{}
+	for name, deps := range result.ReturnDependencies {
+		urns := make([]string, len(deps))
+		for i, urn := range deps {
+			urns[i] = string(urn)
+		}
+		returnDependencies[string(name)] = &pulumirpc.CallResponse_ReturnDependencies{Urns: urns}
+	}
+
+	rpcFailures := make([]*pulumirpc.CheckFailure, len(result.Failures))
+	for i, f := range result.Failures {
+		rpcFailures[i] = &pulumirpc.CheckFailure{Property: string(f.Property), Reason: f.Reason}
+	}
+
+	return &pulumirpc.CallResponse{
+		Return:             rpcResult,
+		ReturnDependencies: returnDependencies,
+		Failures:           rpcFailures,
+	}, nil
+}
@@ -57,6 +57,18 @@ func (fm *FileMutex) Lock() error {
 	return nil
 }
 
+// TryLock attempts to acquire the mutex without blocking. It returns false if the mutex is held by another process
+// or by another FileMutex for the same path.
+func (fm *FileMutex) TryLock() (bool, error) {
+	fm.proclock.Lock()
+	locked, err := fm.fslock.TryLock()
+	if err != nil || !locked {
+		fm.proclock.Unlock()
+		return false, err
+	}
+	return true, nil
+}
+
 // Unlock unlocks the file mutex. It first unlocks the file lock, which allows other processes to lock the file lock,
 // after which it unlocks the proc lock. Unlocking the file lock first ensures that it is not possible for two
 // goroutines to lock or unlock the file mutex without first holding the proc lock.
@@ -0,0 +1,129 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// PluginLockFile is the name of the file, next to a project's Pulumi.yaml, that pins the exact plugins used by the
+// project's deployments.
+const PluginLockFile = "Pulumi.lock"
+
+// LockedPlugin records the exact version of a plugin used by a project, along with the checksum of its executable and
+// the server it was downloaded from, if any.
+type LockedPlugin struct {
+	Name      string     `json:"name" yaml:"name"`
+	Kind      PluginKind `json:"kind" yaml:"kind"`
+	Version   string     `json:"version" yaml:"version"`
+	Checksum  string     `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+	ServerURL string     `json:"server,omitempty" yaml:"server,omitempty"`
+}
+
+// PluginInfo returns the plugin described by this lock entry.
+func (p LockedPlugin) PluginInfo() (PluginInfo, error) {
+	version, err := semver.ParseTolerant(p.Version)
+	if err != nil {
+		return PluginInfo{}, errors.Wrapf(err, "invalid version for locked %s plugin %s", p.Kind, p.Name)
+	}
+	return PluginInfo{Name: p.Name, Kind: p.Kind, Version: &version, ServerURL: p.ServerURL}, nil
+}
+
+// PluginLock pins the exact plugins used by a project. It is stored in the project's Pulumi.lock file.
+type PluginLock struct {
+	Plugins []LockedPlugin `json:"plugins" yaml:"plugins"`
+}
+
+// LoadPluginLock reads the plugin lock at the given path. If the file does not exist, LoadPluginLock returns nil.
+func LoadPluginLock(path string) (*PluginLock, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var lock PluginLock
+	if err = encoding.YAML.Unmarshal(b, &lock); err != nil {
+		return nil, errors.Wrapf(err, "could not read plugin lock file %s", path)
+	}
+	for _, p := range lock.Plugins {
+		if _, err := p.PluginInfo(); err != nil {
+			return nil, errors.Wrapf(err, "invalid plugin lock file %s", path)
+		}
+	}
+	return &lock, nil
+}
+
+// Save writes the plugin lock to the given path.
+func (lock *PluginLock) Save(path string) error {
+	contract.Require(path != "", "path")
+
+	b, err := encoding.YAML.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	//nolint: gosec
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// Get returns the locked plugin with the given kind and name, if any.
+func (lock *PluginLock) Get(kind PluginKind, name string) (LockedPlugin, bool) {
+	if lock != nil {
+		for _, p := range lock.Plugins {
+			if p.Kind == kind && p.Name == name {
+				return p, true
+			}
+		}
+	}
+	return LockedPlugin{}, false
+}
+
+// Set records the given plugin in the lock, replacing any existing entry for a plugin of the same kind and name.
+func (lock *PluginLock) Set(plugin LockedPlugin) {
+	for i, p := range lock.Plugins {
+		if p.Kind == plugin.Kind && p.Name == plugin.Name {
+			lock.Plugins[i] = plugin
+			return
+		}
+	}
+	lock.Plugins = append(lock.Plugins, plugin)
+	sort.Slice(lock.Plugins, func(i, j int) bool {
+		pi, pj := lock.Plugins[i], lock.Plugins[j]
+		if pi.Kind != pj.Kind {
+			return pi.Kind < pj.Kind
+		}
+		return pi.Name < pj.Name
+	})
+}
+
+// PluginChecksum returns the SHA-256 checksum of the given installed plugin's executable, in the form
+// "sha256:<hex digest>".
+func PluginChecksum(info PluginInfo) (string, error) {
+	path, err := info.FilePath()
+	if err != nil {
+		return "", err
+	}
+	return fileChecksum(path)
+}
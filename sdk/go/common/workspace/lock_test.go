@@ -0,0 +1,125 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginLockMissingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugin-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lock, err := LoadPluginLock(filepath.Join(dir, PluginLockFile))
+	assert.NoError(t, err)
+	assert.Nil(t, lock)
+
+	// Lookups on a missing lock find nothing.
+	_, ok := lock.Get(ResourcePlugin, "aws")
+	assert.False(t, ok)
+}
+
+func TestPluginLockRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugin-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lock := &PluginLock{}
+	lock.Set(LockedPlugin{Name: "random", Kind: ResourcePlugin, Version: "2.4.0", Checksum: "sha256:abc"})
+	lock.Set(LockedPlugin{Name: "aws", Kind: ResourcePlugin, Version: "3.0.0", ServerURL: "https://example.com"})
+	lock.Set(LockedPlugin{Name: "random", Kind: ResourcePlugin, Version: "2.5.0", Checksum: "sha256:def"})
+
+	// Entries are kept sorted, and setting an existing plugin replaces it.
+	assert.Len(t, lock.Plugins, 2)
+	assert.Equal(t, "aws", lock.Plugins[0].Name)
+	assert.Equal(t, "random", lock.Plugins[1].Name)
+	assert.Equal(t, "2.5.0", lock.Plugins[1].Version)
+
+	path := filepath.Join(dir, PluginLockFile)
+	assert.NoError(t, lock.Save(path))
+
+	loaded, err := LoadPluginLock(path)
+	assert.NoError(t, err)
+	assert.Equal(t, lock, loaded)
+
+	locked, ok := loaded.Get(ResourcePlugin, "aws")
+	assert.True(t, ok)
+	info, err := locked.PluginInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, semver.MustParse("3.0.0"), *info.Version)
+	assert.Equal(t, "https://example.com", info.ServerURL)
+
+	_, ok = loaded.Get(LanguagePlugin, "aws")
+	assert.False(t, ok)
+}
+
+func TestPluginLockInvalidVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugin-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, PluginLockFile)
+	contents := "plugins:\n- name: aws\n  kind: resource\n  version: not-a-version\n"
+	assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+
+	_, err = LoadPluginLock(path)
+	assert.Error(t, err)
+}
+
+func TestGetExactPluginPath(t *testing.T) {
+	home, err := ioutil.TempDir("", "plugin-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	oldHome := os.Getenv(PulumiHomeEnvVar)
+	defer os.Setenv(PulumiHomeEnvVar, oldHome)
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+
+	// Exact lookups must not slide to a newer version, even with the legacy search enabled.
+	oldLegacy := enableLegacyPluginBehavior
+	defer func() { enableLegacyPluginBehavior = oldLegacy }()
+	enableLegacyPluginBehavior = true
+
+	for _, v := range []string{"1.0.0", "2.0.0"} {
+		info := PluginInfo{Kind: ResourcePlugin, Name: "locktest", Version: semverPtr(semver.MustParse(v))}
+		dir, err := info.DirPath()
+		assert.NoError(t, err)
+		assert.NoError(t, os.MkdirAll(dir, 0700))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, info.FilePrefix()), nil, 0700))
+	}
+
+	_, path, err := GetPluginPath(ResourcePlugin, "locktest", semverPtr(semver.MustParse("1.0.0")))
+	assert.NoError(t, err)
+	assert.Contains(t, path, "v2.0.0")
+
+	_, path, err = GetExactPluginPath(ResourcePlugin, "locktest", semver.MustParse("1.0.0"))
+	assert.NoError(t, err)
+	assert.Contains(t, path, "v1.0.0")
+
+	_, path, err = GetExactPluginPath(ResourcePlugin, "locktest", semver.MustParse("1.5.0"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", path)
+}
+
+func semverPtr(v semver.Version) *semver.Version {
+	return &v
+}
@@ -19,8 +19,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
-	"net/url"
 	"os"
 	"os/exec"
 	"path"
@@ -40,9 +38,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/archive"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/fsutil"
-	"github.com/pulumi/pulumi/sdk/v2/go/common/util/httputil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
-	"github.com/pulumi/pulumi/sdk/v2/go/common/version"
 	"github.com/pulumi/pulumi/sdk/v2/nodejs/npm"
 	"github.com/pulumi/pulumi/sdk/v2/python"
 )
@@ -91,6 +87,7 @@ type PluginInfo struct {
 	InstallTime  time.Time       // the time the plugin was installed.
 	LastUsedTime time.Time       // the last time the plugin was used.
 	ServerURL    string          // an optional server to use when downloading this plugin.
+	Checksum     string          // if set, the checksum that the plugin's executable must match once installed.
 	PluginDir    string          // if set, will be used as the root plugin dir instead of ~/.pulumi/plugins.
 }
 
@@ -165,19 +162,45 @@ func (info PluginInfo) FilePath() (string, error) {
 }
 
 // Delete removes the plugin from the cache.  It also deletes any supporting files in the cache, which includes
-// any files that contain the same prefix as the plugin itself.
+// any files that contain the same prefix as the plugin itself, except for the plugin's install lock file, which may
+// be in use by other processes.  Delete does not check whether the plugin is in use: a running deployment that has
+// already loaded the plugin is unaffected, but one that loads it later will need to install it again.
 func (info PluginInfo) Delete() error {
+	// Hold the plugin's install lock while deleting it so that a concurrent install is not corrupted.
+	unlock, err := info.installLock()
+	if err != nil {
+		return err
+	}
+	return info.deleteLocked(unlock)
+}
+
+// TryDelete removes the plugin from the cache, as Delete does, unless the plugin is locked by a concurrent install, in
+// which case TryDelete returns false without removing anything.
+func (info PluginInfo) TryDelete() (bool, error) {
+	unlock, locked, err := info.tryInstallLock()
+	if err != nil || !locked {
+		return false, err
+	}
+	return true, info.deleteLocked(unlock)
+}
+
+// deleteLocked removes the plugin from the cache, releasing the plugin's install lock once the plugin and its
+// supporting files are removed. The lock file itself is left in place: removing it while another process waits on
+// it would allow a third process to lock a new file at the same path and install the plugin concurrently.
+func (info PluginInfo) deleteLocked(unlock func()) error {
+	defer unlock()
+
 	dir, err := info.DirPath()
 	if err != nil {
 		return err
 	}
-	if err := os.RemoveAll(dir); err != nil {
+	if err = os.RemoveAll(dir); err != nil {
 		return err
 	}
-	// Attempt to delete any leftover .partial or .lock files.
+	// Attempt to delete any leftover .partial or .checksums files.
 	// Don't fail the operation if we can't delete these.
 	contract.IgnoreError(os.Remove(fmt.Sprintf("%s.partial", dir)))
-	contract.IgnoreError(os.Remove(fmt.Sprintf("%s.lock", dir)))
+	contract.IgnoreError(os.Remove(fmt.Sprintf("%s.checksums", dir)))
 	return nil
 }
 
@@ -207,67 +230,74 @@ func (info *PluginInfo) SetFileMetadata(path string) error {
 	return nil
 }
 
-// Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
+// Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known). The plugin
+// is fetched from the first plugin source (see GetPluginSources) that has it, falling back to the plugin's server.
+//
+// The download is verified against the plugin's checksum manifest if its source publishes one. If the source does not,
+// the download is NOT verified and is returned anyway, unless signing keys are configured (see PluginKeysDir) or
+// PULUMI_PLUGIN_VERIFY_STRICT is set, in which case Download returns an error. Callers that want to tell the user
+// about unverified downloads should use DownloadWithWarnings instead.
 func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
+	stream, size, _, err := info.DownloadWithWarnings()
+	return stream, size, err
+}
+
+// DownloadWithWarnings is like Download, but also returns any warnings about the download that should be reported to
+// the user, such as the download not being verified because its source does not publish a checksum manifest.
+func (info PluginInfo) DownloadWithWarnings() (io.ReadCloser, int64, []string, error) {
 	// Figure out the OS/ARCH pair for the download URL.
 	var os string
 	switch runtime.GOOS {
 	case "darwin", "linux", "windows":
 		os = runtime.GOOS
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
+		return nil, -1, nil, errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
 	}
 	var arch string
 	switch runtime.GOARCH {
 	case "amd64":
 		arch = runtime.GOARCH
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+		return nil, -1, nil, errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
 	}
 
-	// If the plugin has a server, associated with it, download from there.  Otherwise use the "default" location, which
-	// is hosted by Pulumi.
-	serverURL := info.ServerURL
-	if serverURL == "" {
-		serverURL = "https://get.pulumi.com/releases/plugins"
-	}
-	serverURL = strings.TrimSuffix(serverURL, "/")
-
-	logging.V(1).Infof("%s downloading from %s", info.Name, serverURL)
-
-	// URL escape the path value to ensure we have the correct path for S3/CloudFront.
-	endpoint := fmt.Sprintf("%s/%s",
-		serverURL,
-		url.QueryEscape(fmt.Sprintf("pulumi-%s-%s-v%s-%s-%s.tar.gz", info.Kind, info.Name, info.Version, os, arch)))
-
-	logging.V(9).Infof("full plugin download url: %s", endpoint)
+	// Download from the first of the configured plugin sources that has the plugin. If none of them do, download
+	// from the plugin's own server, if it has one, or from the "default" location, which is hosted by Pulumi.
+	stream, size, _, warnings, err := info.download(os, arch)
+	return stream, size, warnings, err
+}
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+// installLock acquires a file lock used to prevent concurrent installs.
+func (info PluginInfo) installLock() (unlock func(), err error) {
+	mutex, err := info.installMutex()
 	if err != nil {
-		return nil, -1, err
+		return nil, err
 	}
+	if err := mutex.Lock(); err != nil {
+		return nil, err
+	}
+	return func() {
+		contract.IgnoreError(mutex.Unlock())
+	}, nil
+}
 
-	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
-	req.Header.Set("User-Agent", userAgent)
-
-	logging.V(9).Infof("plugin install request headers: %v", req.Header)
-
-	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
+// tryInstallLock attempts to acquire the file lock used to prevent concurrent installs without blocking. If the lock
+// is held elsewhere, tryInstallLock returns false.
+func (info PluginInfo) tryInstallLock() (unlock func(), locked bool, err error) {
+	mutex, err := info.installMutex()
 	if err != nil {
-		return nil, -1, err
+		return nil, false, err
 	}
-
-	logging.V(9).Infof("plugin install response headers: %v", resp.Header)
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, -1, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
+	if locked, err = mutex.TryLock(); err != nil || !locked {
+		return nil, false, err
 	}
-
-	return resp.Body, resp.ContentLength, nil
+	return func() {
+		contract.IgnoreError(mutex.Unlock())
+	}, true, nil
 }
 
-// installLock acquires a file lock used to prevent concurrent installs.
-func (info PluginInfo) installLock() (unlock func(), err error) {
+// installMutex returns the file mutex that guards installs of the plugin.
+func (info PluginInfo) installMutex() (*fsutil.FileMutex, error) {
 	finalDir, err := info.DirPath()
 	if err != nil {
 		return nil, err
@@ -277,14 +307,7 @@ func (info PluginInfo) installLock() (unlock func(), err error) {
 	if err := os.MkdirAll(filepath.Dir(lockFilePath), 0700); err != nil {
 		return nil, errors.Wrap(err, "creating plugin root")
 	}
-
-	mutex := fsutil.NewFileMutex(lockFilePath)
-	if err := mutex.Lock(); err != nil {
-		return nil, err
-	}
-	return func() {
-		contract.IgnoreError(mutex.Unlock())
-	}, nil
+	return fsutil.NewFileMutex(lockFilePath), nil
 }
 
 // Install installs a plugin's tarball into the cache. It validates that plugin names are in the expected format.
@@ -297,7 +320,8 @@ func (info PluginInfo) installLock() (unlock func(), err error) {
 // The `.partial` file is deleted when installation is complete, indicating that the plugin has finished installing.
 // If a failure occurs during installation, the `.partial` file will remain, indicating the plugin wasn't fully
 // installed. The next time the plugin is installed, the old installation directory will be removed and replaced with
-// a fresh install.
+// a fresh install. If the plugin fails checksum verification, its directory and `.partial` file are removed
+// immediately, so that the unverified contents are never left on disk.
 func (info PluginInfo) Install(tgz io.ReadCloser) error {
 	defer contract.IgnoreClose(tgz)
 
@@ -359,8 +383,30 @@ func (info PluginInfo) Install(tgz io.ReadCloser) error {
 		return err
 	}
 
-	// Uncompress the plugin.
+	// If the download fails verification, remove everything that was extracted from it.
+	removeUnverified := func() {
+		contract.IgnoreError(os.RemoveAll(finalDir))
+		contract.IgnoreError(os.Remove(partialFilePath))
+	}
+
+	// Uncompress the plugin, then read the rest of the tarball so that a download that is being verified against a
+	// checksum is checked in its entirety.
 	if err := archive.ExtractTGZ(tgz, finalDir); err != nil {
+		removeUnverified()
+		return err
+	}
+	if _, err := io.Copy(ioutil.Discard, tgz); err != nil {
+		removeUnverified()
+		return err
+	}
+
+	// Make sure the executable matches its pinned checksum, if any, and record the checksums of the extracted files
+	// so that the installation can be verified later.
+	if err := info.verifyExecutable(); err != nil {
+		removeUnverified()
+		return err
+	}
+	if err := info.recordInstallChecksums(); err != nil {
 		return err
 	}
 
@@ -582,6 +628,17 @@ func getPlugins(dir string) ([]PluginInfo, error) {
 // is >= the version specified.  If no version is supplied, the latest plugin for that given kind/name pair is loaded,
 // using standard semver sorting rules.  A plugin may be overridden entirely by placing it on your $PATH.
 func GetPluginPath(kind PluginKind, name string, version *semver.Version) (string, string, error) {
+	return getPluginPath(kind, name, version, false)
+}
+
+// GetExactPluginPath finds a plugin's path by its kind, name, and version. Unlike GetPluginPath, only a cached plugin
+// whose version is exactly the version specified will match. This is used to resolve plugins pinned by a plugin lock.
+// As with GetPluginPath, a plugin may be overridden entirely by placing it on your $PATH.
+func GetExactPluginPath(kind PluginKind, name string, version semver.Version) (string, string, error) {
+	return getPluginPath(kind, name, &version, true)
+}
+
+func getPluginPath(kind PluginKind, name string, version *semver.Version, exact bool) (string, string, error) {
 	// If we have a version of the plugin on its $PATH, use it.  This supports development scenarios.
 	filename := (&PluginInfo{Kind: kind, Name: name, Version: version}).FilePrefix()
 	if path, err := exec.LookPath(filename); err == nil {
@@ -643,7 +700,11 @@ func GetPluginPath(kind PluginKind, name string, version *semver.Version) (strin
 				// Always pick the most recent version of the plugin available.  Even if this is an exact match, we
 				// keep on searching just in case there's a newer version available.
 				var m *PluginInfo
-				if match == nil && version == nil {
+				if exact {
+					if plugin.Version != nil && plugin.Version.EQ(*version) {
+						m = &plugin // this plugin is exactly the version being requested, use it.
+					}
+				} else if match == nil && version == nil {
 					m = &plugin // no existing match, no version spec, take it.
 				} else if match != nil &&
 					(match.Version == nil || (plugin.Version != nil && plugin.Version.GT(*match.Version))) {
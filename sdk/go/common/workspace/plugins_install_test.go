@@ -122,13 +122,17 @@ func testDeletePlugin(t *testing.T, dir string, plugin PluginInfo) {
 	paths := []string{
 		filepath.Join(dir, plugin.Dir()),
 		filepath.Join(dir, plugin.Dir()+".partial"),
-		filepath.Join(dir, plugin.Dir()+".lock"),
+		filepath.Join(dir, plugin.Dir()+".checksums"),
 	}
 	for _, path := range paths {
 		_, err := os.Stat(path)
 		assert.Error(t, err)
 		assert.True(t, os.IsNotExist(err))
 	}
+
+	// The lock file is kept, as other processes may be waiting on it.
+	_, err = os.Stat(filepath.Join(dir, plugin.Dir()+".lock"))
+	assert.NoError(t, err)
 }
 
 func testPluginInstall(t *testing.T, expectedDir string, files map[string][]byte) {
@@ -258,3 +262,28 @@ func TestGetPluginsSkipsPartial(t *testing.T) {
 	plugins, err := getPlugins(dir)
 	assert.Equal(t, 0, len(plugins))
 }
+
+func TestTryDeleteSkipsLockedPlugin(t *testing.T) {
+	dir, tarball, plugin := prepareTestDir(t, nil)
+	defer os.RemoveAll(dir)
+
+	err := plugin.Install(tarball)
+	assert.NoError(t, err)
+
+	// Hold the plugin's install lock, as a concurrent install would.
+	unlock, err := plugin.installLock()
+	assert.NoError(t, err)
+
+	deleted, err := plugin.TryDelete()
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	assertPluginInstalled(t, dir, plugin)
+
+	unlock()
+
+	deleted, err = plugin.TryDelete()
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	_, err = os.Stat(filepath.Join(dir, plugin.Dir()))
+	assert.True(t, os.IsNotExist(err))
+}
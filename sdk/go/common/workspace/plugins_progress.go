@@ -0,0 +1,142 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cheggaaa/pb"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+)
+
+// ProgressBarPool displays the progress of several concurrent downloads together, with one progress bar per download.
+// The bars are redrawn in place, so the pool should only be used when its output is a terminal. A nil
+// *ProgressBarPool displays nothing.
+type ProgressBarPool struct {
+	out          io.Writer
+	colorization colors.Colorization
+
+	m     sync.Mutex
+	bars  []*pb.ProgressBar
+	lines int // the number of lines drawn by the last render.
+
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewProgressBarPool creates a new progress bar pool that draws to the given writer until it is closed.
+func NewProgressBarPool(out io.Writer, colorization colors.Colorization) *ProgressBarPool {
+	p := &ProgressBarPool{
+		out:          out,
+		colorization: colorization,
+		done:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+	go func() {
+		defer close(p.stopped)
+		ticker := time.NewTicker(pb.DefaultRefreshRate)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.render()
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// render redraws each of the pool's progress bars in place.
+func (p *ProgressBarPool) render() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	var out strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&out, "\033[%dA", p.lines)
+	}
+	for _, bar := range p.bars {
+		bar.Update()
+		fmt.Fprintf(&out, "\r%s\n", bar.String())
+	}
+	p.lines = len(p.bars)
+	fmt.Fprint(p.out, out.String())
+}
+
+// ReadCloser wraps the given download stream, adding a progress bar with the given message to the pool. If the size
+// of the download is unknown, the stream is returned unchanged.
+func (p *ProgressBarPool) ReadCloser(closer io.ReadCloser, size int64, message string) io.ReadCloser {
+	if p == nil || size == -1 {
+		return closer
+	}
+
+	bar := pb.New(int(size))
+	bar.Prefix(p.colorization.Colorize(colors.SpecUnimportant + message + ":"))
+	bar.Postfix(p.colorization.Colorize(colors.Reset))
+	bar.SetMaxWidth(80)
+	bar.SetUnits(pb.U_BYTES)
+	bar.ManualUpdate = true
+	bar.NotPrint = true
+	bar.Start()
+
+	p.m.Lock()
+	p.bars = append(p.bars, bar)
+	p.m.Unlock()
+
+	return &barCloser{
+		bar:        bar,
+		readCloser: bar.NewProxyReader(closer),
+	}
+}
+
+// Printf prints a line of text above the pool's progress bars, which are then redrawn beneath it.
+func (p *ProgressBarPool) Printf(format string, args ...interface{}) {
+	if p == nil {
+		return
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	// Move to the first bar, clear the bars, print the line, and then redraw the bars below it.
+	var out strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&out, "\033[%dA\r\033[J", p.lines)
+	}
+	fmt.Fprintf(&out, format+"\n", args...)
+	for _, bar := range p.bars {
+		fmt.Fprintf(&out, "\r%s\n", bar.String())
+	}
+	p.lines = len(p.bars)
+	fmt.Fprint(p.out, out.String())
+}
+
+// Close stops updating the pool's progress bars after drawing them one final time.
+func (p *ProgressBarPool) Close() error {
+	if p == nil {
+		return nil
+	}
+	close(p.done)
+	<-p.stopped
+	p.render()
+	return nil
+}
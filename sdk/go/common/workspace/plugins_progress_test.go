@@ -0,0 +1,67 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+)
+
+// syncBuffer is a bytes.Buffer that is safe to write to from the pool's render goroutine.
+type syncBuffer struct {
+	m sync.Mutex
+	b bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.b.String()
+}
+
+func TestProgressBarPoolPrintf(t *testing.T) {
+	var out syncBuffer
+	pool := NewProgressBarPool(&out, colors.Never)
+
+	stream := pool.ReadCloser(ioutil.NopCloser(strings.NewReader("contents")), 8, "[resource plugin a] downloading")
+	pool.Printf("%s installing", "[resource plugin b]")
+	_, err := ioutil.ReadAll(stream)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Close())
+	assert.NoError(t, pool.Close())
+
+	// The line is printed once, followed by the redrawn bar, rather than being drawn over by the bar.
+	output := out.String()
+	assert.Equal(t, 1, strings.Count(output, "[resource plugin b] installing\n"))
+	i := strings.Index(output, "[resource plugin b] installing\n")
+	assert.Contains(t, output[i:], "[resource plugin a] downloading")
+
+	// A nil pool prints nothing.
+	var nilPool *ProgressBarPool
+	nilPool.Printf("ignored")
+}
@@ -0,0 +1,299 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/httputil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/version"
+)
+
+// PluginSourcesEnvVar is a comma-separated list of plugin sources to consult, in order, before a plugin's own server.
+const PluginSourcesEnvVar = "PULUMI_PLUGIN_SOURCES"
+
+// defaultPluginServerURL is the server from which plugins are downloaded if they do not specify a server of their own.
+const defaultPluginServerURL = "https://get.pulumi.com/releases/plugins"
+
+// pluginSource is a location from which plugin tarballs and their checksum manifests may be fetched.
+type pluginSource interface {
+	// String returns the location of the source, for display.
+	String() string
+	// open opens the given file and returns its size, if known. If the source does not have the file, open returns
+	// false.
+	open(file string) (io.ReadCloser, int64, bool, error)
+}
+
+// httpPluginSource is a plugin server or HTTP mirror. Files are fetched from the server's root URL.
+type httpPluginSource struct {
+	url string
+}
+
+func (s *httpPluginSource) String() string {
+	return s.url
+}
+
+func (s *httpPluginSource) open(file string) (io.ReadCloser, int64, bool, error) {
+	// URL escape the path value to ensure we have the correct path for S3/CloudFront.
+	endpoint := fmt.Sprintf("%s/%s", s.url, url.QueryEscape(file))
+
+	logging.V(9).Infof("full plugin download url: %s", endpoint)
+
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, -1, false, err
+	}
+
+	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
+	req.Header.Set("User-Agent", userAgent)
+
+	logging.V(9).Infof("plugin install request headers: %v", req.Header)
+
+	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
+	if err != nil {
+		return nil, -1, false, err
+	}
+
+	logging.V(9).Infof("plugin install response headers: %v", resp.Header)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden:
+		// S3 responds with 403 Forbidden for missing objects in buckets that do not allow listing.
+		contract.IgnoreClose(resp.Body)
+		return nil, -1, false, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		contract.IgnoreClose(resp.Body)
+		return nil, -1, false, errors.Errorf("%d HTTP error fetching %s", resp.StatusCode, endpoint)
+	}
+	return resp.Body, resp.ContentLength, true, nil
+}
+
+// dirPluginSource is a local directory, such as one populated by `pulumi plugin mirror`, that contains plugin
+// tarballs and checksum manifests named exactly as they are on a plugin server.
+type dirPluginSource struct {
+	dir string
+}
+
+func (s *dirPluginSource) String() string {
+	return s.dir
+}
+
+func (s *dirPluginSource) open(file string) (io.ReadCloser, int64, bool, error) {
+	path := filepath.Join(s.dir, file)
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, -1, false, nil
+		}
+		return nil, -1, false, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		contract.IgnoreClose(f)
+		return nil, -1, false, err
+	}
+	return f, fi.Size(), true, nil
+}
+
+// newPluginSource parses a plugin source. HTTP and HTTPS URLs refer to plugin servers or mirrors, and `file://` URLs
+// and all other values refer to local directories.
+func newPluginSource(source string) (pluginSource, error) {
+	switch {
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		return &httpPluginSource{url: strings.TrimSuffix(source, "/")}, nil
+	case strings.HasPrefix(source, "file://"):
+		u, err := url.Parse(source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid plugin source %s", source)
+		}
+		path := u.Path
+		if runtime.GOOS == windowsGOOS {
+			// file:///C:/plugins parses to a path of /C:/plugins.
+			path = strings.TrimPrefix(path, "/")
+		}
+		return &dirPluginSource{dir: filepath.FromSlash(path)}, nil
+	default:
+		dir, err := filepath.Abs(source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid plugin source %s", source)
+		}
+		return &dirPluginSource{dir: dir}, nil
+	}
+}
+
+// GetPluginSources returns the configured plugin sources, in the order in which they should be consulted: first those
+// listed in the PULUMI_PLUGIN_SOURCES environment variable, then those in the current workspace's settings. Workspace
+// settings are only available when the current directory is within a project, so plugins installed elsewhere are
+// only fetched from the sources listed in the environment variable.
+func GetPluginSources() []string {
+	var sources []string
+	for _, s := range strings.Split(os.Getenv(PluginSourcesEnvVar), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			sources = append(sources, s)
+		}
+	}
+
+	// Plugins may be installed outside of any project, in which case there are no workspace settings to consult.
+	if w, err := New(); err == nil {
+		sources = append(sources, w.Settings().PluginSources...)
+	} else {
+		logging.V(7).Infof("GetPluginSources(): not using workspace settings: %v", err)
+	}
+	return sources
+}
+
+// pluginSources returns the sources from which the plugin may be downloaded, in the order in which they should be
+// consulted: the configured plugin sources, followed by the plugin's own server or the default server.
+func (info PluginInfo) pluginSources() ([]pluginSource, error) {
+	var sources []pluginSource
+	for _, s := range GetPluginSources() {
+		source, err := newPluginSource(s)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	serverURL := info.ServerURL
+	if serverURL == "" {
+		serverURL = defaultPluginServerURL
+	}
+	server, err := newPluginSource(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	return append(sources, server), nil
+}
+
+// readSourceFile reads the contents of the given file from the given source. If the source does not have the file,
+// readSourceFile returns false.
+func readSourceFile(source pluginSource, file string) ([]byte, bool, error) {
+	r, _, found, err := source.open(file)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	defer contract.IgnoreClose(r)
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+// TarballName returns the name of the tarball that contains the plugin for the given OS and architecture.
+func (info PluginInfo) TarballName(goos, goarch string) string {
+	return fmt.Sprintf("pulumi-%s-%s-v%s-%s-%s.tar.gz", info.Kind, info.Name, info.Version, goos, goarch)
+}
+
+// download fetches the plugin's tarball for the given OS and architecture from the first of the plugin's sources that
+// has it. The tarball is verified against its checksum, if one is published, as it is read. download returns the
+// source that the tarball was fetched from, along with any warnings about the download that should be reported to the
+// user, such as the tarball not being verified.
+func (info PluginInfo) download(goos, goarch string) (io.ReadCloser, int64, pluginSource, []string, error) {
+	sources, err := info.pluginSources()
+	if err != nil {
+		return nil, -1, nil, nil, err
+	}
+
+	tarball := info.TarballName(goos, goarch)
+	var failures []string
+	for _, source := range sources {
+		logging.V(1).Infof("%s downloading from %s", info.Name, source)
+
+		stream, size, found, err := source.open(tarball)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", source, err))
+			continue
+		} else if !found {
+			failures = append(failures, fmt.Sprintf("%s: not found", source))
+			continue
+		}
+
+		checksum, err := info.downloadChecksum(source, tarball)
+		if err != nil {
+			contract.IgnoreClose(stream)
+			failures = append(failures, fmt.Sprintf("%s: %v", source, err))
+			continue
+		}
+		if checksum == "" {
+			warning := fmt.Sprintf("%s does not publish checksums for %s plugin %s; the download was not verified",
+				source, info.Kind, info)
+			return stream, size, source, []string{warning}, nil
+		}
+		return newChecksumReader(stream, tarball, checksum), size, source, nil, nil
+	}
+
+	return nil, -1, nil, nil, errors.Errorf("could not download %s:\n    %s", tarball, strings.Join(failures, "\n    "))
+}
+
+// Mirror copies the plugin's tarball for the given OS and architecture into the given directory, along with its
+// checksum manifest and signature if they are published, so that the directory may be used as a plugin source. Mirror
+// returns any warnings about the download that should be reported to the user.
+func (info PluginInfo) Mirror(dir, goos, goarch string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	stream, _, source, warnings, err := info.download(goos, goarch)
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(stream)
+
+	// The tarball is written to a temporary file and moved into place once it has been verified, so that a failed
+	// download does not leave a corrupt tarball in the mirror.
+	tarball := filepath.Join(dir, info.TarballName(goos, goarch))
+	f, err := ioutil.TempFile(dir, info.TarballName(goos, goarch)+".tmp")
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.Copy(f, stream)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), tarball)
+	}
+	if err != nil {
+		contract.IgnoreError(os.Remove(f.Name()))
+		return nil, errors.Wrapf(err, "mirroring %s", tarball)
+	}
+
+	for _, file := range []string{info.ChecksumsFileName(), info.ChecksumsFileName() + ".sig"} {
+		b, found, err := readSourceFile(source, file)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			if err = ioutil.WriteFile(filepath.Join(dir, file), b, 0644); err != nil { //nolint: gosec
+				return nil, err
+			}
+		}
+	}
+	return warnings, nil
+}
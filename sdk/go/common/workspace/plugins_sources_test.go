@@ -0,0 +1,124 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPluginSource(t *testing.T) {
+	source, err := newPluginSource("https://example.com/plugins/")
+	assert.NoError(t, err)
+	assert.Equal(t, &httpPluginSource{url: "https://example.com/plugins"}, source)
+
+	source, err = newPluginSource("file:///var/plugins")
+	assert.NoError(t, err)
+	assert.Equal(t, &dirPluginSource{dir: filepath.FromSlash("/var/plugins")}, source)
+
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	source, err = newPluginSource("plugins")
+	assert.NoError(t, err)
+	assert.Equal(t, &dirPluginSource{dir: filepath.Join(cwd, "plugins")}, source)
+}
+
+func TestPluginSourcesAndMirror(t *testing.T) {
+	_, cleanup := withPulumiHome(t)
+	defer cleanup()
+
+	v := semver.MustParse("1.0.0")
+	info := PluginInfo{Name: "test", Kind: ResourcePlugin, Version: &v}
+	tarball := makePluginTarball(t, map[string]string{"pulumi-resource-test": "#!/bin/sh\n"})
+	tarballName := info.TarballName("linux", "amd64")
+	manifest := []byte(sha256Hex(tarball) + "  " + tarballName + "\n")
+
+	// The plugin's own server has the plugin, along with its checksums.
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var b []byte
+		switch r.URL.Path[1:] {
+		case tarballName:
+			b = tarball
+		case info.ChecksumsFileName():
+			b = manifest
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(b)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	info.ServerURL = server.URL
+
+	// An empty mirror is skipped in favor of the plugin's server.
+	mirror, err := ioutil.TempDir("", "plugin-mirror")
+	assert.NoError(t, err)
+	defer os.RemoveAll(mirror)
+	assert.NoError(t, os.Setenv(PluginSourcesEnvVar, "file://"+filepath.ToSlash(mirror)))
+	defer os.Unsetenv(PluginSourcesEnvVar)
+
+	stream, _, source, _, err := info.download("linux", "amd64")
+	assert.NoError(t, err)
+	b, err := ioutil.ReadAll(stream)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Close())
+	assert.Equal(t, tarball, b)
+	assert.Equal(t, server.URL, source.String())
+
+	// Mirroring the plugin copies its tarball and checksums into the mirror...
+	_, err = info.Mirror(mirror, "linux", "amd64")
+	assert.NoError(t, err)
+	b, err = ioutil.ReadFile(filepath.Join(mirror, tarballName))
+	assert.NoError(t, err)
+	assert.Equal(t, tarball, b)
+	b, err = ioutil.ReadFile(filepath.Join(mirror, info.ChecksumsFileName()))
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, b)
+
+	// ...after which the plugin is downloaded from the mirror without contacting the server.
+	atomic.StoreInt32(&requests, 0)
+	stream, _, source, _, err = info.download("linux", "amd64")
+	assert.NoError(t, err)
+	b, err = ioutil.ReadAll(stream)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Close())
+	assert.Equal(t, tarball, b)
+	assert.Equal(t, mirror, source.String())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+
+	// A tarball in the mirror that does not match the mirrored checksums fails verification.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(mirror, tarballName), []byte("tampered"), 0600))
+	stream, _, _, _, err = info.download("linux", "amd64")
+	assert.NoError(t, err)
+	_, err = ioutil.ReadAll(stream)
+	assert.Error(t, err)
+	assert.NoError(t, stream.Close())
+
+	// A plugin that no source has cannot be downloaded.
+	missing := PluginInfo{Name: "missing", Kind: ResourcePlugin, Version: &v, ServerURL: server.URL}
+	_, _, _, _, err = missing.download("linux", "amd64")
+	assert.Error(t, err)
+}
@@ -0,0 +1,354 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// PluginKeysDir is the name of the directory, under the '.pulumi' folder, that holds the public keys trusted to sign
+// plugin checksum manifests. Each key is stored in its own file with a `.pub` extension and contains a base64-encoded
+// Ed25519 public key. If any keys are present, every downloaded plugin must have a checksum manifest that is signed
+// by one of them.
+const PluginKeysDir = "plugin-keys"
+
+// PluginVerifyStrictEnvVar is the environment variable that, when set to a non-empty value, makes plugin downloads
+// fail rather than warn when their source does not publish a checksum manifest against which to verify them. Note that
+// unless this variable is set or signing keys are configured, plugins whose sources do not publish manifests are
+// downloaded and installed without any verification.
+const PluginVerifyStrictEnvVar = "PULUMI_PLUGIN_VERIFY_STRICT"
+
+// checksumPrefix is the prefix of the checksums recorded by Pulumi, which are all SHA-256 digests.
+const checksumPrefix = "sha256:"
+
+// ChecksumMismatchError is returned when a plugin's contents do not match their expected checksums.
+type ChecksumMismatchError struct {
+	Info  PluginInfo // the plugin that failed verification.
+	Files []string   // the files whose contents did not match.
+}
+
+func (err *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s plugin %s failed checksum verification: %s was modified",
+		err.Info.Kind, err.Info, strings.Join(err.Files, ", "))
+}
+
+// ChecksumsFileName returns the name of the checksum manifest published alongside the plugin's tarballs.
+func (info PluginInfo) ChecksumsFileName() string {
+	return fmt.Sprintf("pulumi-%s-%s-v%s-checksums.txt", info.Kind, info.Name, info.Version)
+}
+
+// InstallChecksumsPath returns the full path to the file that records the checksums of the plugin's files as they
+// were extracted from its tarball.
+func (info PluginInfo) InstallChecksumsPath() (string, error) {
+	dir, err := info.DirPath()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.checksums", dir), nil
+}
+
+// fileChecksum returns the SHA-256 checksum of the file at the given path, in the form "sha256:<hex digest>".
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer contract.IgnoreClose(f)
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", errors.Wrapf(err, "computing checksum of %s", path)
+	}
+	return checksumPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// parseChecksums parses a checksum manifest in the format produced by `sha256sum`, in which each line contains a
+// hex-encoded digest followed by the name of the file it describes. The result maps each file name to a checksum of
+// the form "sha256:<hex digest>".
+func parseChecksums(b []byte) (map[string]string, error) {
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("line %d: expected a checksum and a file name", line)
+		}
+		digest, name := fields[0], strings.TrimPrefix(fields[1], "*")
+		if _, err := hex.DecodeString(digest); err != nil || len(digest) != sha256.Size*2 {
+			return nil, errors.Errorf("line %d: invalid SHA-256 checksum %q", line, digest)
+		}
+		checksums[name] = checksumPrefix + strings.ToLower(digest)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+// formatChecksums formats the given checksums as a manifest that can be read by parseChecksums.
+func formatChecksums(checksums map[string]string) []byte {
+	names := make([]string, 0, len(checksums))
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s  %s\n", strings.TrimPrefix(checksums[name], checksumPrefix), name)
+	}
+	return b.Bytes()
+}
+
+// GetPluginSigningKeys returns the public keys that are trusted to sign plugin checksum manifests.
+func GetPluginSigningKeys() ([]ed25519.PublicKey, error) {
+	dir, err := GetPulumiPath(PluginKeysDir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var keys []ed25519.PublicKey
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".pub" {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, errors.Errorf("%s does not contain a base64-encoded Ed25519 public key", path)
+		}
+		keys = append(keys, ed25519.PublicKey(key))
+	}
+	return keys, nil
+}
+
+// verifySignature checks that the given detached, base64-encoded signature of the given message was produced by one
+// of the given keys.
+func verifySignature(message, signature []byte, keys []ed25519.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return errors.Wrap(err, "decoding signature")
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key, message, sig) {
+			return nil
+		}
+	}
+	return errors.New("signature does not match any trusted key")
+}
+
+// downloadChecksum returns the expected checksum of the given tarball, as recorded in the checksum manifest that is
+// published alongside the plugin. If signing keys are configured, the manifest must be present and signed by one of
+// them. Otherwise, if the source does not publish a manifest, downloadChecksum returns an empty checksum, meaning that
+// the download cannot be verified, or returns an error if PULUMI_PLUGIN_VERIFY_STRICT is set.
+func (info PluginInfo) downloadChecksum(source pluginSource, tarball string) (string, error) {
+	keys, err := GetPluginSigningKeys()
+	if err != nil {
+		return "", errors.Wrap(err, "loading plugin signing keys")
+	}
+
+	manifestName := info.ChecksumsFileName()
+	manifest, found, err := readSourceFile(source, manifestName)
+	if err != nil {
+		return "", errors.Wrap(err, "downloading plugin checksums")
+	}
+	if !found {
+		if len(keys) > 0 {
+			return "", errors.Errorf("%s does not publish signed checksums for %s plugin %s",
+				source, info.Kind, info)
+		}
+		if os.Getenv(PluginVerifyStrictEnvVar) != "" {
+			return "", errors.Errorf("%s does not publish checksums for %s plugin %s, and %s is set",
+				source, info.Kind, info, PluginVerifyStrictEnvVar)
+		}
+		logging.V(1).Infof("%s no checksums published at %s; skipping verification", info.Name, source)
+		return "", nil
+	}
+
+	if len(keys) > 0 {
+		signature, found, err := readSourceFile(source, manifestName+".sig")
+		if err != nil {
+			return "", errors.Wrap(err, "downloading plugin checksums signature")
+		}
+		if !found {
+			return "", errors.Errorf("%s does not publish a signature for %s", source, manifestName)
+		}
+		if err = verifySignature(manifest, signature, keys); err != nil {
+			return "", errors.Wrapf(err, "verifying %s", manifestName)
+		}
+	}
+
+	checksums, err := parseChecksums(manifest)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing %s", manifestName)
+	}
+	checksum, ok := checksums[tarball]
+	if !ok {
+		return "", errors.Errorf("%s does not contain a checksum for %s", manifestName, tarball)
+	}
+	return checksum, nil
+}
+
+// checksumReader wraps a tarball's download stream, computing the stream's checksum as it is read. Once the stream
+// has been read in its entirety, checksumReader returns an error from Read if the checksum does not match.
+type checksumReader struct {
+	io.ReadCloser
+	name     string
+	expected string
+	hash     hash.Hash
+}
+
+func newChecksumReader(r io.ReadCloser, name, expected string) io.ReadCloser {
+	return &checksumReader{ReadCloser: r, name: name, expected: expected, hash: sha256.New()}
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		if actual := checksumPrefix + hex.EncodeToString(r.hash.Sum(nil)); actual != r.expected {
+			return n, errors.Errorf("checksum mismatch for %s: expected %s, got %s", r.name, r.expected, actual)
+		}
+	}
+	return n, err
+}
+
+// recordInstallChecksums records the checksums of the files in the plugin's installation directory so that the
+// installation can later be checked with VerifyInstall.
+func (info PluginInfo) recordInstallChecksums() error {
+	dir, err := info.DirPath()
+	if err != nil {
+		return err
+	}
+	path, err := info.InstallChecksumsPath()
+	if err != nil {
+		return err
+	}
+
+	checksums := make(map[string]string)
+	err = filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || !fi.Mode().IsRegular() {
+			return err
+		}
+		checksum, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		checksums[filepath.ToSlash(rel)] = checksum
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "computing plugin checksums")
+	}
+	return ioutil.WriteFile(path, formatChecksums(checksums), 0600)
+}
+
+// VerifyInstall checks that the files extracted when the plugin was installed have not since been modified or
+// removed. Files added after extraction, such as installed dependencies, are not checked. If the plugin was installed
+// without recording its checksums, VerifyInstall returns false. If any file does not match, VerifyInstall returns a
+// *ChecksumMismatchError.
+func (info PluginInfo) VerifyInstall() (bool, error) {
+	dir, err := info.DirPath()
+	if err != nil {
+		return false, err
+	}
+	path, err := info.InstallChecksumsPath()
+	if err != nil {
+		return false, err
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	checksums, err := parseChecksums(b)
+	if err != nil {
+		return false, errors.Wrapf(err, "parsing %s", path)
+	}
+
+	var modified []string
+	for name, expected := range checksums {
+		actual, err := fileChecksum(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil && !os.IsNotExist(err) {
+			return true, err
+		}
+		if actual != expected {
+			modified = append(modified, name)
+		}
+	}
+	if len(modified) > 0 {
+		sort.Strings(modified)
+		return true, &ChecksumMismatchError{Info: info, Files: modified}
+	}
+	return true, nil
+}
+
+// verifyExecutable checks that the plugin's installed executable matches the plugin's expected checksum, if any.
+func (info PluginInfo) verifyExecutable() error {
+	if info.Checksum == "" {
+		return nil
+	}
+	path, err := info.FilePath()
+	if err != nil {
+		return err
+	}
+	actual, err := fileChecksum(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if actual != info.Checksum {
+		return &ChecksumMismatchError{Info: info, Files: []string{info.File()}}
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// makePluginTarball creates an in-memory plugin tarball containing the given files.
+func makePluginTarball(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	gw := gzip.NewWriter(&buffer)
+	writer := tar.NewWriter(gw)
+	for name, content := range files {
+		assert.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0700}))
+		_, err := writer.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, gw.Close())
+	return buffer.Bytes()
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// withPulumiHome points the '.pulumi' folder at a new temporary directory for the duration of a test.
+func withPulumiHome(t *testing.T) (string, func()) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	assert.NoError(t, err)
+	old, had := os.LookupEnv(PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+	return home, func() {
+		if had {
+			contract.IgnoreError(os.Setenv(PulumiHomeEnvVar, old))
+		} else {
+			contract.IgnoreError(os.Unsetenv(PulumiHomeEnvVar))
+		}
+		contract.IgnoreError(os.RemoveAll(home))
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	digest := sha256Hex([]byte("hello"))
+	checksums, err := parseChecksums([]byte(digest + "  a.tar.gz\n\n" + digest + " *b.tar.gz\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"a.tar.gz": "sha256:" + digest,
+		"b.tar.gz": "sha256:" + digest,
+	}, checksums)
+
+	roundTripped, err := parseChecksums(formatChecksums(checksums))
+	assert.NoError(t, err)
+	assert.Equal(t, checksums, roundTripped)
+
+	_, err = parseChecksums([]byte("abc  a.tar.gz\n"))
+	assert.Error(t, err)
+	_, err = parseChecksums([]byte(digest + "\n"))
+	assert.Error(t, err)
+}
+
+func TestVerifySignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	message := []byte("checksums")
+	signature := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, message)))
+
+	assert.NoError(t, verifySignature(message, signature, []ed25519.PublicKey{otherPub, pub}))
+	assert.Error(t, verifySignature(message, signature, []ed25519.PublicKey{otherPub}))
+	assert.Error(t, verifySignature([]byte("tampered"), signature, []ed25519.PublicKey{pub}))
+}
+
+func TestDownloadVerifiesChecksum(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("plugin downloads are only supported on amd64")
+	}
+
+	home, cleanup := withPulumiHome(t)
+	defer cleanup()
+
+	v := semver.MustParse("1.0.0")
+	info := PluginInfo{Name: "test", Kind: ResourcePlugin, Version: &v}
+	tarball := makePluginTarball(t, map[string]string{"pulumi-resource-test": "#!/bin/sh\n"})
+	tarballName := fmt.Sprintf("pulumi-resource-test-v1.0.0-%s-amd64.tar.gz", runtime.GOOS)
+	manifestName := "pulumi-resource-test-v1.0.0-checksums.txt"
+
+	files := map[string][]byte{tarballName: tarball}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path[1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(b)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	info.ServerURL = server.URL
+
+	download := func() ([]byte, []string, error) {
+		stream, _, warnings, err := info.DownloadWithWarnings()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer stream.Close()
+		b, err := ioutil.ReadAll(stream)
+		return b, warnings, err
+	}
+
+	// Without a manifest, the download is not verified, and the caller is warned, unless strict verification is
+	// required.
+	b, warnings, err := download()
+	assert.NoError(t, err)
+	assert.Equal(t, tarball, b)
+	assert.Len(t, warnings, 1)
+
+	assert.NoError(t, os.Setenv(PluginVerifyStrictEnvVar, "true"))
+	_, _, err = download()
+	assert.NoError(t, os.Unsetenv(PluginVerifyStrictEnvVar))
+	assert.Error(t, err)
+
+	// With a manifest, the download must match it.
+	manifest := []byte(sha256Hex(tarball) + "  " + tarballName + "\n")
+	files[manifestName] = manifest
+	b, warnings, err = download()
+	assert.NoError(t, err)
+	assert.Equal(t, tarball, b)
+	assert.Empty(t, warnings)
+
+	files[manifestName] = []byte(sha256Hex([]byte("other")) + "  " + tarballName + "\n")
+	_, _, err = download()
+	assert.Error(t, err)
+
+	// Once signing keys are configured, the manifest must be signed by one of them.
+	files[manifestName] = manifest
+	pub, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	keysDir := filepath.Join(home, PluginKeysDir)
+	assert.NoError(t, os.MkdirAll(keysDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(keysDir, "test.pub"),
+		[]byte(base64.StdEncoding.EncodeToString(pub)), 0600))
+
+	_, _, err = download()
+	assert.Error(t, err)
+
+	files[manifestName+".sig"] = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, manifest)))
+	b, _, err = download()
+	assert.NoError(t, err)
+	assert.Equal(t, tarball, b)
+
+	delete(files, manifestName)
+	_, _, err = download()
+	assert.Error(t, err)
+}
+
+func TestInstallVerification(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugins-verify")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	v := semver.MustParse("1.0.0")
+	info := PluginInfo{Name: "test", Kind: ResourcePlugin, Version: &v, PluginDir: dir}
+	executable := "#!/bin/sh\n"
+	tarball := makePluginTarball(t, map[string]string{
+		info.File():  executable,
+		"README.md":  "readme\n",
+		"lib/lib.js": "module.exports = {};\n",
+	})
+
+	// A download or an executable that does not match its checksum is rejected, and nothing is left behind.
+	assertRemoved := func() {
+		for _, path := range []string{info.Dir(), info.Dir() + ".partial"} {
+			_, err := os.Stat(filepath.Join(dir, path))
+			assert.True(t, os.IsNotExist(err), "%s was not removed", path)
+		}
+	}
+	stream := newChecksumReader(ioutil.NopCloser(bytes.NewReader(tarball)), "test.tar.gz",
+		"sha256:"+sha256Hex([]byte("other")))
+	err = info.Install(stream)
+	assert.Error(t, err)
+	assertRemoved()
+
+	info.Checksum = "sha256:" + sha256Hex([]byte("other"))
+	err = info.Install(ioutil.NopCloser(bytes.NewReader(tarball)))
+	assert.Error(t, err)
+	assert.False(t, HasPlugin(info))
+	assertRemoved()
+
+	info.Checksum = "sha256:" + sha256Hex([]byte(executable))
+	assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(tarball))))
+	assert.True(t, HasPlugin(info))
+
+	recorded, err := info.VerifyInstall()
+	assert.True(t, recorded)
+	assert.NoError(t, err)
+
+	// Files added after installation are not checked, but modified or removed files are.
+	pluginDir := filepath.Join(dir, info.Dir())
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(pluginDir, "extra.txt"), []byte("extra"), 0600))
+	recorded, err = info.VerifyInstall()
+	assert.True(t, recorded)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(pluginDir, "README.md"), []byte("changed"), 0600))
+	assert.NoError(t, os.Remove(filepath.Join(pluginDir, "lib", "lib.js")))
+	_, err = info.VerifyInstall()
+	if assert.IsType(t, &ChecksumMismatchError{}, err) {
+		assert.Equal(t, []string{"README.md", "lib/lib.js"}, err.(*ChecksumMismatchError).Files)
+	}
+
+	// Plugins installed without recorded checksums cannot be verified.
+	checksumsPath, err := info.InstallChecksumsPath()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Remove(checksumsPath))
+	recorded, err = info.VerifyInstall()
+	assert.False(t, recorded)
+	assert.NoError(t, err)
+}
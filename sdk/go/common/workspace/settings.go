@@ -18,10 +18,14 @@ package workspace
 type Settings struct {
 	// Stack is an optional default stack to use.
 	Stack string `json:"stack,omitempty" yaml:"env,omitempty"`
+	// PluginSources is an optional list of plugin sources (local directories, `file://` URLs, or HTTP mirrors) to
+	// consult, in order, when downloading plugins. Because workspace settings belong to a project, these sources are
+	// only used when plugins are installed from within a project; PULUMI_PLUGIN_SOURCES applies everywhere.
+	PluginSources []string `json:"pluginSources,omitempty" yaml:"pluginSources,omitempty"`
 }
 
-// IsEmpty returns true when the settings object is logically empty (no selected stack and nothing in the deprecated
-// configuration bag).
+// IsEmpty returns true when the settings object is logically empty (no selected stack, no plugin sources, and nothing
+// in the deprecated configuration bag).
 func (s *Settings) IsEmpty() bool {
-	return s.Stack == ""
+	return s.Stack == "" && len(s.PluginSources) == 0
 }
@@ -0,0 +1,286 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"sync"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// policyInfo is the metadata shared by resource and stack validation policies.
+type policyInfo struct {
+	name             string
+	description      string
+	enforcementLevel EnforcementLevel
+	configSchema     *ConfigSchema
+}
+
+type analyzer struct {
+	pack    PolicyPack
+	version *semver.Version
+
+	policies []policyInfo
+
+	m      sync.RWMutex
+	config map[string]plugin.AnalyzerPolicyConfig
+}
+
+// NewAnalyzer returns a plugin.Analyzer that evaluates the policies in the given Policy Pack. Most Policy Packs should
+// call Run instead; NewAnalyzer is useful for testing policies in-process.
+func NewAnalyzer(pack PolicyPack) (plugin.Analyzer, error) {
+	if pack.Name == "" {
+		return nil, errors.New("the policy pack must have a name")
+	}
+	if pack.EnforcementLevel == "" {
+		pack.EnforcementLevel = Advisory
+	}
+	if !pack.EnforcementLevel.IsValid() {
+		return nil, errors.Errorf("invalid enforcement level %q for policy pack %s", pack.EnforcementLevel, pack.Name)
+	}
+
+	var version *semver.Version
+	if pack.Version != "" {
+		v, err := semver.ParseTolerant(pack.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid version for policy pack %s", pack.Name)
+		}
+		version = &v
+	}
+
+	var policies []policyInfo
+	for _, p := range pack.ResourcePolicies {
+		if p.Validate == nil && p.Remediate == nil {
+			return nil, errors.Errorf("resource policy %s must have a Validate or Remediate function", p.Name)
+		}
+		policies = append(policies, policyInfo{p.Name, p.Description, p.EnforcementLevel, p.ConfigSchema})
+	}
+	for _, p := range pack.StackPolicies {
+		if p.Validate == nil {
+			return nil, errors.Errorf("stack policy %s must have a Validate function", p.Name)
+		}
+		policies = append(policies, policyInfo{p.Name, p.Description, p.EnforcementLevel, p.ConfigSchema})
+	}
+
+	names := make(map[string]bool)
+	for i, p := range policies {
+		if p.name == "" {
+			return nil, errors.Errorf("policy #%d in policy pack %s must have a name", i, pack.Name)
+		}
+		if names[p.name] {
+			return nil, errors.Errorf("duplicate policy name %s in policy pack %s", p.name, pack.Name)
+		}
+		names[p.name] = true
+
+		if p.enforcementLevel != "" && !p.enforcementLevel.IsValid() {
+			return nil, errors.Errorf("invalid enforcement level %q for policy %s", p.enforcementLevel, p.name)
+		}
+	}
+
+	return &analyzer{
+		pack:     pack,
+		version:  version,
+		policies: policies,
+		config:   make(map[string]plugin.AnalyzerPolicyConfig),
+	}, nil
+}
+
+func (a *analyzer) Close() error {
+	return nil
+}
+
+func (a *analyzer) Name() tokens.QName {
+	return tokens.QName(a.pack.Name)
+}
+
+// enforcementLevel returns the effective enforcement level of the named policy. Configured levels take precedence
+// over the policy's own level, which takes precedence over the pack's default level.
+func (a *analyzer) enforcementLevel(name string, level EnforcementLevel) EnforcementLevel {
+	a.m.RLock()
+	defer a.m.RUnlock()
+
+	if c, ok := a.config[name]; ok && c.EnforcementLevel != "" {
+		return c.EnforcementLevel
+	}
+	if level != "" {
+		return level
+	}
+	return a.pack.EnforcementLevel
+}
+
+// policyConfig returns the configuration for the named policy, starting from the defaults in its config schema.
+func (a *analyzer) policyConfig(name string, schema *ConfigSchema) map[string]interface{} {
+	a.m.RLock()
+	defer a.m.RUnlock()
+
+	config := defaultConfig(schema)
+	for k, v := range a.config[name].Properties {
+		config[k] = v
+	}
+	return config
+}
+
+func (a *analyzer) Analyze(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+	var diags []plugin.AnalyzeDiagnostic
+	for _, p := range a.pack.ResourcePolicies {
+		level := a.enforcementLevel(p.Name, p.EnforcementLevel)
+		if level == Disabled || p.Validate == nil {
+			continue
+		}
+
+		args := ResourceValidationArgs{Resource: r, Config: a.policyConfig(p.Name, p.ConfigSchema)}
+		p.Validate(args, func(message string) {
+			diags = append(diags, a.diagnostic(p.Name, p.Description, message, level, r.URN))
+		})
+	}
+	return diags, nil
+}
+
+func (a *analyzer) AnalyzeStack(resources []plugin.AnalyzerStackResource) ([]plugin.AnalyzeDiagnostic, error) {
+	var diags []plugin.AnalyzeDiagnostic
+	for _, p := range a.pack.StackPolicies {
+		level := a.enforcementLevel(p.Name, p.EnforcementLevel)
+		if level == Disabled {
+			continue
+		}
+
+		args := StackValidationArgs{Resources: resources, Config: a.policyConfig(p.Name, p.ConfigSchema)}
+		p.Validate(args, func(message string, urn resource.URN) {
+			diags = append(diags, a.diagnostic(p.Name, p.Description, message, level, urn))
+		})
+	}
+	return diags, nil
+}
+
+func (a *analyzer) Remediate(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+	var remediations []plugin.Remediation
+	for _, p := range a.pack.ResourcePolicies {
+		level := a.enforcementLevel(p.Name, p.EnforcementLevel)
+		if level == Disabled || p.Remediate == nil {
+			continue
+		}
+
+		args := ResourceValidationArgs{Resource: r, Config: a.policyConfig(p.Name, p.ConfigSchema)}
+		remediation := plugin.Remediation{
+			PolicyName:        p.Name,
+			PolicyPackName:    a.pack.Name,
+			PolicyPackVersion: a.pack.Version,
+			Description:       p.Description,
+		}
+
+		props, err := p.Remediate(args)
+		switch {
+		case err != nil:
+			// A failed remediation is reported as a warning rather than failing the deployment outright.
+			remediation.Diagnostic = err.Error()
+		case props == nil:
+			continue
+		default:
+			// Subsequent remediations see the results of earlier ones.
+			remediation.Properties, r.Properties = props, props
+		}
+		remediations = append(remediations, remediation)
+	}
+	return remediations, nil
+}
+
+func (a *analyzer) diagnostic(policyName, description, message string, level EnforcementLevel,
+	urn resource.URN) plugin.AnalyzeDiagnostic {
+
+	return plugin.AnalyzeDiagnostic{
+		PolicyName:        policyName,
+		PolicyPackName:    a.pack.Name,
+		PolicyPackVersion: a.pack.Version,
+		Description:       description,
+		Message:           message,
+		EnforcementLevel:  level,
+		URN:               urn,
+	}
+}
+
+func (a *analyzer) GetAnalyzerInfo() (plugin.AnalyzerInfo, error) {
+	policies := make([]plugin.AnalyzerPolicyInfo, len(a.policies))
+	initialConfig := make(map[string]plugin.AnalyzerPolicyConfig)
+	for i, p := range a.policies {
+		level := p.enforcementLevel
+		if level == "" {
+			level = a.pack.EnforcementLevel
+		}
+
+		policies[i] = plugin.AnalyzerPolicyInfo{
+			Name:             p.name,
+			DisplayName:      p.name,
+			Description:      p.description,
+			EnforcementLevel: level,
+			Message:          p.description,
+			ConfigSchema:     p.configSchema,
+		}
+		initialConfig[p.name] = plugin.AnalyzerPolicyConfig{
+			EnforcementLevel: level,
+			Properties:       defaultConfig(p.configSchema),
+		}
+	}
+
+	return plugin.AnalyzerInfo{
+		Name:           a.pack.Name,
+		DisplayName:    a.pack.Name,
+		Version:        a.pack.Version,
+		SupportsConfig: true,
+		Policies:       policies,
+		InitialConfig:  initialConfig,
+	}, nil
+}
+
+func (a *analyzer) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{
+		Name:    a.pack.Name,
+		Kind:    workspace.AnalyzerPlugin,
+		Version: a.version,
+	}, nil
+}
+
+func (a *analyzer) Configure(policyConfig map[string]plugin.AnalyzerPolicyConfig) error {
+	for name, c := range policyConfig {
+		if c.EnforcementLevel != "" && !c.EnforcementLevel.IsValid() {
+			return errors.Errorf("invalid enforcement level %q for policy %s", c.EnforcementLevel, name)
+		}
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.config = policyConfig
+	return nil
+}
+
+// defaultConfig returns the default values for the properties described by the given config schema.
+func defaultConfig(schema *ConfigSchema) map[string]interface{} {
+	config := make(map[string]interface{})
+	if schema == nil {
+		return config
+	}
+	for k, v := range schema.Properties {
+		if def, ok := v["default"]; ok {
+			config[k] = def
+		}
+	}
+	return config
+}
@@ -0,0 +1,275 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+)
+
+func TestNewAnalyzerValidation(t *testing.T) {
+	validate := func(args ResourceValidationArgs, reportViolation ReportViolation) {}
+
+	_, err := NewAnalyzer(PolicyPack{})
+	assert.EqualError(t, err, "the policy pack must have a name")
+
+	_, err = NewAnalyzer(PolicyPack{Name: "pack", EnforcementLevel: "bogus"})
+	assert.EqualError(t, err, `invalid enforcement level "bogus" for policy pack pack`)
+
+	_, err = NewAnalyzer(PolicyPack{
+		Name:             "pack",
+		ResourcePolicies: []ResourceValidationPolicy{{Name: "a"}},
+	})
+	assert.EqualError(t, err, "resource policy a must have a Validate or Remediate function")
+
+	_, err = NewAnalyzer(PolicyPack{
+		Name: "pack",
+		ResourcePolicies: []ResourceValidationPolicy{
+			{Name: "a", Validate: validate},
+			{Name: "a", Validate: validate},
+		},
+	})
+	assert.EqualError(t, err, "duplicate policy name a in policy pack pack")
+
+	_, err = NewAnalyzer(PolicyPack{
+		Name:             "pack",
+		ResourcePolicies: []ResourceValidationPolicy{{Name: "a", EnforcementLevel: "bogus", Validate: validate}},
+	})
+	assert.EqualError(t, err, `invalid enforcement level "bogus" for policy a`)
+}
+
+func TestAnalyze(t *testing.T) {
+	var seenConfig map[string]interface{}
+	a, err := NewAnalyzer(PolicyPack{
+		Name:             "pack",
+		Version:          "1.0.0",
+		EnforcementLevel: Mandatory,
+		ResourcePolicies: []ResourceValidationPolicy{
+			{
+				Name:        "inherits",
+				Description: "Uses the pack's enforcement level.",
+				ConfigSchema: &ConfigSchema{
+					Properties: map[string]JSONSchema{
+						"max": {"type": "number", "default": 1.0},
+						"min": {"type": "number"},
+					},
+				},
+				Validate: func(args ResourceValidationArgs, reportViolation ReportViolation) {
+					seenConfig = args.Config
+					reportViolation("inherits: " + string(args.Resource.Name))
+				},
+			},
+			{
+				Name:             "advisory",
+				EnforcementLevel: Advisory,
+				Validate: func(args ResourceValidationArgs, reportViolation ReportViolation) {
+					reportViolation("advisory")
+				},
+			},
+			{
+				Name:             "disabled",
+				EnforcementLevel: Disabled,
+				Validate: func(args ResourceValidationArgs, reportViolation ReportViolation) {
+					reportViolation("disabled")
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	urn := resource.NewURN("stack", "proj", "", "pkg:m:typ", "res")
+	diags, err := a.Analyze(plugin.AnalyzerResource{URN: urn, Type: "pkg:m:typ", Name: "res"})
+	assert.NoError(t, err)
+	assert.Equal(t, []plugin.AnalyzeDiagnostic{
+		{
+			PolicyName:        "inherits",
+			PolicyPackName:    "pack",
+			PolicyPackVersion: "1.0.0",
+			Description:       "Uses the pack's enforcement level.",
+			Message:           "inherits: res",
+			EnforcementLevel:  Mandatory,
+			URN:               urn,
+		},
+		{
+			PolicyName:        "advisory",
+			PolicyPackName:    "pack",
+			PolicyPackVersion: "1.0.0",
+			Message:           "advisory",
+			EnforcementLevel:  Advisory,
+			URN:               urn,
+		},
+	}, diags)
+	assert.Equal(t, map[string]interface{}{"max": 1.0}, seenConfig)
+
+	// Configured enforcement levels and properties take precedence.
+	err = a.Configure(map[string]plugin.AnalyzerPolicyConfig{
+		"inherits": {EnforcementLevel: Disabled},
+		"advisory": {EnforcementLevel: Mandatory, Properties: map[string]interface{}{"min": 0.0}},
+		"disabled": {EnforcementLevel: Advisory},
+	})
+	assert.NoError(t, err)
+
+	diags, err = a.Analyze(plugin.AnalyzerResource{URN: urn, Type: "pkg:m:typ", Name: "res"})
+	assert.NoError(t, err)
+	if assert.Len(t, diags, 2) {
+		assert.Equal(t, "advisory", diags[0].PolicyName)
+		assert.Equal(t, Mandatory, diags[0].EnforcementLevel)
+		assert.Equal(t, "disabled", diags[1].PolicyName)
+		assert.Equal(t, Advisory, diags[1].EnforcementLevel)
+	}
+
+	err = a.Configure(map[string]plugin.AnalyzerPolicyConfig{"inherits": {EnforcementLevel: "bogus"}})
+	assert.EqualError(t, err, `invalid enforcement level "bogus" for policy inherits`)
+}
+
+func TestAnalyzeStack(t *testing.T) {
+	a, err := NewAnalyzer(PolicyPack{
+		Name: "pack",
+		StackPolicies: []StackValidationPolicy{{
+			Name: "count",
+			Validate: func(args StackValidationArgs, reportViolation ReportStackViolation) {
+				for _, r := range args.Resources {
+					reportViolation("found", r.URN)
+				}
+			},
+		}},
+	})
+	assert.NoError(t, err)
+
+	urn := resource.NewURN("stack", "proj", "", "pkg:m:typ", "res")
+	diags, err := a.AnalyzeStack([]plugin.AnalyzerStackResource{
+		{AnalyzerResource: plugin.AnalyzerResource{URN: urn}},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, urn, diags[0].URN)
+		assert.Equal(t, Advisory, diags[0].EnforcementLevel)
+	}
+}
+
+func TestRemediate(t *testing.T) {
+	a, err := NewAnalyzer(PolicyPack{
+		Name: "pack",
+		ResourcePolicies: []ResourceValidationPolicy{
+			{
+				Name: "add-tags",
+				Remediate: func(args ResourceValidationArgs) (resource.PropertyMap, error) {
+					props := args.Resource.Properties.Copy()
+					props["tags"] = resource.NewStringProperty("tagged")
+					return props, nil
+				},
+			},
+			{
+				Name: "unchanged",
+				Remediate: func(args ResourceValidationArgs) (resource.PropertyMap, error) {
+					return nil, nil
+				},
+			},
+			{
+				Name: "sees-tags",
+				Remediate: func(args ResourceValidationArgs) (resource.PropertyMap, error) {
+					if _, ok := args.Resource.Properties["tags"]; !ok {
+						return nil, errors.New("missing tags")
+					}
+					props := args.Resource.Properties.Copy()
+					props["checked"] = resource.NewBoolProperty(true)
+					return props, nil
+				},
+			},
+			{
+				Name: "fails",
+				Remediate: func(args ResourceValidationArgs) (resource.PropertyMap, error) {
+					return nil, errors.New("oops")
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	remediations, err := a.Remediate(plugin.AnalyzerResource{
+		Properties: resource.PropertyMap{"name": resource.NewStringProperty("res")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []plugin.Remediation{
+		{
+			PolicyName:     "add-tags",
+			PolicyPackName: "pack",
+			Properties: resource.PropertyMap{
+				"name": resource.NewStringProperty("res"),
+				"tags": resource.NewStringProperty("tagged"),
+			},
+		},
+		{
+			PolicyName:     "sees-tags",
+			PolicyPackName: "pack",
+			Properties: resource.PropertyMap{
+				"name":    resource.NewStringProperty("res"),
+				"tags":    resource.NewStringProperty("tagged"),
+				"checked": resource.NewBoolProperty(true),
+			},
+		},
+		{
+			PolicyName:     "fails",
+			PolicyPackName: "pack",
+			Diagnostic:     "oops",
+		},
+	}, remediations)
+}
+
+func TestGetAnalyzerInfo(t *testing.T) {
+	a, err := NewAnalyzer(PolicyPack{
+		Name:    "pack",
+		Version: "1.2.3",
+		ResourcePolicies: []ResourceValidationPolicy{{
+			Name:        "resource",
+			Description: "A resource policy.",
+			ConfigSchema: &ConfigSchema{
+				Properties: map[string]JSONSchema{"max": {"type": "number", "default": 1.0}},
+			},
+			Validate: func(args ResourceValidationArgs, reportViolation ReportViolation) {},
+		}},
+		StackPolicies: []StackValidationPolicy{{
+			Name:             "stack",
+			EnforcementLevel: Mandatory,
+			Validate:         func(args StackValidationArgs, reportViolation ReportStackViolation) {},
+		}},
+	})
+	assert.NoError(t, err)
+
+	info, err := a.GetAnalyzerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "pack", info.Name)
+	assert.Equal(t, "1.2.3", info.Version)
+	assert.True(t, info.SupportsConfig)
+	if assert.Len(t, info.Policies, 2) {
+		assert.Equal(t, "resource", info.Policies[0].Name)
+		assert.Equal(t, Advisory, info.Policies[0].EnforcementLevel)
+		assert.Equal(t, "stack", info.Policies[1].Name)
+		assert.Equal(t, Mandatory, info.Policies[1].EnforcementLevel)
+	}
+	assert.Equal(t, map[string]plugin.AnalyzerPolicyConfig{
+		"resource": {EnforcementLevel: Advisory, Properties: map[string]interface{}{"max": 1.0}},
+		"stack":    {EnforcementLevel: Mandatory, Properties: map[string]interface{}{}},
+	}, info.InitialConfig)
+
+	pluginInfo, err := a.GetPluginInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", pluginInfo.Version.String())
+}
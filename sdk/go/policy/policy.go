@@ -0,0 +1,129 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy provides the types and entrypoint used to author Pulumi Policy Packs in Go.
+//
+// A Policy Pack is a Go program whose main function calls Run with a PolicyPack that lists its policies:
+//
+//	func main() {
+//		policy.Run(policy.PolicyPack{
+//			Name: "my-policies",
+//			ResourcePolicies: []policy.ResourceValidationPolicy{{
+//				Name:        "no-public-buckets",
+//				Description: "Buckets must not be publicly readable.",
+//				Validate: func(args policy.ResourceValidationArgs, reportViolation policy.ReportViolation) {
+//					if args.Resource.Type == "aws:s3/bucket:Bucket" &&
+//						args.Resource.Properties["acl"].DeepEquals(resource.NewStringProperty("public-read")) {
+//						reportViolation("Buckets must not be publicly readable.")
+//					}
+//				},
+//			}},
+//		})
+//	}
+package policy
+
+import (
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+)
+
+// EnforcementLevel indicates how a policy violation is handled.
+type EnforcementLevel = apitype.EnforcementLevel
+
+const (
+	// Advisory policy violations are displayed to the user but do not block a deployment.
+	Advisory EnforcementLevel = apitype.Advisory
+	// Mandatory policy violations block a deployment.
+	Mandatory EnforcementLevel = apitype.Mandatory
+	// Disabled policies are not run.
+	Disabled EnforcementLevel = apitype.Disabled
+)
+
+// ConfigSchema describes the configuration accepted by a policy. Each property is described by a JSON schema.
+type ConfigSchema = plugin.AnalyzerPolicyConfigSchema
+
+// JSONSchema is a JSON schema that describes a single configuration property.
+type JSONSchema = plugin.JSONSchema
+
+// ReportViolation reports a violation of a resource validation policy by the resource being validated.
+type ReportViolation func(message string)
+
+// ReportStackViolation reports a violation of a stack validation policy. If urn is empty, the violation is
+// associated with the stack as a whole.
+type ReportStackViolation func(message string, urn resource.URN)
+
+// ResourceValidationArgs are the arguments passed to a resource validation policy.
+type ResourceValidationArgs struct {
+	// Resource is the resource being validated. Its properties are the resource's inputs.
+	Resource plugin.AnalyzerResource
+	// Config is the policy's configuration, including defaults from its config schema.
+	Config map[string]interface{}
+}
+
+// ResourceValidationPolicy validates each resource individually before it is created or updated.
+type ResourceValidationPolicy struct {
+	// Name is a unique, URL-safe name for the policy.
+	Name string
+	// Description describes the purpose of the policy.
+	Description string
+	// EnforcementLevel is the policy's enforcement level. If empty, the Policy Pack's level is used.
+	EnforcementLevel EnforcementLevel
+	// ConfigSchema optionally describes the configuration accepted by the policy.
+	ConfigSchema *ConfigSchema
+
+	// Validate validates a resource, calling reportViolation for each violation it finds.
+	Validate func(args ResourceValidationArgs, reportViolation ReportViolation)
+	// Remediate optionally transforms a resource's inputs before they are validated. It returns the new inputs, or
+	// nil if the resource does not need to be changed.
+	Remediate func(args ResourceValidationArgs) (resource.PropertyMap, error)
+}
+
+// StackValidationArgs are the arguments passed to a stack validation policy.
+type StackValidationArgs struct {
+	// Resources are all of the resources in the stack. Their properties are the resources' outputs.
+	Resources []plugin.AnalyzerStackResource
+	// Config is the policy's configuration, including defaults from its config schema.
+	Config map[string]interface{}
+}
+
+// StackValidationPolicy validates all of the resources in a stack after a successful preview or update.
+type StackValidationPolicy struct {
+	// Name is a unique, URL-safe name for the policy.
+	Name string
+	// Description describes the purpose of the policy.
+	Description string
+	// EnforcementLevel is the policy's enforcement level. If empty, the Policy Pack's level is used.
+	EnforcementLevel EnforcementLevel
+	// ConfigSchema optionally describes the configuration accepted by the policy.
+	ConfigSchema *ConfigSchema
+
+	// Validate validates the stack's resources, calling reportViolation for each violation it finds.
+	Validate func(args StackValidationArgs, reportViolation ReportStackViolation)
+}
+
+// PolicyPack is a named collection of policies.
+type PolicyPack struct {
+	// Name is the name of the Policy Pack.
+	Name string
+	// Version is the version of the Policy Pack. A version in PulumiPolicy.yaml takes precedence over this value.
+	Version string
+	// EnforcementLevel is the default enforcement level for the pack's policies. If empty, policies are advisory.
+	EnforcementLevel EnforcementLevel
+
+	// ResourcePolicies are the pack's resource validation policies.
+	ResourcePolicies []ResourceValidationPolicy
+	// StackPolicies are the pack's stack validation policies.
+	StackPolicies []StackValidationPolicy
+}
@@ -0,0 +1,67 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// Run serves the given Policy Pack to the Pulumi engine. It is intended to be called from the main function of a
+// Policy Pack program, which is launched by the Pulumi CLI when the pack is passed to `--policy-pack`. Run does not
+// return; if the pack fails to start, an error is printed and the program exits with a non-zero exit code.
+func Run(pack PolicyPack) {
+	if err := RunErr(pack); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// RunErr serves the given Policy Pack to the Pulumi engine and returns an error if the pack fails to start or stops
+// serving unexpectedly.
+func RunErr(pack PolicyPack) error {
+	analyzer, err := NewAnalyzer(pack)
+	if err != nil {
+		return err
+	}
+
+	// Fire up a gRPC server, letting the kernel choose a free port.
+	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterAnalyzerServer(srv, plugin.NewAnalyzerServer(analyzer))
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not start policy pack RPC server")
+	}
+
+	// Print out the port so that the engine knows how to reach us.
+	fmt.Printf("%d\n", port)
+
+	// And finally wait for the server to stop serving.
+	if err := <-done; err != nil {
+		return errors.Wrap(err, "policy pack RPC stopped serving")
+	}
+	return nil
+}
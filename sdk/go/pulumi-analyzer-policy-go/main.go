@@ -0,0 +1,136 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// pulumi-analyzer-policy-go boots Policy Packs written in Go. The CLI launches it in the Policy Pack's directory with
+// the engine's address, the pack's directory, and any runtime options from PulumiPolicy.yaml as `-key=value`
+// arguments. By default the pack is compiled with `go build`; setting the `binary` runtime option runs a prebuilt
+// executable instead. The pack itself prints the port of its analyzer server, which is passed through to the CLI.
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/executable"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// parseArgs splits the plugin's arguments into positional arguments and `-key=value` runtime options. Runtime options
+// follow the positional arguments, so they cannot be parsed with the flag package.
+func parseArgs(args []string) ([]string, map[string]string) {
+	var positional []string
+	options := make(map[string]string)
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			kv := strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)
+			if len(kv) == 2 {
+				options[kv[0]] = kv[1]
+			} else {
+				options[kv[0]] = "true"
+			}
+			continue
+		}
+		positional = append(positional, arg)
+	}
+	return positional, options
+}
+
+// buildPolicyPack compiles the Policy Pack in the current directory into a temporary directory and returns the path
+// of the resulting executable along with a function that removes it.
+func buildPolicyPack() (string, func(), error) {
+	gobin, err := executable.FindExecutable("go")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to find 'go' executable")
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to get current working directory")
+	}
+	goFileSearchPattern := filepath.Join(cwd, "*.go")
+	if matches, err := filepath.Glob(goFileSearchPattern); err != nil || len(matches) == 0 {
+		return "", nil, errors.Errorf("Failed to find go files for 'go build' matching %s", goFileSearchPattern)
+	}
+
+	dir, err := ioutil.TempDir("", "pulumi-policy-go")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to create temporary directory")
+	}
+	cleanup := func() { contract.IgnoreError(os.RemoveAll(dir)) }
+
+	out := filepath.Join(dir, "policy-pack")
+	logging.V(5).Infof("Building Go policy pack in %s to %s", cwd, out)
+
+	// Build output must not be written to stdout, which is reserved for the port of the pack's analyzer server.
+	build := exec.Command(gobin, "build", "-o", out, cwd)
+	build.Stdout, build.Stderr = os.Stderr, os.Stderr
+	if err := build.Run(); err != nil {
+		cleanup()
+		return "", nil, errors.Wrap(err, "unable to build Go policy pack")
+	}
+	return out, cleanup, nil
+}
+
+// run launches the Policy Pack with the given positional arguments and runtime options.
+func run(args []string, options map[string]string) error {
+	var program string
+	if binary := options["binary"]; binary != "" {
+		p, err := executable.FindExecutable(binary)
+		if err != nil {
+			return errors.Wrap(err, "expected to find prebuilt executable")
+		}
+		program = p
+	} else {
+		p, cleanup, err := buildPolicyPack()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		program = p
+	}
+
+	cmd := exec.Command(program, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exiterr, ok := err.(*exec.ExitError); ok {
+			if status, stok := exiterr.Sys().(syscall.WaitStatus); stok {
+				return errors.Errorf("policy pack exited with non-zero exit code: %d", status.ExitStatus())
+			}
+			return errors.Wrapf(exiterr, "policy pack exited unexpectedly")
+		}
+		return errors.Wrap(err, "problem executing policy pack")
+	}
+	return nil
+}
+
+func main() {
+	logging.InitLogging(false, 0, false)
+
+	args, options := parseArgs(os.Args[1:])
+	if len(args) == 0 {
+		cmdutil.Exit(errors.New("missing required engine RPC address argument"))
+	}
+
+	if err := run(args, options); err != nil {
+		cmdutil.Exit(err)
+	}
+}
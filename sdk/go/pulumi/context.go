@@ -93,7 +93,12 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 	}
 
 	if info.Mocks != nil {
-		monitor = &mockMonitor{project: info.Project, stack: info.Stack, mocks: info.Mocks}
+		monitor = &mockMonitor{
+			project:  info.Project,
+			stack:    info.Stack,
+			mocks:    info.Mocks,
+			registry: info.mockRegistry,
+		}
 		engine = &mockEngine{}
 	}
 
@@ -144,6 +149,9 @@ func (ctx *Context) Close() error {
 	return nil
 }
 
+// Context returns the Go context that the program is running with.
+func (ctx *Context) Context() context.Context { return ctx.ctx }
+
 // Project returns the current project name.
 func (ctx *Context) Project() string { return ctx.info.Project }
 
@@ -268,6 +276,160 @@ func (ctx *Context) Invoke(tok string, args interface{}, result interface{}, opt
 	return nil
 }
 
+// Call will invoke a method, identified by its token tok, on the resource self. Unlike Invoke, this function call is
+// asynchronous: args may contain Outputs, and the result is returned as an Output of the same type as output that
+// depends on the resources the method reports as dependencies of its result.
+//
+// output is used only for its type; it is typically the zero value of the method's result Output type. If self is
+// nil, the method is treated as a function that is not bound to a resource.
+func (ctx *Context) Call(tok string, args Input, output Output, self Resource, opts ...InvokeOption) (Output, error) {
+	if tok == "" {
+		return nil, errors.New("call token must not be empty")
+	}
+	if output == nil {
+		return nil, errors.New("output must not be nil")
+	}
+
+	options := &invokeOptions{}
+	for _, o := range opts {
+		if o != nil {
+			o.applyInvokeOption(options)
+		}
+	}
+
+	// Methods are served by the provider of the resource they are bound to unless another provider is specified.
+	parent := options.Parent
+	if parent == nil {
+		parent = self
+	}
+
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
+	if err := ctx.beginRPC(); err != nil {
+		return nil, err
+	}
+
+	result := newOutput(reflect.TypeOf(output))
+	go func() {
+		// No matter the outcome, make sure all promises are resolved and that we've signaled completion of this RPC.
+		var err error
+		defer func() {
+			if err != nil {
+				result.getState().reject(err)
+			}
+			ctx.endRPC(err)
+		}()
+
+		var providerRef string
+		if provider := mergeProviders(tok, parent, options.Provider, nil)[getPackage(tok)]; provider != nil {
+			if providerRef, err = ctx.resolveProviderReference(provider); err != nil {
+				return
+			}
+		}
+
+		// Serialize the arguments, awaiting any Outputs and recording the dependencies of each.
+		argsMap, argDeps, _, err := marshalInputs(args)
+		if err != nil {
+			err = fmt.Errorf("marshaling arguments: %w", err)
+			return
+		}
+		if self != nil {
+			selfV, selfDeps, selfErr := marshalInput(self, resourceType, true)
+			if selfErr != nil {
+				err = fmt.Errorf("marshaling __self__: %w", selfErr)
+				return
+			}
+			argsMap["__self__"] = selfV
+			for _, dep := range selfDeps {
+				urn, _, _, urnErr := dep.URN().awaitURN(context.TODO())
+				if urnErr != nil {
+					err = urnErr
+					return
+				}
+				argDeps["__self__"] = append(argDeps["__self__"], urn)
+			}
+		}
+
+		keepUnknowns := ctx.DryRun()
+		rpcArgs, err := plugin.MarshalProperties(argsMap, plugin.MarshalOptions{
+			KeepUnknowns:  keepUnknowns,
+			KeepSecrets:   true,
+			KeepResources: ctx.keepResources,
+		})
+		if err != nil {
+			err = fmt.Errorf("marshaling arguments: %w", err)
+			return
+		}
+
+		rpcArgDeps := map[string]*pulumirpc.CallRequest_ArgumentDependencies{}
+		for k, deps := range argDeps {
+			urns := make([]string, len(deps))
+			for i, d := range deps {
+				urns[i] = string(d)
+			}
+			rpcArgDeps[k] = &pulumirpc.CallRequest_ArgumentDependencies{Urns: urns}
+		}
+
+		logging.V(9).Infof("Call(%s, #args=%d): RPC call being made", tok, len(argsMap))
+		resp, err := ctx.monitor.Call(ctx.ctx, &pulumirpc.CallRequest{
+			Tok:             tok,
+			Args:            rpcArgs,
+			ArgDependencies: rpcArgDeps,
+			Provider:        providerRef,
+			Version:         options.Version,
+		})
+		if err != nil {
+			logging.V(9).Infof("Call(%s, ...): error: %v", tok, err)
+			return
+		}
+
+		// If there were any failures from the provider, return them.
+		if len(resp.GetFailures()) > 0 {
+			logging.V(9).Infof("Call(%s, ...): success: w/ %d failures", tok, len(resp.GetFailures()))
+			for _, failure := range resp.GetFailures() {
+				err = multierror.Append(err,
+					fmt.Errorf("%s call failed: %s (%s)", tok, failure.Reason, failure.Property))
+			}
+			return
+		}
+
+		outProps, err := plugin.UnmarshalProperties(resp.GetReturn(), plugin.MarshalOptions{
+			KeepSecrets:   true,
+			KeepResources: true,
+			KeepUnknowns:  keepUnknowns,
+		})
+		if err != nil {
+			return
+		}
+
+		var deps []Resource
+		depset := map[string]bool{}
+		for _, returnDeps := range resp.GetReturnDependencies() {
+			for _, urn := range returnDeps.GetUrns() {
+				if !depset[urn] {
+					deps = append(deps, newDependencyResource(URN(urn)))
+					depset[urn] = true
+				}
+			}
+		}
+
+		ret := resource.NewObjectProperty(outProps)
+		known, secret := !ret.ContainsUnknowns(), false
+		value := reflect.New(result.ElementType()).Elem()
+		if known {
+			if secret, err = unmarshalOutput(ctx, ret, value); err != nil {
+				return
+			}
+		} else {
+			secret = ret.ContainsSecrets()
+		}
+
+		result.getState().resolveValue(value, known, secret, deps)
+		logging.V(9).Infof("Call(%s, ...): success: w/ %d outs", tok, len(outProps))
+	}()
+
+	return result, nil
+}
+
 // ReadResource reads an existing custom resource's state from the resource monitor. t is the fully qualified type
 // token and name is the "name" part to use in creating a stable and globally unique URN for the object. id is the ID
 // of the resource to read, and props contains any state necessary to perform the read (typically props will be nil).
@@ -2,8 +2,12 @@ package pulumi
 
 import (
 	"log"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
@@ -19,16 +23,269 @@ type MockResourceMonitor interface {
 		provider, id string) (string, resource.PropertyMap, error)
 }
 
+// MockMethodMonitor may be implemented by a MockResourceMonitor in order to mock the resource methods called with
+// Context.Call. Calls to methods fail if the mocks do not implement this interface.
+type MockMethodMonitor interface {
+	// MethodCall mocks a call to the method identified by token. self is the URN of the resource the method was
+	// called on, or empty if the method was called as a function; it is not included in args.
+	MethodCall(token string, args resource.PropertyMap, self, provider string) (resource.PropertyMap, error)
+}
+
+// MockResourceMonitorWithPriorState may be implemented by a MockResourceMonitor in order to observe the prior state of
+// the resources that a program updates. If the mocks implement this interface, NewResourceWithPriorState is called in
+// place of NewResource. As with NewResource, id is the ID of the resource to import, if any; prior is the prior state
+// recorded in the mock registry for the resource, or nil if the resource is being created.
+type MockResourceMonitorWithPriorState interface {
+	NewResourceWithPriorState(typeToken, name string, inputs resource.PropertyMap, provider, id string,
+		prior *MockResourceState) (string, resource.PropertyMap, error)
+}
+
 func WithMocks(project, stack string, mocks MockResourceMonitor) RunOption {
 	return func(info *RunInfo) {
 		info.Project, info.Stack, info.Mocks = project, stack, mocks
 	}
 }
 
+// WithMockRegistry records every resource registered by the program in the given registry and seeds registrations
+// with any prior state it contains. It must be used in conjunction with WithMocks.
+func WithMockRegistry(registry *MockRegistry) RunOption {
+	return func(info *RunInfo) {
+		info.mockRegistry = registry
+	}
+}
+
+// MockResourceState is the prior state of a resource, as it would have been recorded by a previous deployment.
+type MockResourceState struct {
+	// ID is the provider-assigned ID of the resource.
+	ID string
+	// Inputs are the resource's inputs as of the previous deployment.
+	Inputs resource.PropertyMap
+	// Outputs are the resource's outputs as of the previous deployment.
+	Outputs resource.PropertyMap
+}
+
+// MockRegistration records a single resource registration observed by the mock resource monitor.
+type MockRegistration struct {
+	// URN is the URN assigned to the resource.
+	URN string
+	// Type is the resource's type token.
+	Type string
+	// Name is the resource's logical name.
+	Name string
+	// Custom is true if the resource is a custom resource.
+	Custom bool
+	// Read is true if the resource was read rather than registered.
+	Read bool
+	// ID is the ID returned by the mocks, if any.
+	ID string
+	// Inputs are the inputs sent by the program. Any ignoreChanges have already been applied.
+	Inputs resource.PropertyMap
+	// Outputs are the outputs returned by the mocks, or the outputs registered for a component resource.
+	Outputs resource.PropertyMap
+	// Parent is the URN of the resource's parent, if any.
+	Parent string
+	// Provider is the provider reference for the resource, if any.
+	Provider string
+	// Dependencies is the list of URNs the resource depends on.
+	Dependencies []string
+	// PropertyDependencies maps each input property to the URNs it depends on.
+	PropertyDependencies map[string][]string
+	// Protect is true if the resource is protected.
+	Protect bool
+	// IgnoreChanges is the list of input properties whose changes are ignored.
+	IgnoreChanges []string
+	// Aliases is the list of aliases for the resource.
+	Aliases []string
+	// DeleteBeforeReplace is true if the resource must be deleted before it is replaced.
+	DeleteBeforeReplace bool
+	// AdditionalSecretOutputs is the list of outputs that should be treated as secrets.
+	AdditionalSecretOutputs []string
+	// ImportID is the ID of the resource to import, if any.
+	ImportID string
+	// Version is the version of the provider plugin requested for the resource, if any.
+	Version string
+	// PriorURN is the URN of the prior state matched by this registration, if any. This will differ from URN if the
+	// prior state was matched by one of the resource's aliases.
+	PriorURN string
+	// PriorState is the prior state matched by this registration, if any.
+	PriorState *MockResourceState
+}
+
+// MockRegistry records the resources registered by a program that runs against mocks. It may also be seeded with
+// prior state in order to simulate an update to an existing stack: if a registered resource's URN (or one of its
+// aliases) matches a prior state, any ignoreChanges are applied to the resource's inputs using the prior inputs, the
+// prior state is passed to mocks that implement MockResourceMonitorWithPriorState, and the prior ID is used if the mocks
+// do not return an ID.
+type MockRegistry struct {
+	m             sync.Mutex
+	prior         map[string]MockResourceState
+	registrations []*MockRegistration
+	byURN         map[string]*MockRegistration
+}
+
+// NewMockRegistry creates a new, empty mock registry.
+func NewMockRegistry() *MockRegistry {
+	return &MockRegistry{
+		prior: map[string]MockResourceState{},
+		byURN: map[string]*MockRegistration{},
+	}
+}
+
+// SetPriorState seeds the registry with the prior state of the resource with the given URN.
+func (r *MockRegistry) SetPriorState(urn string, state MockResourceState) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.prior[urn] = state
+}
+
+// Registrations returns all registrations recorded by the registry, in registration order.
+func (r *MockRegistry) Registrations() []MockRegistration {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	regs := make([]MockRegistration, len(r.registrations))
+	for i, reg := range r.registrations {
+		regs[i] = *reg
+	}
+	return regs
+}
+
+// URNs returns the sorted URNs of all recorded registrations.
+func (r *MockRegistry) URNs() []string {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	urns := make([]string, 0, len(r.byURN))
+	for urn := range r.byURN {
+		urns = append(urns, urn)
+	}
+	sort.Strings(urns)
+	return urns
+}
+
+// Lookup returns the registration for the resource with the given URN.
+func (r *MockRegistry) Lookup(urn string) (MockRegistration, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	reg, ok := r.byURN[urn]
+	if !ok {
+		return MockRegistration{}, false
+	}
+	return *reg, true
+}
+
+// LookupByName returns the registration for the resource with the given type and name. If there is more than one
+// such resource, the first to be registered is returned.
+func (r *MockRegistry) LookupByName(typ, name string) (MockRegistration, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for _, reg := range r.registrations {
+		if reg.Type == typ && reg.Name == name {
+			return *reg, true
+		}
+	}
+	return MockRegistration{}, false
+}
+
+// findPriorState returns the prior state for the given URN or for the first of its aliases that has a prior state.
+func (r *MockRegistry) findPriorState(urn string, aliases []string) (string, *MockResourceState) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for _, candidate := range append([]string{urn}, aliases...) {
+		if state, ok := r.prior[candidate]; ok {
+			return candidate, &state
+		}
+	}
+	return "", nil
+}
+
+func (r *MockRegistry) record(reg *MockRegistration) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.registrations = append(r.registrations, reg)
+	r.byURN[reg.URN] = reg
+}
+
+func (r *MockRegistry) recordOutputs(urn string, outputs resource.PropertyMap) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if reg, ok := r.byURN[urn]; ok {
+		reg.Outputs = outputs
+	}
+}
+
+// applyIgnoreChanges replaces the values of the ignored properties in inputs with their values in oldInputs, as the
+// engine would do during a deployment. An ignoreChanges entry that is not a valid property path is an error.
+func applyIgnoreChanges(inputs, oldInputs resource.PropertyMap,
+	ignoreChanges []string) (resource.PropertyMap, error) {
+
+	ignoredInputs := resource.NewObjectProperty(inputs.Copy())
+	var invalidPaths []string
+	for _, ignoreChange := range ignoreChanges {
+		path, err := resource.ParsePropertyPath(ignoreChange)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ignoreChanges property path %q", ignoreChange)
+		}
+
+		oldValue, hasOld := path.Get(resource.NewObjectProperty(oldInputs))
+		_, hasNew := path.Get(resource.NewObjectProperty(inputs))
+
+		ok := true
+		switch {
+		case hasOld:
+			ok = path.Set(ignoredInputs, oldValue)
+		case hasNew:
+			ok = path.Delete(ignoredInputs)
+		}
+		if !ok {
+			invalidPaths = append(invalidPaths, ignoreChange)
+		}
+	}
+	if len(invalidPaths) != 0 {
+		return nil, errors.Errorf("cannot ignore changes to the following properties because one or more elements of "+
+			"the path are missing: %q", strings.Join(invalidPaths, ", "))
+	}
+	return ignoredInputs.ObjectValue(), nil
+}
+
+// AwaitedOutput is the resolved state of an output.
+type AwaitedOutput struct {
+	// Value is the output's value. This is nil if the output is unknown.
+	Value interface{}
+	// Known is true if the output's value is known.
+	Known bool
+	// Secret is true if the output is secret.
+	Secret bool
+	// Dependencies is the list of resources the output depends on.
+	Dependencies []Resource
+}
+
+// AwaitOutputs blocks until each of the given outputs has resolved and returns their resolved states in the same
+// order. This is intended for use in unit tests that run against mocks, where it provides a deterministic
+// alternative to asserting on output values inside of an Apply.
+func AwaitOutputs(ctx context.Context, outputs ...Output) ([]AwaitedOutput, error) {
+	results := make([]AwaitedOutput, len(outputs))
+	for i, o := range outputs {
+		value, known, secret, deps, err := o.getState().await(ctx)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = AwaitedOutput{Value: value, Known: known, Secret: secret, Dependencies: deps}
+	}
+	return results, nil
+}
+
 type mockMonitor struct {
-	project string
-	stack   string
-	mocks   MockResourceMonitor
+	project  string
+	stack    string
+	mocks    MockResourceMonitor
+	registry *MockRegistry
 }
 
 func (m *mockMonitor) newURN(parent, typ, name string) string {
@@ -78,6 +335,49 @@ func (m *mockMonitor) Invoke(ctx context.Context, in *pulumirpc.InvokeRequest,
 	}, nil
 }
 
+func (m *mockMonitor) Call(ctx context.Context, in *pulumirpc.CallRequest,
+	opts ...grpc.CallOption) (*pulumirpc.CallResponse, error) {
+
+	methods, ok := m.mocks.(MockMethodMonitor)
+	if !ok {
+		return nil, errors.Errorf("cannot call method %s: mocks do not implement MockMethodMonitor", in.GetTok())
+	}
+
+	args, err := plugin.UnmarshalProperties(in.GetArgs(), plugin.MarshalOptions{
+		KeepSecrets:   true,
+		KeepResources: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var self string
+	if selfV, ok := args["__self__"]; ok {
+		if !selfV.IsResourceReference() {
+			return nil, errors.Errorf("expected __self__ to be a resource reference, not a %s", selfV.TypeString())
+		}
+		self = string(selfV.ResourceReferenceValue().URN)
+		delete(args, "__self__")
+	}
+
+	resultV, err := methods.MethodCall(in.GetTok(), args, self, in.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := plugin.MarshalProperties(resultV, plugin.MarshalOptions{
+		KeepSecrets:   true,
+		KeepResources: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pulumirpc.CallResponse{
+		Return: result,
+	}, nil
+}
+
 func (m *mockMonitor) StreamInvoke(ctx context.Context, in *pulumirpc.InvokeRequest,
 	opts ...grpc.CallOption) (pulumirpc.ResourceMonitor_StreamInvokeClient, error) {
 
@@ -108,8 +408,27 @@ func (m *mockMonitor) ReadResource(ctx context.Context, in *pulumirpc.ReadResour
 		return nil, err
 	}
 
+	urn := m.newURN(in.GetParent(), in.GetType(), in.GetName())
+	if m.registry != nil {
+		m.registry.record(&MockRegistration{
+			URN:                     urn,
+			Type:                    in.GetType(),
+			Name:                    in.GetName(),
+			Custom:                  true,
+			Read:                    true,
+			ID:                      in.GetId(),
+			Inputs:                  stateIn,
+			Outputs:                 state,
+			Parent:                  in.GetParent(),
+			Provider:                in.GetProvider(),
+			Dependencies:            in.GetDependencies(),
+			AdditionalSecretOutputs: in.GetAdditionalSecretOutputs(),
+			Version:                 in.GetVersion(),
+		})
+	}
+
 	return &pulumirpc.ReadResourceResponse{
-		Urn:        m.newURN(in.GetParent(), in.GetType(), in.GetName()),
+		Urn:        urn,
 		Properties: stateOut,
 	}, nil
 }
@@ -131,10 +450,35 @@ func (m *mockMonitor) RegisterResource(ctx context.Context, in *pulumirpc.Regist
 		return nil, err
 	}
 
-	id, state, err := m.mocks.NewResource(in.GetType(), in.GetName(), inputs, in.GetProvider(), in.GetImportId())
+	urn := m.newURN(in.GetParent(), in.GetType(), in.GetName())
+
+	// If we have prior state for this resource, simulate an update: apply any ignoreChanges against the prior inputs
+	// and pass the prior state to the mocks if they accept it.
+	var priorURN string
+	var prior *MockResourceState
+	if m.registry != nil {
+		priorURN, prior = m.registry.findPriorState(urn, in.GetAliases())
+		if prior != nil {
+			if inputs, err = applyIgnoreChanges(inputs, prior.Inputs, in.GetIgnoreChanges()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var id string
+	var state resource.PropertyMap
+	if mocks, ok := m.mocks.(MockResourceMonitorWithPriorState); ok {
+		id, state, err = mocks.NewResourceWithPriorState(in.GetType(), in.GetName(), inputs, in.GetProvider(),
+			in.GetImportId(), prior)
+	} else {
+		id, state, err = m.mocks.NewResource(in.GetType(), in.GetName(), inputs, in.GetProvider(), in.GetImportId())
+	}
 	if err != nil {
 		return nil, err
 	}
+	if id == "" && prior != nil {
+		id = prior.ID
+	}
 
 	stateOut, err := plugin.MarshalProperties(state, plugin.MarshalOptions{
 		KeepSecrets:   true,
@@ -144,8 +488,38 @@ func (m *mockMonitor) RegisterResource(ctx context.Context, in *pulumirpc.Regist
 		return nil, err
 	}
 
+	if m.registry != nil {
+		propertyDependencies := map[string][]string{}
+		for k, v := range in.GetPropertyDependencies() {
+			propertyDependencies[k] = v.GetUrns()
+		}
+
+		m.registry.record(&MockRegistration{
+			URN:                     urn,
+			Type:                    in.GetType(),
+			Name:                    in.GetName(),
+			Custom:                  in.GetCustom(),
+			ID:                      id,
+			Inputs:                  inputs,
+			Outputs:                 state,
+			Parent:                  in.GetParent(),
+			Provider:                in.GetProvider(),
+			Dependencies:            in.GetDependencies(),
+			PropertyDependencies:    propertyDependencies,
+			Protect:                 in.GetProtect(),
+			IgnoreChanges:           in.GetIgnoreChanges(),
+			Aliases:                 in.GetAliases(),
+			DeleteBeforeReplace:     in.GetDeleteBeforeReplace(),
+			AdditionalSecretOutputs: in.GetAdditionalSecretOutputs(),
+			ImportID:                in.GetImportId(),
+			Version:                 in.GetVersion(),
+			PriorURN:                priorURN,
+			PriorState:              prior,
+		})
+	}
+
 	return &pulumirpc.RegisterResourceResponse{
-		Urn:    m.newURN(in.GetParent(), in.GetType(), in.GetName()),
+		Urn:    urn,
 		Id:     id,
 		Object: stateOut,
 	}, nil
@@ -154,6 +528,17 @@ func (m *mockMonitor) RegisterResource(ctx context.Context, in *pulumirpc.Regist
 func (m *mockMonitor) RegisterResourceOutputs(ctx context.Context, in *pulumirpc.RegisterResourceOutputsRequest,
 	opts ...grpc.CallOption) (*empty.Empty, error) {
 
+	if m.registry != nil {
+		outputs, err := plugin.UnmarshalProperties(in.GetOutputs(), plugin.MarshalOptions{
+			KeepSecrets:   true,
+			KeepResources: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m.registry.recordOutputs(in.GetUrn(), outputs)
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -0,0 +1,203 @@
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockRegistry(t *testing.T) {
+	mocks := &testMonitor{
+		NewResourceF: func(typeToken, name string, inputs resource.PropertyMap,
+			provider, id string) (string, resource.PropertyMap, error) {
+
+			if id == "" {
+				id = name + "-id"
+			}
+			return id, inputs, nil
+		},
+	}
+	registry := NewMockRegistry()
+
+	err := RunErr(func(ctx *Context) error {
+		var prov ProviderResourceState
+		err := ctx.RegisterResource("pulumi:providers:test", "prov", nil, &prov)
+		assert.NoError(t, err)
+
+		var comp ResourceState
+		err = ctx.RegisterComponentResource("test:index:Component", "comp", &comp)
+		assert.NoError(t, err)
+
+		var resA testResource2
+		err = ctx.RegisterResource("test:resource:type", "resA", &testResource2Inputs{
+			Foo: String("oof"),
+		}, &resA, Parent(&comp), Provider(&prov), Protect(true))
+		assert.NoError(t, err)
+
+		var resB testResource2
+		err = ctx.RegisterResource("test:resource:type", "resB", &testResource2Inputs{
+			Foo: resA.Foo,
+		}, &resB, DependsOn([]Resource{&comp}), IgnoreChanges([]string{"bar"}))
+		assert.NoError(t, err)
+
+		return ctx.RegisterResourceOutputs(&comp, Map{"foo": resA.Foo})
+	}, WithMocks("project", "stack", mocks), WithMockRegistry(registry))
+	assert.NoError(t, err)
+
+	prov, ok := registry.LookupByName("pulumi:providers:test", "prov")
+	assert.True(t, ok)
+	assert.True(t, prov.Custom)
+	assert.Equal(t, "prov-id", prov.ID)
+
+	comp, ok := registry.LookupByName("test:index:Component", "comp")
+	assert.True(t, ok)
+	assert.False(t, comp.Custom)
+	assert.True(t, comp.Outputs.DeepEquals(resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "oof",
+	})))
+
+	resA, ok := registry.LookupByName("test:resource:type", "resA")
+	assert.True(t, ok)
+	assert.Equal(t, comp.URN, resA.Parent)
+	assert.Equal(t, prov.URN+"::prov-id", resA.Provider)
+	assert.True(t, resA.Protect)
+	assert.Equal(t, "resA-id", resA.ID)
+
+	resB, ok := registry.Lookup(string(resource.NewURN("stack", "project", "", "test:resource:type", "resB")))
+	assert.True(t, ok)
+	assert.Contains(t, resB.Dependencies, comp.URN)
+	assert.Contains(t, resB.Dependencies, resA.URN)
+	assert.Equal(t, []string{resA.URN}, resB.PropertyDependencies["foo"])
+	assert.Equal(t, []string{"bar"}, resB.IgnoreChanges)
+	assert.Nil(t, resB.PriorState)
+
+	assert.Len(t, registry.Registrations(), 4)
+	assert.Len(t, registry.URNs(), 4)
+}
+
+// priorStateMonitor is a test monitor that observes the prior state of the resources it creates.
+type priorStateMonitor struct {
+	testMonitor
+
+	NewResourceWithPriorStateF func(typeToken, name string, inputs resource.PropertyMap, provider, id string,
+		prior *MockResourceState) (string, resource.PropertyMap, error)
+}
+
+func (m *priorStateMonitor) NewResourceWithPriorState(typeToken, name string, inputs resource.PropertyMap,
+	provider, id string, prior *MockResourceState) (string, resource.PropertyMap, error) {
+
+	return m.NewResourceWithPriorStateF(typeToken, name, inputs, provider, id, prior)
+}
+
+func TestMockRegistryPriorState(t *testing.T) {
+	var receivedID string
+	var receivedPrior *MockResourceState
+	mocks := &priorStateMonitor{
+		NewResourceWithPriorStateF: func(typeToken, name string, inputs resource.PropertyMap,
+			provider, id string, prior *MockResourceState) (string, resource.PropertyMap, error) {
+
+			receivedID, receivedPrior = id, prior
+			return "", inputs, nil
+		},
+	}
+
+	oldURN := string(resource.NewURN("stack", "project", "", "test:resource:type", "oldName"))
+	registry := NewMockRegistry()
+	registry.SetPriorState(oldURN, MockResourceState{
+		ID: "oldID",
+		Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"foo": "old",
+			"bar": "old",
+		}),
+	})
+
+	err := RunErr(func(ctx *Context) error {
+		var res testResource2
+		err := ctx.RegisterResource("test:resource:type", "newName", &testResource2Inputs{
+			Foo: String("new"),
+			Bar: String("new"),
+		}, &res, Aliases([]Alias{{Name: String("oldName")}}), IgnoreChanges([]string{"bar"}))
+		assert.NoError(t, err)
+
+		results, err := AwaitOutputs(context.Background(), res.ID(), res.Foo)
+		assert.NoError(t, err)
+		assert.Equal(t, ID("oldID"), results[0].Value)
+		assert.Equal(t, "new", results[1].Value)
+		assert.True(t, results[1].Known)
+		assert.False(t, results[1].Secret)
+		return nil
+	}, WithMocks("project", "stack", mocks), WithMockRegistry(registry))
+	assert.NoError(t, err)
+
+	res, ok := registry.LookupByName("test:resource:type", "newName")
+	assert.True(t, ok)
+	// The resource is updated rather than imported, so the mocks receive the prior state but no import ID.
+	assert.Equal(t, "", receivedID)
+	if assert.NotNil(t, receivedPrior) {
+		assert.Equal(t, "oldID", receivedPrior.ID)
+	}
+	assert.Equal(t, oldURN, res.PriorURN)
+	assert.NotNil(t, res.PriorState)
+	assert.Equal(t, "oldID", res.ID)
+	assert.True(t, res.Inputs.DeepEquals(resource.NewPropertyMapFromMap(map[string]interface{}{
+		"foo": "new",
+		"bar": "old",
+	})))
+}
+
+func TestMockRegistryInvalidIgnoreChanges(t *testing.T) {
+	mocks := &testMonitor{
+		NewResourceF: func(typeToken, name string, inputs resource.PropertyMap,
+			provider, id string) (string, resource.PropertyMap, error) {
+
+			return name + "-id", inputs, nil
+		},
+	}
+
+	urn := string(resource.NewURN("stack", "project", "", "test:resource:type", "res"))
+	registry := NewMockRegistry()
+	registry.SetPriorState(urn, MockResourceState{ID: "oldID"})
+
+	err := RunErr(func(ctx *Context) error {
+		var res testResource2
+		return ctx.RegisterResource("test:resource:type", "res", &testResource2Inputs{
+			Foo: String("foo"),
+		}, &res, IgnoreChanges([]string{"foo["}))
+	}, WithMocks("project", "stack", mocks), WithMockRegistry(registry))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `invalid ignoreChanges property path "foo["`)
+	}
+}
+
+func TestMockRegistryPriorStateIsNotAnImport(t *testing.T) {
+	var receivedIDs []string
+	mocks := &testMonitor{
+		NewResourceF: func(typeToken, name string, inputs resource.PropertyMap,
+			provider, id string) (string, resource.PropertyMap, error) {
+
+			receivedIDs = append(receivedIDs, id)
+			return "", inputs, nil
+		},
+	}
+
+	urn := string(resource.NewURN("stack", "project", "", "test:resource:type", "updated"))
+	registry := NewMockRegistry()
+	registry.SetPriorState(urn, MockResourceState{ID: "oldID"})
+
+	err := RunErr(func(ctx *Context) error {
+		var updated, imported testResource2
+		err := ctx.RegisterResource("test:resource:type", "updated", &testResource2Inputs{}, &updated)
+		assert.NoError(t, err)
+		return ctx.RegisterResource("test:resource:type", "imported", &testResource2Inputs{}, &imported,
+			Import(ID("importID")))
+	}, WithMocks("project", "stack", mocks), WithMockRegistry(registry))
+	assert.NoError(t, err)
+
+	// Only the imported resource is passed an ID; the updated resource keeps its prior ID.
+	assert.Equal(t, []string{"", "importID"}, receivedIDs)
+	updated, ok := registry.Lookup(urn)
+	assert.True(t, ok)
+	assert.Equal(t, "oldID", updated.ID)
+}
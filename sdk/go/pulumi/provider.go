@@ -0,0 +1,422 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// ConstructFunc constructs a component resource on behalf of a provider's Construct method. The function must
+// register the component resource using the given context, name, and options, and return its URN and the state
+// that should be reported back to the caller.
+type ConstructFunc func(ctx *Context, typ, name string, inputs ConstructInputs,
+	options ResourceOption) (*ConstructResult, error)
+
+// ConstructResult is the result of a call to a ConstructFunc.
+type ConstructResult struct {
+	// URN is the URN of the constructed component resource.
+	URN URNInput
+	// State is the state of the component resource. It must be a struct with `pulumi` tags or a map.
+	State Input
+}
+
+// CallFunc executes a method on behalf of a provider's Call method. self is the resource the method is bound to, or
+// nil if the method was called as a plain function. The function returns the method's result, which is reported back
+// to the caller along with the resources each of its values depends on.
+type CallFunc func(ctx *Context, tok string, args CallArgs, self Resource) (*CallResult, error)
+
+// CallArgs holds the arguments to a method called by a provider's Call method. The `__self__` argument is not
+// included; it is passed to the CallFunc separately.
+type CallArgs struct {
+	ConstructInputs
+}
+
+// CallResult is the result of a call to a CallFunc.
+type CallResult struct {
+	// Return is the method's result. It must be a struct with `pulumi` tags or a map.
+	Return Input
+}
+
+// constructInput is a single input to a component resource along with the URNs of the resources it depends on.
+type constructInput struct {
+	value resource.PropertyValue
+	deps  []Resource
+}
+
+// ConstructInputs holds the inputs to a component resource constructed by a provider's Construct method.
+type ConstructInputs struct {
+	ctx    *Context
+	inputs map[string]constructInput
+}
+
+// Keys returns the sorted names of the inputs.
+func (inputs ConstructInputs) Keys() []string {
+	keys := make([]string, 0, len(inputs.inputs))
+	for k := range inputs.inputs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Map returns the inputs as a Map of Outputs. Each output carries the secretness and dependencies of its input.
+func (inputs ConstructInputs) Map() (Map, error) {
+	result := Map{}
+	for k, input := range inputs.inputs {
+		out, err := inputs.toOutput(anyOutputType, input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unmarshaling input %s", k)
+		}
+		result[k] = out.(AnyOutput)
+	}
+	return result, nil
+}
+
+// CopyTo copies the inputs into the struct pointed to by args. Each exported field of args with a `pulumi` tag
+// receives the input of the same name. Fields with Input or Output types receive outputs that carry the secretness
+// and dependencies of their inputs; fields with plain types receive the raw values of their inputs, and it is an
+// error for such inputs to be unknown.
+func (inputs ConstructInputs) CopyTo(args interface{}) error {
+	argsV := reflect.ValueOf(args)
+	if argsV.Kind() != reflect.Ptr || argsV.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("args must be a pointer to a struct, not %T", args)
+	}
+	argsV, argsT := argsV.Elem(), argsV.Elem().Type()
+
+	for i := 0; i < argsT.NumField(); i++ {
+		field := argsT.Field(i)
+		tag := strings.Split(field.Tag.Get("pulumi"), ",")[0]
+		if tag == "" || field.PkgPath != "" {
+			continue
+		}
+		input, ok := inputs.inputs[tag]
+		if !ok {
+			continue
+		}
+
+		dest := argsV.Field(i)
+		if outputT, ok := outputTypeForField(field.Type); ok {
+			out, err := inputs.toOutput(outputT, input)
+			if err != nil {
+				return errors.Wrapf(err, "unmarshaling input %s", tag)
+			}
+			dest.Set(reflect.ValueOf(out))
+			continue
+		}
+
+		if input.value.ContainsUnknowns() {
+			return errors.Errorf("input %s is unknown, but field %s has a plain type", tag, field.Name)
+		}
+		if _, err := unmarshalOutput(inputs.ctx, input.value, dest); err != nil {
+			return errors.Wrapf(err, "unmarshaling input %s", tag)
+		}
+	}
+	return nil
+}
+
+// toOutput creates a resolved output of the given type for the given input.
+func (inputs ConstructInputs) toOutput(outputT reflect.Type, input constructInput) (Output, error) {
+	out := newOutput(outputT, input.deps...)
+
+	known := !input.value.ContainsUnknowns()
+	secret := false
+	value := reflect.New(out.ElementType()).Elem()
+	if known {
+		s, err := unmarshalOutput(inputs.ctx, input.value, value)
+		if err != nil {
+			return nil, err
+		}
+		secret = s
+	} else {
+		secret = input.value.ContainsSecrets()
+	}
+
+	out.resolveValue(value, known, secret, nil)
+	return out, nil
+}
+
+// outputTypeForField returns the Output type that should be used to populate a field of the given type, if any. If
+// the field's type is itself an Output, that type is used. If the field's type is an Input interface, the result type
+// of its ToXXXOutput method is used.
+func outputTypeForField(t reflect.Type) (reflect.Type, bool) {
+	if t.Implements(outputType) && t.Kind() == reflect.Struct {
+		return t, true
+	}
+	if t.Kind() != reflect.Interface || !t.Implements(inputType) {
+		return nil, false
+	}
+	if t == inputType {
+		return anyOutputType, true
+	}
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		if strings.HasPrefix(m.Name, "To") && strings.HasSuffix(m.Name, "Output") &&
+			m.Type.NumIn() == 0 && m.Type.NumOut() == 1 && m.Type.Out(0).Implements(outputType) {
+			return m.Type.Out(0), true
+		}
+	}
+	return nil, false
+}
+
+// newConstructInputs deserializes the inputs in a ConstructRequest.
+func newConstructInputs(ctx *Context, req *pulumirpc.ConstructRequest) (ConstructInputs, error) {
+	props, err := plugin.UnmarshalProperties(req.GetInputs(), plugin.MarshalOptions{
+		KeepUnknowns:  true,
+		KeepSecrets:   true,
+		KeepResources: true,
+	})
+	if err != nil {
+		return ConstructInputs{}, err
+	}
+
+	inputDeps := map[string][]string{}
+	for k, deps := range req.GetInputDependencies() {
+		inputDeps[k] = deps.GetUrns()
+	}
+	return makeConstructInputs(ctx, props, inputDeps), nil
+}
+
+// makeConstructInputs pairs each of the given properties with the resources named by its dependency URNs.
+func makeConstructInputs(ctx *Context, props resource.PropertyMap, inputDeps map[string][]string) ConstructInputs {
+	inputs := map[string]constructInput{}
+	for k, v := range props {
+		var deps []Resource
+		for _, urn := range inputDeps[string(k)] {
+			deps = append(deps, newDependencyResource(URN(urn)))
+		}
+		inputs[string(k)] = constructInput{value: v, deps: deps}
+	}
+	return ConstructInputs{ctx: ctx, inputs: inputs}
+}
+
+// newConstructOptions rebuilds the resource options in a ConstructRequest.
+func newConstructOptions(req *pulumirpc.ConstructRequest) (ResourceOption, error) {
+	var opts []ResourceOption
+
+	if req.GetParent() != "" {
+		opts = append(opts, Parent(newDependencyResource(URN(req.GetParent()))))
+	}
+	if req.GetProtect() {
+		opts = append(opts, Protect(true))
+	}
+	if len(req.GetAliases()) > 0 {
+		aliases := make([]Alias, len(req.GetAliases()))
+		for i, urn := range req.GetAliases() {
+			aliases[i] = Alias{URN: URN(urn)}
+		}
+		opts = append(opts, Aliases(aliases))
+	}
+	if len(req.GetDependencies()) > 0 {
+		dependsOn := make([]Resource, len(req.GetDependencies()))
+		for i, urn := range req.GetDependencies() {
+			dependsOn[i] = newDependencyResource(URN(urn))
+		}
+		opts = append(opts, DependsOn(dependsOn))
+	}
+	if len(req.GetProviders()) > 0 {
+		providers := map[string]ProviderResource{}
+		for pkg, ref := range req.GetProviders() {
+			lastSep := strings.LastIndex(ref, "::")
+			if lastSep == -1 {
+				return nil, errors.Errorf("expected '::' in provider reference %s", ref)
+			}
+			providers[pkg] = newDependencyProviderResource(URN(ref[:lastSep]), ID(ref[lastSep+2:]))
+		}
+		opts = append(opts, ProviderMap(providers))
+	}
+
+	return resourceOption(func(ro *resourceOptions) {
+		for _, o := range opts {
+			o.applyResourceOption(ro)
+		}
+	}), nil
+}
+
+// Construct implements a provider's Construct method using the given ConstructFunc. It connects to the resource
+// monitor and engine named in the request, deserializes the component's inputs and options, invokes the
+// ConstructFunc, waits for all resource registrations to complete, and marshals the component's URN and state.
+func Construct(ctx context.Context, req *pulumirpc.ConstructRequest, engineAddr string,
+	construct ConstructFunc) (*pulumirpc.ConstructResponse, error) {
+
+	pulumiCtx, err := NewContext(ctx, RunInfo{
+		Project:     req.GetProject(),
+		Stack:       req.GetStack(),
+		Config:      req.GetConfig(),
+		Parallel:    int(req.GetParallel()),
+		DryRun:      req.GetDryRun(),
+		MonitorAddr: req.GetMonitorEndpoint(),
+		EngineAddr:  engineAddr,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "constructing run context")
+	}
+	defer contract.IgnoreClose(pulumiCtx)
+
+	inputs, err := newConstructInputs(pulumiCtx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling inputs")
+	}
+	opts, err := newConstructOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := construct(pulumiCtx, req.GetType(), req.GetName(), inputs, opts)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || result.URN == nil {
+		return nil, fmt.Errorf("construct(%s, %s) did not return a URN", req.GetType(), req.GetName())
+	}
+
+	// Wait for the component's URN and state before waiting for outstanding RPCs: both may depend on resources that
+	// are still being registered.
+	urn, _, _, err := result.URN.ToURNOutput().awaitURN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state, stateDeps, _, err := marshalInputs(result.State)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshaling state of %s", urn)
+	}
+
+	pulumiCtx.waitForRPCs()
+	if pulumiCtx.rpcError != nil {
+		return nil, pulumiCtx.rpcError
+	}
+
+	rpcState, err := plugin.MarshalProperties(state, plugin.MarshalOptions{
+		KeepUnknowns:  true,
+		KeepSecrets:   true,
+		KeepResources: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshaling state of %s", urn)
+	}
+
+	rpcStateDeps := map[string]*pulumirpc.ConstructResponse_PropertyDependencies{}
+	for k, deps := range stateDeps {
+		urns := make([]string, len(deps))
+		for i, d := range deps {
+			urns[i] = string(d)
+		}
+		rpcStateDeps[k] = &pulumirpc.ConstructResponse_PropertyDependencies{Urns: urns}
+	}
+
+	return &pulumirpc.ConstructResponse{
+		Urn:               string(urn),
+		State:             rpcState,
+		StateDependencies: rpcStateDeps,
+	}, nil
+}
+
+// Call implements a provider's Call method using the given CallFunc. It connects to the resource monitor and engine
+// named in the request, deserializes the method's arguments and the resource it is bound to, invokes the CallFunc,
+// waits for all resource registrations to complete, and marshals the method's result and its dependencies.
+func Call(ctx context.Context, req *pulumirpc.CallRequest, engineAddr string,
+	call CallFunc) (*pulumirpc.CallResponse, error) {
+
+	pulumiCtx, err := NewContext(ctx, RunInfo{
+		Project:     req.GetProject(),
+		Stack:       req.GetStack(),
+		Config:      req.GetConfig(),
+		Parallel:    int(req.GetParallel()),
+		DryRun:      req.GetDryRun(),
+		MonitorAddr: req.GetMonitorEndpoint(),
+		EngineAddr:  engineAddr,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "constructing run context")
+	}
+	defer contract.IgnoreClose(pulumiCtx)
+
+	props, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{
+		KeepUnknowns:  true,
+		KeepSecrets:   true,
+		KeepResources: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling arguments")
+	}
+
+	var self Resource
+	if selfV, ok := props["__self__"]; ok {
+		if !selfV.IsResourceReference() {
+			return nil, errors.Errorf("expected __self__ to be a resource reference, not a %s", selfV.TypeString())
+		}
+		if self, err = unmarshalResourceReference(pulumiCtx, selfV.ResourceReferenceValue()); err != nil {
+			return nil, errors.Wrap(err, "unmarshaling __self__")
+		}
+		delete(props, "__self__")
+	}
+
+	argDeps := map[string][]string{}
+	for k, deps := range req.GetArgDependencies() {
+		argDeps[k] = deps.GetUrns()
+	}
+	args := CallArgs{makeConstructInputs(pulumiCtx, props, argDeps)}
+
+	result, err := call(pulumiCtx, req.GetTok(), args, self)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("call(%s) did not return a result", req.GetTok())
+	}
+
+	ret, retDeps, _, err := marshalInputs(result.Return)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshaling result of %s", req.GetTok())
+	}
+
+	pulumiCtx.waitForRPCs()
+	if pulumiCtx.rpcError != nil {
+		return nil, pulumiCtx.rpcError
+	}
+
+	rpcRet, err := plugin.MarshalProperties(ret, plugin.MarshalOptions{
+		KeepUnknowns:  true,
+		KeepSecrets:   true,
+		KeepResources: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshaling result of %s", req.GetTok())
+	}
+
+	rpcRetDeps := map[string]*pulumirpc.CallResponse_ReturnDependencies{}
+	for k, deps := range retDeps {
+		urns := make([]string, len(deps))
+		for i, d := range deps {
+			urns[i] = string(d)
+		}
+		rpcRetDeps[k] = &pulumirpc.CallResponse_ReturnDependencies{Urns: urns}
+	}
+
+	return &pulumirpc.CallResponse{
+		Return:             rpcRet,
+		ReturnDependencies: rpcRetDeps,
+	}, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package provider implements component resource providers in Go. A component provider exposes Go component resource
+// constructors via the resource provider protocol's Construct method and the components' methods via its Call method,
+// which allows components written in Go to be consumed from any Pulumi language using RegisterRemoteComponentResource
+// (or its equivalent).
+//
+// A component provider's main function typically looks like:
+//
+//	func main() {
+//		err := provider.Main("mycomponents", "1.0.0", schema, map[string]pulumi.ConstructFunc{
+//			"mycomponents:index:StaticPage": constructStaticPage,
+//		}, map[string]pulumi.CallFunc{
+//			"mycomponents:index:StaticPage/getUrl": callStaticPageGetURL,
+//		})
+//		if err != nil {
+//			cmdutil.ExitError(err.Error())
+//		}
+//	}
+package provider
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// ComponentProvider is a resource provider that implements Construct for a set of component resource types and Call
+// for their methods. All other resource provider methods that operate on custom resources return errors.
+type ComponentProvider struct {
+	pulumirpc.UnimplementedResourceProviderServer
+
+	name         string
+	version      string
+	schema       []byte
+	engineAddr   string
+	constructors map[string]pulumi.ConstructFunc
+	methods      map[string]pulumi.CallFunc
+}
+
+// NewComponentProvider creates a new component provider with the given name, version, and schema that constructs
+// components using the given map of type token to constructor and calls methods using the given map of method token
+// to implementation. The engine address is used to connect each constructed component's or called method's context to
+// the engine.
+func NewComponentProvider(engineAddr, name, version string, schema []byte,
+	constructors map[string]pulumi.ConstructFunc, methods map[string]pulumi.CallFunc) *ComponentProvider {
+
+	return &ComponentProvider{
+		name:         name,
+		version:      version,
+		schema:       schema,
+		engineAddr:   engineAddr,
+		constructors: constructors,
+		methods:      methods,
+	}
+}
+
+// GetSchema returns the provider's schema.
+func (p *ComponentProvider) GetSchema(ctx context.Context,
+	req *pulumirpc.GetSchemaRequest) (*pulumirpc.GetSchemaResponse, error) {
+
+	if v := req.GetVersion(); v != 0 {
+		return nil, errors.Errorf("unsupported schema version %d", v)
+	}
+	schema := string(p.schema)
+	if schema == "" {
+		schema = "{}"
+	}
+	return &pulumirpc.GetSchemaResponse{Schema: schema}, nil
+}
+
+// CheckConfig validates the provider's configuration. Component providers accept any configuration.
+func (p *ComponentProvider) CheckConfig(ctx context.Context,
+	req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+
+	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
+}
+
+// DiffConfig diffs the provider's configuration. Component providers never require replacement.
+func (p *ComponentProvider) DiffConfig(ctx context.Context,
+	req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+
+	return &pulumirpc.DiffResponse{}, nil
+}
+
+// Configure configures the provider.
+func (p *ComponentProvider) Configure(ctx context.Context,
+	req *pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+
+	return &pulumirpc.ConfigureResponse{
+		AcceptSecrets:   true,
+		SupportsPreview: true,
+		AcceptResources: true,
+	}, nil
+}
+
+// Construct constructs a component resource using the constructor registered for the requested type.
+func (p *ComponentProvider) Construct(ctx context.Context,
+	req *pulumirpc.ConstructRequest) (*pulumirpc.ConstructResponse, error) {
+
+	construct, ok := p.constructors[req.GetType()]
+	if !ok {
+		return nil, errors.Errorf("unknown resource type %s", req.GetType())
+	}
+	return pulumi.Construct(ctx, req, p.engineAddr, construct)
+}
+
+// Call calls a component resource method using the implementation registered for the requested token.
+func (p *ComponentProvider) Call(ctx context.Context, req *pulumirpc.CallRequest) (*pulumirpc.CallResponse, error) {
+	call, ok := p.methods[req.GetTok()]
+	if !ok {
+		return nil, errors.Errorf("unknown method %s", req.GetTok())
+	}
+	return pulumi.Call(ctx, req, p.engineAddr, call)
+}
+
+// Cancel signals the provider to gracefully shut down and abort any ongoing resource operations.
+func (p *ComponentProvider) Cancel(ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
+	return &pbempty.Empty{}, nil
+}
+
+// GetPluginInfo returns the provider's version information.
+func (p *ComponentProvider) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{Version: p.version}, nil
+}
+
+// tracing is the optional command line flag passed to this provider for configuring a Zipkin-compatible tracing
+// endpoint.
+var tracing string
+
+// Main is the typical entrypoint for a component provider plugin. It parses the provider's command line, serves a
+// ComponentProvider for the given constructors and methods over gRPC, and prints the port on which it is listening as
+// required by the resource provider protocol.
+func Main(name, version string, schema []byte, constructors map[string]pulumi.ConstructFunc,
+	methods map[string]pulumi.CallFunc) error {
+
+	flag.StringVar(&tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
+	flag.Parse()
+
+	// Initialize loggers before going any further.
+	logging.InitLogging(false, 0, false)
+	cmdutil.InitTracing(name, name, tracing)
+
+	// Read the non-flags args. The first is the address of the engine.
+	args := flag.Args()
+	if len(args) == 0 {
+		return errors.New("fatal: could not connect to host RPC; missing argument")
+	}
+	engineAddr := args[0]
+
+	// Fire up a gRPC server, letting the kernel choose a free port for us.
+	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			prov := NewComponentProvider(engineAddr, name, version, schema, constructors, methods)
+			pulumirpc.RegisterResourceProviderServer(srv, prov)
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		return errors.Errorf("fatal: %v", err)
+	}
+
+	// The resource provider protocol requires that we now write out the port we have chosen to listen on.
+	fmt.Printf("%d\n", port)
+
+	// Finally, wait for the server to stop serving.
+	if err := <-done; err != nil {
+		return errors.Errorf("fatal: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+	"github.com/stretchr/testify/assert"
+)
+
+type constructArgs struct {
+	Foo    StringInput `pulumi:"foo"`
+	Bar    StringInput `pulumi:"bar"`
+	Count  int         `pulumi:"count"`
+	Secret StringInput `pulumi:"secret"`
+	Any    Input       `pulumi:"any"`
+}
+
+func newTestConstructRequest(t *testing.T, inputs resource.PropertyMap) *pulumirpc.ConstructRequest {
+	rpcInputs, err := plugin.MarshalProperties(inputs, plugin.MarshalOptions{
+		KeepUnknowns: true,
+		KeepSecrets:  true,
+	})
+	assert.NoError(t, err)
+
+	return &pulumirpc.ConstructRequest{
+		Type:   "test:index:Component",
+		Name:   "comp",
+		Inputs: rpcInputs,
+		InputDependencies: map[string]*pulumirpc.ConstructRequest_PropertyDependencies{
+			"foo": {Urns: []string{"urn:pulumi:stack::project::test:index:Dep::dep"}},
+		},
+		Parent:       "urn:pulumi:stack::project::test:index:Parent::parent",
+		Protect:      true,
+		Dependencies: []string{"urn:pulumi:stack::project::test:index:Dep::dep"},
+		Providers: map[string]string{
+			"test": "urn:pulumi:stack::project::pulumi:providers:test::prov::some-id",
+		},
+		Aliases: []string{"urn:pulumi:stack::project::test:index:Component::old"},
+	}
+}
+
+func TestConstructInputs(t *testing.T) {
+	ctx, err := NewContext(context.Background(), RunInfo{
+		Project: "project",
+		Stack:   "stack",
+		Mocks:   &testMonitor{},
+	})
+	assert.NoError(t, err)
+
+	req := newTestConstructRequest(t, resource.PropertyMap{
+		"foo":    resource.NewStringProperty("oof"),
+		"bar":    resource.MakeComputed(resource.NewStringProperty("")),
+		"count":  resource.NewNumberProperty(42),
+		"secret": resource.MakeSecret(resource.NewStringProperty("shh")),
+		"any":    resource.NewObjectProperty(resource.PropertyMap{"a": resource.NewStringProperty("b")}),
+	})
+
+	inputs, err := newConstructInputs(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"any", "bar", "count", "foo", "secret"}, inputs.Keys())
+
+	var args constructArgs
+	assert.NoError(t, inputs.CopyTo(&args))
+	assert.Equal(t, 42, args.Count)
+
+	foo, known, secret, deps, err := await(args.Foo.ToStringOutput())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.False(t, secret)
+	assert.Equal(t, "oof", foo)
+	assert.Len(t, deps, 1)
+	depURN, _, _, _, err := await(deps[0].URN())
+	assert.NoError(t, err)
+	assert.Equal(t, URN("urn:pulumi:stack::project::test:index:Dep::dep"), depURN)
+
+	_, known, _, _, err = await(args.Bar.ToStringOutput())
+	assert.NoError(t, err)
+	assert.False(t, known)
+
+	sec, known, secret, _, err := await(args.Secret.ToStringOutput())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, "shh", sec)
+
+	anyV, _, _, _, err := await(args.Any.(AnyOutput))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "b"}, anyV)
+
+	m, err := inputs.Map()
+	assert.NoError(t, err)
+	assert.Len(t, m, 5)
+	count, _, _, _, err := await(m["count"].(AnyOutput))
+	assert.NoError(t, err)
+	assert.Equal(t, 42.0, count)
+
+	// Plain fields cannot receive unknown values.
+	var plain struct {
+		Bar string `pulumi:"bar"`
+	}
+	assert.Error(t, inputs.CopyTo(&plain))
+	assert.Error(t, inputs.CopyTo(plain))
+}
+
+func TestConstructOptions(t *testing.T) {
+	req := newTestConstructRequest(t, resource.PropertyMap{})
+
+	opt, err := newConstructOptions(req)
+	assert.NoError(t, err)
+	opts := merge(opt)
+
+	assert.True(t, opts.Protect)
+	assert.Len(t, opts.Aliases, 1)
+	assert.Len(t, opts.DependsOn, 1)
+
+	parentURN, _, _, _, err := await(opts.Parent.URN())
+	assert.NoError(t, err)
+	assert.Equal(t, URN(req.Parent), parentURN)
+
+	prov, ok := opts.Providers["test"]
+	assert.True(t, ok)
+	id, known, _, _, err := await(prov.ID())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, ID("some-id"), id)
+
+	req.Providers["test"] = "not-a-reference"
+	_, err = newConstructOptions(req)
+	assert.Error(t, err)
+}
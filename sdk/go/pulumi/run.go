@@ -132,6 +132,8 @@ type RunInfo struct {
 	EngineAddr  string
 	Mocks       MockResourceMonitor
 	getPlugins  bool
+
+	mockRegistry *MockRegistry
 }
 
 // getEnvInfo reads various program information from the process environment.
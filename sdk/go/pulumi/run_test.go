@@ -12,6 +12,7 @@ type testMonitor struct {
 	CallF        func(tok string, args resource.PropertyMap, provider string) (resource.PropertyMap, error)
 	NewResourceF func(typeToken, name string, inputs resource.PropertyMap,
 		provider, id string) (string, resource.PropertyMap, error)
+	MethodCallF func(tok string, args resource.PropertyMap, self, provider string) (resource.PropertyMap, error)
 }
 
 func (m *testMonitor) Call(tok string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
@@ -21,6 +22,15 @@ func (m *testMonitor) Call(tok string, args resource.PropertyMap, provider strin
 	return m.CallF(tok, args, provider)
 }
 
+func (m *testMonitor) MethodCall(tok string, args resource.PropertyMap,
+	self, provider string) (resource.PropertyMap, error) {
+
+	if m.MethodCallF == nil {
+		return resource.PropertyMap{}, nil
+	}
+	return m.MethodCallF(tok, args, self, provider)
+}
+
 func (m *testMonitor) NewResource(typeToken, name string, inputs resource.PropertyMap,
 	provider, id string) (string, resource.PropertyMap, error) {
 
@@ -263,3 +273,52 @@ func TestInvoke(t *testing.T) {
 	}, WithMocks("project", "stack", mocks))
 	assert.NoError(t, err)
 }
+
+func TestCall(t *testing.T) {
+	mocks := &testMonitor{
+		NewResourceF: func(typeToken, name string, inputs resource.PropertyMap,
+			provider, id string) (string, resource.PropertyMap, error) {
+
+			return name + "-id", inputs, nil
+		},
+		CallF: func(token string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+			assert.Fail(t, "methods must not be mocked as invokes")
+			return nil, nil
+		},
+		MethodCallF: func(token string, args resource.PropertyMap,
+			self, provider string) (resource.PropertyMap, error) {
+
+			assert.Equal(t, "test:resource:type/method", token)
+			assert.Equal(t, resource.PropertyMap{"bang": resource.NewStringProperty("gnab")}, args)
+			assert.Equal(t, "urn:pulumi:stack::project::test:resource:type::resA", self)
+			return resource.NewPropertyMapFromMap(map[string]interface{}{
+				"foo": "oof",
+				"baz": "zab",
+			}), nil
+		},
+	}
+
+	err := RunErr(func(ctx *Context) error {
+		var res testResource2
+		err := ctx.RegisterResource("test:resource:type", "resA", &testResource2Inputs{
+			Foo: String("oof"),
+		}, &res)
+		assert.NoError(t, err)
+
+		out, err := ctx.Call("test:resource:type/method", Map{
+			"bang": String("gnab"),
+		}, MapOutput{}, &res)
+		assert.NoError(t, err)
+
+		result, known, secret, _, err := await(out.(MapOutput))
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.False(t, secret)
+		assert.Equal(t, map[string]interface{}{"foo": "oof", "baz": "zab"}, result)
+
+		_, err = ctx.Call("", nil, MapOutput{}, nil)
+		assert.Error(t, err)
+		return nil
+	}, WithMocks("project", "stack", mocks))
+	assert.NoError(t, err)
+}
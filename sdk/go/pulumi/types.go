@@ -629,6 +629,12 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, bool, []
 			return true, false, nil, nil
 		}
 
+		// Resources are references: they are never copied.
+		if r, ok := input.(Resource); ok && reflect.TypeOf(r).AssignableTo(resolved.Type()) {
+			resolved.Set(reflect.ValueOf(r))
+			return true, false, nil, nil
+		}
+
 		v, isInput = reflect.ValueOf(input), true
 
 		// We require that the kind of an `Input`'s `ElementType` agrees with the kind of the `Input`'s underlying value.
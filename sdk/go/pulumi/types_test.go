@@ -248,6 +248,29 @@ func TestToOutputConvert(t *testing.T) {
 	assert.Equal(t, nestedType{Foo: "bar", Bar: 1}, v)
 }
 
+type inputResource struct {
+	simpleCustomResource
+}
+
+func (*inputResource) ElementType() reflect.Type {
+	return reflect.TypeOf((*inputResource)(nil))
+}
+
+// Test that ToOutput does not copy resources that are used as inputs.
+func TestToOutputResource(t *testing.T) {
+	type args struct {
+		R *inputResource
+	}
+
+	res := &inputResource{simpleCustomResource: *newSimpleCustomResource("urn", "id").(*simpleCustomResource)}
+
+	v, known, secret, _, err := await(ToOutput(args{R: res}))
+	assert.True(t, known)
+	assert.False(t, secret)
+	assert.NoError(t, err)
+	assert.True(t, v.(args).R == res)
+}
+
 // Test that ToOutput correctly handles nested inputs and outputs when the argument is an input or interface{}.
 func TestToOutputAny(t *testing.T) {
 	type args struct {
@@ -99,6 +99,17 @@ function deserialize_pulumirpc_PluginInfo(buffer_arg) {
   return plugin_pb.PluginInfo.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_RemediateResponse(arg) {
+  if (!(arg instanceof analyzer_pb.RemediateResponse)) {
+    throw new Error('Expected argument of type pulumirpc.RemediateResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_RemediateResponse(buffer_arg) {
+  return analyzer_pb.RemediateResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 
 // Analyzer provides a pluggable interface for checking resource definitions against some number of
 // resource policies. It is intentionally open-ended, allowing for implementations that check
@@ -132,6 +143,20 @@ analyzeStack: {
     responseSerialize: serialize_pulumirpc_AnalyzeResponse,
     responseDeserialize: deserialize_pulumirpc_AnalyzeResponse,
   },
+  // Remediate optionally transforms a single resource object. This effectively rewrites a single resource object's
+// properties instead of using what was generated by the program. Called with the "inputs" to the resource, after
+// they have been checked by the provider and before the resource is diffed.
+remediate: {
+    path: '/pulumirpc.Analyzer/Remediate',
+    requestStream: false,
+    responseStream: false,
+    requestType: analyzer_pb.AnalyzeRequest,
+    responseType: analyzer_pb.RemediateResponse,
+    requestSerialize: serialize_pulumirpc_AnalyzeRequest,
+    requestDeserialize: deserialize_pulumirpc_AnalyzeRequest,
+    responseSerialize: serialize_pulumirpc_RemediateResponse,
+    responseDeserialize: deserialize_pulumirpc_RemediateResponse,
+  },
   // GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
 getAnalyzerInfo: {
     path: '/pulumirpc.Analyzer/GetAnalyzerInfo',
@@ -23,7 +23,13 @@ import { InvokeOptions } from "../invoke";
 import * as log from "../log";
 import { Inputs, Output } from "../output";
 import { debuggablePromise } from "./debuggable";
-import { deserializeProperties, serializeProperties } from "./rpc";
+import {
+    deserializeProperties,
+    isRpcSecret,
+    serializeProperties,
+    serializePropertiesReturnDeps,
+    unwrapRpcSecret,
+} from "./rpc";
 import {
     excessiveDebugOutput,
     getMonitor,
@@ -31,7 +37,7 @@ import {
     terminateRpcs,
 } from "./settings";
 
-import { ProviderResource, Resource } from "../resource";
+import { DependencyResource, ProviderResource, Resource } from "../resource";
 import * as utils from "../utils";
 import { PushableAsyncIterable } from "./asyncIterableUtil";
 
@@ -101,27 +107,27 @@ export async function streamInvoke(
         const req = createInvokeRequest(tok, serialized, provider, opts);
 
         // Call `streamInvoke`.
-        const call = monitor.streamInvoke(req, {});
+        const stream = monitor.streamInvoke(req, {});
 
         const queue = new PushableAsyncIterable();
-        call.on("data", function(thing: any) {
+        stream.on("data", function(thing: any) {
             const live = deserializeResponse(tok, thing);
             queue.push(live);
         });
-        call.on("error", (err: any) => {
+        stream.on("error", (err: any) => {
             if (err.code === 1) {
                 return;
             }
             throw err;
         });
-        call.on("end", () => {
+        stream.on("end", () => {
             queue.complete();
         });
 
         // Return a cancellable handle to the stream.
         return new StreamInvokeResponse(
             queue,
-            () => call.cancel());
+            () => stream.cancel());
     } finally {
         done();
     }
@@ -173,6 +179,89 @@ async function invokeAsync(tok: string, props: Inputs, opts: InvokeOptions): Pro
     }
 }
 
+/**
+ * `call` dynamically calls the method, `tok`, which is offered by a provider plugin. Unlike `invoke`, the `props`
+ * passed to `call` may contain outputs, and the result is an output that depends on the resources that the provider
+ * reports as dependencies of the method's return value. If `res` is given, it is the resource to which the method is
+ * bound, and its provider is used to serve the call.
+ */
+export function call<T>(tok: string, props: Inputs, res?: Resource): Output<T> {
+    const label = `Calling function: tok=${tok}`;
+    log.debug(label + (excessiveDebugOutput ? `, props=${JSON.stringify(props)}` : ``));
+
+    const result = debuggablePromise(callAsync(tok, props, res, label), label);
+    return new Output<T>(
+        [],
+        result.then(r => r.value),
+        Promise.resolve(true),
+        result.then(r => r.isSecret),
+        result.then(r => r.deps));
+}
+
+async function callAsync(
+    tok: string, props: Inputs, res: Resource | undefined, label: string,
+): Promise<{ value: any, isSecret: boolean, deps: Resource[] }> {
+    // Wait for all values to be available, and then perform the RPC.
+    const done = rpcKeepAlive();
+    try {
+        const [serialized, propertyDepsResources] = await serializePropertiesReturnDeps(`call:${tok}`, props);
+        log.debug(`Call RPC prepared: tok=${tok}` + (excessiveDebugOutput ? `, obj=${JSON.stringify(serialized)}` : ``));
+
+        // Fetch the monitor and make an RPC request.
+        const monitor: any = getMonitor();
+
+        const provider = await ProviderResource.register(res ? res.getProvider(tok) : undefined);
+        const req = await createCallRequest(tok, serialized, propertyDepsResources, provider);
+
+        const resp: any = await debuggablePromise(new Promise((innerResolve, innerReject) =>
+            monitor.call(req, (err: grpc.ServiceError, innerResponse: any) => {
+                log.debug(`Call RPC finished: tok=${tok}; err: ${err}, resp: ${innerResponse}`);
+                if (err) {
+                    // If the monitor is unavailable, it is in the process of shutting down or has already
+                    // shut down. Don't emit an error and don't do any more RPCs, just exit.
+                    if (err.code === grpc.status.UNAVAILABLE || err.code === grpc.status.CANCELLED) {
+                        terminateRpcs();
+                        err.message = "Resource monitor is terminating";
+                        innerReject(err);
+                        return;
+                    }
+
+                    // If the RPC failed, rethrow the error with a native exception and the message that
+                    // the engine provided - it's suitable for user presentation.
+                    innerReject(new Error(err.details));
+                }
+                else {
+                    innerResolve(innerResponse);
+                }
+            })), label);
+
+        // Secrets are tracked on the result as a whole, so unwrap any secret properties.
+        const value = deserializeResponse(tok, resp);
+        let isSecret = false;
+        if (value !== undefined) {
+            for (const k of Object.keys(value)) {
+                isSecret = isSecret || isRpcSecret(value[k]);
+                value[k] = unwrapRpcSecret(value[k]);
+            }
+        }
+
+        // The result depends on the resources that the provider reported for any of its properties.
+        const urns = new Set<string>();
+        const returnDependencies = resp.getReturndependenciesMap();
+        returnDependencies.forEach((returnDeps: any) => {
+            for (const urn of returnDeps.getUrnsList()) {
+                urns.add(urn);
+            }
+        });
+        const deps = Array.from(urns).map(urn => new DependencyResource(urn));
+
+        return { value, isSecret, deps };
+    }
+    finally {
+        done();
+    }
+}
+
 // StreamInvokeResponse represents a (potentially infinite) streaming response to `streamInvoke`,
 // with facilities to gracefully cancel and clean up the stream.
 export class StreamInvokeResponse<T> implements AsyncIterable<T> {
@@ -207,6 +296,33 @@ function createInvokeRequest(tok: string, serialized: any, provider: string | un
     return req;
 }
 
+async function createCallRequest(
+    tok: string, serialized: any, propertyDepsResources: Map<string, Set<Resource>>, provider: string | undefined,
+) {
+    if (provider !== undefined && typeof provider !== "string") {
+        throw new Error("Incorrect provider type.");
+    }
+
+    const obj = gstruct.Struct.fromJavaScript(serialized);
+
+    const req = new providerproto.CallRequest();
+    req.setTok(tok);
+    req.setArgs(obj);
+    req.setProvider(provider);
+
+    const argDependencies = req.getArgdependenciesMap();
+    for (const [key, resources] of propertyDepsResources) {
+        const urns = new Set<string>();
+        for (const r of resources) {
+            urns.add(await r.urn.promise());
+        }
+        const deps = new providerproto.CallRequest.ArgumentDependencies();
+        deps.setUrnsList(Array.from(urns));
+        argDependencies.set(key, deps);
+    }
+    return req;
+}
+
 function getProvider(tok: string, opts: InvokeOptions) {
     return opts.provider ? opts.provider :
            opts.parent ? opts.parent.getProvider(tok) : undefined;
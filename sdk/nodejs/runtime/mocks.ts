@@ -12,7 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import { deserializeProperties, serializeProperties } from "./rpc";
+import {
+    deserializeProperties,
+    deserializeProperty,
+    serializeProperties,
+    specialResourceSig,
+    specialSigKey,
+} from "./rpc";
 import { getProject, getStack, setMockOptions } from "./settings";
 
 const provproto = require("../proto/provider_pb.js");
@@ -45,6 +51,17 @@ export interface Mocks {
      * @param id: If provided, the physical identifier of an existing resource to read or import.
      */
     newResource(type: string, name: string, inputs: any, provider?: string, id?: string): { id: string | undefined, state: Record<string, any> };
+
+    /**
+     * Mocks calls to resource methods (e.g. those made with `runtime.call`). Calls to methods fail if this is not
+     * implemented.
+     *
+     * @param token: The token that indicates which method is being called. This token is of the form "package:module:type/method".
+     * @param args: The arguments provided to the method call, not including the resource it is called on.
+     * @param self: If provided, the URN of the resource the method is called on.
+     * @param provider: If provided, the identifier of the provider instance being used to make the call.
+     */
+    methodCall?(token: string, args: any, self?: string, provider?: string): Record<string, any>;
 }
 
 export class MockMonitor {
@@ -87,6 +104,36 @@ export class MockMonitor {
         }
     }
 
+    public async call(req: any, callback: (err: any, innerResponse: any) => void) {
+        try {
+            const tok = req.getTok();
+            if (!this.mocks.methodCall) {
+                throw new Error(`cannot call method ${tok}: mocks do not implement methodCall`);
+            }
+
+            // The resource the method is called on is passed to the mocks by URN.
+            const args = req.getArgs().toJavaScript();
+            let self: string | undefined;
+            const selfRef = args["__self__"];
+            if (selfRef !== undefined) {
+                self = selfRef[specialSigKey] === specialResourceSig ? selfRef.urn : selfRef;
+                delete args["__self__"];
+            }
+
+            const inputs: any = {};
+            for (const k of Object.keys(args)) {
+                inputs[k] = deserializeProperty(args[k]);
+            }
+
+            const result = this.mocks.methodCall(tok, inputs, self, req.getProvider());
+            const response = new provproto.CallResponse();
+            response.setReturn(structproto.Struct.fromJavaScript(await serializeProperties("", result)));
+            callback(null, response);
+        } catch (err) {
+            callback(err, undefined);
+        }
+    }
+
     public async readResource(req: any, callback: (err: any, innterResponse: any) => void) {
         try {
             const result = this.mocks.newResource(
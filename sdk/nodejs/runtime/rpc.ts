@@ -129,6 +129,15 @@ export async function serializeProperties(label: string, props: Inputs) {
     return result;
 }
 
+/**
+ * serializePropertiesReturnDeps walks the props object passed in, awaiting all interior promises, creating a
+ * reasonable POJO object that can be remoted over to a provider's call method. It also returns the set of resources
+ * upon which each of the properties depends.
+ */
+export async function serializePropertiesReturnDeps(label: string, props: Inputs) {
+    return serializeFilteredProperties(label, props, _ => true);
+}
+
 /**
  * deserializeProperties fetches the raw outputs and deserializes them from a gRPC call result.
  */
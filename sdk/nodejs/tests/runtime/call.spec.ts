@@ -0,0 +1,87 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as assert from "assert";
+import { ComponentResource, Output, ResourceOptions, runtime, secret } from "../../index";
+import { asyncTest } from "../util";
+
+class TestComponentResource extends ComponentResource {
+    constructor(name: string, opts?: ResourceOptions) {
+        super("test:index:component", name, {}, opts);
+
+        super.registerOutputs({});
+    }
+
+    getValue(arg: string | Output<string>): Output<{ value: string }> {
+        return runtime.call("test:index:component/getValue", { "__self__": this, "arg": arg }, this);
+    }
+}
+
+class TestMocks implements runtime.Mocks {
+    readonly methodCalls: { token: string, args: any, self?: string }[] = [];
+
+    call(token: string, args: any, provider?: string): Record<string, any> {
+        throw new Error(`unknown function ${token}`);
+    }
+
+    methodCall(token: string, args: any, self?: string, provider?: string): Record<string, any> {
+        this.methodCalls.push({ token, args, self });
+        const value = `${runtime.unwrapRpcSecret(args.arg)}!`;
+        return { value: runtime.isRpcSecret(args.arg) ? secret(value) : value };
+    }
+
+    newResource(type: string, name: string, inputs: any, provider?: string, id?: string): { id: string | undefined, state: Record<string, any> } {
+        return { id: undefined, state: {} };
+    }
+}
+
+describe("runtime", () => {
+    beforeEach(() => {
+        runtime._reset();
+        runtime._setFeatureSupport("resourceReferences", true);
+    });
+
+    after(() => {
+        runtime._setFeatureSupport("resourceReferences", false);
+    });
+
+    describe("call", () => {
+        it("passes the resource and its arguments to the method", asyncTest(async () => {
+            const mocks = new TestMocks();
+            runtime.setMocks(mocks);
+
+            const component = new TestComponentResource("test");
+            const result = component.getValue("hello");
+
+            assert.deepEqual(await result.promise(), { value: "hello!" });
+            assert.strictEqual(await result.isSecret, false);
+            assert.deepEqual(mocks.methodCalls, [{
+                token: "test:index:component/getValue",
+                args: { arg: "hello" },
+                self: await component.urn.promise(),
+            }]);
+        }));
+
+        it("propagates the secretness of the result", asyncTest(async () => {
+            const mocks = new TestMocks();
+            runtime.setMocks(mocks);
+
+            const component = new TestComponentResource("test");
+            const result = component.getValue(secret("hello"));
+
+            assert.deepEqual(await result.promise(), { value: "hello!" });
+            assert.strictEqual(await result.isSecret, true);
+        }));
+    });
+});
@@ -568,6 +568,126 @@ func (m *AnalyzeResponse) GetDiagnostics() []*AnalyzeDiagnostic {
 	return nil
 }
 
+// Remediation is a single resource remediation result.
+type Remediation struct {
+	PolicyName           string          `protobuf:"bytes,1,opt,name=policyName,proto3" json:"policyName,omitempty"`
+	PolicyPackName       string          `protobuf:"bytes,2,opt,name=policyPackName,proto3" json:"policyPackName,omitempty"`
+	PolicyPackVersion    string          `protobuf:"bytes,3,opt,name=policyPackVersion,proto3" json:"policyPackVersion,omitempty"`
+	Description          string          `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,5,opt,name=properties,proto3" json:"properties,omitempty"`
+	Diagnostic           string          `protobuf:"bytes,6,opt,name=diagnostic,proto3" json:"diagnostic,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Remediation) Reset()         { *m = Remediation{} }
+func (m *Remediation) String() string { return proto.CompactTextString(m) }
+func (*Remediation) ProtoMessage()    {}
+func (*Remediation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fadbb7eccb91f143, []int{7}
+}
+
+func (m *Remediation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Remediation.Unmarshal(m, b)
+}
+func (m *Remediation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Remediation.Marshal(b, m, deterministic)
+}
+func (m *Remediation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Remediation.Merge(m, src)
+}
+func (m *Remediation) XXX_Size() int {
+	return xxx_messageInfo_Remediation.Size(m)
+}
+func (m *Remediation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Remediation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Remediation proto.InternalMessageInfo
+
+func (m *Remediation) GetPolicyName() string {
+	if m != nil {
+		return m.PolicyName
+	}
+	return ""
+}
+
+func (m *Remediation) GetPolicyPackName() string {
+	if m != nil {
+		return m.PolicyPackName
+	}
+	return ""
+}
+
+func (m *Remediation) GetPolicyPackVersion() string {
+	if m != nil {
+		return m.PolicyPackVersion
+	}
+	return ""
+}
+
+func (m *Remediation) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Remediation) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Remediation) GetDiagnostic() string {
+	if m != nil {
+		return m.Diagnostic
+	}
+	return ""
+}
+
+// RemediateResponse contains a sequence of remediations applied, in order.
+type RemediateResponse struct {
+	Remediations         []*Remediation `protobuf:"bytes,1,rep,name=remediations,proto3" json:"remediations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RemediateResponse) Reset()         { *m = RemediateResponse{} }
+func (m *RemediateResponse) String() string { return proto.CompactTextString(m) }
+func (*RemediateResponse) ProtoMessage()    {}
+func (*RemediateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fadbb7eccb91f143, []int{8}
+}
+
+func (m *RemediateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemediateResponse.Unmarshal(m, b)
+}
+func (m *RemediateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemediateResponse.Marshal(b, m, deterministic)
+}
+func (m *RemediateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemediateResponse.Merge(m, src)
+}
+func (m *RemediateResponse) XXX_Size() int {
+	return xxx_messageInfo_RemediateResponse.Size(m)
+}
+func (m *RemediateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemediateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemediateResponse proto.InternalMessageInfo
+
+func (m *RemediateResponse) GetRemediations() []*Remediation {
+	if m != nil {
+		return m.Remediations
+	}
+	return nil
+}
+
 type AnalyzeDiagnostic struct {
 	PolicyName           string           `protobuf:"bytes,1,opt,name=policyName,proto3" json:"policyName,omitempty"`
 	PolicyPackName       string           `protobuf:"bytes,2,opt,name=policyPackName,proto3" json:"policyPackName,omitempty"`
@@ -586,7 +706,7 @@ func (m *AnalyzeDiagnostic) Reset()         { *m = AnalyzeDiagnostic{} }
 func (m *AnalyzeDiagnostic) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeDiagnostic) ProtoMessage()    {}
 func (*AnalyzeDiagnostic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{7}
+	return fileDescriptor_fadbb7eccb91f143, []int{9}
 }
 
 func (m *AnalyzeDiagnostic) XXX_Unmarshal(b []byte) error {
@@ -680,7 +800,7 @@ func (m *AnalyzerInfo) Reset()         { *m = AnalyzerInfo{} }
 func (m *AnalyzerInfo) String() string { return proto.CompactTextString(m) }
 func (*AnalyzerInfo) ProtoMessage()    {}
 func (*AnalyzerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{8}
+	return fileDescriptor_fadbb7eccb91f143, []int{10}
 }
 
 func (m *AnalyzerInfo) XXX_Unmarshal(b []byte) error {
@@ -760,7 +880,7 @@ func (m *PolicyInfo) Reset()         { *m = PolicyInfo{} }
 func (m *PolicyInfo) String() string { return proto.CompactTextString(m) }
 func (*PolicyInfo) ProtoMessage()    {}
 func (*PolicyInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{9}
+	return fileDescriptor_fadbb7eccb91f143, []int{11}
 }
 
 func (m *PolicyInfo) XXX_Unmarshal(b []byte) error {
@@ -836,7 +956,7 @@ func (m *PolicyConfigSchema) Reset()         { *m = PolicyConfigSchema{} }
 func (m *PolicyConfigSchema) String() string { return proto.CompactTextString(m) }
 func (*PolicyConfigSchema) ProtoMessage()    {}
 func (*PolicyConfigSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{10}
+	return fileDescriptor_fadbb7eccb91f143, []int{12}
 }
 
 func (m *PolicyConfigSchema) XXX_Unmarshal(b []byte) error {
@@ -884,7 +1004,7 @@ func (m *PolicyConfig) Reset()         { *m = PolicyConfig{} }
 func (m *PolicyConfig) String() string { return proto.CompactTextString(m) }
 func (*PolicyConfig) ProtoMessage()    {}
 func (*PolicyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{11}
+	return fileDescriptor_fadbb7eccb91f143, []int{13}
 }
 
 func (m *PolicyConfig) XXX_Unmarshal(b []byte) error {
@@ -931,7 +1051,7 @@ func (m *ConfigureAnalyzerRequest) Reset()         { *m = ConfigureAnalyzerReque
 func (m *ConfigureAnalyzerRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigureAnalyzerRequest) ProtoMessage()    {}
 func (*ConfigureAnalyzerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fadbb7eccb91f143, []int{12}
+	return fileDescriptor_fadbb7eccb91f143, []int{14}
 }
 
 func (m *ConfigureAnalyzerRequest) XXX_Unmarshal(b []byte) error {
@@ -970,6 +1090,8 @@ func init() {
 	proto.RegisterType((*AnalyzerPropertyDependencies)(nil), "pulumirpc.AnalyzerPropertyDependencies")
 	proto.RegisterType((*AnalyzeStackRequest)(nil), "pulumirpc.AnalyzeStackRequest")
 	proto.RegisterType((*AnalyzeResponse)(nil), "pulumirpc.AnalyzeResponse")
+	proto.RegisterType((*Remediation)(nil), "pulumirpc.Remediation")
+	proto.RegisterType((*RemediateResponse)(nil), "pulumirpc.RemediateResponse")
 	proto.RegisterType((*AnalyzeDiagnostic)(nil), "pulumirpc.AnalyzeDiagnostic")
 	proto.RegisterType((*AnalyzerInfo)(nil), "pulumirpc.AnalyzerInfo")
 	proto.RegisterMapType((map[string]*PolicyConfig)(nil), "pulumirpc.AnalyzerInfo.InitialConfigEntry")
@@ -980,81 +1102,87 @@ func init() {
 	proto.RegisterMapType((map[string]*PolicyConfig)(nil), "pulumirpc.ConfigureAnalyzerRequest.PolicyConfigEntry")
 }
 
-func init() { proto.RegisterFile("analyzer.proto", fileDescriptor_fadbb7eccb91f143) }
+func init() {
+	proto.RegisterFile("analyzer.proto", fileDescriptor_fadbb7eccb91f143)
+}
 
 var fileDescriptor_fadbb7eccb91f143 = []byte{
-	// 1130 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x57, 0x51, 0x6f, 0x1b, 0x45,
-	0x10, 0xce, 0xd9, 0x69, 0x62, 0x8f, 0x1d, 0xd7, 0xd9, 0x42, 0x73, 0xbd, 0x86, 0x2a, 0xba, 0x22,
-	0x88, 0x2a, 0x70, 0xa9, 0x11, 0xa2, 0x20, 0x0a, 0x38, 0x71, 0x14, 0x05, 0x85, 0xc6, 0xac, 0xab,
-	0xaa, 0x79, 0xbc, 0x9e, 0xc7, 0xee, 0x2a, 0xe7, 0xbb, 0xeb, 0xde, 0x5e, 0x24, 0xf3, 0xc8, 0x23,
-	0x12, 0x12, 0x7f, 0x80, 0x7f, 0xc1, 0xff, 0xe0, 0x85, 0xdf, 0xc0, 0x33, 0x3f, 0x01, 0xed, 0xee,
-	0x9d, 0x73, 0xe7, 0x3b, 0xbb, 0x55, 0x84, 0xc4, 0x03, 0x6f, 0x3b, 0xb3, 0xdf, 0xcc, 0xee, 0x7c,
-	0xf3, 0xdd, 0xac, 0x0d, 0x2d, 0xc7, 0x77, 0xbc, 0xd9, 0x8f, 0xc8, 0x3b, 0x21, 0x0f, 0x44, 0x40,
-	0xea, 0x61, 0xec, 0xc5, 0x53, 0xc6, 0x43, 0xd7, 0x6a, 0x86, 0x5e, 0x3c, 0x61, 0xbe, 0xde, 0xb0,
-	0xee, 0x4e, 0x82, 0x60, 0xe2, 0xe1, 0x43, 0x65, 0xbd, 0x8c, 0xc7, 0x0f, 0x71, 0x1a, 0x8a, 0x59,
-	0xb2, 0xb9, 0xbb, 0xb8, 0x19, 0x09, 0x1e, 0xbb, 0x42, 0xef, 0xda, 0x3f, 0x55, 0xa0, 0xd5, 0xd3,
-	0xc7, 0x50, 0x7c, 0x1d, 0x63, 0x24, 0x08, 0x81, 0x75, 0x31, 0x0b, 0xd1, 0x34, 0xf6, 0x8c, 0xfd,
-	0x3a, 0x55, 0x6b, 0xf2, 0x39, 0x40, 0xc8, 0x83, 0x10, 0xb9, 0x60, 0x18, 0x99, 0x95, 0x3d, 0x63,
-	0xbf, 0xd1, 0xdd, 0xe9, 0xe8, 0xcc, 0x9d, 0x34, 0x73, 0x67, 0xa8, 0x32, 0xd3, 0x0c, 0x94, 0xb4,
-	0xa1, 0x1a, 0x73, 0xdf, 0xac, 0xaa, 0x5c, 0x72, 0x29, 0xd3, 0xfb, 0xce, 0x14, 0xcd, 0x75, 0x9d,
-	0x5e, 0xae, 0xc9, 0x57, 0xb0, 0x19, 0x84, 0x82, 0x05, 0x7e, 0x64, 0xde, 0x50, 0xb9, 0xed, 0xce,
-	0xbc, 0xd6, 0x4e, 0x72, 0x3d, 0x4e, 0x31, 0x0a, 0x62, 0xee, 0xe2, 0x99, 0x46, 0xd2, 0x34, 0x84,
-	0x7c, 0x03, 0xb5, 0x90, 0x07, 0x97, 0x6c, 0x84, 0xdc, 0xdc, 0x50, 0xe1, 0xf7, 0x4b, 0xc2, 0x07,
-	0x09, 0x24, 0x4d, 0x43, 0xe7, 0x41, 0xf6, 0x6f, 0xeb, 0xd0, 0x5e, 0x3c, 0xe5, 0xff, 0x47, 0x03,
-	0xb9, 0x0d, 0x1b, 0xa1, 0xc3, 0xd1, 0x17, 0xe6, 0xa6, 0xba, 0x54, 0x62, 0x11, 0x1b, 0x9a, 0x23,
-	0x0c, 0xd1, 0x1f, 0xa1, 0xef, 0xca, 0xba, 0x6b, 0x7b, 0xd5, 0xfd, 0x3a, 0xcd, 0xf9, 0x08, 0x83,
-	0x77, 0x92, 0x72, 0x67, 0xfd, 0x2c, 0xb6, 0xbe, 0x57, 0xdd, 0x6f, 0x74, 0x3f, 0x5b, 0x51, 0x47,
-	0x67, 0x50, 0x12, 0x77, 0xe4, 0x0b, 0x3e, 0xa3, 0xa5, 0x29, 0xad, 0x10, 0xee, 0x2c, 0x0d, 0x91,
-	0x44, 0x5f, 0xe0, 0x2c, 0x69, 0x9a, 0x5c, 0x92, 0x27, 0x70, 0xe3, 0xd2, 0xf1, 0x62, 0x4c, 0xda,
-	0xf5, 0x61, 0x39, 0x27, 0x85, 0x74, 0x54, 0x47, 0x7d, 0x59, 0x79, 0x6c, 0xd8, 0x7f, 0x56, 0x61,
-	0x67, 0x09, 0xfd, 0xc4, 0x84, 0x4d, 0xd9, 0x78, 0x74, 0x85, 0x3a, 0xb4, 0x46, 0x53, 0x93, 0xbc,
-	0x0f, 0x5b, 0x6c, 0xe2, 0x07, 0x1c, 0x0f, 0x5f, 0x39, 0xfe, 0x44, 0xe9, 0x45, 0xf2, 0x96, 0x77,
-	0x92, 0x4f, 0xe0, 0xd6, 0x08, 0x3d, 0x14, 0x78, 0x80, 0xe3, 0x80, 0x23, 0xc5, 0xd0, 0x73, 0x5c,
-	0x54, 0x4a, 0xa9, 0xd1, 0xb2, 0x2d, 0xf2, 0x35, 0x58, 0x25, 0xee, 0x3e, 0x8e, 0x99, 0x8f, 0x23,
-	0xa5, 0xa7, 0x1a, 0x5d, 0x81, 0x20, 0x8f, 0x61, 0xc7, 0x19, 0x8d, 0x98, 0xbc, 0xbe, 0xe3, 0x0d,
-	0xd1, 0xe5, 0x28, 0xce, 0x62, 0x11, 0xc6, 0x42, 0xaa, 0x4e, 0xde, 0x70, 0xd9, 0xb6, 0xac, 0xd5,
-	0xf1, 0x98, 0x13, 0x61, 0x64, 0x6e, 0x28, 0x64, 0x6a, 0x92, 0x73, 0x68, 0xb9, 0x71, 0x24, 0x82,
-	0xe9, 0x33, 0x36, 0xc5, 0x40, 0xa6, 0xda, 0x54, 0x6c, 0x3f, 0x7a, 0xb3, 0x80, 0x3b, 0x87, 0xb9,
-	0x40, 0xba, 0x90, 0xc8, 0x7a, 0x01, 0xad, 0x3c, 0x42, 0xea, 0xd4, 0xe5, 0xe8, 0x08, 0xfd, 0x6d,
-	0x1a, 0x34, 0xb1, 0xa4, 0x3f, 0x0e, 0x47, 0xd2, 0x5f, 0xd1, 0x7e, 0x6d, 0x49, 0xbf, 0xa6, 0x43,
-	0xb1, 0x6a, 0xd0, 0xc4, 0xb2, 0x7f, 0x31, 0xc0, 0x5c, 0xf6, 0x59, 0xfc, 0x07, 0x9f, 0xbf, 0xdd,
-	0x85, 0xdd, 0x55, 0x8a, 0x94, 0x31, 0x31, 0xf7, 0x23, 0xd3, 0x50, 0xdc, 0xab, 0xb5, 0x3d, 0x80,
-	0x5b, 0x49, 0xcc, 0x50, 0x38, 0xee, 0x45, 0x3a, 0xc3, 0xbf, 0x80, 0x3a, 0x4f, 0x2a, 0xd1, 0xf8,
-	0x46, 0xf7, 0xee, 0x8a, 0x56, 0xd0, 0x2b, 0xb4, 0xfd, 0x03, 0xdc, 0x9c, 0x3f, 0x08, 0x51, 0x18,
-	0xf8, 0x91, 0x54, 0x5c, 0x63, 0xc4, 0x9c, 0x89, 0x1f, 0x44, 0x82, 0xb9, 0x5a, 0xc7, 0x8d, 0xee,
-	0x6e, 0x31, 0x5f, 0x7f, 0x0e, 0xa2, 0xd9, 0x00, 0xfb, 0xf7, 0x0a, 0x6c, 0x17, 0x20, 0xe4, 0x1e,
-	0x40, 0x18, 0x78, 0xcc, 0x9d, 0x3d, 0x95, 0x44, 0x68, 0x9e, 0x33, 0x1e, 0xf2, 0x01, 0xb4, 0xb4,
-	0x35, 0x70, 0xdc, 0x0b, 0x85, 0xa9, 0x28, 0xcc, 0x82, 0x97, 0x7c, 0x04, 0xdb, 0x57, 0x9e, 0xe7,
-	0xc8, 0x23, 0x16, 0xa4, 0x54, 0x17, 0x37, 0xc8, 0x1e, 0x34, 0x46, 0x18, 0xb9, 0x9c, 0x29, 0xf5,
-	0x25, 0xfc, 0x67, 0x5d, 0x52, 0xe5, 0x53, 0x8c, 0x22, 0x67, 0x82, 0x6a, 0x0a, 0xd7, 0x69, 0x6a,
-	0x2a, 0x4d, 0x38, 0x93, 0x54, 0xfc, 0x6a, 0x4d, 0x8e, 0xa1, 0x8d, 0xfe, 0x38, 0xe0, 0x2e, 0x4e,
-	0xd1, 0x17, 0xa7, 0x78, 0x89, 0x9e, 0xd2, 0x7e, 0x2b, 0x47, 0xf8, 0xd1, 0x02, 0x84, 0x16, 0x82,
-	0x52, 0x8d, 0xd4, 0xe6, 0x1a, 0xb1, 0xff, 0xaa, 0x40, 0x33, 0xed, 0xd4, 0x89, 0x3f, 0x0e, 0xe6,
-	0xa2, 0x31, 0x32, 0x6f, 0x86, 0xac, 0x87, 0x45, 0xa1, 0xe7, 0xcc, 0x32, 0x14, 0x65, 0x5d, 0xe4,
-	0x11, 0xd4, 0x14, 0x0d, 0x52, 0xb3, 0x55, 0xd5, 0xba, 0x77, 0x33, 0x37, 0x1b, 0x28, 0x86, 0x64,
-	0x7a, 0x3a, 0x87, 0x49, 0x0a, 0x2e, 0x13, 0x22, 0x35, 0x41, 0xa9, 0x29, 0x9b, 0x12, 0xc5, 0x61,
-	0x18, 0x70, 0x11, 0x1d, 0x06, 0xfe, 0x98, 0x4d, 0x14, 0x47, 0x35, 0xba, 0xe0, 0x25, 0x03, 0xd8,
-	0x62, 0x3e, 0x13, 0xcc, 0xf1, 0x12, 0xd8, 0x86, 0x3a, 0xf9, 0x41, 0x89, 0x08, 0xe5, 0xd9, 0x9d,
-	0x93, 0x2c, 0x58, 0x4f, 0xff, 0x7c, 0x02, 0xeb, 0x1c, 0x48, 0x11, 0x54, 0x32, 0xef, 0x3f, 0xce,
-	0xcf, 0xfb, 0x9d, 0x42, 0xad, 0x3a, 0x3c, 0x3b, 0xdf, 0x7f, 0xae, 0x00, 0x5c, 0xf1, 0x70, 0x4d,
-	0x9a, 0x17, 0x84, 0x55, 0x5d, 0x29, 0xac, 0xf5, 0xbc, 0xb0, 0xca, 0x44, 0x74, 0xe3, 0x3a, 0x22,
-	0xea, 0x41, 0xd3, 0x55, 0xe5, 0x0d, 0xdd, 0x57, 0x38, 0x75, 0x92, 0xdf, 0x01, 0xef, 0x2d, 0xe1,
-	0x40, 0x83, 0x68, 0x2e, 0xc4, 0x66, 0x40, 0x8a, 0x98, 0x85, 0xd1, 0x67, 0xbc, 0xfd, 0xe8, 0xb3,
-	0xa0, 0xc6, 0xf1, 0x75, 0xcc, 0x38, 0x8e, 0x92, 0x07, 0x70, 0x6e, 0xdb, 0xbf, 0x1a, 0xd0, 0xcc,
-	0x9e, 0x55, 0xca, 0x83, 0x71, 0x1d, 0x1e, 0xae, 0x3b, 0xa9, 0xed, 0x3f, 0x0c, 0x30, 0xf5, 0x65,
-	0x62, 0x8e, 0x57, 0x63, 0x52, 0x4f, 0xd5, 0x73, 0x68, 0x86, 0x99, 0xeb, 0x26, 0x83, 0x35, 0xfb,
-	0xe3, 0x66, 0x59, 0x68, 0x8e, 0x76, 0x2d, 0xef, 0x5c, 0x2a, 0xeb, 0x05, 0x6c, 0x17, 0x20, 0xff,
-	0x8a, 0xb8, 0x1f, 0x3c, 0x81, 0xf6, 0x22, 0x61, 0xa4, 0x09, 0xb5, 0x5e, 0xff, 0xf9, 0xc9, 0xf0,
-	0x8c, 0x9e, 0xb7, 0xd7, 0xc8, 0x16, 0xd4, 0xbf, 0xef, 0x3d, 0xed, 0xf7, 0x9e, 0x49, 0xd3, 0x90,
-	0x9b, 0xfd, 0x93, 0x61, 0xef, 0xe0, 0xf4, 0xa8, 0xdf, 0xae, 0x74, 0xff, 0xae, 0x40, 0x2d, 0x2d,
-	0x86, 0x1c, 0xc0, 0x66, 0xb2, 0x26, 0x77, 0x8a, 0x5f, 0x72, 0x52, 0xab, 0x65, 0x95, 0x6d, 0xe9,
-	0xa7, 0xc4, 0x5e, 0x23, 0xa7, 0xf3, 0xa1, 0xa6, 0x5e, 0x2c, 0x72, 0xaf, 0x88, 0xce, 0x3e, 0x65,
-	0x6f, 0xc8, 0xd6, 0x87, 0x9b, 0xc7, 0x28, 0x72, 0x53, 0xf2, 0x76, 0xa1, 0xcf, 0x47, 0xf2, 0xef,
-	0x90, 0xb5, 0xb3, 0x64, 0xf6, 0xd8, 0x6b, 0xe4, 0x5b, 0xd8, 0x3a, 0x46, 0x31, 0x50, 0xff, 0xa9,
-	0x56, 0xe6, 0xc8, 0x4d, 0xce, 0x39, 0xdc, 0x5e, 0x23, 0xdf, 0x41, 0x7d, 0xde, 0x7b, 0x72, 0xff,
-	0x2d, 0x14, 0x61, 0x2d, 0x39, 0xc2, 0x5e, 0x7b, 0xb9, 0xa1, 0x3c, 0x9f, 0xfe, 0x13, 0x00, 0x00,
-	0xff, 0xff, 0x13, 0xd0, 0x20, 0xeb, 0x00, 0x0e, 0x00, 0x00,
+	// 1197 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x57, 0x51, 0x8f, 0xdb, 0x44,
+	0x10, 0x3e, 0x27, 0x77, 0x97, 0x64, 0x92, 0x4b, 0x73, 0x5b, 0xe8, 0xb9, 0xee, 0x51, 0x9d, 0x5c,
+	0x04, 0xa7, 0x0a, 0x52, 0x1a, 0x84, 0x28, 0x15, 0x05, 0xd2, 0xe6, 0xa8, 0x0e, 0x95, 0x5e, 0xd8,
+	0x54, 0x55, 0xef, 0xd1, 0xb5, 0x27, 0xe9, 0xea, 0x1c, 0xdb, 0x5d, 0xaf, 0x4f, 0x0a, 0x8f, 0x3c,
+	0x22, 0x21, 0xf1, 0x07, 0xf8, 0x17, 0x3c, 0xf0, 0x2f, 0x78, 0xe1, 0x37, 0xf0, 0x33, 0x10, 0xda,
+	0xb5, 0x9d, 0xd8, 0xb1, 0x93, 0x9e, 0x4e, 0x48, 0x20, 0xf1, 0xb6, 0x33, 0xfb, 0xcd, 0xec, 0xce,
+	0xb7, 0xdf, 0xee, 0xd8, 0xd0, 0xb6, 0x3c, 0xcb, 0x9d, 0x7d, 0x8f, 0xbc, 0x1b, 0x70, 0x5f, 0xf8,
+	0xa4, 0x11, 0x44, 0x6e, 0x34, 0x65, 0x3c, 0xb0, 0x8d, 0x56, 0xe0, 0x46, 0x13, 0xe6, 0xc5, 0x13,
+	0xc6, 0x8d, 0x89, 0xef, 0x4f, 0x5c, 0xbc, 0xa3, 0xac, 0x97, 0xd1, 0xf8, 0x0e, 0x4e, 0x03, 0x31,
+	0x4b, 0x26, 0xf7, 0x97, 0x27, 0x43, 0xc1, 0x23, 0x5b, 0xc4, 0xb3, 0xe6, 0x0f, 0x15, 0x68, 0xf7,
+	0xe3, 0x65, 0x28, 0xbe, 0x8e, 0x30, 0x14, 0x84, 0xc0, 0xa6, 0x98, 0x05, 0xa8, 0x6b, 0x07, 0xda,
+	0x61, 0x83, 0xaa, 0x31, 0xf9, 0x14, 0x20, 0xe0, 0x7e, 0x80, 0x5c, 0x30, 0x0c, 0xf5, 0xca, 0x81,
+	0x76, 0xd8, 0xec, 0xed, 0x75, 0xe3, 0xcc, 0xdd, 0x34, 0x73, 0x77, 0xa4, 0x32, 0xd3, 0x0c, 0x94,
+	0x74, 0xa0, 0x1a, 0x71, 0x4f, 0xaf, 0xaa, 0x5c, 0x72, 0x28, 0xd3, 0x7b, 0xd6, 0x14, 0xf5, 0xcd,
+	0x38, 0xbd, 0x1c, 0x93, 0xcf, 0xa1, 0xe6, 0x07, 0x82, 0xf9, 0x5e, 0xa8, 0x6f, 0xa9, 0xdc, 0x66,
+	0x77, 0x5e, 0x6b, 0x37, 0xd9, 0x1e, 0xa7, 0x18, 0xfa, 0x11, 0xb7, 0xf1, 0x24, 0x46, 0xd2, 0x34,
+	0x84, 0x7c, 0x09, 0xf5, 0x80, 0xfb, 0xe7, 0xcc, 0x41, 0xae, 0x6f, 0xab, 0xf0, 0x5b, 0x25, 0xe1,
+	0xc3, 0x04, 0x92, 0xa6, 0xa1, 0xf3, 0x20, 0xf3, 0x97, 0x4d, 0xe8, 0x2c, 0xaf, 0xf2, 0xff, 0xa3,
+	0x81, 0x5c, 0x83, 0xed, 0xc0, 0xe2, 0xe8, 0x09, 0xbd, 0xa6, 0x36, 0x95, 0x58, 0xc4, 0x84, 0x96,
+	0x83, 0x01, 0x7a, 0x0e, 0x7a, 0xb6, 0xac, 0xbb, 0x7e, 0x50, 0x3d, 0x6c, 0xd0, 0x9c, 0x8f, 0x30,
+	0x78, 0x2b, 0x29, 0x77, 0x36, 0xc8, 0x62, 0x1b, 0x07, 0xd5, 0xc3, 0x66, 0xef, 0x93, 0x35, 0x75,
+	0x74, 0x87, 0x25, 0x71, 0x47, 0x9e, 0xe0, 0x33, 0x5a, 0x9a, 0xd2, 0x08, 0xe0, 0xfa, 0xca, 0x10,
+	0x49, 0xf4, 0x19, 0xce, 0x92, 0x43, 0x93, 0x43, 0xf2, 0x00, 0xb6, 0xce, 0x2d, 0x37, 0xc2, 0xe4,
+	0xb8, 0xde, 0x2f, 0xe7, 0xa4, 0x90, 0x8e, 0xc6, 0x51, 0xf7, 0x2b, 0xf7, 0x34, 0xf3, 0x8f, 0x2a,
+	0xec, 0xad, 0xa0, 0x9f, 0xe8, 0x50, 0x93, 0x07, 0x8f, 0xb6, 0x50, 0x8b, 0xd6, 0x69, 0x6a, 0x92,
+	0x77, 0x61, 0x87, 0x4d, 0x3c, 0x9f, 0xe3, 0xa3, 0x57, 0x96, 0x37, 0x51, 0x7a, 0x91, 0xbc, 0xe5,
+	0x9d, 0xe4, 0x23, 0xb8, 0xea, 0xa0, 0x8b, 0x02, 0x1f, 0xe2, 0xd8, 0xe7, 0x48, 0x31, 0x70, 0x2d,
+	0x1b, 0x95, 0x52, 0xea, 0xb4, 0x6c, 0x8a, 0x7c, 0x01, 0x46, 0x89, 0x7b, 0x80, 0x63, 0xe6, 0xa1,
+	0xa3, 0xf4, 0x54, 0xa7, 0x6b, 0x10, 0xe4, 0x1e, 0xec, 0x59, 0x8e, 0xc3, 0xe4, 0xf6, 0x2d, 0x77,
+	0x84, 0x36, 0x47, 0x71, 0x12, 0x89, 0x20, 0x12, 0x52, 0x75, 0x72, 0x87, 0xab, 0xa6, 0x65, 0xad,
+	0x96, 0xcb, 0xac, 0x10, 0x43, 0x7d, 0x5b, 0x21, 0x53, 0x93, 0x9c, 0x42, 0xdb, 0x8e, 0x42, 0xe1,
+	0x4f, 0x9f, 0xb1, 0x29, 0xfa, 0x32, 0x55, 0x4d, 0xb1, 0x7d, 0xf7, 0xcd, 0x02, 0xee, 0x3e, 0xca,
+	0x05, 0xd2, 0xa5, 0x44, 0xc6, 0x0b, 0x68, 0xe7, 0x11, 0x52, 0xa7, 0x36, 0x47, 0x4b, 0xc4, 0x77,
+	0x53, 0xa3, 0x89, 0x25, 0xfd, 0x51, 0xe0, 0x58, 0x22, 0x3e, 0x6a, 0x8d, 0x26, 0x96, 0xf4, 0xc7,
+	0x74, 0x28, 0x56, 0x35, 0x9a, 0x58, 0xe6, 0x4f, 0x1a, 0xe8, 0xab, 0xae, 0xc5, 0xbf, 0x70, 0xfd,
+	0xcd, 0x1e, 0xec, 0xaf, 0x53, 0xa4, 0x8c, 0x89, 0xb8, 0x17, 0xea, 0x9a, 0xe2, 0x5e, 0x8d, 0xcd,
+	0x21, 0x5c, 0x4d, 0x62, 0x46, 0xc2, 0xb2, 0xcf, 0xd2, 0x37, 0xfc, 0x33, 0x68, 0xf0, 0xa4, 0x92,
+	0x18, 0xdf, 0xec, 0xdd, 0x58, 0x73, 0x14, 0x74, 0x81, 0x36, 0xbf, 0x83, 0x2b, 0xf3, 0x86, 0x10,
+	0x06, 0xbe, 0x17, 0x4a, 0xc5, 0x35, 0x1d, 0x66, 0x4d, 0x3c, 0x3f, 0x14, 0xcc, 0x8e, 0x75, 0xdc,
+	0xec, 0xed, 0x17, 0xf3, 0x0d, 0xe6, 0x20, 0x9a, 0x0d, 0x30, 0xff, 0xd2, 0xa0, 0x49, 0x71, 0x8a,
+	0x0e, 0xb3, 0xe4, 0x91, 0x93, 0x9b, 0x00, 0x81, 0xef, 0x32, 0x7b, 0xf6, 0xd4, 0x9a, 0xa6, 0x0c,
+	0x67, 0x3c, 0xe4, 0x3d, 0x68, 0xc7, 0xd6, 0xd0, 0xb2, 0xcf, 0x14, 0xa6, 0xa2, 0x30, 0x4b, 0x5e,
+	0xf2, 0x01, 0xec, 0x2e, 0x3c, 0xcf, 0x91, 0x87, 0xcc, 0x4f, 0x49, 0x2e, 0x4e, 0x90, 0x03, 0x68,
+	0x3a, 0x18, 0xda, 0x9c, 0x29, 0xdd, 0x25, 0xcc, 0x67, 0x5d, 0x4b, 0xe7, 0xbb, 0x75, 0xf1, 0xf3,
+	0xbd, 0x09, 0xb0, 0xa8, 0x57, 0x3d, 0xbe, 0x0d, 0x9a, 0xf1, 0x98, 0x27, 0xb0, 0x9b, 0xd6, 0xbf,
+	0x60, 0xf5, 0x3e, 0xb4, 0xf8, 0x82, 0x94, 0xf4, 0x98, 0xae, 0x65, 0x68, 0xcd, 0x70, 0x46, 0x73,
+	0x58, 0xf3, 0xd7, 0x0a, 0xec, 0x16, 0x48, 0xff, 0xcf, 0xf2, 0xaa, 0x43, 0x6d, 0x8a, 0x61, 0x68,
+	0x4d, 0x50, 0x91, 0xda, 0xa0, 0xa9, 0xa9, 0x6e, 0x99, 0x35, 0x49, 0x9f, 0x13, 0x35, 0x26, 0x8f,
+	0xa1, 0x83, 0xde, 0xd8, 0xe7, 0x36, 0x4e, 0xd1, 0x13, 0x4f, 0xf0, 0x1c, 0x5d, 0xf5, 0x9a, 0xb4,
+	0x73, 0x12, 0x3e, 0x5a, 0x82, 0xd0, 0x42, 0x50, 0x7a, 0xeb, 0xea, 0xf3, 0x5b, 0x67, 0xfe, 0x59,
+	0x81, 0x56, 0xaa, 0xfd, 0x63, 0x6f, 0xec, 0xcf, 0xaf, 0xa1, 0x96, 0xe9, 0xc2, 0xb2, 0x1e, 0x16,
+	0x06, 0xae, 0x35, 0xcb, 0x50, 0x94, 0x75, 0x91, 0xbb, 0x50, 0x57, 0x34, 0x48, 0x95, 0x54, 0xd5,
+	0xa9, 0xbd, 0x9d, 0xd9, 0xd9, 0x50, 0x31, 0x24, 0xd3, 0xd3, 0x39, 0x4c, 0x52, 0x70, 0x9e, 0x10,
+	0x19, 0x13, 0x94, 0x9a, 0xf2, 0x50, 0xc2, 0x28, 0x08, 0x7c, 0x2e, 0xc2, 0x47, 0xbe, 0x37, 0x66,
+	0x13, 0xc5, 0x51, 0x9d, 0x2e, 0x79, 0xc9, 0x10, 0x76, 0x98, 0xc7, 0x04, 0xb3, 0xdc, 0x04, 0xb6,
+	0xad, 0x56, 0xbe, 0x5d, 0x72, 0xad, 0xe5, 0xda, 0xdd, 0xe3, 0x2c, 0x38, 0xee, 0xa7, 0xf9, 0x04,
+	0xc6, 0x29, 0x90, 0x22, 0xa8, 0xa4, 0x83, 0x7e, 0x98, 0xef, 0xa0, 0x7b, 0x85, 0x5a, 0xe3, 0xf0,
+	0x6c, 0xc7, 0xfc, 0xb1, 0x02, 0xb0, 0xe0, 0xe1, 0x92, 0x34, 0x2f, 0x09, 0xab, 0xba, 0x56, 0x58,
+	0x9b, 0x79, 0x61, 0x95, 0x89, 0x68, 0xeb, 0x32, 0x22, 0xea, 0x43, 0xcb, 0x56, 0xe5, 0x8d, 0xec,
+	0x57, 0x38, 0xb5, 0x92, 0x2f, 0xab, 0x77, 0x56, 0x70, 0x10, 0x83, 0x68, 0x2e, 0xc4, 0x64, 0x40,
+	0x8a, 0x98, 0xa5, 0xc7, 0x46, 0xbb, 0xf8, 0x63, 0x63, 0x40, 0x9d, 0xe3, 0xeb, 0x88, 0x71, 0x74,
+	0x92, 0x4f, 0x8a, 0xb9, 0x6d, 0xfe, 0xac, 0x41, 0x2b, 0xbb, 0x56, 0x29, 0x0f, 0xda, 0x65, 0x78,
+	0xb8, 0x6c, 0xef, 0x33, 0x7f, 0xd7, 0x40, 0x8f, 0x37, 0x13, 0x71, 0x5c, 0x34, 0x9e, 0xb8, 0x4f,
+	0x9d, 0x42, 0x2b, 0xc8, 0x6c, 0x57, 0xd7, 0x0a, 0x9f, 0x8b, 0xab, 0x42, 0x73, 0xb4, 0xc7, 0xf2,
+	0xce, 0xa5, 0x32, 0x5e, 0xc0, 0x6e, 0x01, 0xf2, 0x8f, 0x88, 0xfb, 0xf6, 0x03, 0xe8, 0x2c, 0x13,
+	0x46, 0x5a, 0x50, 0xef, 0x0f, 0x9e, 0x1f, 0x8f, 0x4e, 0xe8, 0x69, 0x67, 0x83, 0xec, 0x40, 0xe3,
+	0xdb, 0xfe, 0xd3, 0x41, 0xff, 0x99, 0x34, 0x35, 0x39, 0x39, 0x38, 0x1e, 0xf5, 0x1f, 0x3e, 0x39,
+	0x1a, 0x74, 0x2a, 0xbd, 0xdf, 0xaa, 0x50, 0x4f, 0x8b, 0x21, 0x0f, 0xa1, 0x96, 0x8c, 0xc9, 0xf5,
+	0xe2, 0x4d, 0x4e, 0x6a, 0x35, 0x8c, 0xb2, 0xa9, 0xb8, 0x8d, 0x98, 0x1b, 0xe4, 0x09, 0xb4, 0xb2,
+	0xdf, 0x00, 0xe4, 0x66, 0x11, 0x9d, 0xfd, 0x38, 0x78, 0x43, 0xb6, 0xaf, 0xa1, 0x31, 0xef, 0x55,
+	0xeb, 0xf6, 0xb4, 0x5f, 0xd2, 0xa8, 0xb2, 0x79, 0x06, 0x70, 0xe5, 0x31, 0x8a, 0xdc, 0x6b, 0x7b,
+	0xad, 0xa0, 0x97, 0x23, 0xf9, 0xa3, 0x6a, 0xec, 0xad, 0x78, 0xc3, 0xcc, 0x0d, 0xf2, 0x15, 0xec,
+	0x3c, 0x46, 0x31, 0x54, 0x7f, 0xbb, 0x6b, 0x73, 0xe4, 0x5e, 0xe0, 0x39, 0xdc, 0xdc, 0x20, 0xdf,
+	0x40, 0x63, 0xae, 0x21, 0x72, 0xeb, 0x02, 0xca, 0x32, 0x56, 0x2c, 0x61, 0x6e, 0xbc, 0xdc, 0x56,
+	0x9e, 0x8f, 0xff, 0x1e, 0x00, 0x78, 0xaf, 0x8d, 0x79, 0x9a, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1076,6 +1204,10 @@ type AnalyzerClient interface {
 	// preview or update. The provided resources are the "outputs", after any mutations
 	// have taken place.
 	AnalyzeStack(ctx context.Context, in *AnalyzeStackRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error)
+	// Remediate optionally transforms a single resource object. This effectively rewrites a single resource object's
+	// properties instead of using what was generated by the program. Called with the "inputs" to the resource, after
+	// they have been checked by the provider and before the resource is diffed.
+	Remediate(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*RemediateResponse, error)
 	// GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
 	GetAnalyzerInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*AnalyzerInfo, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
@@ -1110,6 +1242,15 @@ func (c *analyzerClient) AnalyzeStack(ctx context.Context, in *AnalyzeStackReque
 	return out, nil
 }
 
+func (c *analyzerClient) Remediate(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*RemediateResponse, error) {
+	out := new(RemediateResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.Analyzer/Remediate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *analyzerClient) GetAnalyzerInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*AnalyzerInfo, error) {
 	out := new(AnalyzerInfo)
 	err := c.cc.Invoke(ctx, "/pulumirpc.Analyzer/GetAnalyzerInfo", in, out, opts...)
@@ -1146,6 +1287,10 @@ type AnalyzerServer interface {
 	// preview or update. The provided resources are the "outputs", after any mutations
 	// have taken place.
 	AnalyzeStack(context.Context, *AnalyzeStackRequest) (*AnalyzeResponse, error)
+	// Remediate optionally transforms a single resource object. This effectively rewrites a single resource object's
+	// properties instead of using what was generated by the program. Called with the "inputs" to the resource, after
+	// they have been checked by the provider and before the resource is diffed.
+	Remediate(context.Context, *AnalyzeRequest) (*RemediateResponse, error)
 	// GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
 	GetAnalyzerInfo(context.Context, *empty.Empty) (*AnalyzerInfo, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
@@ -1164,6 +1309,9 @@ func (*UnimplementedAnalyzerServer) Analyze(ctx context.Context, req *AnalyzeReq
 func (*UnimplementedAnalyzerServer) AnalyzeStack(ctx context.Context, req *AnalyzeStackRequest) (*AnalyzeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeStack not implemented")
 }
+func (*UnimplementedAnalyzerServer) Remediate(ctx context.Context, req *AnalyzeRequest) (*RemediateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remediate not implemented")
+}
 func (*UnimplementedAnalyzerServer) GetAnalyzerInfo(ctx context.Context, req *empty.Empty) (*AnalyzerInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAnalyzerInfo not implemented")
 }
@@ -1214,6 +1362,24 @@ func _Analyzer_AnalyzeStack_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analyzer_Remediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Remediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.Analyzer/Remediate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Remediate(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Analyzer_GetAnalyzerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -1280,6 +1446,10 @@ var _Analyzer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AnalyzeStack",
 			Handler:    _Analyzer_AnalyzeStack_Handler,
 		},
+		{
+			MethodName: "Remediate",
+			Handler:    _Analyzer_Remediate_Handler,
+		},
 		{
 			MethodName: "GetAnalyzerInfo",
 			Handler:    _Analyzer_GetAnalyzerInfo_Handler,
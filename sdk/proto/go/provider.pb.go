@@ -1581,6 +1581,260 @@ func (m *ConstructResponse_PropertyDependencies) GetUrns() []string {
 	return nil
 }
 
+type CallRequest struct {
+	Tok                  string                                       `protobuf:"bytes,1,opt,name=tok,proto3" json:"tok,omitempty"`
+	Args                 *_struct.Struct                              `protobuf:"bytes,2,opt,name=args,proto3" json:"args,omitempty"`
+	ArgDependencies      map[string]*CallRequest_ArgumentDependencies `protobuf:"bytes,3,rep,name=argDependencies,proto3" json:"argDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Provider             string                                       `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	Version              string                                       `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Project              string                                       `protobuf:"bytes,6,opt,name=project,proto3" json:"project,omitempty"`
+	Stack                string                                       `protobuf:"bytes,7,opt,name=stack,proto3" json:"stack,omitempty"`
+	Config               map[string]string                            `protobuf:"bytes,8,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DryRun               bool                                         `protobuf:"varint,9,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Parallel             int32                                        `protobuf:"varint,10,opt,name=parallel,proto3" json:"parallel,omitempty"`
+	MonitorEndpoint      string                                       `protobuf:"bytes,11,opt,name=monitorEndpoint,proto3" json:"monitorEndpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
+}
+
+func (m *CallRequest) Reset()         { *m = CallRequest{} }
+func (m *CallRequest) String() string { return proto.CompactTextString(m) }
+func (*CallRequest) ProtoMessage()    {}
+func (*CallRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{22}
+}
+
+func (m *CallRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallRequest.Unmarshal(m, b)
+}
+func (m *CallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallRequest.Marshal(b, m, deterministic)
+}
+func (m *CallRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallRequest.Merge(m, src)
+}
+func (m *CallRequest) XXX_Size() int {
+	return xxx_messageInfo_CallRequest.Size(m)
+}
+func (m *CallRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallRequest proto.InternalMessageInfo
+
+func (m *CallRequest) GetTok() string {
+	if m != nil {
+		return m.Tok
+	}
+	return ""
+}
+
+func (m *CallRequest) GetArgs() *_struct.Struct {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *CallRequest) GetArgDependencies() map[string]*CallRequest_ArgumentDependencies {
+	if m != nil {
+		return m.ArgDependencies
+	}
+	return nil
+}
+
+func (m *CallRequest) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+func (m *CallRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CallRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *CallRequest) GetStack() string {
+	if m != nil {
+		return m.Stack
+	}
+	return ""
+}
+
+func (m *CallRequest) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *CallRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *CallRequest) GetParallel() int32 {
+	if m != nil {
+		return m.Parallel
+	}
+	return 0
+}
+
+func (m *CallRequest) GetMonitorEndpoint() string {
+	if m != nil {
+		return m.MonitorEndpoint
+	}
+	return ""
+}
+
+// ArgumentDependencies describes the resources that a particular argument depends on.
+type CallRequest_ArgumentDependencies struct {
+	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallRequest_ArgumentDependencies) Reset()         { *m = CallRequest_ArgumentDependencies{} }
+func (m *CallRequest_ArgumentDependencies) String() string { return proto.CompactTextString(m) }
+func (*CallRequest_ArgumentDependencies) ProtoMessage()    {}
+func (*CallRequest_ArgumentDependencies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{22, 0}
+}
+
+func (m *CallRequest_ArgumentDependencies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallRequest_ArgumentDependencies.Unmarshal(m, b)
+}
+func (m *CallRequest_ArgumentDependencies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallRequest_ArgumentDependencies.Marshal(b, m, deterministic)
+}
+func (m *CallRequest_ArgumentDependencies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallRequest_ArgumentDependencies.Merge(m, src)
+}
+func (m *CallRequest_ArgumentDependencies) XXX_Size() int {
+	return xxx_messageInfo_CallRequest_ArgumentDependencies.Size(m)
+}
+func (m *CallRequest_ArgumentDependencies) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallRequest_ArgumentDependencies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallRequest_ArgumentDependencies proto.InternalMessageInfo
+
+func (m *CallRequest_ArgumentDependencies) GetUrns() []string {
+	if m != nil {
+		return m.Urns
+	}
+	return nil
+}
+
+type CallResponse struct {
+	Return               *_struct.Struct                             `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	ReturnDependencies   map[string]*CallResponse_ReturnDependencies `protobuf:"bytes,2,rep,name=returnDependencies,proto3" json:"returnDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Failures             []*CheckFailure                             `protobuf:"bytes,3,rep,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *CallResponse) Reset()         { *m = CallResponse{} }
+func (m *CallResponse) String() string { return proto.CompactTextString(m) }
+func (*CallResponse) ProtoMessage()    {}
+func (*CallResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{23}
+}
+
+func (m *CallResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallResponse.Unmarshal(m, b)
+}
+func (m *CallResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallResponse.Marshal(b, m, deterministic)
+}
+func (m *CallResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallResponse.Merge(m, src)
+}
+func (m *CallResponse) XXX_Size() int {
+	return xxx_messageInfo_CallResponse.Size(m)
+}
+func (m *CallResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallResponse proto.InternalMessageInfo
+
+func (m *CallResponse) GetReturn() *_struct.Struct {
+	if m != nil {
+		return m.Return
+	}
+	return nil
+}
+
+func (m *CallResponse) GetReturnDependencies() map[string]*CallResponse_ReturnDependencies {
+	if m != nil {
+		return m.ReturnDependencies
+	}
+	return nil
+}
+
+func (m *CallResponse) GetFailures() []*CheckFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
+// ReturnDependencies describes the resources that a particular return value depends on.
+type CallResponse_ReturnDependencies struct {
+	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallResponse_ReturnDependencies) Reset()         { *m = CallResponse_ReturnDependencies{} }
+func (m *CallResponse_ReturnDependencies) String() string { return proto.CompactTextString(m) }
+func (*CallResponse_ReturnDependencies) ProtoMessage()    {}
+func (*CallResponse_ReturnDependencies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{23, 0}
+}
+
+func (m *CallResponse_ReturnDependencies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallResponse_ReturnDependencies.Unmarshal(m, b)
+}
+func (m *CallResponse_ReturnDependencies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallResponse_ReturnDependencies.Marshal(b, m, deterministic)
+}
+func (m *CallResponse_ReturnDependencies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallResponse_ReturnDependencies.Merge(m, src)
+}
+func (m *CallResponse_ReturnDependencies) XXX_Size() int {
+	return xxx_messageInfo_CallResponse_ReturnDependencies.Size(m)
+}
+func (m *CallResponse_ReturnDependencies) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallResponse_ReturnDependencies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallResponse_ReturnDependencies proto.InternalMessageInfo
+
+func (m *CallResponse_ReturnDependencies) GetUrns() []string {
+	if m != nil {
+		return m.Urns
+	}
+	return nil
+}
+
 // ErrorResourceInitFailed is sent as a Detail `ResourceProvider.{Create, Update}` fail because a
 // resource was created successfully, but failed to initialize.
 type ErrorResourceInitFailed struct {
@@ -1597,7 +1851,7 @@ func (m *ErrorResourceInitFailed) Reset()         { *m = ErrorResourceInitFailed
 func (m *ErrorResourceInitFailed) String() string { return proto.CompactTextString(m) }
 func (*ErrorResourceInitFailed) ProtoMessage()    {}
 func (*ErrorResourceInitFailed) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6a9f3c02af3d1c8, []int{22}
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{24}
 }
 
 func (m *ErrorResourceInitFailed) XXX_Unmarshal(b []byte) error {
@@ -1680,118 +1934,138 @@ func init() {
 	proto.RegisterType((*ConstructResponse)(nil), "pulumirpc.ConstructResponse")
 	proto.RegisterMapType((map[string]*ConstructResponse_PropertyDependencies)(nil), "pulumirpc.ConstructResponse.StateDependenciesEntry")
 	proto.RegisterType((*ConstructResponse_PropertyDependencies)(nil), "pulumirpc.ConstructResponse.PropertyDependencies")
+	proto.RegisterType((*CallRequest)(nil), "pulumirpc.CallRequest")
+	proto.RegisterMapType((map[string]*CallRequest_ArgumentDependencies)(nil), "pulumirpc.CallRequest.ArgDependenciesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "pulumirpc.CallRequest.ConfigEntry")
+	proto.RegisterType((*CallRequest_ArgumentDependencies)(nil), "pulumirpc.CallRequest.ArgumentDependencies")
+	proto.RegisterType((*CallResponse)(nil), "pulumirpc.CallResponse")
+	proto.RegisterMapType((map[string]*CallResponse_ReturnDependencies)(nil), "pulumirpc.CallResponse.ReturnDependenciesEntry")
+	proto.RegisterType((*CallResponse_ReturnDependencies)(nil), "pulumirpc.CallResponse.ReturnDependencies")
 	proto.RegisterType((*ErrorResourceInitFailed)(nil), "pulumirpc.ErrorResourceInitFailed")
 }
 
-func init() { proto.RegisterFile("provider.proto", fileDescriptor_c6a9f3c02af3d1c8) }
+func init() {
+	proto.RegisterFile("provider.proto", fileDescriptor_c6a9f3c02af3d1c8)
+}
 
 var fileDescriptor_c6a9f3c02af3d1c8 = []byte{
-	// 1670 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcd, 0x72, 0x1b, 0x45,
-	0x10, 0xf6, 0x4a, 0xb2, 0x6c, 0xb5, 0x7e, 0x22, 0x0f, 0xc1, 0x51, 0x14, 0x1f, 0x5c, 0x0b, 0x55,
-	0x98, 0x84, 0xc8, 0xc6, 0x39, 0x40, 0x52, 0x4e, 0x05, 0xdb, 0x92, 0x8d, 0x2b, 0x89, 0x63, 0xd6,
-	0x09, 0x3f, 0xa7, 0x64, 0xb3, 0x1a, 0xc9, 0x8b, 0xa5, 0xdd, 0x65, 0x76, 0x56, 0x29, 0x73, 0xe6,
-	0xc0, 0x05, 0xae, 0x14, 0x0f, 0x01, 0x54, 0xf1, 0x04, 0x3c, 0x08, 0x1c, 0x79, 0x00, 0x8a, 0x17,
-	0xa0, 0xe6, 0x6f, 0x3d, 0x23, 0xad, 0x7f, 0x49, 0xc1, 0x6d, 0x7b, 0xba, 0xa7, 0xa7, 0xfb, 0x9b,
-	0x9e, 0xfe, 0x59, 0xa8, 0x45, 0x24, 0x1c, 0xf9, 0x5d, 0x4c, 0x5a, 0x11, 0x09, 0x69, 0x88, 0x4a,
-	0x51, 0x32, 0x48, 0x86, 0x3e, 0x89, 0xbc, 0x66, 0x25, 0x1a, 0x24, 0x7d, 0x3f, 0x10, 0x8c, 0xe6,
-	0x8d, 0x7e, 0x18, 0xf6, 0x07, 0x78, 0x99, 0x53, 0x2f, 0x93, 0xde, 0x32, 0x1e, 0x46, 0xf4, 0x48,
-	0x32, 0x17, 0xc6, 0x99, 0x31, 0x25, 0x89, 0x47, 0x05, 0xd7, 0x7e, 0x0f, 0xea, 0xdb, 0x98, 0xee,
-	0x7b, 0x07, 0x78, 0xe8, 0x3a, 0xf8, 0xab, 0x04, 0xc7, 0x14, 0x35, 0x60, 0x66, 0x84, 0x49, 0xec,
-	0x87, 0x41, 0xc3, 0x5a, 0xb4, 0x96, 0xa6, 0x1d, 0x45, 0xda, 0xb7, 0x60, 0x4e, 0x93, 0x8e, 0xa3,
-	0x30, 0x88, 0x31, 0x9a, 0x87, 0x62, 0xcc, 0x57, 0xb8, 0x74, 0xc9, 0x91, 0x94, 0xfd, 0x43, 0x0e,
-	0xea, 0x9b, 0x61, 0xd0, 0xf3, 0xfb, 0x09, 0xc1, 0x4a, 0xf7, 0xc7, 0x50, 0x1a, 0xb9, 0xc4, 0x77,
-	0x5f, 0x0e, 0x70, 0xdc, 0xb0, 0x16, 0xf3, 0x4b, 0xe5, 0xd5, 0x9b, 0xad, 0xd4, 0xaf, 0xd6, 0xb8,
-	0x7c, 0xeb, 0x53, 0x25, 0xdc, 0x09, 0x28, 0x39, 0x72, 0x8e, 0x37, 0xa3, 0x5b, 0x50, 0x70, 0x49,
-	0x3f, 0x6e, 0xe4, 0x16, 0xad, 0xa5, 0xf2, 0xea, 0xb5, 0x96, 0x70, 0xb3, 0xa5, 0xdc, 0x6c, 0xed,
-	0x73, 0x37, 0x1d, 0x2e, 0x84, 0xde, 0x86, 0xaa, 0xeb, 0x79, 0x38, 0xa2, 0xfb, 0xd8, 0x23, 0x98,
-	0xc6, 0x8d, 0xfc, 0xa2, 0xb5, 0x34, 0xeb, 0x98, 0x8b, 0x68, 0x09, 0xae, 0x88, 0x05, 0x07, 0xc7,
-	0x61, 0x42, 0x3c, 0x1c, 0x37, 0x0a, 0x5c, 0x6e, 0x7c, 0xb9, 0xb9, 0x06, 0x35, 0xd3, 0x32, 0x54,
-	0x87, 0xfc, 0x21, 0x3e, 0x92, 0x10, 0xb0, 0x4f, 0x74, 0x15, 0xa6, 0x47, 0xee, 0x20, 0xc1, 0xdc,
-	0xc2, 0x92, 0x23, 0x88, 0x7b, 0xb9, 0x0f, 0x2d, 0xfb, 0x3b, 0x0b, 0xe6, 0x34, 0x4f, 0x25, 0x8e,
-	0x13, 0x36, 0x5a, 0x27, 0xd8, 0x18, 0x27, 0x51, 0x14, 0x12, 0x1a, 0xef, 0x11, 0x3c, 0xf2, 0xf1,
-	0x2b, 0xae, 0x7f, 0xd6, 0x19, 0x5f, 0xce, 0xf2, 0x26, 0x9f, 0xe9, 0x8d, 0xfd, 0xab, 0x05, 0xd7,
-	0x53, 0x7b, 0x3a, 0x84, 0x84, 0xe4, 0xb1, 0x1f, 0xc7, 0x7e, 0xd0, 0x7f, 0x88, 0x8f, 0x62, 0xf4,
-	0x09, 0x94, 0x87, 0xc7, 0xa4, 0xbc, 0xb4, 0xe5, 0xac, 0x4b, 0x1b, 0xdf, 0xda, 0x3a, 0xfe, 0x76,
-	0x74, 0x1d, 0xcd, 0x0d, 0x80, 0x63, 0x16, 0x42, 0x50, 0x08, 0xdc, 0x21, 0x96, 0xd8, 0xf1, 0x6f,
-	0xb4, 0x08, 0xe5, 0x2e, 0x8e, 0x3d, 0xe2, 0x47, 0x94, 0xc5, 0xa1, 0x80, 0x50, 0x5f, 0xb2, 0x7f,
-	0xb6, 0xa0, 0xba, 0x13, 0x8c, 0xc2, 0xc3, 0x34, 0xb6, 0xea, 0x90, 0xa7, 0xe1, 0xa1, 0xba, 0x02,
-	0x1a, 0x1e, 0x5e, 0x2c, 0x46, 0x9a, 0x30, 0xab, 0x1e, 0x1c, 0x07, 0xaa, 0xe4, 0xa4, 0xb4, 0xfe,
-	0x24, 0x0a, 0x9c, 0xa5, 0xc8, 0x2c, 0x94, 0xa7, 0xb3, 0x51, 0x1e, 0x41, 0x4d, 0xd9, 0x2b, 0x6f,
-	0x7c, 0x19, 0x8a, 0x04, 0xd3, 0x84, 0x88, 0x77, 0x76, 0x8a, 0x81, 0x52, 0x0c, 0xdd, 0x81, 0xd9,
-	0x9e, 0xeb, 0x0f, 0x12, 0x82, 0x99, 0x4f, 0x79, 0xbe, 0x45, 0xbb, 0x87, 0x03, 0xec, 0x1d, 0x6e,
-	0x09, 0xbe, 0x93, 0x0a, 0xda, 0x5f, 0x43, 0x85, 0x73, 0x34, 0x98, 0xd4, 0x91, 0x25, 0x87, 0x7d,
-	0x32, 0x98, 0xc2, 0x41, 0xf7, 0x6c, 0x98, 0x98, 0x10, 0x13, 0x0e, 0xf0, 0x2b, 0x11, 0x4b, 0xa7,
-	0x09, 0x33, 0x21, 0x3b, 0x81, 0xaa, 0x3c, 0xfb, 0xd8, 0x65, 0x3f, 0x88, 0x12, 0x19, 0xdd, 0xa7,
-	0xb9, 0x2c, 0xc4, 0x2e, 0xe7, 0xf2, 0x86, 0x74, 0x59, 0x72, 0xe4, 0xd5, 0x46, 0x98, 0x50, 0xf5,
-	0x42, 0x53, 0x9a, 0xa5, 0x2f, 0x82, 0xdd, 0x38, 0x0d, 0x32, 0x49, 0xd9, 0xbf, 0x58, 0x50, 0x6e,
-	0xfb, 0xbd, 0x9e, 0x82, 0xad, 0x06, 0x39, 0xbf, 0x2b, 0x77, 0xe7, 0xfc, 0xae, 0x82, 0x31, 0x37,
-	0x09, 0x63, 0xfe, 0x22, 0x30, 0x16, 0xce, 0x01, 0x23, 0x4b, 0x0d, 0x7e, 0x3f, 0x08, 0x09, 0xde,
-	0x3c, 0x70, 0x83, 0x3e, 0x0f, 0xb1, 0xfc, 0x52, 0xc9, 0x31, 0x17, 0xed, 0xdf, 0x2c, 0xa8, 0xec,
-	0x49, 0xb7, 0x98, 0xe5, 0x68, 0x05, 0x0a, 0x87, 0x7e, 0x20, 0x8c, 0xae, 0xad, 0x2e, 0x68, 0xb8,
-	0xe9, 0x62, 0xad, 0x87, 0x7e, 0xd0, 0x75, 0xb8, 0x24, 0x5a, 0x80, 0x12, 0xc7, 0x9d, 0xad, 0xcb,
-	0xbc, 0x72, 0xbc, 0x60, 0xbf, 0x80, 0x02, 0x93, 0x45, 0x33, 0x90, 0x5f, 0x6f, 0xb7, 0xeb, 0x53,
-	0xe8, 0x0a, 0x94, 0xd7, 0xdb, 0xed, 0xe7, 0x4e, 0x67, 0xef, 0xd1, 0xfa, 0x66, 0xa7, 0x6e, 0x21,
-	0x80, 0x62, 0xbb, 0xf3, 0xa8, 0xf3, 0xb4, 0x53, 0xcf, 0x21, 0x04, 0x35, 0xf1, 0x9d, 0xf2, 0xf3,
-	0x8c, 0xff, 0x6c, 0xaf, 0xbd, 0xfe, 0xb4, 0x53, 0x2f, 0x30, 0xbe, 0xf8, 0x4e, 0xf9, 0xd3, 0xf6,
-	0x1f, 0x79, 0xa8, 0x08, 0xd0, 0x65, 0xbc, 0x34, 0x61, 0x96, 0xe0, 0x68, 0xe0, 0x7a, 0xb2, 0x5c,
-	0x94, 0x9c, 0x94, 0x66, 0x8f, 0x32, 0xa6, 0xa2, 0x92, 0xe4, 0x38, 0x4b, 0x91, 0x68, 0x05, 0xde,
-	0xe8, 0xe2, 0x01, 0xa6, 0x78, 0x03, 0xf7, 0x42, 0x96, 0x62, 0xf9, 0x0e, 0x99, 0xfe, 0xb2, 0x58,
-	0xe8, 0x3e, 0xcc, 0x78, 0x12, 0xdb, 0x02, 0x47, 0xeb, 0x2d, 0x0d, 0x2d, 0xdd, 0x22, 0x4e, 0x48,
-	0xc4, 0x1d, 0xb5, 0x87, 0xe5, 0xfa, 0xae, 0xdf, 0xeb, 0xa9, 0x8b, 0x11, 0x04, 0x7a, 0x0c, 0x95,
-	0x2e, 0xa6, 0xae, 0x3f, 0xc0, 0x5d, 0x0e, 0x68, 0x91, 0xc7, 0xef, 0xbb, 0x27, 0x6a, 0xd6, 0x64,
-	0x45, 0xb9, 0x33, 0xb6, 0xb3, 0x54, 0x73, 0xe0, 0xc6, 0xba, 0x54, 0x63, 0x46, 0xa4, 0x9a, 0xb1,
-	0xe5, 0xe6, 0xe7, 0x30, 0x37, 0xa1, 0x2c, 0xa3, 0x42, 0xdd, 0xd6, 0x2b, 0x94, 0xf9, 0xb0, 0xf4,
-	0x00, 0xd1, 0x4b, 0xd7, 0x7d, 0xf1, 0x28, 0x24, 0x00, 0xa8, 0x0e, 0x95, 0xf6, 0xce, 0xd6, 0xd6,
-	0xf3, 0x67, 0xbb, 0x0f, 0x77, 0x9f, 0x7c, 0xb6, 0x5b, 0x9f, 0x42, 0x55, 0x28, 0xf1, 0x95, 0xdd,
-	0x27, 0xbb, 0x2c, 0x20, 0x14, 0xb9, 0xff, 0xe4, 0x71, 0xa7, 0x9e, 0xb3, 0xbf, 0xb7, 0xa0, 0xba,
-	0x49, 0xb0, 0x4b, 0xf1, 0xc9, 0xd9, 0xe8, 0x03, 0x00, 0xf9, 0x38, 0x7d, 0x7c, 0x66, 0x4e, 0xd2,
-	0x44, 0x59, 0x3c, 0x50, 0x7f, 0x88, 0xc3, 0x84, 0xf2, 0x9b, 0xb6, 0x1c, 0x45, 0x32, 0x4e, 0x24,
-	0x8b, 0xa5, 0x28, 0xe8, 0x8a, 0xb4, 0xbf, 0x80, 0x9a, 0xb2, 0x47, 0x46, 0xdc, 0xf8, 0x3b, 0xbf,
-	0xac, 0x39, 0xf6, 0x8f, 0x16, 0x94, 0x1d, 0xec, 0x76, 0xcf, 0x9f, 0x40, 0xcc, 0xa3, 0xf2, 0xe7,
-	0xf7, 0xfc, 0x38, 0xab, 0x16, 0xce, 0x95, 0x55, 0xed, 0x6f, 0x2d, 0xa8, 0x08, 0xdb, 0x5e, 0xb3,
-	0xd7, 0x9a, 0x29, 0xf9, 0xf3, 0x99, 0xf2, 0xa7, 0x05, 0xd5, 0x67, 0x51, 0x57, 0x0b, 0x89, 0xff,
-	0x33, 0xd3, 0x6a, 0x31, 0x34, 0x6d, 0xc6, 0xd0, 0x44, 0x0e, 0x2e, 0x66, 0xe4, 0x60, 0x3d, 0xd2,
-	0x66, 0xcc, 0x48, 0xdb, 0x81, 0x9a, 0x72, 0x53, 0x62, 0x6e, 0x62, 0x6c, 0x9d, 0x3f, 0xb2, 0xbe,
-	0xb1, 0xa0, 0xda, 0xe6, 0x49, 0xec, 0x3f, 0x88, 0x2d, 0x0d, 0x91, 0x82, 0x81, 0x88, 0xfd, 0x77,
-	0x91, 0x37, 0xf8, 0x62, 0x9e, 0xd0, 0x86, 0x87, 0x88, 0x84, 0x5f, 0x62, 0x8f, 0x4a, 0x73, 0x14,
-	0xc9, 0x72, 0x64, 0x4c, 0x5d, 0xef, 0x50, 0xf5, 0xc3, 0x9c, 0x40, 0x0f, 0xa0, 0xe8, 0xf1, 0xfe,
-	0xb1, 0x91, 0xe7, 0xd9, 0xf1, 0x1d, 0xb3, 0xb1, 0x34, 0x94, 0xcb, 0x4e, 0x53, 0xe4, 0x46, 0xb9,
-	0x8d, 0xd5, 0xef, 0x2e, 0x39, 0x72, 0x92, 0x40, 0x3e, 0x6d, 0x49, 0xf1, 0x9a, 0xef, 0x12, 0x77,
-	0x30, 0xc0, 0x03, 0x7e, 0x95, 0xd3, 0x4e, 0x4a, 0xb3, 0x4c, 0x3a, 0x0c, 0x03, 0x9f, 0x86, 0xa4,
-	0x13, 0x74, 0xa3, 0xd0, 0x0f, 0x68, 0xa3, 0xc8, 0x8d, 0x1a, 0x5f, 0x66, 0xbd, 0x29, 0x3d, 0x8a,
-	0x30, 0xbf, 0xcc, 0x92, 0xc3, 0xbf, 0xd3, 0x7e, 0x75, 0x56, 0xeb, 0x57, 0xe7, 0xa1, 0x18, 0xb9,
-	0x04, 0x07, 0xb4, 0x51, 0x12, 0x5d, 0x84, 0xa0, 0xb4, 0xe7, 0x00, 0xe7, 0xeb, 0x77, 0x5e, 0xc0,
-	0x9c, 0x28, 0xb8, 0x38, 0xc2, 0x41, 0x17, 0x07, 0x1e, 0xbb, 0xae, 0x32, 0x87, 0x66, 0xf5, 0x34,
-	0x68, 0x76, 0xc6, 0x37, 0x09, 0x94, 0x26, 0x95, 0xc9, 0x1b, 0xa2, 0xec, 0x86, 0x2a, 0x2a, 0x44,
-	0x39, 0xc9, 0x86, 0x33, 0xd5, 0xf1, 0xc6, 0x8d, 0x6a, 0xd6, 0x70, 0x66, 0x9e, 0xb9, 0xa7, 0x84,
-	0xe5, 0x70, 0x96, 0x6e, 0x66, 0x67, 0xb8, 0x03, 0xdf, 0x8d, 0x71, 0xdc, 0xa8, 0x89, 0xd2, 0x2c,
-	0x49, 0x64, 0xb3, 0x9a, 0xa8, 0xb9, 0x76, 0x85, 0xb3, 0x8d, 0xb5, 0xe6, 0x4d, 0xb8, 0x9a, 0xd6,
-	0x1f, 0xdd, 0x72, 0x04, 0x85, 0x84, 0x04, 0xaa, 0x11, 0xe0, 0xdf, 0xcd, 0xbb, 0x50, 0xd6, 0xa2,
-	0xe2, 0x22, 0x63, 0x58, 0x73, 0x04, 0xf3, 0xd9, 0xa8, 0x65, 0x68, 0xd9, 0x32, 0x4b, 0xe5, 0xca,
-	0x19, 0xb0, 0x4c, 0xd8, 0xae, 0x9f, 0xbb, 0x06, 0x35, 0x13, 0xb9, 0x0b, 0x0d, 0x8f, 0xbf, 0xe7,
-	0xf8, 0xf0, 0xa8, 0x8e, 0x94, 0xb9, 0x64, 0xb2, 0x8c, 0xde, 0xe6, 0xcf, 0x8d, 0xe2, 0xb3, 0x92,
-	0xb7, 0x90, 0x42, 0x2e, 0xcc, 0xf1, 0x0f, 0x23, 0xee, 0xc4, 0x93, 0xbc, 0x93, 0xed, 0xac, 0xec,
-	0x5a, 0xf6, 0xc7, 0x77, 0xc9, 0xc0, 0x9b, 0xd0, 0x76, 0xa1, 0x6b, 0x7d, 0x05, 0xf3, 0xd9, 0x8a,
-	0x33, 0xb0, 0xda, 0x36, 0xef, 0xe6, 0xfd, 0x53, 0xcd, 0x3d, 0xe3, 0x72, 0xec, 0x9f, 0x2c, 0xb8,
-	0xc6, 0xe7, 0x58, 0x35, 0xb8, 0xed, 0x04, 0x3e, 0xdd, 0xe2, 0xad, 0xd4, 0xeb, 0x2b, 0x92, 0x0d,
-	0x98, 0x11, 0x53, 0x86, 0x80, 0xb8, 0xe4, 0x28, 0xf2, 0xc2, 0x95, 0x7c, 0xf5, 0xaf, 0x19, 0xa8,
-	0x2b, 0x53, 0x55, 0x54, 0xb1, 0x87, 0x9c, 0xfe, 0xa7, 0x41, 0x37, 0x34, 0x3c, 0xc6, 0xff, 0xf5,
-	0x34, 0x17, 0xb2, 0x99, 0x02, 0x2c, 0x7b, 0x0a, 0x6d, 0x40, 0x99, 0x4f, 0x52, 0xe2, 0x8d, 0xa1,
-	0x89, 0xd9, 0x4b, 0xe9, 0x69, 0x4c, 0x32, 0x52, 0x1d, 0x0f, 0x00, 0x78, 0xcf, 0x28, 0xf3, 0xf5,
-	0x44, 0xfb, 0x2b, 0x34, 0x5c, 0x3b, 0xa1, 0x2d, 0xb6, 0xa7, 0x98, 0x3b, 0xe9, 0x3f, 0x06, 0xc3,
-	0x9d, 0xf1, 0xdf, 0x45, 0x86, 0x3b, 0x13, 0x7f, 0x58, 0xb8, 0x29, 0x45, 0x31, 0x83, 0x23, 0xdd,
-	0x60, 0xe3, 0x37, 0x42, 0xf3, 0x7a, 0x06, 0x27, 0x55, 0xb0, 0x0d, 0x95, 0x7d, 0x4a, 0xb0, 0x3b,
-	0xfc, 0x57, 0x6a, 0x56, 0x2c, 0xb4, 0x06, 0xd3, 0x1c, 0xa7, 0xcb, 0x41, 0x7a, 0x17, 0x0a, 0x7c,
-	0x24, 0xb8, 0x04, 0x98, 0x0f, 0xa0, 0x28, 0x3a, 0x5e, 0xc3, 0x76, 0xa3, 0x29, 0x37, 0x6c, 0x37,
-	0xdb, 0x63, 0x71, 0x36, 0x6b, 0x1d, 0x8d, 0xb3, 0xb5, 0x3e, 0xd7, 0x38, 0x5b, 0xef, 0x31, 0xc5,
-	0xd9, 0xa2, 0x07, 0x32, 0xce, 0x36, 0xba, 0x3f, 0xe3, 0x6c, 0xb3, 0x61, 0xb2, 0xa7, 0xd0, 0x1a,
-	0x14, 0x45, 0xe3, 0x63, 0x28, 0x30, 0x7a, 0xa1, 0xe6, 0xfc, 0xc4, 0x93, 0xe9, 0x0c, 0x23, 0x7a,
-	0x94, 0xc6, 0x91, 0x48, 0x08, 0xe3, 0x71, 0x64, 0xa4, 0xf0, 0xf1, 0x38, 0x32, 0x73, 0x88, 0x3d,
-	0x85, 0xee, 0x41, 0x71, 0xd3, 0x0d, 0x3c, 0x3c, 0x40, 0x27, 0x9c, 0x76, 0x8a, 0x15, 0x1f, 0x41,
-	0x75, 0x1b, 0xd3, 0x3d, 0xfe, 0x03, 0x77, 0x27, 0xe8, 0x85, 0x27, 0xaa, 0x78, 0x53, 0x9f, 0xc7,
-	0x52, 0x71, 0x7b, 0xea, 0x65, 0x91, 0x0b, 0xde, 0xf9, 0x27, 0x00, 0x00, 0xff, 0xff, 0xac, 0xa3,
-	0xd1, 0x1f, 0x21, 0x16, 0x00, 0x00,
+	// 1845 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xcd, 0x72, 0xdb, 0xc8,
+	0x11, 0x16, 0x48, 0x88, 0x12, 0x9b, 0x3f, 0xa6, 0x26, 0x1b, 0x89, 0xc6, 0xea, 0xa0, 0x42, 0x52,
+	0x15, 0xc5, 0xce, 0xd2, 0x8e, 0x7c, 0x48, 0xec, 0xf2, 0x96, 0x57, 0x16, 0x29, 0x47, 0xe5, 0xb5,
+	0xac, 0x40, 0xeb, 0xfc, 0x9c, 0xbc, 0x30, 0x38, 0xa4, 0x10, 0x81, 0x00, 0x3c, 0x18, 0xd0, 0xa5,
+	0x9c, 0x73, 0xc8, 0x25, 0xb9, 0xa6, 0xf2, 0x0e, 0xf9, 0xa9, 0xca, 0x13, 0xe4, 0x41, 0x92, 0x63,
+	0x5e, 0x20, 0x39, 0xe6, 0x92, 0x9a, 0x1f, 0x80, 0x33, 0x04, 0x48, 0x51, 0x8a, 0x2b, 0xb9, 0xa1,
+	0xa7, 0x7b, 0x7a, 0xba, 0xbf, 0xee, 0xe9, 0x99, 0x1e, 0x40, 0x3b, 0x26, 0xd1, 0xd4, 0x1f, 0x62,
+	0xd2, 0x8b, 0x49, 0x44, 0x23, 0x54, 0x8f, 0xd3, 0x20, 0x9d, 0xf8, 0x24, 0xf6, 0xac, 0x66, 0x1c,
+	0xa4, 0x63, 0x3f, 0x14, 0x0c, 0xeb, 0xd3, 0x71, 0x14, 0x8d, 0x03, 0xfc, 0x80, 0x53, 0xef, 0xd2,
+	0xd1, 0x03, 0x3c, 0x89, 0xe9, 0x95, 0x64, 0xee, 0xce, 0x33, 0x13, 0x4a, 0x52, 0x8f, 0x0a, 0xae,
+	0xfd, 0x3d, 0xe8, 0xbc, 0xc0, 0xf4, 0xdc, 0xbb, 0xc0, 0x13, 0xd7, 0xc1, 0xef, 0x53, 0x9c, 0x50,
+	0xd4, 0x85, 0x8d, 0x29, 0x26, 0x89, 0x1f, 0x85, 0x5d, 0x63, 0xcf, 0xd8, 0x5f, 0x77, 0x32, 0xd2,
+	0xbe, 0x0f, 0x5b, 0x8a, 0x74, 0x12, 0x47, 0x61, 0x82, 0xd1, 0x36, 0xd4, 0x12, 0x3e, 0xc2, 0xa5,
+	0xeb, 0x8e, 0xa4, 0xec, 0xdf, 0x55, 0xa0, 0x73, 0x14, 0x85, 0x23, 0x7f, 0x9c, 0x12, 0x9c, 0xe9,
+	0xfe, 0x11, 0xd4, 0xa7, 0x2e, 0xf1, 0xdd, 0x77, 0x01, 0x4e, 0xba, 0xc6, 0x5e, 0x75, 0xbf, 0x71,
+	0x70, 0xaf, 0x97, 0xfb, 0xd5, 0x9b, 0x97, 0xef, 0xfd, 0x24, 0x13, 0x1e, 0x84, 0x94, 0x5c, 0x39,
+	0xb3, 0xc9, 0xe8, 0x3e, 0x98, 0x2e, 0x19, 0x27, 0xdd, 0xca, 0x9e, 0xb1, 0xdf, 0x38, 0xd8, 0xe9,
+	0x09, 0x37, 0x7b, 0x99, 0x9b, 0xbd, 0x73, 0xee, 0xa6, 0xc3, 0x85, 0xd0, 0xb7, 0xa1, 0xe5, 0x7a,
+	0x1e, 0x8e, 0xe9, 0x39, 0xf6, 0x08, 0xa6, 0x49, 0xb7, 0xba, 0x67, 0xec, 0x6f, 0x3a, 0xfa, 0x20,
+	0xda, 0x87, 0x3b, 0x62, 0xc0, 0xc1, 0x49, 0x94, 0x12, 0x0f, 0x27, 0x5d, 0x93, 0xcb, 0xcd, 0x0f,
+	0x5b, 0x4f, 0xa1, 0xad, 0x5b, 0x86, 0x3a, 0x50, 0xbd, 0xc4, 0x57, 0x12, 0x02, 0xf6, 0x89, 0x3e,
+	0x81, 0xf5, 0xa9, 0x1b, 0xa4, 0x98, 0x5b, 0x58, 0x77, 0x04, 0xf1, 0xa4, 0xf2, 0x43, 0xc3, 0xfe,
+	0x8d, 0x01, 0x5b, 0x8a, 0xa7, 0x12, 0xc7, 0x82, 0x8d, 0xc6, 0x02, 0x1b, 0x93, 0x34, 0x8e, 0x23,
+	0x42, 0x93, 0x33, 0x82, 0xa7, 0x3e, 0xfe, 0xc0, 0xf5, 0x6f, 0x3a, 0xf3, 0xc3, 0x65, 0xde, 0x54,
+	0x4b, 0xbd, 0xb1, 0xff, 0x62, 0xc0, 0xdd, 0xdc, 0x9e, 0x01, 0x21, 0x11, 0x79, 0xe5, 0x27, 0x89,
+	0x1f, 0x8e, 0x5f, 0xe2, 0xab, 0x04, 0xfd, 0x18, 0x1a, 0x93, 0x19, 0x29, 0x83, 0xf6, 0xa0, 0x2c,
+	0x68, 0xf3, 0x53, 0x7b, 0xb3, 0x6f, 0x47, 0xd5, 0x61, 0x3d, 0x07, 0x98, 0xb1, 0x10, 0x02, 0x33,
+	0x74, 0x27, 0x58, 0x62, 0xc7, 0xbf, 0xd1, 0x1e, 0x34, 0x86, 0x38, 0xf1, 0x88, 0x1f, 0x53, 0x96,
+	0x87, 0x02, 0x42, 0x75, 0xc8, 0xfe, 0x93, 0x01, 0xad, 0x93, 0x70, 0x1a, 0x5d, 0xe6, 0xb9, 0xd5,
+	0x81, 0x2a, 0x8d, 0x2e, 0xb3, 0x10, 0xd0, 0xe8, 0xf2, 0x66, 0x39, 0x62, 0xc1, 0x66, 0xb6, 0xe1,
+	0x38, 0x50, 0x75, 0x27, 0xa7, 0xd5, 0x2d, 0x61, 0x72, 0x56, 0x46, 0x96, 0xa1, 0xbc, 0x5e, 0x8e,
+	0xf2, 0x14, 0xda, 0x99, 0xbd, 0x32, 0xe2, 0x0f, 0xa0, 0x46, 0x30, 0x4d, 0x89, 0xd8, 0x67, 0x4b,
+	0x0c, 0x94, 0x62, 0xe8, 0x11, 0x6c, 0x8e, 0x5c, 0x3f, 0x48, 0x09, 0x66, 0x3e, 0x55, 0xf9, 0x14,
+	0x25, 0x0e, 0x17, 0xd8, 0xbb, 0x3c, 0x16, 0x7c, 0x27, 0x17, 0xb4, 0x7f, 0x09, 0x4d, 0xce, 0x51,
+	0x60, 0xca, 0x96, 0xac, 0x3b, 0xec, 0x93, 0xc1, 0x14, 0x05, 0xc3, 0xeb, 0x61, 0x62, 0x42, 0x4c,
+	0x38, 0xc4, 0x1f, 0x44, 0x2e, 0x2d, 0x13, 0x66, 0x42, 0x76, 0x0a, 0x2d, 0xb9, 0xf6, 0xcc, 0x65,
+	0x3f, 0x8c, 0x53, 0x99, 0xdd, 0xcb, 0x5c, 0x16, 0x62, 0xb7, 0x73, 0xf9, 0x39, 0x34, 0x55, 0x8e,
+	0x0c, 0x6d, 0x8c, 0x09, 0xcd, 0x76, 0x68, 0x4e, 0xb3, 0xf2, 0x45, 0xb0, 0x9b, 0xe4, 0x49, 0x26,
+	0x29, 0xfb, 0xcf, 0x06, 0x34, 0xfa, 0xfe, 0x68, 0x94, 0xc1, 0xd6, 0x86, 0x8a, 0x3f, 0x94, 0xb3,
+	0x2b, 0xfe, 0x30, 0x83, 0xb1, 0x52, 0x84, 0xb1, 0x7a, 0x13, 0x18, 0xcd, 0x15, 0x60, 0x64, 0xa5,
+	0xc1, 0x1f, 0x87, 0x11, 0xc1, 0x47, 0x17, 0x6e, 0x38, 0xe6, 0x29, 0x56, 0xdd, 0xaf, 0x3b, 0xfa,
+	0xa0, 0xfd, 0x57, 0x03, 0x9a, 0x67, 0xd2, 0x2d, 0x66, 0x39, 0x7a, 0x08, 0xe6, 0xa5, 0x1f, 0x0a,
+	0xa3, 0xdb, 0x07, 0xbb, 0x0a, 0x6e, 0xaa, 0x58, 0xef, 0xa5, 0x1f, 0x0e, 0x1d, 0x2e, 0x89, 0x76,
+	0xa1, 0xce, 0x71, 0x67, 0xe3, 0xb2, 0xae, 0xcc, 0x06, 0xec, 0xaf, 0xc1, 0x64, 0xb2, 0x68, 0x03,
+	0xaa, 0x87, 0xfd, 0x7e, 0x67, 0x0d, 0xdd, 0x81, 0xc6, 0x61, 0xbf, 0xff, 0xd6, 0x19, 0x9c, 0x7d,
+	0x79, 0x78, 0x34, 0xe8, 0x18, 0x08, 0xa0, 0xd6, 0x1f, 0x7c, 0x39, 0xf8, 0x6a, 0xd0, 0xa9, 0x20,
+	0x04, 0x6d, 0xf1, 0x9d, 0xf3, 0xab, 0x8c, 0xff, 0xe6, 0xac, 0x7f, 0xf8, 0xd5, 0xa0, 0x63, 0x32,
+	0xbe, 0xf8, 0xce, 0xf9, 0xeb, 0xf6, 0xdf, 0xab, 0xd0, 0x14, 0xa0, 0xcb, 0x7c, 0xb1, 0x60, 0x93,
+	0xe0, 0x38, 0x70, 0x3d, 0x79, 0x5c, 0xd4, 0x9d, 0x9c, 0x66, 0x9b, 0x32, 0xa1, 0xe2, 0x24, 0xa9,
+	0x70, 0x56, 0x46, 0xa2, 0x87, 0xf0, 0x8d, 0x21, 0x0e, 0x30, 0xc5, 0xcf, 0xf1, 0x28, 0x62, 0x25,
+	0x96, 0xcf, 0x90, 0xe5, 0xaf, 0x8c, 0x85, 0x3e, 0x87, 0x0d, 0x4f, 0x62, 0x6b, 0x72, 0xb4, 0xbe,
+	0xa5, 0xa0, 0xa5, 0x5a, 0xc4, 0x09, 0x89, 0xb8, 0x93, 0xcd, 0x61, 0xb5, 0x7e, 0xe8, 0x8f, 0x46,
+	0x59, 0x60, 0x04, 0x81, 0x5e, 0x41, 0x73, 0x88, 0xa9, 0xeb, 0x07, 0x78, 0xc8, 0x01, 0xad, 0xf1,
+	0xfc, 0xfd, 0xee, 0x42, 0xcd, 0x8a, 0xac, 0x38, 0xee, 0xb4, 0xe9, 0xac, 0xd4, 0x5c, 0xb8, 0x89,
+	0x2a, 0xd5, 0xdd, 0x10, 0xa5, 0x66, 0x6e, 0xd8, 0xfa, 0x19, 0x6c, 0x15, 0x94, 0x95, 0x9c, 0x50,
+	0x9f, 0xa9, 0x27, 0x94, 0xbe, 0xb1, 0xd4, 0x04, 0x51, 0x8f, 0xae, 0xcf, 0xa1, 0xa1, 0x00, 0x80,
+	0x3a, 0xd0, 0xec, 0x9f, 0x1c, 0x1f, 0xbf, 0x7d, 0x73, 0xfa, 0xf2, 0xf4, 0xf5, 0x4f, 0x4f, 0x3b,
+	0x6b, 0xa8, 0x05, 0x75, 0x3e, 0x72, 0xfa, 0xfa, 0x94, 0x25, 0x44, 0x46, 0x9e, 0xbf, 0x7e, 0x35,
+	0xe8, 0x54, 0xec, 0xdf, 0x1a, 0xd0, 0x3a, 0x22, 0xd8, 0xa5, 0x78, 0x71, 0x35, 0xfa, 0x01, 0x80,
+	0xdc, 0x9c, 0x3e, 0xbe, 0xb6, 0x26, 0x29, 0xa2, 0x2c, 0x1f, 0xa8, 0x3f, 0xc1, 0x51, 0x4a, 0x79,
+	0xa4, 0x0d, 0x27, 0x23, 0x19, 0x27, 0x96, 0x87, 0xa5, 0x38, 0xd0, 0x33, 0xd2, 0xfe, 0x39, 0xb4,
+	0x33, 0x7b, 0x64, 0xc6, 0xcd, 0xef, 0xf3, 0xdb, 0x9a, 0x63, 0xff, 0xde, 0x80, 0x86, 0x83, 0xdd,
+	0xe1, 0xea, 0x05, 0x44, 0x5f, 0xaa, 0xba, 0xba, 0xe7, 0xb3, 0xaa, 0x6a, 0xae, 0x54, 0x55, 0xed,
+	0x5f, 0x1b, 0xd0, 0x14, 0xb6, 0x7d, 0x64, 0xaf, 0x15, 0x53, 0xaa, 0xab, 0x99, 0xf2, 0x0f, 0x03,
+	0x5a, 0x6f, 0xe2, 0xa1, 0x92, 0x12, 0xff, 0xcf, 0x4a, 0xab, 0xe4, 0xd0, 0xba, 0x9e, 0x43, 0x85,
+	0x1a, 0x5c, 0x2b, 0xa9, 0xc1, 0x6a, 0xa6, 0x6d, 0xe8, 0x99, 0x76, 0x02, 0xed, 0xcc, 0x4d, 0x89,
+	0xb9, 0x8e, 0xb1, 0xb1, 0x7a, 0x66, 0xfd, 0xca, 0x80, 0x56, 0x9f, 0x17, 0xb1, 0xff, 0x41, 0x6e,
+	0x29, 0x88, 0x98, 0x1a, 0x22, 0xf6, 0xbf, 0x6a, 0xfc, 0x82, 0x2f, 0xfa, 0x09, 0xa5, 0x79, 0x88,
+	0x49, 0xf4, 0x0b, 0xec, 0x51, 0x69, 0x4e, 0x46, 0xb2, 0x1a, 0x99, 0x50, 0xd7, 0xbb, 0xcc, 0xee,
+	0xc3, 0x9c, 0x40, 0xcf, 0xa0, 0xe6, 0xf1, 0xfb, 0x63, 0xb7, 0xca, 0xab, 0xe3, 0x77, 0xf4, 0x8b,
+	0xa5, 0xa6, 0x5c, 0xde, 0x34, 0x45, 0x6d, 0x94, 0xd3, 0xd8, 0xf9, 0x3d, 0x24, 0x57, 0x4e, 0x1a,
+	0xca, 0xad, 0x2d, 0x29, 0x7e, 0xe6, 0xbb, 0xc4, 0x0d, 0x02, 0x1c, 0xf0, 0x50, 0xae, 0x3b, 0x39,
+	0xcd, 0x2a, 0xe9, 0x24, 0x0a, 0x7d, 0x1a, 0x91, 0x41, 0x38, 0x8c, 0x23, 0x3f, 0xa4, 0xdd, 0x1a,
+	0x37, 0x6a, 0x7e, 0x98, 0xdd, 0x4d, 0xe9, 0x55, 0x8c, 0x79, 0x30, 0xeb, 0x0e, 0xff, 0xce, 0xef,
+	0xab, 0x9b, 0xca, 0x7d, 0x75, 0x1b, 0x6a, 0xb1, 0x4b, 0x70, 0x48, 0xbb, 0x75, 0x3e, 0x2a, 0x29,
+	0x65, 0x3b, 0xc0, 0x6a, 0xf7, 0x9d, 0xaf, 0x61, 0x8b, 0x7f, 0xf5, 0x71, 0x8c, 0xc3, 0x21, 0x0e,
+	0x3d, 0x16, 0xae, 0x06, 0x87, 0xe6, 0x60, 0x19, 0x34, 0x27, 0xf3, 0x93, 0x04, 0x4a, 0x45, 0x65,
+	0x32, 0x42, 0x94, 0x45, 0xa8, 0x99, 0xa5, 0x28, 0x27, 0x59, 0x73, 0x96, 0xdd, 0x78, 0x93, 0x6e,
+	0xab, 0xac, 0x39, 0xd3, 0xd7, 0x3c, 0xcb, 0x84, 0x65, 0x73, 0x96, 0x4f, 0x66, 0x6b, 0xb8, 0x81,
+	0xef, 0x26, 0x38, 0xe9, 0xb6, 0xc5, 0xd1, 0x2c, 0x49, 0x64, 0xb3, 0x33, 0x51, 0x71, 0xed, 0x0e,
+	0x67, 0x6b, 0x63, 0xd6, 0x3d, 0xf8, 0x24, 0x3f, 0x7f, 0x54, 0xcb, 0x11, 0x98, 0x29, 0x09, 0xb3,
+	0x8b, 0x00, 0xff, 0xb6, 0x1e, 0x43, 0x43, 0xc9, 0x8a, 0x9b, 0xb4, 0x61, 0xd6, 0x14, 0xb6, 0xcb,
+	0x51, 0x2b, 0xd1, 0x72, 0xac, 0x1f, 0x95, 0x0f, 0xaf, 0x81, 0xa5, 0x60, 0xbb, 0xba, 0xee, 0x53,
+	0x68, 0xeb, 0xc8, 0xdd, 0xa8, 0x79, 0xfc, 0x5b, 0x05, 0xb6, 0x94, 0x25, 0x65, 0x2d, 0x29, 0x1e,
+	0xa3, 0x9f, 0xf1, 0xed, 0x46, 0xf1, 0x75, 0xc5, 0x5b, 0x48, 0x21, 0x17, 0xb6, 0xf8, 0x87, 0x96,
+	0x77, 0x62, 0x4b, 0x3e, 0x2a, 0x77, 0x56, 0xac, 0xdc, 0x3b, 0x9f, 0x9f, 0x25, 0x13, 0xaf, 0xa0,
+	0xed, 0x46, 0x61, 0xfd, 0x00, 0xdb, 0xe5, 0x8a, 0x4b, 0xb0, 0x7a, 0xa1, 0xc7, 0xe6, 0xfb, 0x4b,
+	0xcd, 0xbd, 0x26, 0x38, 0xf6, 0xbf, 0x4d, 0x68, 0x1c, 0xb9, 0x41, 0xf0, 0x91, 0x9a, 0xca, 0x37,
+	0x70, 0xc7, 0x25, 0xe3, 0x12, 0x50, 0xef, 0xab, 0x56, 0xce, 0xd6, 0xeb, 0x1d, 0x92, 0x71, 0xc1,
+	0x67, 0x67, 0x5e, 0x87, 0xd6, 0xab, 0x9a, 0x8b, 0x7b, 0xd5, 0x75, 0xbd, 0x57, 0x55, 0x6a, 0x73,
+	0x6d, 0x41, 0x6d, 0xde, 0x50, 0x6b, 0xf3, 0x93, 0xbc, 0x36, 0x6f, 0x72, 0x9b, 0xed, 0x05, 0x36,
+	0x2f, 0x2f, 0xcb, 0xf5, 0x85, 0x65, 0x19, 0xae, 0x2f, 0xcb, 0x8d, 0xd2, 0xb2, 0xcc, 0x52, 0xe9,
+	0x90, 0x8c, 0xd3, 0x09, 0x0e, 0xe9, 0xb5, 0xa9, 0x14, 0x71, 0xd9, 0x55, 0x12, 0xe9, 0x50, 0x4f,
+	0xa4, 0x25, 0x21, 0x2a, 0xac, 0xac, 0xee, 0xef, 0xdb, 0x97, 0x24, 0xfb, 0x9f, 0x15, 0x68, 0x8a,
+	0xa5, 0x6e, 0xfb, 0x44, 0xf0, 0x16, 0x90, 0xf8, 0xd2, 0x72, 0xae, 0x52, 0x7c, 0xb4, 0x51, 0x56,
+	0xe9, 0x39, 0x85, 0x19, 0x22, 0x98, 0x25, 0xaa, 0xb4, 0x86, 0xbc, 0xba, 0x62, 0x43, 0x6e, 0xed,
+	0x03, 0x2a, 0xae, 0x51, 0x1a, 0xad, 0xf7, 0xb0, 0xb3, 0xc0, 0x9a, 0x12, 0x20, 0xbf, 0xd0, 0x03,
+	0x76, 0x6f, 0x75, 0xff, 0x54, 0xd0, 0xff, 0x68, 0xc0, 0x0e, 0x7f, 0xba, 0xca, 0xde, 0x6a, 0x4e,
+	0x42, 0x9f, 0x1e, 0xf3, 0xee, 0xe9, 0xe3, 0xdd, 0x8b, 0xbb, 0xb0, 0x21, 0x1e, 0x16, 0x04, 0x6a,
+	0x75, 0x27, 0x23, 0x6f, 0x7c, 0x79, 0x3f, 0xf8, 0xc3, 0x26, 0x74, 0x32, 0x53, 0xb3, 0x83, 0x84,
+	0x9d, 0xdd, 0xf9, 0xd3, 0x2c, 0xfa, 0x54, 0x01, 0x62, 0xfe, 0x79, 0xd7, 0xda, 0x2d, 0x67, 0x0a,
+	0xa8, 0xec, 0x35, 0xf4, 0x1c, 0x1a, 0x3c, 0x8a, 0x22, 0x87, 0x51, 0x21, 0xba, 0x99, 0x9e, 0x6e,
+	0x91, 0x91, 0xeb, 0x78, 0x06, 0xc0, 0xdb, 0x44, 0x59, 0x0b, 0x0a, 0x1d, 0xaf, 0xd0, 0xb0, 0xb3,
+	0xa0, 0x13, 0xb6, 0xd7, 0x98, 0x3b, 0xf9, 0xb3, 0xa2, 0xe6, 0xce, 0xfc, 0x0b, 0xb1, 0xb5, 0x5b,
+	0xce, 0x54, 0x4c, 0xa9, 0x89, 0x67, 0x37, 0xa4, 0x1a, 0xac, 0xbd, 0x1c, 0x5a, 0x77, 0x4b, 0x38,
+	0xb9, 0x82, 0x17, 0xd0, 0x3c, 0xa7, 0x04, 0xbb, 0x93, 0xff, 0x4a, 0xcd, 0x43, 0x03, 0x3d, 0x06,
+	0x93, 0x65, 0xa5, 0x06, 0x87, 0x52, 0x57, 0xac, 0x9d, 0xc2, 0x78, 0x6e, 0xc3, 0x53, 0x58, 0xe7,
+	0x10, 0xdf, 0x2e, 0x1a, 0x8f, 0xc1, 0xe4, 0x0f, 0x08, 0xb7, 0x88, 0xc3, 0x33, 0xa8, 0x89, 0xfe,
+	0x58, 0x73, 0x5b, 0x6b, 0xe1, 0xad, 0xbb, 0x25, 0x1c, 0x75, 0x6d, 0xd6, 0x68, 0x6a, 0x6b, 0x2b,
+	0x5d, 0xb1, 0xb5, 0x53, 0x18, 0x57, 0xd7, 0x16, 0x1d, 0x93, 0xb6, 0xb6, 0xd6, 0x2b, 0x5a, 0x77,
+	0x4b, 0x38, 0x0a, 0x6a, 0x35, 0xd1, 0x26, 0x69, 0x0a, 0xb4, 0xce, 0xc9, 0xda, 0x2e, 0xec, 0xb6,
+	0x01, 0xfb, 0x79, 0x92, 0xa7, 0xa0, 0xb8, 0x3e, 0xcc, 0xa7, 0xa0, 0x76, 0xe1, 0xb3, 0x76, 0xcb,
+	0x99, 0xb9, 0x1d, 0x4f, 0xa0, 0x76, 0xe4, 0x86, 0x1e, 0x0e, 0xd0, 0x82, 0xd5, 0x96, 0x58, 0xf1,
+	0x05, 0xb4, 0x5e, 0x60, 0x7a, 0xc6, 0x7f, 0xf7, 0x9c, 0x84, 0xa3, 0x68, 0xa1, 0x8a, 0x6f, 0xaa,
+	0xaf, 0x37, 0xb9, 0xb8, 0xbd, 0xf6, 0xae, 0xc6, 0x05, 0x1f, 0xfd, 0x67, 0x00, 0xa0, 0x5e, 0x60,
+	0x7c, 0x4f, 0x1a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1819,6 +2093,8 @@ type ResourceProviderClient interface {
 	// StreamInvoke dynamically executes a built-in function in the provider, which returns a stream
 	// of responses.
 	StreamInvoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (ResourceProvider_StreamInvokeClient, error)
+	// Call dynamically executes a method in the provider associated with a component resource.
+	Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error)
 	// Check validates that the given property bag is valid for a resource of the given type and returns the inputs
 	// that should be passed to successive calls to Diff, Create, or Update for this resource. As a rule, the provider
 	// inputs returned by a call to Check should preserve the original representation of the properties as present in
@@ -1930,6 +2206,15 @@ func (x *resourceProviderStreamInvokeClient) Recv() (*InvokeResponse, error) {
 	return m, nil
 }
 
+func (c *resourceProviderClient) Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.ResourceProvider/Call", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resourceProviderClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
 	out := new(CheckResponse)
 	err := c.cc.Invoke(ctx, "/pulumirpc.ResourceProvider/Check", in, out, opts...)
@@ -2026,6 +2311,8 @@ type ResourceProviderServer interface {
 	// StreamInvoke dynamically executes a built-in function in the provider, which returns a stream
 	// of responses.
 	StreamInvoke(*InvokeRequest, ResourceProvider_StreamInvokeServer) error
+	// Call dynamically executes a method in the provider associated with a component resource.
+	Call(context.Context, *CallRequest) (*CallResponse, error)
 	// Check validates that the given property bag is valid for a resource of the given type and returns the inputs
 	// that should be passed to successive calls to Diff, Create, or Update for this resource. As a rule, the provider
 	// inputs returned by a call to Check should preserve the original representation of the properties as present in
@@ -2074,6 +2361,9 @@ func (*UnimplementedResourceProviderServer) Invoke(ctx context.Context, req *Inv
 func (*UnimplementedResourceProviderServer) StreamInvoke(req *InvokeRequest, srv ResourceProvider_StreamInvokeServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamInvoke not implemented")
 }
+func (*UnimplementedResourceProviderServer) Call(ctx context.Context, req *CallRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
 func (*UnimplementedResourceProviderServer) Check(ctx context.Context, req *CheckRequest) (*CheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
@@ -2217,6 +2507,24 @@ func (x *resourceProviderStreamInvokeServer) Send(m *InvokeResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ResourceProvider_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceProviderServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceProvider/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceProviderServer).Call(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ResourceProvider_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckRequest)
 	if err := dec(in); err != nil {
@@ -2403,6 +2711,10 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Invoke",
 			Handler:    _ResourceProvider_Invoke_Handler,
 		},
+		{
+			MethodName: "Call",
+			Handler:    _ResourceProvider_Call_Handler,
+		},
 		{
 			MethodName: "Check",
 			Handler:    _ResourceProvider_Check_Handler,
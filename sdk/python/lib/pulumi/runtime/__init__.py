@@ -44,6 +44,7 @@ from .stack import (
 )
 
 from .invoke import (
+    call,
     invoke,
 )
 
@@ -83,6 +84,7 @@ __all__ = [
     "register_stack_transformation",
 
     # invoke
+    "call",
     "invoke",
 
     # _json
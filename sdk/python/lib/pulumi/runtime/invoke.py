@@ -13,7 +13,7 @@
 # limitations under the License.
 import asyncio
 import os
-from typing import Optional, TYPE_CHECKING
+from typing import Any, Dict, List, Optional, Set, TYPE_CHECKING
 import grpc
 
 from .. import log
@@ -26,7 +26,7 @@ from .settings import get_monitor, handle_grpc_error
 from .sync_await import _sync_await
 
 if TYPE_CHECKING:
-    from .. import Inputs
+    from .. import Inputs, Output, Resource
 
 # This setting overrides a hardcoded maximum protobuf size in the python protobuf bindings. This avoids deserialization
 # exceptions on large gRPC payloads, but makes it possible to use enough memory to cause an OOM error instead [1].
@@ -133,3 +133,106 @@ def invoke(tok: str, props: 'Inputs', opts: Optional[InvokeOptions] = None, typ:
         return resp
 
     return InvokeResult(_sync_await(asyncio.ensure_future(do_rpc())))
+
+
+def call(tok: str, props: 'Inputs', res: Optional['Resource'] = None, typ: Optional[type] = None) -> 'Output[Any]':
+    """
+    call dynamically calls the method, tok, which is offered by a provider plugin. Unlike invoke, the inputs may
+    contain outputs, and the result is an Output that depends on the resources that the provider reports as
+    dependencies of the method's return value. If res is provided, it is the resource to which the method is bound,
+    and its provider is used to serve the call.
+    """
+    log.debug(f"Calling function: tok={tok}")
+
+    if typ and not _types.is_output_type(typ):
+        raise TypeError("Expected typ to be decorated with @output_type")
+
+    from .. import Output  # pylint: disable=import-outside-toplevel
+    from ..resource import DependencyResource  # pylint: disable=import-outside-toplevel
+
+    # Setup the futures for the output.
+    resolve_value: 'asyncio.Future[Any]' = asyncio.Future()
+    resolve_is_known: 'asyncio.Future[bool]' = asyncio.Future()
+    resolve_is_secret: 'asyncio.Future[bool]' = asyncio.Future()
+    resolve_deps: 'asyncio.Future[Set[Resource]]' = asyncio.Future()
+
+    out = Output(resolve_deps, resolve_value, resolve_is_known, resolve_is_secret)
+
+    async def do_call():
+        try:
+            # Construct a provider reference from the resource's provider, if it has one.
+            provider_ref = None
+            provider = res.get_provider(tok) if res is not None else None
+            if provider is not None:
+                provider_urn = await provider.urn.future()
+                provider_id = (await provider.id.future()) or rpc.UNKNOWN
+                provider_ref = f"{provider_urn}::{provider_id}"
+                log.debug(f"Call using provider {provider_ref}")
+
+            monitor = get_monitor()
+
+            # Serialize out all our props to their final values, keeping track of the resources each depends upon.
+            property_dependencies_resources: Dict[str, List['Resource']] = {}
+            inputs = await rpc.serialize_properties(props, property_dependencies_resources)
+
+            property_dependencies = {}
+            for key, deps in property_dependencies_resources.items():
+                urns = set()
+                for dep in deps:
+                    urn = await dep.urn.future()
+                    urns.add(urn)
+                property_dependencies[key] = provider_pb2.CallRequest.ArgumentDependencies(urns=list(urns))
+
+            log.debug(f"Calling function prepared: tok={tok}")
+            req = provider_pb2.CallRequest(
+                tok=tok,
+                args=inputs,
+                argDependencies=property_dependencies,
+                provider=provider_ref,
+            )
+
+            def do_rpc_call():
+                try:
+                    return monitor.Call(req)
+                except grpc.RpcError as exn:
+                    handle_grpc_error(exn)
+                    return None
+
+            resp = await asyncio.get_event_loop().run_in_executor(None, do_rpc_call)
+            if resp is None:
+                return
+
+            log.debug(f"Calling function completed successfully: tok={tok}")
+            # If the call failed, raise an error.
+            if resp.failures:
+                raise Exception(f"call of {tok} failed: {resp.failures[0].reason} ({resp.failures[0].property})")
+
+            # Secrets are tracked on the result as a whole, so unwrap any secret properties.
+            value = None
+            is_secret = False
+            ret_obj = getattr(resp, 'return')
+            if ret_obj:
+                deserialized = rpc.deserialize_properties(ret_obj)
+                is_secret = any(rpc.is_rpc_secret(v) for v in deserialized.values())
+                deserialized = {k: rpc.unwrap_rpc_secret(v) for k, v in deserialized.items()}
+                # If typ is not None, call translate_output_properties to instantiate any output types.
+                value = rpc.translate_output_properties(deserialized, lambda prop: prop, typ) if typ else deserialized
+
+            # The result depends on the resources that the provider reported for any of its properties.
+            dep_urns: Set[str] = set()
+            for return_deps in resp.returnDependencies.values():
+                dep_urns.update(return_deps.urns)
+
+            resolve_value.set_result(value)
+            resolve_is_known.set_result(True)
+            resolve_is_secret.set_result(is_secret)
+            resolve_deps.set_result(set(map(DependencyResource, dep_urns)))
+        except Exception as exn:
+            for future in [resolve_value, resolve_is_known, resolve_is_secret, resolve_deps]:
+                if not future.done():
+                    future.set_exception(exn)
+            raise
+
+    asyncio.ensure_future(RPC_MANAGER.do_rpc("call", do_call)())
+
+    return out
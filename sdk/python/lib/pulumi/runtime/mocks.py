@@ -20,7 +20,7 @@ import logging
 from abc import ABC, abstractmethod
 from typing import Dict, NamedTuple, Optional, Tuple, TYPE_CHECKING
 
-from google.protobuf import empty_pb2
+from google.protobuf import empty_pb2, struct_pb2
 from . import rpc
 from .settings import Settings, configure, get_stack, get_project, get_root_resource
 from .sync_await import _sync_await
@@ -68,6 +68,18 @@ class Mocks(ABC):
         """
         return ("", {})
 
+    def method_call(self, token: str, args: dict, self_: Optional[str], provider: Optional[str]) -> dict:
+        """
+        method_call mocks calls to resource methods (e.g. those made with pulumi.runtime.call). Calls to methods fail
+        unless this is overridden.
+
+        :param str token: The token that indicates which method is being called. This token is of the form "package:module:type/method".
+        :param dict args: The arguments provided to the method call, not including the resource it is called on.
+        :param Optional[str] self_: If provided, the URN of the resource the method is called on.
+        :param Optional[str] provider: If provided, the identifier of the provider instance being used to make the call.
+        """
+        raise Exception(f"cannot call method {token}: mocks do not implement method_call")
+
 
 class MockMonitor:
     class ResourceRegistration(NamedTuple):
@@ -108,6 +120,25 @@ class MockMonitor:
         fields = {"failures": None, "return": ret_proto}
         return provider_pb2.InvokeResponse(**fields)
 
+    def Call(self, request):
+        args = rpc.deserialize_properties(request.args)
+
+        # The resource the method is called on is passed to the mocks by URN.
+        self_ = None
+        if "__self__" in request.args:
+            self_ref = request.args["__self__"]
+            if isinstance(self_ref, struct_pb2.Struct):
+                self_ = self_ref["urn"]
+            else:
+                self_ = self_ref
+
+        ret = self.mocks.method_call(request.tok, args, self_, request.provider)
+
+        ret_proto = _sync_await(rpc.serialize_properties(ret, {}))
+
+        fields = {"failures": None, "return": ret_proto}
+        return provider_pb2.CallResponse(**fields)
+
     def ReadResource(self, request):
         state = rpc.deserialize_properties(request.properties)
 
@@ -29,6 +29,11 @@ class AnalyzerStub(object):
         request_serializer=analyzer__pb2.AnalyzeStackRequest.SerializeToString,
         response_deserializer=analyzer__pb2.AnalyzeResponse.FromString,
         )
+    self.Remediate = channel.unary_unary(
+        '/pulumirpc.Analyzer/Remediate',
+        request_serializer=analyzer__pb2.AnalyzeRequest.SerializeToString,
+        response_deserializer=analyzer__pb2.RemediateResponse.FromString,
+        )
     self.GetAnalyzerInfo = channel.unary_unary(
         '/pulumirpc.Analyzer/GetAnalyzerInfo',
         request_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
@@ -70,6 +75,15 @@ class AnalyzerServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def Remediate(self, request, context):
+    """Remediate optionally transforms a single resource object. This effectively rewrites a single resource object's
+    properties instead of using what was generated by the program. Called with the "inputs" to the resource, after
+    they have been checked by the provider and before the resource is diffed.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
   def GetAnalyzerInfo(self, request, context):
     """GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
     """
@@ -104,6 +118,11 @@ def add_AnalyzerServicer_to_server(servicer, server):
           request_deserializer=analyzer__pb2.AnalyzeStackRequest.FromString,
           response_serializer=analyzer__pb2.AnalyzeResponse.SerializeToString,
       ),
+      'Remediate': grpc.unary_unary_rpc_method_handler(
+          servicer.Remediate,
+          request_deserializer=analyzer__pb2.AnalyzeRequest.FromString,
+          response_serializer=analyzer__pb2.RemediateResponse.SerializeToString,
+      ),
       'GetAnalyzerInfo': grpc.unary_unary_rpc_method_handler(
           servicer.GetAnalyzerInfo,
           request_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
@@ -10,6 +10,9 @@ class MyComponent(pulumi.ComponentResource):
             raise TypeError("Missing required property 'inprop'")
         self.outprop = pulumi.Output.from_input(inprop).apply(lambda x: f"output: {x}")
 
+    def get_value(__self__, *, arg: pulumi.Input[str] = None) -> pulumi.Output[dict]:
+        return pulumi.runtime.call("pkg:index:MyComponent/getValue", {"__self__": __self__, "arg": arg}, res=__self__)
+
 
 class Instance(pulumi.CustomResource):
     public_ip: pulumi.Output[str]
@@ -55,6 +58,7 @@ myinstance = Instance("instance",
                       value=pulumi.Output.secret("secret_value"))
 mycustom = MyCustom("mycustom", {"instance": myinstance})
 invoke_result = do_invoke()
+call_result = mycomponent.get_value(arg="hello")
 
 pulumi.export("hello", "world")
 pulumi.export("outprop", mycomponent.outprop)
@@ -23,6 +23,15 @@ class MyMocks(pulumi.runtime.Mocks):
         else:
             return {}
 
+    def method_call(self, token, args, self_, provider):
+        if token == 'pkg:index:MyComponent/getValue':
+            return {
+                'value': args['arg'] + '!',
+                'self': self_,
+            }
+        else:
+            return {}
+
     def new_resource(self, type_, name, inputs, provider, id_):
         if type_ == 'aws:ec2/securityGroup:SecurityGroup':
             state = {
@@ -72,3 +81,10 @@ class TestingWithMocks(unittest.TestCase):
     @pulumi.runtime.test
     def test_invoke(self):
         return self.assertEqual(resources.invoke_result, 59)
+
+    @pulumi.runtime.test
+    def test_call(self):
+        def check_result(result):
+            self.assertEqual(result['value'], 'hello!')
+            self.assertTrue(result['self'].endswith('pkg:index:MyComponent::mycomponent'))
+        return resources.call_result.apply(check_result)